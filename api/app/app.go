@@ -0,0 +1,191 @@
+package app
+
+import (
+	"context"
+	stdLog "log"
+	"net/http"
+
+	"shared-bike/blobstore"
+	"shared-bike/customlogger"
+	"shared-bike/domain"
+	"shared-bike/health"
+	"shared-bike/lock"
+	"shared-bike/metrics"
+	customMiddleware "shared-bike/middleware"
+	"shared-bike/pkg/audit"
+	"shared-bike/pkg/bike"
+	"shared-bike/pkg/consistency"
+	"shared-bike/pkg/idempotency"
+	"shared-bike/pkg/label"
+	"shared-bike/pkg/maintenance"
+	"shared-bike/pkg/photo"
+	"shared-bike/pkg/privacy"
+	"shared-bike/pkg/role"
+	"shared-bike/pkg/station"
+	"shared-bike/pkg/telemetry"
+	"shared-bike/pkg/user"
+	"shared-bike/pkg/zone"
+	"shared-bike/tracing"
+
+	"github.com/gorilla/sessions"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	swagger "github.com/swaggo/echo-swagger"
+	"gorm.io/gorm"
+)
+
+// Dependencies are what the API talks to outside the process, main opens them from the config and the tests hand
+// in their own.
+type Dependencies struct {
+	DB             *gorm.DB
+	Logger         echo.Logger
+	LockController lock.LockController
+	PhotoStore     blobstore.BlobStore
+	Metrics        *metrics.Metrics
+	// Health answers /health/live and /health/ready, its checks are registered by the caller.
+	Health *health.Registry
+}
+
+// New returns the API with its middleware chain and all its routes, ready to be served.
+func New(config Config, deps Dependencies) (*echo.Echo, error) {
+	e := echo.New()
+	e.Logger = deps.Logger
+	e.StdLogger = stdLog.New(e.Logger.Output(), e.Logger.Prefix()+": ", 0)
+	contextLogger := customlogger.NewContextLogger(e.Logger)
+	appMetrics := deps.Metrics
+	db := deps.DB
+	e.Use(
+		session.Middleware(sessions.NewCookieStore([]byte(config.Secret))),
+		middleware.GzipWithConfig(middleware.GzipConfig{
+			Level: 5,
+		}),
+		middleware.RequestID(),
+		tracing.Middleware(),
+		appMetrics.Middleware(),
+		customMiddleware.AddLoggerContext(contextLogger),
+		customMiddleware.Recover,
+		customMiddleware.BodyLimit(config.BodyLimit),
+		middleware.Logger(),
+		middleware.CORSWithConfig(middleware.CORSConfig{
+			AllowOrigins:     []string{"http://localhost:3000"},
+			AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, echo.HeaderAuthorization, customMiddleware.HeaderIdempotencyKey, customMiddleware.HeaderIfMatch, customMiddleware.HeaderIfNoneMatch, echo.HeaderIfModifiedSince, tracing.HeaderTraceparent, tracing.HeaderTracestate},
+			ExposeHeaders:    []string{customMiddleware.HeaderIdempotentReplayed, customMiddleware.HeaderETag, echo.HeaderLastModified},
+			AllowCredentials: true,
+		}),
+		middleware.JWTWithConfig(middleware.JWTConfig{
+			SigningKey:              []byte(config.Secret),
+			Claims:                  &domain.Claims{},
+			ErrorHandlerWithContext: customMiddleware.CustomJWTError,
+			TokenLookup:             "header:" + echo.HeaderAuthorization,
+			Skipper:                 customMiddleware.WhiteListAPI,
+		}),
+		customMiddleware.AddClaimsContext,
+		customMiddleware.AddRequestMetadata,
+	)
+	dbInstance, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	// kept for the monitors written before the probes, it checks no dependency
+	e.GET("/health", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, "OK")
+	})
+	e.GET("/health/live", deps.Health.Live)
+	e.GET("/health/ready", deps.Health.Ready)
+	e.GET("/swagger/*", swagger.WrapHandler)
+	appMetrics.RegisterDB(dbInstance)
+	if config.MetricsToken != "" {
+		e.GET("/metrics", appMetrics.Handler(config.MetricsToken))
+	} else {
+		e.Logger.Warn("METRICS_TOKEN is not set, /metrics is disabled")
+	}
+	root := e.Group("/api/v1")
+	auditRepo := audit.NewRepository(db)
+	auditUseCase := audit.NewUseCase(contextLogger, auditRepo)
+	userRepo := user.NewRepository(db)
+	roleRepo := role.NewRepository(db)
+	userUseCase := user.NewUseCase(contextLogger, userRepo, roleRepo, auditUseCase)
+	userHandler := user.NewHandler(userUseCase, config.Secret)
+	userAPIs := root.Group("/users")
+	userAPIs.POST("/login", userHandler.Login)
+	userAPIs.POST("/register", userHandler.Register)
+	userCache := user.NewCachedRepository(userRepo, config.UserCacheTTL, config.UserCacheSize)
+	appMetrics.RegisterUserCache(func() metrics.CacheStats {
+		stats := userCache.Stats()
+		return metrics.CacheStats{Hits: stats.Hits, Misses: stats.Misses, Evictions: stats.Evictions, Entries: stats.Entries}
+	})
+	photoSigner := blobstore.NewURLSigner(config.PhotoSigningSecret, photoLinkTTL)
+	privacyUseCase := privacy.NewUseCase(contextLogger, privacy.NewRepository(db), userCache, deps.PhotoStore, photoSigner, auditUseCase)
+	privacyHandler := privacy.NewHandler(privacyUseCase)
+	userAPIs.GET("/me/export", privacyHandler.Export)
+	userAPIs.POST("/me/erasure", privacyHandler.Erase)
+
+	bikeRepo := bike.NewRepository(db)
+	ticketRepo := maintenance.NewRepository(db)
+	stationRepo := station.NewRepository(db)
+	zoneUseCase := zone.NewUseCase(contextLogger, zone.NewRepository(db), auditUseCase)
+	appMetrics.RegisterRentedBikes(func(ctx context.Context) (int64, error) {
+		return bikeRepo.CountByStatus(ctx, domain.BikeStatusRented)
+	})
+	bikeUseCase := bike.NewUseCase(contextLogger, bikeRepo, userCache, ticketRepo, stationRepo, zoneUseCase, deps.LockController, auditUseCase, appMetrics, config.ReturnMode, config.MinRentBattery)
+	bikeHandler := bike.NewHandler(bikeUseCase)
+	bikeAPIs := root.Group("/bikes")
+	bikeAPIs.GET("", bikeHandler.GetAllBike)
+	bikeAPIs.GET("/:id", bikeHandler.GetByID)
+	idempotent := customMiddleware.Idempotency(idempotency.NewRepository(db), config.IdempotencyTTL, config.IdempotencyLease)
+	bikeAPIs.PATCH("/:id/rent", bikeHandler.Rent, idempotent)
+	bikeAPIs.PATCH("/by-code/:code/rent", bikeHandler.RentByCode, idempotent)
+	bikeAPIs.PATCH("/:id/return", bikeHandler.Return, idempotent)
+
+	stationUseCase := station.NewUseCase(contextLogger, stationRepo)
+	stationHandler := station.NewHandler(stationUseCase)
+	root.GET("/stations", stationHandler.GetList)
+
+	zoneHandler := zone.NewHandler(zoneUseCase)
+	root.GET("/zones", zoneHandler.GetList)
+
+	telemetryUseCase := telemetry.NewUseCase(contextLogger, telemetry.NewRepository(db), bikeRepo)
+	telemetryHandler := telemetry.NewHandler(telemetryUseCase)
+	root.POST("/devices/telemetry", telemetryHandler.Ingest)
+
+	maintenanceUseCase := maintenance.NewUseCase(contextLogger, ticketRepo, bikeRepo, auditUseCase)
+	maintenanceHandler := maintenance.NewHandler(maintenanceUseCase)
+	bikeAPIs.POST("/:id/report", maintenanceHandler.Report)
+	maintenanceAPIs := root.Group("/maintenance", customMiddleware.RequirePermission(domain.PermissionBikesMaintain))
+	maintenanceAPIs.GET("/tickets", maintenanceHandler.GetList)
+	maintenanceAPIs.PATCH("/tickets/:id", maintenanceHandler.UpdateStatus)
+
+	photoRepo := photo.NewRepository(db)
+	photoUseCase := photo.NewUseCase(contextLogger, photoRepo, bikeRepo, ticketRepo, deps.PhotoStore, photoSigner, auditUseCase)
+	photoHandler := photo.NewHandler(photoUseCase)
+	bikeAPIs.GET("/:id/photos", photoHandler.GetListByBikeID)
+	bikeAPIs.POST("/:id/report/:ticketId/photos", photoHandler.UploadReportPhoto)
+	bikeAPIs.POST("/:id/return/photos", photoHandler.UploadReturnPhoto)
+	root.GET("/photos/:id/:variant", photoHandler.Download)
+
+	roleUseCase := role.NewUseCase(contextLogger, roleRepo, userRepo, auditUseCase)
+	roleHandler := role.NewHandler(roleUseCase)
+	adminAPIs := root.Group("/admin")
+	roleAPIs := adminAPIs.Group("", customMiddleware.RequirePermission(domain.PermissionRolesManage))
+	roleAPIs.GET("/roles", roleHandler.GetAllRoles)
+	roleAPIs.GET("/users/:id/roles", roleHandler.GetUserRoles)
+	roleAPIs.POST("/users/:id/roles", roleHandler.AssignRole)
+	roleAPIs.DELETE("/users/:id/roles/:roleId", roleHandler.UnassignRole)
+
+	adminAPIs.POST("/zones", zoneHandler.Import, customMiddleware.RequirePermission(domain.PermissionZonesManage))
+
+	labelUseCase := label.NewUseCase(contextLogger, bikeRepo, config.BikeLinkBaseURL)
+	labelHandler := label.NewHandler(labelUseCase)
+	labelAPIs := adminAPIs.Group("/bikes", customMiddleware.RequirePermission(domain.PermissionBikesManage))
+	labelAPIs.GET("/labels", labelHandler.GetSheet)
+	labelAPIs.GET("/:id/label", labelHandler.GetLabel)
+
+	consistencyUseCase := consistency.NewUseCase(contextLogger, consistency.NewRepository(db), auditUseCase)
+	consistencyHandler := consistency.NewHandler(consistencyUseCase)
+	adminAPIs.POST("/consistency", consistencyHandler.Check, customMiddleware.RequirePermission(domain.PermissionBikesManage))
+
+	auditHandler := audit.NewHandler(auditUseCase)
+	adminAPIs.GET("/audit", auditHandler.GetList, customMiddleware.RequirePermission(domain.PermissionAuditRead))
+	return e, nil
+}
@@ -0,0 +1,230 @@
+package app
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"shared-bike/domain"
+	"shared-bike/lock"
+	"shared-bike/server"
+	"shared-bike/tracing"
+
+	"github.com/labstack/gommon/bytes"
+)
+
+const (
+	defaultPort            = "8000"
+	defaultPhotoStorageDir = "storage/photos"
+	photoLinkTTL           = 15 * time.Minute
+	photoSigningKeySize    = 32
+	defaultLockController  = "fake"
+	defaultLockServerAddr  = "localhost:9100"
+	defaultLockTimeout     = 3 * time.Second
+	defaultLockRetries     = 2
+	lockRetryBackoff       = 200 * time.Millisecond
+	defaultMinRentBattery  = 20
+	defaultBikeLinkBaseURL = "sharedbike://bikes/"
+	defaultIdempotencyTTL  = 24 * time.Hour
+	// longer than a request may run, see the write timeout below
+	defaultIdempotencyLease = time.Minute
+	defaultUserCacheTTL     = time.Minute
+	defaultUserCacheSize    = 10000
+	defaultTraceSampleRate  = 1.0
+	defaultDrainDelay       = 5 * time.Second
+	// the write timeout and the shutdown timeout cover a rent waiting for all the attempts of a slow lock
+	defaultReadHeaderTimeout = 5 * time.Second
+	defaultReadTimeout       = 30 * time.Second
+	defaultWriteTimeout      = 30 * time.Second
+	defaultIdleTimeout       = 2 * time.Minute
+	defaultShutdownTimeout   = 30 * time.Second
+	defaultBodyLimit         = "8M"
+)
+
+// Config is the API settings, LoadConfig reads them from the environment variables documented in .env.sample.
+type Config struct {
+	DBConnectionString string
+	Secret             string
+	// PhotoSigningSecret keys the photo download links, so a leaked link key cannot forge a JWT and the other way round.
+	PhotoSigningSecret string
+	// SwaggerScheme and SwaggerHost are where the Swagger page sends its requests.
+	SwaggerScheme   string
+	SwaggerHost     string
+	PhotoStorageDir string
+	ReturnMode      domain.ReturnMode
+	MinRentBattery  int64
+	BikeLinkBaseURL string
+	IdempotencyTTL  time.Duration
+	// IdempotencyLease is how long a retry waits on a first attempt that never finished before running itself.
+	IdempotencyLease time.Duration
+	UserCacheTTL     time.Duration
+	UserCacheSize    int
+	BodyLimit        string
+	// MetricsToken turns on /metrics for scrapers presenting it.
+	MetricsToken string
+	// LockController is "fake" or "tcp", the tcp one talks to the gateway at LockServerAddr.
+	LockController string
+	LockServerAddr string
+	Lock           lock.Config
+	Tracing        tracing.Config
+	Server         server.Config
+	// DrainDelay is how long readiness fails before the server stops, ShutdownTimeout how long requests in flight
+	// may take to finish after.
+	DrainDelay      time.Duration
+	ShutdownTimeout time.Duration
+}
+
+// LoadConfig reads the settings with getenv, like os.Getenv, and applies the defaults of the unset ones.
+func LoadConfig(getenv func(key string) string) (Config, error) {
+	config := Config{
+		DBConnectionString: getenv("DB_CONNECTION_STRING"),
+		Secret:             getenv("SECRET"),
+		PhotoSigningSecret: getenv("PHOTO_SIGNING_SECRET"),
+		SwaggerScheme:      getenv("TLS"),
+		SwaggerHost:        getenv("BASE_URL"),
+		PhotoStorageDir:    getenv("PHOTO_STORAGE_DIR"),
+		ReturnMode:         domain.ReturnMode(getenv("RETURN_MODE")),
+		MinRentBattery:     defaultMinRentBattery,
+		BikeLinkBaseURL:    getenv("BIKE_LINK_BASE_URL"),
+		IdempotencyTTL:     defaultIdempotencyTTL,
+		IdempotencyLease:   defaultIdempotencyLease,
+		UserCacheTTL:       defaultUserCacheTTL,
+		UserCacheSize:      defaultUserCacheSize,
+		BodyLimit:          getenv("HTTP_BODY_LIMIT"),
+		MetricsToken:       getenv("METRICS_TOKEN"),
+		LockController:     getenv("LOCK_CONTROLLER"),
+		LockServerAddr:     getenv("LOCK_SERVER_ADDR"),
+		Lock: lock.Config{
+			Timeout: defaultLockTimeout,
+			Retries: defaultLockRetries,
+			Backoff: lockRetryBackoff,
+		},
+		Tracing: tracing.Config{
+			Exporter:    getenv("TRACING_EXPORTER"),
+			Endpoint:    getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+			ServiceName: getenv("OTEL_SERVICE_NAME"),
+			SampleRatio: defaultTraceSampleRate,
+		},
+		Server: server.Config{
+			Port:              getenv("PORT"),
+			ReadHeaderTimeout: defaultReadHeaderTimeout,
+			ReadTimeout:       defaultReadTimeout,
+			WriteTimeout:      defaultWriteTimeout,
+			IdleTimeout:       defaultIdleTimeout,
+			CertFile:          getenv("TLS_CERT_FILE"),
+			KeyFile:           getenv("TLS_KEY_FILE"),
+		},
+		DrainDelay:      defaultDrainDelay,
+		ShutdownTimeout: defaultShutdownTimeout,
+	}
+	if config.SwaggerScheme == "" && config.Server.CertFile != "" {
+		config.SwaggerScheme = "https"
+	}
+	if config.Server.Port == "" {
+		config.Server.Port = defaultPort
+	}
+	if config.BodyLimit == "" {
+		config.BodyLimit = defaultBodyLimit
+	}
+	if config.PhotoStorageDir == "" {
+		config.PhotoStorageDir = defaultPhotoStorageDir
+	}
+	if config.PhotoSigningSecret != "" && config.PhotoSigningSecret == config.Secret {
+		return Config{}, errors.New("PHOTO_SIGNING_SECRET must differ from SECRET")
+	}
+	if config.PhotoSigningSecret == "" {
+		key := make([]byte, photoSigningKeySize)
+		if _, err := rand.Read(key); err != nil {
+			return Config{}, fmt.Errorf("generate PHOTO_SIGNING_SECRET: %w", err)
+		}
+		config.PhotoSigningSecret = hex.EncodeToString(key)
+	}
+	if config.BikeLinkBaseURL == "" {
+		config.BikeLinkBaseURL = defaultBikeLinkBaseURL
+	}
+	if config.ReturnMode == "" {
+		config.ReturnMode = domain.ReturnModeFreeFloating
+	}
+	if config.LockController == "" {
+		config.LockController = defaultLockController
+	}
+	if config.LockServerAddr == "" {
+		config.LockServerAddr = defaultLockServerAddr
+	}
+	if !config.ReturnMode.IsValid() {
+		return Config{}, fmt.Errorf("invalid RETURN_MODE %q", config.ReturnMode)
+	}
+	if value := getenv("MIN_RENT_BATTERY"); value != "" {
+		minRentBattery, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || minRentBattery < 0 || minRentBattery > 100 {
+			return Config{}, fmt.Errorf("invalid MIN_RENT_BATTERY %q", value)
+		}
+		config.MinRentBattery = minRentBattery
+	}
+	if value := getenv("USER_CACHE_SIZE"); value != "" {
+		userCacheSize, err := strconv.Atoi(value)
+		if err != nil || userCacheSize <= 0 {
+			return Config{}, fmt.Errorf("invalid USER_CACHE_SIZE %q", value)
+		}
+		config.UserCacheSize = userCacheSize
+	}
+	if value := getenv("LOCK_RETRIES"); value != "" {
+		retries, err := strconv.Atoi(value)
+		if err != nil || retries < 0 {
+			return Config{}, fmt.Errorf("invalid LOCK_RETRIES %q", value)
+		}
+		config.Lock.Retries = retries
+	}
+	if value := getenv("TRACING_SAMPLE_RATIO"); value != "" {
+		sampleRatio, err := strconv.ParseFloat(value, 64)
+		// written so that NaN fails as well
+		if err != nil || !(sampleRatio >= 0 && sampleRatio <= 1) {
+			return Config{}, fmt.Errorf("invalid TRACING_SAMPLE_RATIO %q", value)
+		}
+		config.Tracing.SampleRatio = sampleRatio
+	}
+	if _, err := bytes.Parse(config.BodyLimit); err != nil {
+		return Config{}, fmt.Errorf("invalid HTTP_BODY_LIMIT %q", config.BodyLimit)
+	}
+	for _, duration := range []struct {
+		name      string
+		field     *time.Duration
+		allowZero bool
+	}{
+		{"IDEMPOTENCY_TTL", &config.IdempotencyTTL, false},
+		{"IDEMPOTENCY_LEASE", &config.IdempotencyLease, false},
+		{"USER_CACHE_TTL", &config.UserCacheTTL, false},
+		{"LOCK_TIMEOUT", &config.Lock.Timeout, false},
+		{"HTTP_READ_HEADER_TIMEOUT", &config.Server.ReadHeaderTimeout, false},
+		{"HTTP_READ_TIMEOUT", &config.Server.ReadTimeout, false},
+		{"HTTP_WRITE_TIMEOUT", &config.Server.WriteTimeout, false},
+		{"HTTP_IDLE_TIMEOUT", &config.Server.IdleTimeout, false},
+		{"SHUTDOWN_TIMEOUT", &config.ShutdownTimeout, false},
+		{"SHUTDOWN_DRAIN_DELAY", &config.DrainDelay, true},
+	} {
+		value := getenv(duration.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 || (parsed == 0 && !duration.allowZero) {
+			return Config{}, fmt.Errorf("invalid %s %q", duration.name, value)
+		}
+		*duration.field = parsed
+	}
+	return config, nil
+}
+
+// NewLockController picks the in-process fake lock or a TCP lock gateway, see cmd/lockserver for a local stand-in.
+func NewLockController(config Config) (lock.LockController, error) {
+	switch config.LockController {
+	case defaultLockController:
+		return lock.NewController(lock.NewFakeLock(), config.Lock), nil
+	case "tcp":
+		return lock.NewController(lock.NewTCPTransport(config.LockServerAddr), config.Lock), nil
+	}
+	return nil, fmt.Errorf("invalid LOCK_CONTROLLER %q", config.LockController)
+}
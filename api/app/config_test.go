@@ -0,0 +1,138 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigTestSuite struct {
+	suite.Suite
+	env map[string]string
+}
+
+func (s *ConfigTestSuite) SetupTest() {
+	s.env = map[string]string{}
+}
+
+func TestConfigTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigTestSuite))
+}
+
+func (s *ConfigTestSuite) getenv(key string) string {
+	return s.env[key]
+}
+
+func (s *ConfigTestSuite) TestLoadConfig_Defaults() {
+	config, err := LoadConfig(s.getenv)
+	s.Nil(err)
+	s.Equal("8000", config.Server.Port)
+	s.Equal(5*time.Second, config.Server.ReadHeaderTimeout)
+	s.Equal(30*time.Second, config.Server.WriteTimeout)
+	s.Equal("8M", config.BodyLimit)
+	s.Equal("storage/photos", config.PhotoStorageDir)
+	s.Equal(domain.ReturnModeFreeFloating, config.ReturnMode)
+	s.Equal(int64(20), config.MinRentBattery)
+	s.Equal(time.Minute, config.IdempotencyLease)
+	s.Equal("fake", config.LockController)
+	s.Equal(3*time.Second, config.Lock.Timeout)
+	s.Equal(2, config.Lock.Retries)
+	s.Equal(1.0, config.Tracing.SampleRatio)
+	s.Equal(5*time.Second, config.DrainDelay)
+	s.Equal(30*time.Second, config.ShutdownTimeout)
+	s.Equal("", config.SwaggerScheme)
+	s.Len(config.PhotoSigningSecret, 64)
+}
+
+func (s *ConfigTestSuite) TestLoadConfig_FromEnv() {
+	s.env = map[string]string{
+		"PORT":                 "9000",
+		"SECRET":               "secret",
+		"PHOTO_SIGNING_SECRET": "photo-secret",
+		"RETURN_MODE":          "station",
+		"MIN_RENT_BATTERY":     "35",
+		"USER_CACHE_SIZE":      "10",
+		"LOCK_CONTROLLER":      "tcp",
+		"LOCK_RETRIES":         "0",
+		"LOCK_TIMEOUT":         "1s",
+		"TRACING_SAMPLE_RATIO": "0.5",
+		"HTTP_BODY_LIMIT":      "2M",
+		"HTTP_READ_TIMEOUT":    "10s",
+		"SHUTDOWN_DRAIN_DELAY": "0s",
+		"TLS_CERT_FILE":        "tls.crt",
+		"TLS_KEY_FILE":         "tls.key",
+	}
+	config, err := LoadConfig(s.getenv)
+	s.Nil(err)
+	s.Equal("9000", config.Server.Port)
+	s.Equal("secret", config.Secret)
+	s.Equal("photo-secret", config.PhotoSigningSecret)
+	s.Equal(domain.ReturnModeStation, config.ReturnMode)
+	s.Equal(int64(35), config.MinRentBattery)
+	s.Equal(10, config.UserCacheSize)
+	s.Equal("tcp", config.LockController)
+	s.Equal(0, config.Lock.Retries)
+	s.Equal(time.Second, config.Lock.Timeout)
+	s.Equal(0.5, config.Tracing.SampleRatio)
+	s.Equal("2M", config.BodyLimit)
+	s.Equal(10*time.Second, config.Server.ReadTimeout)
+	s.Equal(time.Duration(0), config.DrainDelay)
+	s.Equal("tls.crt", config.Server.CertFile)
+	s.Equal("https", config.SwaggerScheme)
+}
+
+func (s *ConfigTestSuite) TestLoadConfig_PhotoSigningSecretReusesSecret() {
+	s.env = map[string]string{
+		"SECRET":               "secret",
+		"PHOTO_SIGNING_SECRET": "secret",
+	}
+	_, err := LoadConfig(s.getenv)
+	s.EqualError(err, "PHOTO_SIGNING_SECRET must differ from SECRET")
+}
+
+func (s *ConfigTestSuite) TestLoadConfig_PhotoSigningSecretGenerated() {
+	first, err := LoadConfig(s.getenv)
+	s.Require().Nil(err)
+	second, err := LoadConfig(s.getenv)
+	s.Require().Nil(err)
+	s.NotEqual(first.PhotoSigningSecret, second.PhotoSigningSecret)
+}
+
+func (s *ConfigTestSuite) TestLoadConfig_Invalid() {
+	for _, tc := range []struct{ key, value string }{
+		{"RETURN_MODE", "anywhere"},
+		{"MIN_RENT_BATTERY", "101"},
+		{"USER_CACHE_SIZE", "0"},
+		{"LOCK_RETRIES", "-1"},
+		{"TRACING_SAMPLE_RATIO", "all"},
+		{"TRACING_SAMPLE_RATIO", "1.5"},
+		{"TRACING_SAMPLE_RATIO", "-0.1"},
+		{"TRACING_SAMPLE_RATIO", "NaN"},
+		{"HTTP_BODY_LIMIT", "big"},
+		{"IDEMPOTENCY_TTL", "0s"},
+		{"IDEMPOTENCY_LEASE", "-1m"},
+		{"HTTP_WRITE_TIMEOUT", "soon"},
+		{"SHUTDOWN_DRAIN_DELAY", "-1s"},
+	} {
+		s.env = map[string]string{tc.key: tc.value}
+		_, err := LoadConfig(s.getenv)
+		s.EqualError(err, "invalid "+tc.key+" \""+tc.value+"\"")
+	}
+}
+
+func (s *ConfigTestSuite) TestNewLockController() {
+	config, _ := LoadConfig(s.getenv)
+	controller, err := NewLockController(config)
+	s.Nil(err)
+	s.NotNil(controller)
+	config.LockController = "tcp"
+	controller, err = NewLockController(config)
+	s.Nil(err)
+	s.NotNil(controller)
+	config.LockController = "bluetooth"
+	_, err = NewLockController(config)
+	s.EqualError(err, "invalid LOCK_CONTROLLER \"bluetooth\"")
+}
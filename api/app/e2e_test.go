@@ -0,0 +1,258 @@
+//go:build cgo
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"shared-bike/blobstore"
+	"shared-bike/domain"
+	"shared-bike/health"
+	"shared-bike/lock"
+	"shared-bike/metrics"
+	customMiddleware "shared-bike/middleware"
+
+	"github.com/labstack/gommon/log"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// EndToEndTestSuite serves the API built by New over real HTTP, against an SQLite database with the schema of
+// testdata/schema.sql and the fake lock.
+type EndToEndTestSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	server *httptest.Server
+}
+
+func (s *EndToEndTestSuite) SetupTest() {
+	dir := s.T().TempDir()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(dir, "shared-bike.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	s.Require().Nil(err)
+	sqlDB, err := db.DB()
+	s.Require().Nil(err)
+	// SQLite takes one writer at a time, a single connection queues the requests instead of failing them as busy
+	sqlDB.SetMaxOpenConns(1)
+	schema, err := os.ReadFile(filepath.Join("testdata", "schema.sql"))
+	s.Require().Nil(err)
+	s.Require().Nil(db.Exec(string(schema)).Error)
+	s.db = db
+
+	config, err := LoadConfig(func(key string) string {
+		return map[string]string{
+			"SECRET":            "e2e-secret",
+			"PHOTO_STORAGE_DIR": filepath.Join(dir, "photos"),
+			"METRICS_TOKEN":     "e2e-metrics",
+		}[key]
+	})
+	s.Require().Nil(err)
+	appLogger := log.New("e2e")
+	appLogger.SetOutput(io.Discard)
+	photoStore, err := blobstore.NewLocalStore(config.PhotoStorageDir)
+	s.Require().Nil(err)
+	healthRegistry := health.NewRegistry(appLogger, time.Second)
+	healthRegistry.AddReadiness("db", health.PingCheck(sqlDB))
+	migrationCheck, err := health.MigrationCheck(sqlDB, os.DirFS(filepath.Join("..", "sql", "migrations")), ".")
+	s.Require().Nil(err)
+	healthRegistry.AddReadiness("migrations", migrationCheck)
+	e, err := New(config, Dependencies{
+		DB:             db,
+		Logger:         appLogger,
+		LockController: lock.NewController(lock.NewFakeLock(), config.Lock),
+		PhotoStore:     photoStore,
+		Metrics:        metrics.New(),
+		Health:         healthRegistry,
+	})
+	s.Require().Nil(err)
+	s.server = httptest.NewServer(e)
+}
+
+func (s *EndToEndTestSuite) TearDownTest() {
+	s.server.Close()
+	sqlDB, _ := s.db.DB()
+	sqlDB.Close()
+}
+
+func TestEndToEndTestSuite(t *testing.T) {
+	suite.Run(t, new(EndToEndTestSuite))
+}
+
+// do sends body as JSON with the access token and the headers, and decodes a JSON answer into result.
+func (s *EndToEndTestSuite) do(method, path, token string, headers map[string]string, body interface{}, result interface{}) *http.Response {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		s.Require().Nil(err)
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, s.server.URL+path, reader)
+	s.Require().Nil(err)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	res, err := s.server.Client().Do(req)
+	s.Require().Nil(err)
+	defer res.Body.Close()
+	if result != nil {
+		s.Require().Nil(json.NewDecoder(res.Body).Decode(result), "%s %s answered %d", method, path, res.StatusCode)
+	}
+	return res
+}
+
+func (s *EndToEndTestSuite) seedBike(name, code string, lat, long string) domain.Bike {
+	latitude, longitude := decimal.RequireFromString(lat), decimal.RequireFromString(long)
+	bike := domain.Bike{
+		Name:      name,
+		Type:      domain.BikeTypeClassic,
+		Code:      code,
+		Lat:       &latitude,
+		Long:      &longitude,
+		Status:    domain.BikeStatusAvailable,
+		LockState: domain.LockStateLocked,
+		Version:   1,
+	}
+	s.Require().Nil(s.db.Create(&bike).Error)
+	return bike
+}
+
+func (s *EndToEndTestSuite) register(username string) string {
+	credentials := domain.Credentials{}
+	res := s.do(http.MethodPost, "/api/v1/users/register", "", nil, domain.RegisterBody{
+		Username: username,
+		Password: "secret-password",
+		Name:     "Rider " + username,
+	}, &credentials)
+	s.Require().Equal(http.StatusCreated, res.StatusCode)
+	s.Require().NotEmpty(credentials.AccessToken)
+	return credentials.AccessToken
+}
+
+func (s *EndToEndTestSuite) TestRentAndReturn() {
+	bike := s.seedBike("henry", "7F3K9Q2M", "50.119504", "8.638137")
+	s.seedBike("lisa", "4H8N2R6T", "50.110924", "8.682127")
+	s.register("rider")
+
+	credentials := domain.Credentials{}
+	res := s.do(http.MethodPost, "/api/v1/users/login", "", nil, domain.LoginBody{
+		Username: "rider",
+		Password: "secret-password",
+	}, &credentials)
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	token := credentials.AccessToken
+
+	bikes := []domain.BikeDTO{}
+	res = s.do(http.MethodGet, "/api/v1/bikes", token, nil, nil, &bikes)
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	s.Require().Len(bikes, 2)
+	s.Equal(bike.ID, bikes[0].ID)
+	s.Equal(domain.BikeStatusAvailable, bikes[0].Status)
+
+	rented := domain.BikeDTO{}
+	rentHeaders := map[string]string{
+		customMiddleware.HeaderIdempotencyKey: "rent-henry-1",
+		customMiddleware.HeaderIfMatch:        domain.BikeETag(bikes[0].Version),
+	}
+	res = s.do(http.MethodPatch, "/api/v1/bikes/"+strconv.FormatInt(bike.ID, 10)+"/rent", token, rentHeaders, nil, &rented)
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	s.Equal(domain.BikeStatusRented, rented.Status)
+	s.Equal("Rider rider", rented.NameOfRenter)
+	s.Equal(domain.BikeETag(bikes[0].Version+1), res.Header.Get(customMiddleware.HeaderETag))
+
+	replayed := domain.BikeDTO{}
+	res = s.do(http.MethodPatch, "/api/v1/bikes/"+strconv.FormatInt(bike.ID, 10)+"/rent", token, rentHeaders, nil, &replayed)
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal("true", res.Header.Get(customMiddleware.HeaderIdempotentReplayed))
+	s.Equal(domain.BikeETag(bikes[0].Version+1), res.Header.Get(customMiddleware.HeaderETag))
+	s.Equal(rented, replayed)
+
+	var message string
+	res = s.do(http.MethodPatch, "/api/v1/bikes/"+strconv.FormatInt(bikes[1].ID, 10)+"/rent", token, nil, nil, &message)
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+	s.Contains(message, "already rented a bike")
+
+	returned := domain.BikeDTO{}
+	res = s.do(http.MethodPatch, "/api/v1/bikes/"+strconv.FormatInt(bike.ID, 10)+"/return", token, nil, map[string]string{
+		"lat":  "50.119600",
+		"long": "8.638200",
+	}, &returned)
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	s.Equal(domain.BikeStatusAvailable, returned.Status)
+	s.Equal("50.1196", returned.Lat)
+
+	stored := domain.Bike{}
+	s.Require().Nil(s.db.First(&stored, bike.ID).Error)
+	s.Equal(domain.BikeStatusAvailable, stored.Status)
+	s.False(stored.UserID.Valid)
+	s.Equal(int64(3), stored.Version)
+	var audited int64
+	s.Nil(s.db.Model(&domain.AuditEvent{}).Where("target_type = ? AND target_id = ?", domain.AuditTargetBike, bike.ID).Count(&audited).Error)
+	s.Equal(int64(2), audited)
+}
+
+func (s *EndToEndTestSuite) TestNewBikesGetCodes() {
+	created := s.seedBike("henry", "", "50.119504", "8.638137")
+	s.Regexp(`^[0-9A-F]{12}$`, created.Code)
+	s.Require().Nil(s.db.Exec("INSERT INTO `bike` (`name`, `status`) VALUES (?, ?)", "lisa", domain.BikeStatusAvailable).Error)
+	inserted := domain.Bike{}
+	s.Require().Nil(s.db.Where("name = ?", "lisa").First(&inserted).Error)
+	s.Regexp(`^[0-9A-F]{12}$`, inserted.Code)
+	s.NotEqual(created.Code, inserted.Code)
+
+	rented := domain.BikeDTO{}
+	res := s.do(http.MethodPatch, "/api/v1/bikes/by-code/"+strings.ToLower(inserted.Code)+"/rent", s.register("rider"), nil, nil, &rented)
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	s.Equal(inserted.ID, rented.ID)
+}
+
+func (s *EndToEndTestSuite) TestReturnBikeOfSomeoneElse() {
+	bike := s.seedBike("henry", "7F3K9Q2M", "50.119504", "8.638137")
+	renter := s.register("renter")
+	other := s.register("other")
+	res := s.do(http.MethodPatch, "/api/v1/bikes/"+strconv.FormatInt(bike.ID, 10)+"/rent", renter, nil, nil, nil)
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	var message string
+	res = s.do(http.MethodPatch, "/api/v1/bikes/"+strconv.FormatInt(bike.ID, 10)+"/return", other, nil, map[string]string{
+		"lat":  "50.119504",
+		"long": "8.638137",
+	}, &message)
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+	s.Contains(message, "not yours")
+}
+
+func (s *EndToEndTestSuite) TestUnauthorized() {
+	bike := s.seedBike("henry", "7F3K9Q2M", "50.119504", "8.638137")
+	res := s.do(http.MethodPatch, "/api/v1/bikes/"+strconv.FormatInt(bike.ID, 10)+"/rent", "", nil, nil, nil)
+	s.Equal(http.StatusUnauthorized, res.StatusCode)
+	res = s.do(http.MethodGet, "/api/v1/admin/audit", s.register("rider"), nil, nil, nil)
+	s.Equal(http.StatusForbidden, res.StatusCode)
+}
+
+func (s *EndToEndTestSuite) TestProbesAndMetrics() {
+	report := health.Report{}
+	res := s.do(http.MethodGet, "/health/ready", "", nil, nil, &report)
+	s.Equal(http.StatusOK, res.StatusCode, "%+v", report)
+	s.Equal(health.StatusOK, report.Status)
+	res = s.do(http.MethodGet, "/health/live", "", nil, nil, nil)
+	s.Equal(http.StatusOK, res.StatusCode)
+	res = s.do(http.MethodGet, "/metrics", "", map[string]string{"Authorization": "Bearer e2e-metrics"}, nil, nil)
+	s.Equal(http.StatusOK, res.StatusCode)
+}
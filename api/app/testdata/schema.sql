@@ -0,0 +1,219 @@
+-- The schema of sql/migrations for SQLite, which the end-to-end tests run against. MySQL only DDL like ENGINE,
+-- ON UPDATE or multi-column ALTER has no SQLite equivalent, so the end state of the migrations is written out here:
+-- keep it in step with every new migration and add its version below, readiness fails in the tests until then.
+CREATE TABLE `user` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `username` varchar(128) NOT NULL DEFAULT '',
+  `password` varchar(255) NOT NULL DEFAULT '',
+  `name` varchar(128) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `deleted_at` datetime DEFAULT NULL
+);
+CREATE UNIQUE INDEX `uk_username` ON `user` (`username`);
+
+CREATE TABLE `bike` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `name` varchar(128) NOT NULL DEFAULT '',
+  `type` varchar(32) NOT NULL DEFAULT 'classic',
+  `code` varchar(16) NOT NULL DEFAULT (upper(hex(randomblob(6)))),
+  `lat` decimal(8,6) DEFAULT NULL,
+  `long` decimal(9,6) DEFAULT NULL,
+  `status` varchar(128) NOT NULL DEFAULT '',
+  `user_id` bigint(20) DEFAULT NULL,
+  `station_id` bigint(20) DEFAULT NULL,
+  `battery` tinyint(3) DEFAULT NULL,
+  `lock_state` varchar(16) NOT NULL DEFAULT '',
+  `last_seen_at` datetime(3) DEFAULT NULL,
+  `version` bigint(20) NOT NULL DEFAULT 1,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `deleted_at` datetime DEFAULT NULL
+);
+CREATE UNIQUE INDEX `uk_bike_user_id` ON `bike` (`user_id`);
+CREATE UNIQUE INDEX `uk_bike_code` ON `bike` (`code`);
+
+CREATE TABLE `role` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `name` varchar(128) NOT NULL DEFAULT '',
+  `description` varchar(255) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX `uk_role_name` ON `role` (`name`);
+
+CREATE TABLE `permission` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `code` varchar(128) NOT NULL DEFAULT '',
+  `description` varchar(255) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX `uk_permission_code` ON `permission` (`code`);
+
+CREATE TABLE `role_permission` (
+  `role_id` bigint(20) NOT NULL,
+  `permission_id` bigint(20) NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`role_id`, `permission_id`)
+);
+
+CREATE TABLE `user_role` (
+  `user_id` bigint(20) NOT NULL,
+  `role_id` bigint(20) NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`user_id`, `role_id`)
+);
+
+INSERT INTO `role` (`id`, `name`, `description`) VALUES
+(1, 'admin', 'Full access to every staff operation'),
+(2, 'mechanic', 'Inspects, repairs and maintains bikes'),
+(3, 'support', 'Helps riders with their accounts and rides'),
+(4, 'finance', 'Reviews rides and payments');
+
+INSERT INTO `permission` (`id`, `code`, `description`) VALUES
+(1, 'bikes:read', 'View bikes including renter details'),
+(2, 'bikes:manage', 'Create and edit bikes'),
+(3, 'bikes:maintain', 'Handle maintenance of bikes'),
+(4, 'users:read', 'View user profiles'),
+(5, 'users:manage', 'Edit and deactivate users'),
+(6, 'rides:read', 'View rides'),
+(7, 'rides:manage', 'Correct and close rides'),
+(8, 'payments:read', 'View payments'),
+(9, 'roles:manage', 'Assign and remove staff roles'),
+(10, 'audit:read', 'Query the audit log'),
+(11, 'zones:manage', 'Import service areas and no-parking zones');
+
+INSERT INTO `role_permission` (`role_id`, `permission_id`) VALUES
+(1, 1), (1, 2), (1, 3), (1, 4), (1, 5), (1, 6), (1, 7), (1, 8), (1, 9), (1, 10), (1, 11),
+(2, 1), (2, 3),
+(3, 1), (3, 4), (3, 6),
+(4, 4), (4, 6), (4, 8);
+
+CREATE TABLE `audit_event` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `actor_id` bigint(20) DEFAULT NULL,
+  `action` varchar(128) NOT NULL DEFAULT '',
+  `target_type` varchar(64) NOT NULL DEFAULT '',
+  `target_id` bigint(20) NOT NULL DEFAULT 0,
+  `before` json DEFAULT NULL,
+  `after` json DEFAULT NULL,
+  `request_id` varchar(64) NOT NULL DEFAULT '',
+  `ip` varchar(45) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE `maintenance_ticket` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `bike_id` bigint(20) NOT NULL,
+  `reporter_id` bigint(20) NOT NULL,
+  `category` varchar(64) NOT NULL DEFAULT '',
+  `note` varchar(1024) NOT NULL DEFAULT '',
+  `status` varchar(32) NOT NULL DEFAULT 'open',
+  `resolved_at` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE `bike_photo` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `bike_id` bigint(20) NOT NULL,
+  `ticket_id` bigint(20) DEFAULT NULL,
+  `uploader_id` bigint(20) NOT NULL,
+  `kind` varchar(32) NOT NULL DEFAULT '',
+  `content_type` varchar(64) NOT NULL DEFAULT '',
+  `size` bigint(20) NOT NULL DEFAULT 0,
+  `storage_key` varchar(255) NOT NULL DEFAULT '',
+  `thumbnail_key` varchar(255) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE `station` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `name` varchar(128) NOT NULL DEFAULT '',
+  `lat` decimal(8,6) NOT NULL,
+  `long` decimal(9,6) NOT NULL,
+  `capacity` int(11) NOT NULL DEFAULT 0,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `deleted_at` datetime DEFAULT NULL
+);
+
+CREATE TABLE `zone` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `name` varchar(128) NOT NULL DEFAULT '',
+  `kind` varchar(32) NOT NULL DEFAULT '',
+  `policy` varchar(32) NOT NULL DEFAULT 'reject',
+  `surcharge` decimal(10,2) NOT NULL DEFAULT 0,
+  `geometry` json NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `deleted_at` datetime DEFAULT NULL
+);
+
+CREATE TABLE `device` (
+  `id` varchar(64) NOT NULL PRIMARY KEY,
+  `bike_id` bigint(20) NOT NULL,
+  `secret` varchar(128) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `deleted_at` datetime DEFAULT NULL
+);
+CREATE UNIQUE INDEX `uk_device_bike_id` ON `device` (`bike_id`);
+
+CREATE TABLE `telemetry_reading` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `device_id` varchar(64) NOT NULL,
+  `bike_id` bigint(20) NOT NULL,
+  `recorded_at` datetime(3) NOT NULL,
+  `lat` decimal(8,6) DEFAULT NULL,
+  `long` decimal(9,6) DEFAULT NULL,
+  `battery` tinyint(3) DEFAULT NULL,
+  `lock_state` varchar(16) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX `uk_device_recorded_at` ON `telemetry_reading` (`device_id`, `recorded_at`);
+
+CREATE TABLE `idempotent_request` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `user_id` bigint(20) NOT NULL,
+  `idempotency_key` varchar(255) NOT NULL,
+  `fingerprint` char(64) NOT NULL DEFAULT '',
+  `status_code` smallint(5) NOT NULL DEFAULT 0,
+  `content_type` varchar(128) NOT NULL DEFAULT '',
+  `etag` varchar(128) NOT NULL DEFAULT '',
+  `body` mediumblob DEFAULT NULL,
+  `expires_at` datetime NOT NULL,
+  `locked_until` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX `uk_user_id_idempotency_key` ON `idempotent_request` (`user_id`, `idempotency_key`);
+
+CREATE TABLE `goose_db_version` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `version_id` bigint(20) NOT NULL,
+  `is_applied` tinyint(1) NOT NULL,
+  `tstamp` datetime DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO `goose_db_version` (`version_id`, `is_applied`) VALUES
+(0, 1),
+(20220704135733, 1),
+(20220704135747, 1),
+(20261019100000, 1),
+(20261019110000, 1),
+(20261019120000, 1),
+(20261019130000, 1),
+(20261019140000, 1),
+(20261019150000, 1),
+(20261019160000, 1),
+(20261019170000, 1),
+(20261019180000, 1),
+(20261019190000, 1),
+(20261019200000, 1),
+(20261019210000, 1),
+(20261019220000, 1),
+(20261019230000, 1),
+(20261019240000, 1),
+(20261019250000, 1);
@@ -3,25 +3,77 @@ package apperrors
 import (
 	"errors"
 	"net/http"
+	"regexp"
+	"strings"
 )
 
+var codePattern = regexp.MustCompile(`^e[0-9]{4,5}$`)
+
+// CodeUnknown labels errors that do not come from this package.
+const CodeUnknown = "unknown"
+
 var (
 	// 500
 	ErrInternalServerError = errors.New("e5000 internal server error")
+	// 504
+	ErrLockNotConfirmed = errors.New("e5040 the bike lock did not confirm, please try again")
 	// 401
-	ErrUnauthorizeError = errors.New("e4010 unauthorized")
+	ErrUnauthorizeError       = errors.New("e4010 unauthorized")
+	ErrInvalidDeviceSignature = errors.New("e4011 invalid device signature")
 	// 400
-	ErrBikeRented         = errors.New("e4000 cannot rent because the bike is rented")
-	ErrUserHasBikeAlready = errors.New("e4001 cannot rent because you have already rented a bike")
-	ErrBikeAvailable      = errors.New("e4002 cannot return because the bike is available")
-	ErrBikeNotYours       = errors.New("e4003 cannot return because the bike is not yours")
-	ErrUserAlreadyExisted = errors.New("e4004 user already existed")
-	ErrInvalidBody        = errors.New("e4005 invalid body")
-	ErrInvalidBikeID      = errors.New("e4006 invalid bike id")
+	ErrBikeRented            = errors.New("e4000 cannot rent because the bike is rented")
+	ErrUserHasBikeAlready    = errors.New("e4001 cannot rent because you have already rented a bike")
+	ErrBikeAvailable         = errors.New("e4002 cannot return because the bike is available")
+	ErrBikeNotYours          = errors.New("e4003 cannot return because the bike is not yours")
+	ErrUserAlreadyExisted    = errors.New("e4004 user already existed")
+	ErrInvalidBody           = errors.New("e4005 invalid body")
+	ErrInvalidBikeID         = errors.New("e4006 invalid bike id")
+	ErrInvalidUserID         = errors.New("e4007 invalid user id")
+	ErrInvalidRoleID         = errors.New("e4008 invalid role id")
+	ErrInvalidAuditQuery     = errors.New("e4009 invalid audit query")
+	ErrInvalidCategory       = errors.New("e40010 invalid report category")
+	ErrInvalidTicketID       = errors.New("e40011 invalid maintenance ticket id")
+	ErrInvalidPhoto          = errors.New("e40012 invalid photo, only jpeg and png images are accepted")
+	ErrInvalidPhotoID        = errors.New("e40013 invalid photo id")
+	ErrInvalidLocation       = errors.New("e40014 invalid drop-off location")
+	ErrReturnTooFar          = errors.New("e40015 cannot return because the drop-off location is too far from the bike")
+	ErrStationRequired       = errors.New("e40016 a station id is required to return a bike")
+	ErrOutsideServiceArea    = errors.New("e40017 cannot return outside the service area")
+	ErrInNoParkingZone       = errors.New("e40018 cannot return inside a no-parking zone")
+	ErrInvalidZone           = errors.New("e40019 invalid zone, expected a GeoJSON FeatureCollection of polygons")
+	ErrInvalidTelemetry      = errors.New("e40020 invalid telemetry reading")
+	ErrInvalidBikeQuery      = errors.New("e40021 invalid bike query")
+	ErrInvalidBikeCode       = errors.New("e40022 invalid bike code")
+	ErrInvalidLabelQuery     = errors.New("e40023 invalid label query, expected format svg or png and 1 to 24 bike ids")
+	ErrInvalidIdempotencyKey = errors.New("e40024 invalid idempotency key, expected 1 to 255 visible characters")
+	ErrInvalidIfMatch        = errors.New("e40025 invalid If-Match header, expected the ETag of the bike")
+	ErrWrongPassword         = errors.New("e40026 password is wrong")
+	// 403
+	ErrForbidden        = errors.New("e4030 you do not have permission to perform this action")
+	ErrInvalidSignature = errors.New("e4031 download link is invalid or expired")
 	// 404
 	ErrBikeNotFound      = errors.New("e4040 bike not found")
 	ErrUserLoginNotFound = errors.New("e4041 username or password is wrong")
 	ErrUserNotExisted    = errors.New("e4042 user does not exist or inactive")
+	ErrRoleNotFound      = errors.New("e4043 role not found")
+	ErrTicketNotFound    = errors.New("e4044 maintenance ticket not found")
+	ErrPhotoNotFound     = errors.New("e4045 photo not found")
+	ErrStationNotFound   = errors.New("e4046 station not found")
+	// 409
+	ErrBikeOutOfService         = errors.New("e4090 cannot rent because the bike is out of service")
+	ErrInvalidTicketTransition  = errors.New("e4091 cannot move the maintenance ticket to this status")
+	ErrStationFull              = errors.New("e4092 cannot return because the station has no free dock")
+	ErrBatteryTooLow            = errors.New("e4093 cannot rent because the e-bike battery is too low")
+	ErrBikeHasNoCode            = errors.New("e4094 the bike has no short code yet")
+	ErrInvalidBikeTransition    = errors.New("e4095 cannot move the bike to this status")
+	ErrIdempotencyKeyInProgress = errors.New("e4096 a request with this idempotency key is still in progress")
+	ErrBikeVersionConflict      = errors.New("e4097 the bike was changed by someone else, reload it and try again")
+	ErrErasureWhileRenting      = errors.New("e4098 cannot erase the account while a bike is rented")
+	// 422
+	ErrIdempotencyKeyReused = errors.New("e4220 the idempotency key was already used for a different request")
+	// 413
+	ErrPhotoTooLarge       = errors.New("e4130 photo is too large")
+	ErrRequestBodyTooLarge = errors.New("e4131 request body is too large")
 )
 
 func GetStatusCode(err error) int {
@@ -34,6 +86,12 @@ func GetStatusCode(err error) int {
 		return http.StatusInternalServerError
 	case ErrUnauthorizeError:
 		return http.StatusUnauthorized
+	case ErrInvalidDeviceSignature:
+		return http.StatusUnauthorized
+	case ErrForbidden:
+		return http.StatusForbidden
+	case ErrInvalidSignature:
+		return http.StatusForbidden
 	case ErrBikeNotFound:
 		return http.StatusNotFound
 	case ErrBikeRented:
@@ -42,6 +100,8 @@ func GetStatusCode(err error) int {
 		return http.StatusBadRequest
 	case ErrBikeAvailable:
 		return http.StatusBadRequest
+	case ErrBikeNotYours:
+		return http.StatusBadRequest
 	case ErrUserNotExisted:
 		return http.StatusBadRequest
 	case ErrInvalidBody:
@@ -50,9 +110,95 @@ func GetStatusCode(err error) int {
 		return http.StatusBadRequest
 	case ErrUserAlreadyExisted:
 		return http.StatusBadRequest
+	case ErrInvalidUserID:
+		return http.StatusBadRequest
+	case ErrInvalidRoleID:
+		return http.StatusBadRequest
+	case ErrInvalidAuditQuery:
+		return http.StatusBadRequest
+	case ErrInvalidCategory:
+		return http.StatusBadRequest
+	case ErrInvalidTicketID:
+		return http.StatusBadRequest
+	case ErrInvalidPhoto:
+		return http.StatusBadRequest
+	case ErrInvalidPhotoID:
+		return http.StatusBadRequest
+	case ErrInvalidLocation:
+		return http.StatusBadRequest
+	case ErrReturnTooFar:
+		return http.StatusBadRequest
+	case ErrStationRequired:
+		return http.StatusBadRequest
+	case ErrOutsideServiceArea:
+		return http.StatusBadRequest
+	case ErrInNoParkingZone:
+		return http.StatusBadRequest
+	case ErrInvalidZone:
+		return http.StatusBadRequest
+	case ErrInvalidTelemetry:
+		return http.StatusBadRequest
+	case ErrInvalidBikeQuery:
+		return http.StatusBadRequest
+	case ErrInvalidBikeCode:
+		return http.StatusBadRequest
+	case ErrInvalidLabelQuery:
+		return http.StatusBadRequest
+	case ErrInvalidIdempotencyKey:
+		return http.StatusBadRequest
+	case ErrInvalidIfMatch:
+		return http.StatusBadRequest
+	case ErrWrongPassword:
+		return http.StatusBadRequest
 	case ErrUserLoginNotFound:
 		return http.StatusNotFound
+	case ErrRoleNotFound:
+		return http.StatusNotFound
+	case ErrTicketNotFound:
+		return http.StatusNotFound
+	case ErrPhotoNotFound:
+		return http.StatusNotFound
+	case ErrStationNotFound:
+		return http.StatusNotFound
+	case ErrBikeOutOfService:
+		return http.StatusConflict
+	case ErrInvalidTicketTransition:
+		return http.StatusConflict
+	case ErrStationFull:
+		return http.StatusConflict
+	case ErrBatteryTooLow:
+		return http.StatusConflict
+	case ErrBikeHasNoCode:
+		return http.StatusConflict
+	case ErrInvalidBikeTransition:
+		return http.StatusConflict
+	case ErrIdempotencyKeyInProgress:
+		return http.StatusConflict
+	case ErrBikeVersionConflict:
+		return http.StatusConflict
+	case ErrErasureWhileRenting:
+		return http.StatusConflict
+	case ErrIdempotencyKeyReused:
+		return http.StatusUnprocessableEntity
+	case ErrPhotoTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case ErrRequestBodyTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case ErrLockNotConfirmed:
+		return http.StatusGatewayTimeout
 	default:
 		return http.StatusInternalServerError
 	}
 }
+
+// Code returns the code an error message starts with, like e4097, so errors can be counted without their text.
+func Code(err error) string {
+	if err == nil {
+		return ""
+	}
+	code := strings.SplitN(err.Error(), " ", 2)[0]
+	if !codePattern.MatchString(code) {
+		return CodeUnknown
+	}
+	return code
+}
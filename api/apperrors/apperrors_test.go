@@ -52,6 +52,11 @@ func (s *AppErrorsTestSuite) TestGetStatusCode_ReturnBikeAvailable() {
 	s.Equal(http.StatusBadRequest, GetStatusCode(err))
 }
 
+func (s *AppErrorsTestSuite) TestGetStatusCode_ReturnBikeNotYours() {
+	err := ErrBikeNotYours
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
 func (s *AppErrorsTestSuite) TestGetStatusCode_ReturnUserNotExisted() {
 	err := ErrUserNotExisted
 	s.Equal(http.StatusBadRequest, GetStatusCode(err))
@@ -81,3 +86,212 @@ func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidBikeID() {
 	err := ErrInvalidBikeID
 	s.Equal(http.StatusBadRequest, GetStatusCode(err))
 }
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrForbidden() {
+	err := ErrForbidden
+	s.Equal(http.StatusForbidden, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidUserID() {
+	err := ErrInvalidUserID
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidRoleID() {
+	err := ErrInvalidRoleID
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrRoleNotFound() {
+	err := ErrRoleNotFound
+	s.Equal(http.StatusNotFound, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidAuditQuery() {
+	err := ErrInvalidAuditQuery
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidCategory() {
+	err := ErrInvalidCategory
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidTicketID() {
+	err := ErrInvalidTicketID
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrTicketNotFound() {
+	err := ErrTicketNotFound
+	s.Equal(http.StatusNotFound, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrBikeOutOfService() {
+	err := ErrBikeOutOfService
+	s.Equal(http.StatusConflict, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidTicketTransition() {
+	err := ErrInvalidTicketTransition
+	s.Equal(http.StatusConflict, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidPhoto() {
+	err := ErrInvalidPhoto
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidPhotoID() {
+	err := ErrInvalidPhotoID
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidSignature() {
+	err := ErrInvalidSignature
+	s.Equal(http.StatusForbidden, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrPhotoNotFound() {
+	err := ErrPhotoNotFound
+	s.Equal(http.StatusNotFound, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrPhotoTooLarge() {
+	err := ErrPhotoTooLarge
+	s.Equal(http.StatusRequestEntityTooLarge, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrRequestBodyTooLarge() {
+	err := ErrRequestBodyTooLarge
+	s.Equal(http.StatusRequestEntityTooLarge, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidLocation() {
+	err := ErrInvalidLocation
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrReturnTooFar() {
+	err := ErrReturnTooFar
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrStationRequired() {
+	err := ErrStationRequired
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrStationNotFound() {
+	err := ErrStationNotFound
+	s.Equal(http.StatusNotFound, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrStationFull() {
+	err := ErrStationFull
+	s.Equal(http.StatusConflict, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrOutsideServiceArea() {
+	err := ErrOutsideServiceArea
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInNoParkingZone() {
+	err := ErrInNoParkingZone
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidZone() {
+	err := ErrInvalidZone
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidDeviceSignature() {
+	err := ErrInvalidDeviceSignature
+	s.Equal(http.StatusUnauthorized, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidTelemetry() {
+	err := ErrInvalidTelemetry
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrLockNotConfirmed() {
+	err := ErrLockNotConfirmed
+	s.Equal(http.StatusGatewayTimeout, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidBikeQuery() {
+	err := ErrInvalidBikeQuery
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrBatteryTooLow() {
+	err := ErrBatteryTooLow
+	s.Equal(http.StatusConflict, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidBikeCode() {
+	err := ErrInvalidBikeCode
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidLabelQuery() {
+	err := ErrInvalidLabelQuery
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrBikeHasNoCode() {
+	err := ErrBikeHasNoCode
+	s.Equal(http.StatusConflict, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidBikeTransition() {
+	err := ErrInvalidBikeTransition
+	s.Equal(http.StatusConflict, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidIdempotencyKey() {
+	err := ErrInvalidIdempotencyKey
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrIdempotencyKeyInProgress() {
+	err := ErrIdempotencyKeyInProgress
+	s.Equal(http.StatusConflict, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrIdempotencyKeyReused() {
+	err := ErrIdempotencyKeyReused
+	s.Equal(http.StatusUnprocessableEntity, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrInvalidIfMatch() {
+	err := ErrInvalidIfMatch
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrBikeVersionConflict() {
+	err := ErrBikeVersionConflict
+	s.Equal(http.StatusConflict, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrWrongPassword() {
+	err := ErrWrongPassword
+	s.Equal(http.StatusBadRequest, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestGetStatusCode_ErrErasureWhileRenting() {
+	err := ErrErasureWhileRenting
+	s.Equal(http.StatusConflict, GetStatusCode(err))
+}
+
+func (s *AppErrorsTestSuite) TestCode() {
+	s.Equal("e4097", Code(ErrBikeVersionConflict))
+	s.Equal("e40026", Code(ErrWrongPassword))
+	s.Equal("e5000", Code(ErrInternalServerError))
+	s.Equal(CodeUnknown, Code(errors.New("connection refused")))
+	s.Equal(CodeUnknown, Code(errors.New("e12 too short")))
+	s.Equal("", Code(nil))
+}
@@ -0,0 +1,56 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LocalStoreTestSuite struct {
+	suite.Suite
+	store *LocalStore
+}
+
+func (s *LocalStoreTestSuite) SetupTest() {
+	store, err := NewLocalStore(s.T().TempDir())
+	s.Nil(err)
+	s.store = store
+}
+
+func TestLocalStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(LocalStoreTestSuite))
+}
+
+func (s *LocalStoreTestSuite) TestPutAndGet_Success() {
+	err := s.store.Put(context.TODO(), "bikes/1/photo.jpg", strings.NewReader("content"))
+	s.Nil(err)
+	body, err := s.store.Get(context.TODO(), "bikes/1/photo.jpg")
+	s.Nil(err)
+	defer body.Close()
+	actual, err := io.ReadAll(body)
+	s.Nil(err)
+	s.Equal("content", string(actual))
+}
+
+func (s *LocalStoreTestSuite) TestGet_FailedNotFound() {
+	body, err := s.store.Get(context.TODO(), "bikes/1/missing.jpg")
+	s.Nil(body)
+	s.Equal(ErrNotFound, err)
+}
+
+func (s *LocalStoreTestSuite) TestDelete_Success() {
+	s.Nil(s.store.Put(context.TODO(), "bikes/1/photo.jpg", strings.NewReader("content")))
+	s.Nil(s.store.Delete(context.TODO(), "bikes/1/photo.jpg"))
+	_, err := s.store.Get(context.TODO(), "bikes/1/photo.jpg")
+	s.Equal(ErrNotFound, err)
+	s.Nil(s.store.Delete(context.TODO(), "bikes/1/photo.jpg"))
+}
+
+func (s *LocalStoreTestSuite) TestPut_FailedInvalidKey() {
+	s.Equal(ErrInvalidKey, s.store.Put(context.TODO(), "../outside.jpg", strings.NewReader("content")))
+	s.Equal(ErrInvalidKey, s.store.Put(context.TODO(), "/etc/passwd", strings.NewReader("content")))
+	s.Equal(ErrInvalidKey, s.store.Put(context.TODO(), "", strings.NewReader("content")))
+}
@@ -0,0 +1,64 @@
+package blobstore
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type URLSignerTestSuite struct {
+	suite.Suite
+	signer   *URLSigner
+	mockTime time.Time
+}
+
+func (s *URLSignerTestSuite) SetupTest() {
+	s.mockTime = time.Date(2022, 7, 6, 18, 51, 44, 0, time.UTC)
+	s.signer = NewURLSigner("my-secret", 15*time.Minute)
+	s.signer.now = func() time.Time { return s.mockTime }
+}
+
+func TestURLSignerTestSuite(t *testing.T) {
+	suite.Run(t, new(URLSignerTestSuite))
+}
+
+func (s *URLSignerTestSuite) parse(signed string) (string, int64, string) {
+	parts := strings.SplitN(signed, "?", 2)
+	query, err := url.ParseQuery(parts[1])
+	s.Nil(err)
+	expires, err := strconv.ParseInt(query.Get(ExpiresParam), 10, 64)
+	s.Nil(err)
+	return parts[0], expires, query.Get(SignatureParam)
+}
+
+func (s *URLSignerTestSuite) TestSignAndVerify_Success() {
+	path, expires, signature := s.parse(s.signer.Sign("/api/v1/photos/1/original"))
+	s.Equal("/api/v1/photos/1/original", path)
+	s.Equal(s.mockTime.Add(15*time.Minute).Unix(), expires)
+	s.Nil(s.signer.Verify(path, expires, signature))
+}
+
+func (s *URLSignerTestSuite) TestVerify_FailedTamperedPath() {
+	_, expires, signature := s.parse(s.signer.Sign("/api/v1/photos/1/original"))
+	s.Equal(ErrSignatureInvalid, s.signer.Verify("/api/v1/photos/2/original", expires, signature))
+}
+
+func (s *URLSignerTestSuite) TestVerify_FailedTamperedExpiry() {
+	path, expires, signature := s.parse(s.signer.Sign("/api/v1/photos/1/original"))
+	s.Equal(ErrSignatureInvalid, s.signer.Verify(path, expires+3600, signature))
+}
+
+func (s *URLSignerTestSuite) TestVerify_FailedMalformedSignature() {
+	path, expires, _ := s.parse(s.signer.Sign("/api/v1/photos/1/original"))
+	s.Equal(ErrSignatureInvalid, s.signer.Verify(path, expires, "not-hex"))
+}
+
+func (s *URLSignerTestSuite) TestVerify_FailedExpired() {
+	path, expires, signature := s.parse(s.signer.Sign("/api/v1/photos/1/original"))
+	s.mockTime = s.mockTime.Add(16 * time.Minute)
+	s.Equal(ErrSignatureExpired, s.signer.Verify(path, expires, signature))
+}
@@ -0,0 +1,60 @@
+// Command check-consistency scans the bike fleet for rows that contradict themselves or the user table,
+// such as a rented bike without a renter, and prints a JSON report by category.
+//
+// It only reports by default. With -dry-run=false every repairable anomaly is repaired and recorded in the
+// audit log as bike.repair, all events of one run share a request id so they can be queried together. Repairs need
+// -actor, the user id of the staff member running them, which the audit log records as actor of every event.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"shared-bike/domain"
+	"shared-bike/pkg/audit"
+	"shared-bike/pkg/consistency"
+
+	"github.com/joho/godotenv"
+	"github.com/labstack/gommon/log"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", true, "only report anomalies, set to false to repair them")
+	actorID := flag.Int64("actor", 0, "user id of the staff member running the repairs, required with -dry-run=false")
+	flag.Parse()
+	godotenv.Load()
+
+	logger := log.New("check-consistency")
+	if !*dryRun && *actorID <= 0 {
+		logger.Fatal("-actor is required with -dry-run=false, the audit log needs to know who repaired the bikes")
+	}
+	db, err := gorm.Open(mysql.Open(os.Getenv("DB_CONNECTION_STRING")), &gorm.Config{})
+	if err != nil {
+		logger.Fatal(fmt.Errorf("connect db error: %w", err))
+	}
+	auditUseCase := audit.NewUseCase(logger, audit.NewRepository(db))
+	useCase := consistency.NewUseCase(logger, consistency.NewRepository(db), auditUseCase)
+
+	ctx := domain.NewContextWithRequestMetadata(context.Background(), domain.RequestMetadata{
+		RequestID: fmt.Sprintf("check-consistency-%d", time.Now().Unix()),
+	})
+	if *actorID > 0 {
+		// the audit log takes the actor from the caller's claims like for a request of that user
+		ctx = domain.NewContextWithClaims(ctx, &domain.Claims{ID: *actorID})
+	}
+	report, err := useCase.Check(ctx, *dryRun)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		logger.Fatal(err)
+	}
+}
@@ -0,0 +1,43 @@
+// Command lockserver is a stand-in for the smart lock gateway. It speaks the line protocol of the lock
+// package on TCP and keeps the lock states in memory, so rent and return can unlock and lock bikes locally.
+//
+// Point the API at it with LOCK_CONTROLLER=tcp and LOCK_SERVER_ADDR=localhost:9100.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"shared-bike/lock"
+)
+
+func main() {
+	addr := flag.String("addr", ":9100", "listen address")
+	dropAcks := flag.Int("drop-acks", 0, "apply the first n commands without acknowledging them, to exercise retries")
+	reject := flag.String("reject", "", "refuse every command with this reason, to exercise rollbacks")
+	flag.Parse()
+
+	fake := lock.NewFakeLock()
+	fake.DropAcks(*dropAcks)
+	fake.Reject(*reject)
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal("[LockServer] cannot listen ", err)
+	}
+	server := lock.NewServer(fake)
+	server.Logf = log.Printf
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-quit
+		server.Close()
+	}()
+	log.Println("[LockServer] listening on", listener.Addr())
+	if err := server.Serve(listener); err != nil {
+		log.Fatal("[LockServer] serve failed ", err)
+	}
+}
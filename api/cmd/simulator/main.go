@@ -0,0 +1,117 @@
+// Command simulator plays back fake lock traffic against the telemetry endpoint for local testing.
+//
+// It walks a bike around a starting point, drains its battery and toggles the lock, signing every
+// message like a real device. Every few messages it also resends the previous reading and sends one
+// from the past, so the duplicate and out-of-order handling of the API can be observed.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"shared-bike/domain"
+	"shared-bike/pkg/telemetry"
+
+	"github.com/shopspring/decimal"
+)
+
+// stepDegrees moves the bike roughly ten meters per reading.
+const stepDegrees = 0.0001
+
+type simulator struct {
+	client   *http.Client
+	url      string
+	deviceID string
+	secret   string
+}
+
+func main() {
+	url := flag.String("url", "http://localhost:8000/api/v1/devices/telemetry", "telemetry endpoint")
+	deviceID := flag.String("device", "lock-0001", "device id")
+	secret := flag.String("secret", "dev-secret-1", "device secret")
+	lat := flag.Float64("lat", 50.119504, "starting latitude")
+	long := flag.Float64("long", 8.638137, "starting longitude")
+	interval := flag.Duration("interval", 2*time.Second, "time between readings")
+	count := flag.Int("count", 0, "number of readings to send, 0 sends until interrupted")
+	replayEvery := flag.Int("replay-every", 5, "resend a duplicate and an out-of-order reading every n readings, 0 disables it")
+	flag.Parse()
+
+	sim := simulator{
+		client:   &http.Client{Timeout: 10 * time.Second},
+		url:      *url,
+		deviceID: *deviceID,
+		secret:   *secret,
+	}
+	battery := int64(100)
+	lockState := domain.LockStateLocked
+	var previous domain.TelemetryBody
+	for i := 1; *count == 0 || i <= *count; i++ {
+		*lat += (rand.Float64()*2 - 1) * stepDegrees
+		*long += (rand.Float64()*2 - 1) * stepDegrees
+		if i%10 == 0 && battery > 0 {
+			battery--
+		}
+		if rand.Intn(20) == 0 {
+			lockState = toggle(lockState)
+		}
+		body := reading(time.Now(), *lat, *long, battery, lockState)
+		sim.send("reading", body)
+		if *replayEvery > 0 && i%*replayEvery == 0 && !previous.RecordedAt.IsZero() {
+			sim.send("duplicate", previous)
+			sim.send("out-of-order", reading(previous.RecordedAt.Add(-*interval), *lat, *long, battery, lockState))
+		}
+		previous = body
+		time.Sleep(*interval)
+	}
+}
+
+func reading(recordedAt time.Time, lat, long float64, battery int64, lockState domain.LockState) domain.TelemetryBody {
+	latDecimal := decimal.NewFromFloat(lat).Round(6)
+	longDecimal := decimal.NewFromFloat(long).Round(6)
+	return domain.TelemetryBody{
+		RecordedAt: recordedAt.UTC().Truncate(time.Millisecond),
+		Lat:        &latDecimal,
+		Long:       &longDecimal,
+		Battery:    &battery,
+		LockState:  lockState,
+	}
+}
+
+func toggle(state domain.LockState) domain.LockState {
+	if state == domain.LockStateLocked {
+		return domain.LockStateUnlocked
+	}
+	return domain.LockStateLocked
+}
+
+func (s *simulator) send(kind string, body domain.TelemetryBody) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		log.Fatal("[Simulator] cannot encode reading", err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		log.Fatal("[Simulator] cannot build request", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(telemetry.HeaderDeviceID, s.deviceID)
+	req.Header.Set(telemetry.HeaderTimestamp, timestamp)
+	req.Header.Set(telemetry.HeaderSignature, telemetry.Sign(s.secret, timestamp, payload))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Println("[Simulator] request failed", err)
+		return
+	}
+	defer resp.Body.Close()
+	response, _ := io.ReadAll(resp.Body)
+	fmt.Printf("%-12s %s -> %d %s", kind, payload, resp.StatusCode, response)
+}
@@ -0,0 +1,45 @@
+// Command tracecollector is a stand-in for an OpenTelemetry collector. It accepts spans on the OTLP/HTTP protobuf
+// endpoint and prints one line per span, so traces can be checked locally without running a tracing backend.
+//
+// Point the API at it with TRACING_EXPORTER=otlp and OTEL_EXPORTER_OTLP_ENDPOINT=http://localhost:4318.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"shared-bike/tracing"
+)
+
+func main() {
+	addr := flag.String("addr", ":4318", "listen address")
+	flag.Parse()
+
+	collector := tracing.NewCollector()
+	collector.OnSpan = func(span tracing.CollectedSpan) {
+		log.Println("[TraceCollector]", span)
+	}
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           collector,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-quit
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}()
+	log.Println("[TraceCollector] listening on", *addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal("[TraceCollector] serve failed ", err)
+	}
+}
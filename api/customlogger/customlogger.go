@@ -10,6 +10,7 @@ import (
 type logger struct {
 	echoLog   echo.Logger
 	requestID string
+	traceID   string
 }
 
 func NewContextLogger(echoLog echo.Logger) *logger {
@@ -18,8 +19,21 @@ func NewContextLogger(echoLog echo.Logger) *logger {
 	}
 }
 
-func (l *logger) SetRequestID(requestID string) {
-	l.requestID = requestID
+// With returns a logger for one request whose lines carry requestID and traceID, the trace ID is empty when tracing
+// is off. l is left alone, so concurrent requests never write each other's IDs.
+func (l *logger) With(requestID, traceID string) echo.Logger {
+	return &logger{
+		echoLog:   l.echoLog,
+		requestID: requestID,
+		traceID:   traceID,
+	}
+}
+
+func (l *logger) withTraceID(j log.JSON) log.JSON {
+	if l.traceID != "" {
+		j["traceId"] = l.traceID
+	}
+	return j
 }
 
 func (l *logger) Output() io.Writer {
@@ -63,11 +77,11 @@ func (l *logger) Debugj(j log.JSON) {
 	l.echoLog.Debugj(j)
 }
 func (l *logger) Info(i ...interface{}) {
-	l.echoLog.Infoj(log.JSON{
+	l.echoLog.Infoj(l.withTraceID(log.JSON{
 		"id":      l.requestID,
 		"message": i[0],
 		"args":    i[1:],
-	})
+	}))
 }
 func (l *logger) Infof(format string, i ...interface{}) {
 	l.echoLog.Infof(format, i...)
@@ -76,11 +90,11 @@ func (l *logger) Infoj(j log.JSON) {
 	l.echoLog.Infoj(j)
 }
 func (l *logger) Warn(i ...interface{}) {
-	l.echoLog.Warnj(log.JSON{
+	l.echoLog.Warnj(l.withTraceID(log.JSON{
 		"id":      l.requestID,
 		"message": i[0],
 		"args":    i[1:],
-	})
+	}))
 }
 func (l *logger) Warnf(format string, i ...interface{}) {
 	l.echoLog.Warnf(format, i...)
@@ -89,12 +103,12 @@ func (l *logger) Warnj(j log.JSON) {
 	l.echoLog.Warnj(j)
 }
 func (l *logger) Error(i ...interface{}) {
-	l.echoLog.Errorj(log.JSON{
+	l.echoLog.Errorj(l.withTraceID(log.JSON{
 		"id":      l.requestID,
 		"message": i[0],
 		"error":   i[1],
 		"args":    i[2:],
-	})
+	}))
 }
 func (l *logger) Errorf(format string, i ...interface{}) {
 	l.echoLog.Errorf(format, i...)
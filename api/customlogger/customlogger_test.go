@@ -1,7 +1,11 @@
 package customlogger
 
 import (
+	"bytes"
+	"io"
 	"shared-bike/apperrors"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/labstack/echo/v4"
@@ -24,10 +28,37 @@ func TestCustomLoggerTestSuite(t *testing.T) {
 	suite.Run(t, new(CustomLoggerTestSuite))
 }
 
-func (s *CustomLoggerTestSuite) TestSetRequestID() {
-	s.logger.SetHeader("mockHeader")
-	s.logger.SetRequestID("requestID")
-	s.Equal("requestID", s.logger.requestID)
+func (s *CustomLoggerTestSuite) TestWith() {
+	output := &bytes.Buffer{}
+	s.logger.SetOutput(output)
+	s.logger.SetLevel(log.INFO)
+	requestLogger := s.logger.With("requestID", "4bf92f3577b34da6a3ce929d0e0e4736")
+	requestLogger.Info("[Test] with trace")
+	s.Contains(output.String(), `"id":"requestID"`)
+	s.Contains(output.String(), `"traceId":"4bf92f3577b34da6a3ce929d0e0e4736"`)
+	output.Reset()
+	s.logger.With("other", "").Error("[Test] without trace", apperrors.ErrInternalServerError)
+	s.Contains(output.String(), `"id":"other"`)
+	s.NotContains(output.String(), "traceId")
+	output.Reset()
+	s.logger.Info("[Test] shared logger")
+	s.Contains(output.String(), `"id":""`)
+	s.NotContains(output.String(), "traceId")
+}
+
+func (s *CustomLoggerTestSuite) TestWith_ConcurrentRequests() {
+	s.logger.SetOutput(io.Discard)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			requestLogger := s.logger.With(strconv.Itoa(i), "").(*logger)
+			s.Equal(strconv.Itoa(i), requestLogger.requestID)
+		}(i)
+	}
+	wg.Wait()
+	s.Equal("", s.logger.requestID)
 }
 
 func (s *CustomLoggerTestSuite) TestOutput() {
@@ -20,9 +20,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/bikes": {
+        "/admin/audit": {
             "get": {
-                "description": "API for getting all bikes",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for querying the audit log of state-changing actions, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,9 +35,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bikes"
+                    "admin"
+                ],
+                "summary": "Get audit events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user who performed the action",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "action, e.g. bike.rent",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "target type, e.g. bike",
+                        "name": "targetType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "target id",
+                        "name": "targetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "request id",
+                        "name": "requestId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "inclusive lower bound in RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "exclusive upper bound in RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, default 50, max 200",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all bikes",
                 "responses": {
                     "200": {
                         "description": "Success",
@@ -41,11 +102,23 @@ const docTemplate = `{
                             "items": {
                                 "type": "array",
                                 "items": {
-                                    "$ref": "#/definitions/domain.BikeDTO"
+                                    "$ref": "#/definitions/domain.AuditEventDTO"
                                 }
                             }
                         }
                     },
+                    "400": {
+                        "description": "invalid audit query",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "you do not have permission to perform this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "internal server error",
                         "schema": {
@@ -55,37 +128,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/bikes/{id}/rent": {
-            "patch": {
-                "description": "API for renting a bike",
-                "consumes": [
-                    "application/json"
+        "/admin/bikes/labels": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "API for rendering the labels of up to 24 bikes on one A4 page of 4 by 6 stickers",
                 "produces": [
-                    "application/json"
+                    "image/svg+xml",
+                    "image/png"
                 ],
                 "tags": [
-                    "bikes"
+                    "labels"
                 ],
-                "summary": "Rent a bike",
+                "summary": "Get a printable sheet of bike labels",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "bike id",
-                        "name": "id",
-                        "in": "path",
+                        "example": "1,2,3",
+                        "description": "comma separated bike ids",
+                        "name": "ids",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "svg (default) or png",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "Sheet",
                         "schema": {
-                            "$ref": "#/definitions/domain.BikeDTO"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "invalid bike id | cannot rent because you have already rented a bike | user not exists or inactive | bike not found | cannot rent because bike is rented",
+                        "description": "invalid label query, expected format svg or png and 1 to 24 bike ids",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "permission denied",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "bike not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "the bike has no short code yet",
                         "schema": {
                             "type": "string"
                         }
@@ -99,19 +200,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/bikes/{id}/return": {
-            "patch": {
-                "description": "API for returning a bike",
-                "consumes": [
-                    "application/json"
+        "/admin/bikes/{id}/label": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "API for rendering the printable QR code label of a bike, the QR code encodes a deep-link with the bike code",
                 "produces": [
-                    "application/json"
+                    "image/svg+xml",
+                    "image/png"
                 ],
                 "tags": [
-                    "bikes"
+                    "labels"
                 ],
-                "summary": "Return a bike",
+                "summary": "Get the label of a bike",
                 "parameters": [
                     {
                         "type": "string",
@@ -119,20 +223,41 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "svg (default) or png",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "Label",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.BikeDTO"
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "invalid bike id | bike not found | cannot return because bike is available | cannot return because bike is not yours",
+                        "description": "invalid bike id | invalid label query, expected format svg or png and 1 to 24 bike ids",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "permission denied",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "bike not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "the bike has no short code yet",
                         "schema": {
                             "type": "string"
                         }
@@ -146,9 +271,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/login": {
+        "/admin/consistency": {
             "post": {
-                "description": "API for logining",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for scanning every bike for anomalies such as a rented bike without renter, reported by category. Runs dry unless dryRun is false, then repairable anomalies are repaired and audited as bike.repair.",
                 "consumes": [
                     "application/json"
                 ],
@@ -156,25 +286,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Login",
+                "summary": "Check the fleet for inconsistent bikes",
                 "parameters": [
                     {
-                        "description": "Login body",
+                        "description": "Dry run or repair",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.LoginBody"
+                            "$ref": "#/definitions/domain.ConsistencyCheckBody"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/domain.Credentials"
+                            "$ref": "#/definitions/domain.ConsistencyReport"
                         }
                     },
                     "400": {
@@ -183,8 +312,8 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "username or password is wrong",
+                    "403": {
+                        "description": "you do not have permission to perform this action",
                         "schema": {
                             "type": "string"
                         }
@@ -198,9 +327,119 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/register": {
+        "/admin/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for getting all staff roles and their permissions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get all roles",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/domain.RoleDTO"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "you do not have permission to perform this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for getting the roles assigned to a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get roles of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/domain.RoleDTO"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid user id | user does not exist or inactive",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "you do not have permission to perform this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "API for registering new user",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for granting a staff role to a user, the user has to login again to receive the new permissions",
                 "consumes": [
                     "application/json"
                 ],
@@ -208,29 +447,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Register new user",
+                "summary": "Assign a role to a user",
                 "parameters": [
                     {
-                        "description": "Register body",
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assign role body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.RegisterBody"
+                            "$ref": "#/definitions/domain.AssignRoleBody"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/domain.Credentials"
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/domain.RoleDTO"
+                                }
+                            }
                         }
                     },
                     "400": {
-                        "description": "invalid body",
+                        "description": "invalid user id | invalid role id | invalid body | user does not exist or inactive",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "you do not have permission to perform this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "role not found",
                         "schema": {
                             "type": "string"
                         }
@@ -243,25 +507,1533 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.BikeDTO": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "lat": {
-                    "type": "string",
-                    "example": "50.119504"
-                },
-                "long": {
-                    "type": "string",
-                    "example": "8.638137"
-                },
-                "name": {
+        },
+        "/admin/users/{id}/roles/{roleId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for revoking a staff role from a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Remove a role from a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "role id",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/domain.RoleDTO"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid user id | invalid role id | user does not exist or inactive",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "you do not have permission to perform this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "role not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/zones": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for importing service areas and no-parking zones from a GeoJSON FeatureCollection of Polygon or MultiPolygon features",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "zones"
+                ],
+                "summary": "Import zones",
+                "parameters": [
+                    {
+                        "description": "Zones",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ZoneFeatureCollection"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ZoneFeatureCollection"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid body | invalid zone, expected a GeoJSON FeatureCollection of polygons",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "you do not have permission to perform this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bikes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for getting all bikes, optionally only of one type or e-bikes charged at least minBattery percent. userId and nameOfRenter of a rented bike are only filled for its renter and for staff with bikes:read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bikes"
+                ],
+                "summary": "Get all bikes",
+                "parameters": [
+                    {
+                        "enum": [
+                            "classic",
+                            "ebike",
+                            "cargo"
+                        ],
+                        "type": "string",
+                        "description": "bike type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "minimum battery percentage",
+                        "name": "minBattery",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the list the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of the list the client has",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/domain.BikeDTO"
+                                }
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "weak tag over the ids and versions of the listed bikes"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "latest change among the listed bikes"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "400": {
+                        "description": "invalid bike query",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bikes/by-code/{code}/rent": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for renting the bike whose QR label carries the code, lowercase codes are accepted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bikes"
+                ],
+                "summary": "Rent a bike by its label code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bike short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "client generated key, retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the bike as read, the action fails with 409 if the bike changed since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BikeDTO"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the bike after the action"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid bike code | invalid idempotency key | invalid If-Match header | cannot rent because you have already rented a bike | user not exists or inactive | cannot rent because bike is rented",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "bike not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot rent because the bike is out of service | cannot rent because the e-bike battery is too low | a request with this idempotency key is still in progress | the bike was changed by someone else, reload it and try again",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "the idempotency key was already used for a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "the bike lock did not confirm, please try again",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bikes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for getting one bike. userId and nameOfRenter are only filled for its renter and for staff with bikes:read. Its ETag is weak and changes with the version and every lock reading, If-Match on rent and return takes the version in quotes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bikes"
+                ],
+                "summary": "Get a bike",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bike id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the bike the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of the bike the client has",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BikeDTO"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "weak tag over the version and the newest lock reading"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "last change of the bike"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "400": {
+                        "description": "invalid bike id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "bike not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bikes/{id}/photos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for the maintenance crew to list report and return photos of a bike with signed download links",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "photos"
+                ],
+                "summary": "Get photos of a bike",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bike id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/domain.PhotoDTO"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid bike id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "you do not have permission to perform this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bikes/{id}/rent": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for renting a bike",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bikes"
+                ],
+                "summary": "Rent a bike",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bike id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "client generated key, retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the bike as read, the action fails with 409 if the bike changed since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BikeDTO"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the bike after the action"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid bike id | invalid idempotency key | invalid If-Match header | cannot rent because you have already rented a bike | user not exists or inactive | bike not found | cannot rent because bike is rented",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot rent because the bike is out of service | cannot rent because the e-bike battery is too low | a request with this idempotency key is still in progress | the bike was changed by someone else, reload it and try again",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "the idempotency key was already used for a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "the bike lock did not confirm, please try again",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bikes/{id}/report": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for riders to report a problem with a bike, the bike stays in service until staff confirm the ticket",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bikes"
+                ],
+                "summary": "Report a damaged bike",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bike id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Report body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReportBikeBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MaintenanceTicketDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid bike id | invalid body | invalid report category | bike not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "the bike was changed by someone else, reload it and try again",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bikes/{id}/report/{ticketId}/photos": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for attaching photo evidence to a bike report, jpeg or png up to 5MB",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "photos"
+                ],
+                "summary": "Upload a photo for a damage report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bike id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "maintenance ticket id returned by the report",
+                        "name": "ticketId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "photo",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PhotoDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid bike id | invalid maintenance ticket id | invalid photo, only jpeg and png images are accepted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "you do not have permission to perform this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "maintenance ticket not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "photo is too large",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bikes/{id}/return": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for returning a bike, lat and long are required in free-floating mode and stationId in station mode. A return in a zone charging extra carries the surcharge.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bikes"
+                ],
+                "summary": "Return a bike",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bike id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "client generated key, retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the bike as read, the action fails with 409 if the bike changed since",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Drop-off location or station",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReturnBikeBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BikeDTO"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the bike after the action"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid bike id | invalid body | invalid idempotency key | invalid If-Match header | invalid drop-off location | cannot return because the drop-off location is too far from the bike | a station id is required to return a bike | cannot return outside the service area | cannot return inside a no-parking zone | bike not found | cannot return because bike is available | cannot return because bike is not yours",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "station not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot return because the station has no free dock | a request with this idempotency key is still in progress | the bike was changed by someone else, reload it and try again",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "the idempotency key was already used for a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "the bike lock did not confirm, please try again",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bikes/{id}/return/photos": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for the current renter to attach photos of the bike before ending the ride, jpeg or png up to 5MB",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "photos"
+                ],
+                "summary": "Upload a photo when returning a bike",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bike id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "photo",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PhotoDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid bike id | invalid photo, only jpeg and png images are accepted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "you do not have permission to perform this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "bike not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "photo is too large",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/telemetry": {
+            "post": {
+                "description": "Device-facing API for a smart lock to report its position, battery and lock state. The body is signed with the device secret: X-Device-Signature is the hex HMAC-SHA256 of \"\u003cX-Device-Timestamp\u003e.\u003craw body\u003e\", the timestamp is unix seconds and must be within 5 minutes of the server clock. Readings are deduplicated by device and recordedAt, a reading older than the newest one is stored but does not move the bike",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Ingest lock telemetry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "X-Device-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unix timestamp in seconds",
+                        "name": "X-Device-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hex HMAC-SHA256 signature",
+                        "name": "X-Device-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Telemetry reading",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TelemetryBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TelemetryResultDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid body | invalid telemetry reading",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid device signature",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance/tickets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for the maintenance crew to list tickets, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Get maintenance tickets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "open | in_progress | resolved",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "bike id",
+                        "name": "bikeId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/domain.MaintenanceTicketDTO"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "you do not have permission to perform this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance/tickets/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for moving a ticket between open, in_progress and resolved. in_progress confirms the problem and takes the bike out of service, resolving with retireBike retires it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Update a maintenance ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ticket id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update ticket body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateTicketBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MaintenanceTicketDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid maintenance ticket id | invalid body | bike not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "you do not have permission to perform this action",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "maintenance ticket not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot move the maintenance ticket to this status | the bike was changed by someone else, reload it and try again",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/photos/{id}/{variant}": {
+            "get": {
+                "description": "API for downloading a photo or its thumbnail with a signed link, no bearer token is needed",
+                "produces": [
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "photos"
+                ],
+                "summary": "Download a photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "photo id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "original | thumbnail",
+                        "name": "variant",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "unix time the link expires at",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "link signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid photo id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "download link is invalid or expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "photo not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for getting all docking stations with their available bikes and free docks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stations"
+                ],
+                "summary": "Get all stations",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/domain.StationDTO"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/login": {
+            "post": {
+                "description": "API for logining",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "Login body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.LoginBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Credentials"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "username or password is wrong",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/erasure": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for erasing the logged in user. Username, name and password are anonymised and the account cannot log in again, rides, payments and the audit trail are kept without personal fields. Refused while a bike is rented.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Erase my account",
+                "parameters": [
+                    {
+                        "description": "Current password to confirm",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErasureBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Erased"
+                    },
+                    "400": {
+                        "description": "invalid body | password is wrong | user does not exist or inactive",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot erase the account while a bike is rented",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for downloading everything stored about the logged in user as a JSON file: profile, rides, payments, reports and audit entries. Rides and payments are rebuilt from the audit trail.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Export my data",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.UserExport"
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "attachment; filename=\\\"shared-bike-export-1.json\\"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "user does not exist or inactive",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/register": {
+            "post": {
+                "description": "API for registering new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Register new user",
+                "parameters": [
+                    {
+                        "description": "Register body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RegisterBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Credentials"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/zones": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API for getting the service areas and no-parking zones as a GeoJSON FeatureCollection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "zones"
+                ],
+                "summary": "Get all zones",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ZoneFeatureCollection"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AssignRoleBody": {
+            "type": "object",
+            "properties": {
+                "roleId": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "domain.AuditEventDTO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "bike.rent"
+                },
+                "actorId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2022-07-06T18:51:44Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "127.0.0.1"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+                },
+                "targetId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "targetType": {
+                    "type": "string",
+                    "example": "bike"
+                }
+            }
+        },
+        "domain.BikeAnomaly": {
+            "type": "object",
+            "properties": {
+                "bikeId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category": {
+                    "type": "string",
+                    "example": "rented_without_renter"
+                },
+                "repairStatus": {
+                    "description": "RepairStatus is the status a repair sets along with clearing the renter, empty when a human has to decide.",
+                    "type": "string",
+                    "example": "available"
+                },
+                "repaired": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "rented"
+                },
+                "userId": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "domain.BikeDTO": {
+            "type": "object",
+            "properties": {
+                "battery": {
+                    "type": "integer",
+                    "example": 80
+                },
+                "code": {
+                    "type": "string",
+                    "example": "7F3K9Q2M"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lat": {
+                    "type": "string",
+                    "example": "50.119504"
+                },
+                "long": {
+                    "type": "string",
+                    "example": "8.638137"
+                },
+                "name": {
                     "type": "string",
                     "example": "henry"
                 },
@@ -269,13 +2041,87 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Bob"
                 },
+                "stationId": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "status": {
                     "type": "string",
                     "example": "rented"
                 },
-                "userId": {
+                "surcharge": {
+                    "type": "string",
+                    "example": "5.00"
+                },
+                "telemetry": {
+                    "$ref": "#/definitions/domain.BikeTelemetryDTO"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "ebike"
+                },
+                "userId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "domain.BikeTelemetryDTO": {
+            "type": "object",
+            "properties": {
+                "battery": {
+                    "type": "integer",
+                    "example": 87
+                },
+                "lockState": {
+                    "type": "string",
+                    "example": "locked"
+                },
+                "recordedAt": {
+                    "type": "string",
+                    "example": "2026-10-19T14:00:00Z"
+                }
+            }
+        },
+        "domain.ConsistencyCheckBody": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "domain.ConsistencyReport": {
+            "type": "object",
+            "properties": {
+                "anomalies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BikeAnomaly"
+                    }
+                },
+                "counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "dryRun": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "repaired": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "scanned": {
                     "type": "integer",
-                    "example": 1
+                    "example": 120
                 }
             }
         },
@@ -287,6 +2133,15 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.ErasureBody": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "mypassword"
+                }
+            }
+        },
         "domain.LoginBody": {
             "type": "object",
             "properties": {
@@ -300,6 +2155,109 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.MaintenanceTicketDTO": {
+            "type": "object",
+            "properties": {
+                "bikeId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category": {
+                    "type": "string",
+                    "example": "brakes"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2022-07-06T18:51:44Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": "front brake does not stop the bike"
+                },
+                "reporterId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "resolvedAt": {
+                    "type": "string",
+                    "example": "2022-07-06T18:51:44Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                }
+            }
+        },
+        "domain.PaymentExport": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "5.00"
+                },
+                "bikeId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "chargedAt": {
+                    "type": "string",
+                    "example": "2026-10-19T14:00:00Z"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "return_surcharge"
+                }
+            }
+        },
+        "domain.PhotoDTO": {
+            "type": "object",
+            "properties": {
+                "bikeId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "contentType": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2022-07-06T18:51:44Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "report"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 204800
+                },
+                "thumbnailUrl": {
+                    "type": "string",
+                    "example": "/api/v1/photos/1/thumbnail?expires=1657133504\u0026signature=5d41402abc4b2a76b9719d911017c592"
+                },
+                "ticketId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "uploaderId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/api/v1/photos/1/original?expires=1657133504\u0026signature=5d41402abc4b2a76b9719d911017c592"
+                }
+            }
+        },
         "domain.RegisterBody": {
             "type": "object",
             "properties": {
@@ -316,6 +2274,295 @@ const docTemplate = `{
                     "example": "myusername"
                 }
             }
+        },
+        "domain.ReportBikeBody": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "brakes"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "front brake does not stop the bike"
+                }
+            }
+        },
+        "domain.ReturnBikeBody": {
+            "type": "object",
+            "properties": {
+                "lat": {
+                    "type": "string",
+                    "example": "50.119504"
+                },
+                "long": {
+                    "type": "string",
+                    "example": "8.638137"
+                },
+                "stationId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "domain.RideExport": {
+            "type": "object",
+            "properties": {
+                "bikeId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rentedAt": {
+                    "type": "string",
+                    "example": "2026-10-19T13:00:00Z"
+                },
+                "returnedAt": {
+                    "type": "string",
+                    "example": "2026-10-19T14:00:00Z"
+                },
+                "surcharge": {
+                    "type": "string",
+                    "example": "5.00"
+                }
+            }
+        },
+        "domain.RoleDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Repairs and maintains bikes"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "mechanic"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "bikes:read",
+                        "bikes:maintain"
+                    ]
+                }
+            }
+        },
+        "domain.StationDTO": {
+            "type": "object",
+            "properties": {
+                "availableBikes": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "capacity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "freeDocks": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lat": {
+                    "type": "string",
+                    "example": "50.107145"
+                },
+                "long": {
+                    "type": "string",
+                    "example": "8.663789"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Hauptbahnhof"
+                }
+            }
+        },
+        "domain.TelemetryBody": {
+            "type": "object",
+            "properties": {
+                "battery": {
+                    "type": "integer",
+                    "example": 87
+                },
+                "lat": {
+                    "type": "string",
+                    "example": "50.119504"
+                },
+                "lockState": {
+                    "type": "string",
+                    "example": "locked"
+                },
+                "long": {
+                    "type": "string",
+                    "example": "8.638137"
+                },
+                "recordedAt": {
+                    "type": "string",
+                    "example": "2026-10-19T14:00:00Z"
+                }
+            }
+        },
+        "domain.TelemetryResultDTO": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "applied"
+                }
+            }
+        },
+        "domain.UpdateTicketBody": {
+            "type": "object",
+            "properties": {
+                "retireBike": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "resolved"
+                }
+            }
+        },
+        "domain.UserExport": {
+            "type": "object",
+            "properties": {
+                "auditEntries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AuditEventDTO"
+                    }
+                },
+                "exportedAt": {
+                    "type": "string",
+                    "example": "2026-10-19T14:00:00Z"
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PaymentExport"
+                    }
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PhotoDTO"
+                    }
+                },
+                "profile": {
+                    "$ref": "#/definitions/domain.UserExportProfile"
+                },
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MaintenanceTicketDTO"
+                    }
+                },
+                "rides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RideExport"
+                    }
+                }
+            }
+        },
+        "domain.UserExportProfile": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2022-07-06T18:51:44Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "myname"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "support"
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2022-07-06T18:51:44Z"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "myusername"
+                }
+            }
+        },
+        "domain.ZoneFeature": {
+            "type": "object",
+            "properties": {
+                "geometry": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "properties": {
+                    "$ref": "#/definitions/domain.ZoneProperties"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Feature"
+                }
+            }
+        },
+        "domain.ZoneFeatureCollection": {
+            "type": "object",
+            "properties": {
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ZoneFeature"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "FeatureCollection"
+                }
+            }
+        },
+        "domain.ZoneProperties": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "example": "no_parking"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Zeil"
+                },
+                "policy": {
+                    "type": "string",
+                    "example": "surcharge"
+                },
+                "surcharge": {
+                    "type": "string",
+                    "example": "5.00"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+var (
+	AuditActionBikeRent     AuditAction = "bike.rent"
+	AuditActionBikeReturn   AuditAction = "bike.return"
+	AuditActionBikeReport   AuditAction = "bike.report"
+	AuditActionBikeStatus   AuditAction = "bike.status"
+	AuditActionBikeRepair   AuditAction = "bike.repair"
+	AuditActionTicketUpdate AuditAction = "maintenance_ticket.update"
+	AuditActionPhotoUpload  AuditAction = "bike_photo.upload"
+	AuditActionZoneImport   AuditAction = "zone.import"
+	AuditActionUserRegister AuditAction = "user.register"
+	AuditActionUserExport   AuditAction = "user.export"
+	AuditActionUserErase    AuditAction = "user.erase"
+	AuditActionRoleAssign   AuditAction = "role.assign"
+	AuditActionRoleUnassign AuditAction = "role.unassign"
+)
+
+var (
+	AuditTargetBike   = "bike"
+	AuditTargetUser   = "user"
+	AuditTargetTicket = "maintenance_ticket"
+	AuditTargetPhoto  = "bike_photo"
+	AuditTargetZone   = "zone"
+)
+
+type requestMetadataContextKey struct{}
+
+type RequestMetadata struct {
+	RequestID string
+	IP        string
+}
+
+func NewContextWithRequestMetadata(ctx context.Context, metadata RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataContextKey{}, metadata)
+}
+
+func RequestMetadataFromContext(ctx context.Context) RequestMetadata {
+	metadata, _ := ctx.Value(requestMetadataContextKey{}).(RequestMetadata)
+	return metadata
+}
+
+type AuditEvent struct {
+	ID         int64          `json:"id"`
+	ActorID    sql.NullInt64  `json:"actorId"`
+	Action     AuditAction    `json:"action"`
+	TargetType string         `json:"targetType"`
+	TargetID   int64          `json:"targetId"`
+	Before     sql.NullString `json:"before"`
+	After      sql.NullString `json:"after"`
+	RequestID  string         `json:"requestId"`
+	IP         string         `json:"ip"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
+func (a *AuditEvent) ToDTO() AuditEventDTO {
+	auditEventDTO := AuditEventDTO{
+		ID:         a.ID,
+		Action:     a.Action,
+		TargetType: a.TargetType,
+		TargetID:   a.TargetID,
+		RequestID:  a.RequestID,
+		IP:         a.IP,
+		CreatedAt:  a.CreatedAt,
+	}
+	if a.ActorID.Valid {
+		auditEventDTO.ActorID = a.ActorID.Int64
+	}
+	if a.Before.Valid {
+		auditEventDTO.Before = json.RawMessage(a.Before.String)
+	}
+	if a.After.Valid {
+		auditEventDTO.After = json.RawMessage(a.After.String)
+	}
+	return auditEventDTO
+}
+
+func (AuditEvent) TableName() string {
+	return "audit_event"
+}
+
+// AuditRecord is what use cases hand to the auditor; Before and After are marshalled to JSON as they are.
+type AuditRecord struct {
+	ActorID    int64
+	Action     AuditAction
+	TargetType string
+	TargetID   int64
+	Before     interface{}
+	After      interface{}
+}
+
+type AuditFilter struct {
+	ActorID    int64
+	Action     AuditAction
+	TargetType string
+	TargetID   int64
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type AuditQuery struct {
+	ActorID    int64  `query:"actorId"`
+	Action     string `query:"action"`
+	TargetType string `query:"targetType"`
+	TargetID   int64  `query:"targetId"`
+	RequestID  string `query:"requestId"`
+	From       string `query:"from"`
+	To         string `query:"to"`
+	Limit      int    `query:"limit"`
+	Offset     int    `query:"offset"`
+}
+
+type AuditEventDTO struct {
+	ID         int64           `json:"id" example:"1"`
+	ActorID    int64           `json:"actorId" example:"1"`
+	Action     AuditAction     `json:"action" example:"bike.rent"`
+	TargetType string          `json:"targetType" example:"bike"`
+	TargetID   int64           `json:"targetId" example:"1"`
+	Before     json.RawMessage `json:"before" swaggertype:"object"`
+	After      json.RawMessage `json:"after" swaggertype:"object"`
+	RequestID  string          `json:"requestId" example:"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+	IP         string          `json:"ip" example:"127.0.0.1"`
+	CreatedAt  time.Time       `json:"createdAt" example:"2022-07-06T18:51:44Z"`
+}
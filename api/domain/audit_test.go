@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditDomainTestSuite struct {
+	suite.Suite
+	event *AuditEvent
+}
+
+func (s *AuditDomainTestSuite) SetupTest() {
+	s.event = &AuditEvent{
+		ID:         1,
+		ActorID:    sql.NullInt64{Valid: true, Int64: 2},
+		Action:     AuditActionBikeReturn,
+		TargetType: AuditTargetBike,
+		TargetID:   3,
+		Before:     sql.NullString{Valid: true, String: `{"status":"rented"}`},
+		After:      sql.NullString{Valid: true, String: `{"status":"available"}`},
+		RequestID:  "request-id",
+		IP:         "127.0.0.1",
+		CreatedAt:  time.Time{},
+	}
+}
+
+func TestAuditDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditDomainTestSuite))
+}
+
+func (s *AuditDomainTestSuite) TestToDTO_Success() {
+	expected := AuditEventDTO{
+		ID:         1,
+		ActorID:    2,
+		Action:     AuditActionBikeReturn,
+		TargetType: AuditTargetBike,
+		TargetID:   3,
+		Before:     json.RawMessage(`{"status":"rented"}`),
+		After:      json.RawMessage(`{"status":"available"}`),
+		RequestID:  "request-id",
+		IP:         "127.0.0.1",
+	}
+	s.Equal(expected, s.event.ToDTO())
+}
+
+func (s *AuditDomainTestSuite) TestToDTO_SuccessWithoutSnapshots() {
+	s.event.ActorID = sql.NullInt64{}
+	s.event.Before = sql.NullString{}
+	s.event.After = sql.NullString{}
+	actual := s.event.ToDTO()
+	s.Equal(int64(0), actual.ActorID)
+	s.Nil(actual.Before)
+	s.Nil(actual.After)
+}
+
+func (s *AuditDomainTestSuite) TestTableName_Success() {
+	s.Equal("audit_event", s.event.TableName())
+}
+
+func (s *AuditDomainTestSuite) TestRequestMetadataFromContext_Success() {
+	metadata := RequestMetadata{RequestID: "request-id", IP: "127.0.0.1"}
+	ctx := NewContextWithRequestMetadata(context.TODO(), metadata)
+	s.Equal(metadata, RequestMetadataFromContext(ctx))
+	s.Equal(RequestMetadata{}, RequestMetadataFromContext(context.TODO()))
+}
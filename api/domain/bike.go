@@ -1,7 +1,11 @@
 package domain
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -11,33 +15,66 @@ import (
 type BikeStatus string
 
 var (
-	BikeStatusRented    BikeStatus = "rented"
-	BikeStatusAvailable BikeStatus = "available"
+	BikeStatusRented      BikeStatus = "rented"
+	BikeStatusAvailable   BikeStatus = "available"
+	BikeStatusMaintenance BikeStatus = "maintenance"
+	BikeStatusRetired     BikeStatus = "retired"
 )
 
 type Bike struct {
-	ID        int64            `json:"id"`
-	Name      string           `json:"name"`
-	Lat       *decimal.Decimal `json:"lat"`
-	Long      *decimal.Decimal `json:"long"`
-	Status    BikeStatus       `json:"status"`
-	UserID    sql.NullInt64    `json:"userId"`
-	CreatedAt time.Time        `json:"-"`
-	UpdatedAt time.Time        `json:"-"`
-	DeletedAt gorm.DeletedAt   `json:"-"`
+	ID         int64            `json:"id"`
+	Name       string           `json:"name"`
+	Type       BikeType         `json:"type"`
+	Code       string           `json:"code"`
+	Lat        *decimal.Decimal `json:"lat"`
+	Long       *decimal.Decimal `json:"long"`
+	Status     BikeStatus       `json:"status"`
+	UserID     sql.NullInt64    `json:"userId"`
+	StationID  sql.NullInt64    `json:"stationId"`
+	Battery    sql.NullInt64    `json:"battery"`
+	LockState  LockState        `json:"lockState"`
+	LastSeenAt sql.NullTime     `json:"lastSeenAt"`
+	Version    int64            `json:"version"`
+	CreatedAt  time.Time        `json:"-"`
+	UpdatedAt  time.Time        `json:"-"`
+	DeletedAt  gorm.DeletedAt   `json:"-"`
 }
 
 func (b *Bike) ToDTO() BikeDTO {
 	bikeDTO := BikeDTO{
-		ID:     b.ID,
-		Name:   b.Name,
-		Lat:    b.Lat.String(),
-		Long:   b.Long.String(),
-		Status: b.Status,
+		ID:           b.ID,
+		Name:         b.Name,
+		Type:         b.Type,
+		Code:         b.Code,
+		Status:       b.Status,
+		Version:      b.Version,
+		LastModified: b.UpdatedAt,
+	}
+	if b.HasLocation() {
+		bikeDTO.Lat = b.Lat.String()
+		bikeDTO.Long = b.Long.String()
 	}
 	if b.UserID.Valid {
 		bikeDTO.UserID = b.UserID.Int64
 	}
+	if b.StationID.Valid {
+		bikeDTO.StationID = b.StationID.Int64
+	}
+	if b.Type.IsElectric() && b.Battery.Valid {
+		battery := b.Battery.Int64
+		bikeDTO.Battery = &battery
+	}
+	if b.LastSeenAt.Valid {
+		telemetry := &BikeTelemetryDTO{
+			LockState:  b.LockState,
+			RecordedAt: b.LastSeenAt.Time,
+		}
+		if b.Battery.Valid {
+			battery := b.Battery.Int64
+			telemetry.Battery = &battery
+		}
+		bikeDTO.Telemetry = telemetry
+	}
 	return bikeDTO
 }
 
@@ -49,21 +86,154 @@ func (b *Bike) IsAvailable() bool {
 	return b.Status == BikeStatusAvailable && !b.UserID.Valid
 }
 
+// IsOutOfService reports whether the bike is withdrawn from riders for service reasons.
+func (b *Bike) IsOutOfService() bool {
+	return b.Status == BikeStatusMaintenance || b.Status == BikeStatusRetired
+}
+
+// BatteryBelow reports whether the bike is an e-bike known to be charged below percent.
+// An e-bike whose lock never reported a battery level is not assumed to be empty.
+func (b *Bike) BatteryBelow(percent int64) bool {
+	return b.Type.IsElectric() && b.Battery.Valid && b.Battery.Int64 < percent
+}
+
+// HasLocation reports whether the last known position of the bike is recorded.
+func (b *Bike) HasLocation() bool {
+	return b.Lat != nil && b.Long != nil
+}
+
 func (Bike) TableName() string {
 	return "bike"
 }
 
+// BeforeCreate gives a bike created without a code a random one, bikes inserted by SQL get one from the column default.
+func (b *Bike) BeforeCreate(tx *gorm.DB) error {
+	if b.Code != "" {
+		return nil
+	}
+	code, err := NewBikeCode()
+	if err != nil {
+		return err
+	}
+	b.Code = code
+	return nil
+}
+
+// BikeETag is the entity tag of a bike, its version in quotes. Rider and staff writes bump the version, lock readings do not.
+func BikeETag(version int64) string {
+	return fmt.Sprintf("%q", strconv.FormatInt(version, 10))
+}
+
+// BikeReadETag is the weak entity tag GET sends for one bike, its version plus the time of its newest lock reading.
+// Readings leave the version alone, so the version on its own would answer 304 to a client holding an old position.
+// It is not accepted by If-Match, writes compare against BikeETag.
+func BikeReadETag(bike BikeDTO) string {
+	tag := strconv.FormatInt(bike.Version, 10)
+	if bike.Telemetry != nil {
+		tag += "-" + strconv.FormatInt(bike.Telemetry.RecordedAt.UnixNano(), 10)
+	}
+	return fmt.Sprintf("W/%q", tag)
+}
+
+// VisibleTo shapes the bike for the caller. Renter identity is only shown to the renter itself and to staff
+// allowed to read bike details, everyone else only learns from the status that the bike is taken.
+// Without claims nothing about the renter is shown.
+func (d BikeDTO) VisibleTo(claims *Claims) BikeDTO {
+	if d.UserID == 0 {
+		return d
+	}
+	if claims != nil && (claims.ID == d.UserID || claims.HasPermission(PermissionBikesRead)) {
+		return d
+	}
+	d.UserID = 0
+	d.NameOfRenter = ""
+	return d
+}
+
+// BikesVisibleTo applies VisibleTo to every bike, the given slice is left untouched.
+func BikesVisibleTo(bikes []BikeDTO, claims *Claims) []BikeDTO {
+	results := make([]BikeDTO, 0, len(bikes))
+	for _, bike := range bikes {
+		results = append(results, bike.VisibleTo(claims))
+	}
+	return results
+}
+
+// BikeListETag is a weak entity tag over the ids, versions and latest lock readings of bikes. It changes whenever a bike
+// of the list changes, reports, joins or leaves it, and is computed without serialising the list.
+func BikeListETag(bikes []BikeDTO) string {
+	hash := sha256.New()
+	for _, bike := range bikes {
+		fmt.Fprintf(hash, "%d:%d", bike.ID, bike.Version)
+		if bike.Telemetry != nil {
+			fmt.Fprintf(hash, ":%d", bike.Telemetry.RecordedAt.UnixNano())
+		}
+		hash.Write([]byte{','})
+	}
+	return fmt.Sprintf("W/\"%x\"", hash.Sum(nil)[:16])
+}
+
+// BikeListLastModified is the latest change among bikes, zero for an empty list. A bike leaving the list does not move
+// it, which is why the list ETag is checked first.
+func BikeListLastModified(bikes []BikeDTO) time.Time {
+	lastModified := time.Time{}
+	for _, bike := range bikes {
+		if bike.LastModified.After(lastModified) {
+			lastModified = bike.LastModified
+		}
+	}
+	return lastModified
+}
+
+// ParseBikeIfMatch reads the version a client expects from an If-Match header. An empty header or "*" accepts any
+// version and gives 0. Weak tags are refused because If-Match compares strongly.
+func ParseBikeIfMatch(header string) (int64, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, true
+	}
+	if len(header) < 3 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// ReturnBikeBody carries the drop-off coordinates in free-floating mode and the station in station mode.
+type ReturnBikeBody struct {
+	Lat       *decimal.Decimal `json:"lat" swaggertype:"string" example:"50.119504"`
+	Long      *decimal.Decimal `json:"long" swaggertype:"string" example:"8.638137"`
+	StationID int64            `json:"stationId" example:"1"`
+}
+
 type RentOrReturnRequestPayload struct {
-	ID     int64 `json:"id"`
-	UserID int64 `json:"userId"`
+	ID        int64            `json:"id"`
+	UserID    int64            `json:"userId"`
+	Lat       *decimal.Decimal `json:"lat"`
+	Long      *decimal.Decimal `json:"long"`
+	StationID int64            `json:"stationId"`
+	// Version is the version the client read, taken from If-Match. Zero skips the check.
+	Version int64 `json:"version"`
 }
 
 type BikeDTO struct {
-	ID           int64      `json:"id" example:"1"`
-	Name         string     `json:"name" example:"henry"`
-	Lat          string     `json:"lat" example:"50.119504"`
-	Long         string     `json:"long" example:"8.638137"`
-	Status       BikeStatus `json:"status" example:"rented"`
-	UserID       int64      `json:"userId" example:"1"`
-	NameOfRenter string     `json:"nameOfRenter" example:"Bob"`
+	ID           int64             `json:"id" example:"1"`
+	Name         string            `json:"name" example:"henry"`
+	Type         BikeType          `json:"type,omitempty" example:"ebike"`
+	Code         string            `json:"code,omitempty" example:"7F3K9Q2M"`
+	Lat          string            `json:"lat" example:"50.119504"`
+	Long         string            `json:"long" example:"8.638137"`
+	Status       BikeStatus        `json:"status" example:"rented"`
+	UserID       int64             `json:"userId" example:"1"`
+	NameOfRenter string            `json:"nameOfRenter" example:"Bob"`
+	Battery      *int64            `json:"battery,omitempty" example:"80"`
+	StationID    int64             `json:"stationId,omitempty" example:"1"`
+	Surcharge    string            `json:"surcharge,omitempty" example:"5.00"`
+	Telemetry    *BikeTelemetryDTO `json:"telemetry,omitempty"`
+	Version      int64             `json:"version" example:"3"`
+	// LastModified feeds the Last-Modified header and is not part of the body.
+	LastModified time.Time `json:"-"`
 }
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"fmt"
+
+	"shared-bike/apperrors"
+)
+
+// BikeEvent is something that happens to a bike and may move it to another status.
+type BikeEvent string
+
+var (
+	BikeEventRent            BikeEvent = "rent"
+	BikeEventReturn          BikeEvent = "return"
+	BikeEventReturnForRepair BikeEvent = "return_for_repair"
+	BikeEventReport          BikeEvent = "report"
+	BikeEventRepair          BikeEvent = "repair"
+	BikeEventRetire          BikeEvent = "retire"
+)
+
+var (
+	BikeStatuses = []BikeStatus{BikeStatusAvailable, BikeStatusRented, BikeStatusMaintenance, BikeStatusRetired}
+	BikeEvents   = []BikeEvent{BikeEventRent, BikeEventReturn, BikeEventReturnForRepair, BikeEventReport, BikeEventRepair, BikeEventRetire}
+)
+
+// bikeTransitions is the whole bike lifecycle, an event missing for a status is not allowed in that status.
+var bikeTransitions = map[BikeStatus]map[BikeEvent]BikeStatus{
+	BikeStatusAvailable: {
+		BikeEventRent:   BikeStatusRented,
+		BikeEventReport: BikeStatusMaintenance,
+	},
+	BikeStatusRented: {
+		BikeEventReturn:          BikeStatusAvailable,
+		BikeEventReturnForRepair: BikeStatusMaintenance,
+	},
+	BikeStatusMaintenance: {
+		BikeEventRepair: BikeStatusAvailable,
+		BikeEventRetire: BikeStatusRetired,
+	},
+	BikeStatusRetired: {},
+}
+
+// bikeGuards are checked once the event is allowed in the current status, actorID is the user causing the event.
+var bikeGuards = map[BikeEvent]func(b *Bike, actorID int64) error{
+	BikeEventRent:            requireRenter,
+	BikeEventReturn:          requireCurrentRenter,
+	BikeEventReturnForRepair: requireCurrentRenter,
+}
+
+func requireRenter(b *Bike, actorID int64) error {
+	if actorID <= 0 {
+		return apperrors.ErrUserNotExisted
+	}
+	return nil
+}
+
+func requireCurrentRenter(b *Bike, actorID int64) error {
+	if b.UserID.Int64 != actorID {
+		return apperrors.ErrBikeNotYours
+	}
+	return nil
+}
+
+func (s BikeStatus) IsValid() bool {
+	_, ok := bikeTransitions[s]
+	return ok
+}
+
+// TransitionError is returned when a bike cannot take an event, Err is the apperrors code to surface to the caller.
+type TransitionError struct {
+	BikeID int64
+	From   BikeStatus
+	Event  BikeEvent
+	Err    error
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("bike %d cannot %s while %s: %v", e.BikeID, e.Event, e.From, e.Err)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return e.Err
+}
+
+// State is the status the lifecycle works with. Status and renter are written separately, so a row can say rented
+// without a renter, nobody can return such a bike and it is treated as available so it can be rented again.
+func (b *Bike) State() BikeStatus {
+	if b.Status == BikeStatusRented && !b.UserID.Valid {
+		return BikeStatusAvailable
+	}
+	return b.Status
+}
+
+// Next returns the status the bike moves to when actorID causes event, or a *TransitionError.
+func (b *Bike) Next(event BikeEvent, actorID int64) (BikeStatus, error) {
+	from := b.State()
+	next, ok := bikeTransitions[from][event]
+	if !ok {
+		return from, &TransitionError{BikeID: b.ID, From: from, Event: event, Err: rejection(from, event)}
+	}
+	if guard, ok := bikeGuards[event]; ok {
+		if err := guard(b, actorID); err != nil {
+			return from, &TransitionError{BikeID: b.ID, From: from, Event: event, Err: err}
+		}
+	}
+	return next, nil
+}
+
+// rejection keeps the errors riders already know for rent and return, staff events get a generic conflict.
+func rejection(from BikeStatus, event BikeEvent) error {
+	switch event {
+	case BikeEventRent:
+		if from == BikeStatusRented {
+			return apperrors.ErrBikeRented
+		}
+		return apperrors.ErrBikeOutOfService
+	case BikeEventReturn, BikeEventReturnForRepair:
+		if from == BikeStatusAvailable {
+			return apperrors.ErrBikeAvailable
+		}
+		return apperrors.ErrBikeNotYours
+	default:
+		return apperrors.ErrInvalidBikeTransition
+	}
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"shared-bike/apperrors"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BikeStateDomainTestSuite struct {
+	suite.Suite
+}
+
+func TestBikeStateDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(BikeStateDomainTestSuite))
+}
+
+var (
+	noRenter    = sql.NullInt64{}
+	renterAlice = sql.NullInt64{Valid: true, Int64: 1}
+)
+
+func (s *BikeStateDomainTestSuite) TestNext_TransitionMatrix() {
+	// every status of a consistent row against every event, with the renter (1) as actor where a renter exists
+	cases := []struct {
+		status BikeStatus
+		userID sql.NullInt64
+		event  BikeEvent
+		next   BikeStatus
+		err    error
+	}{
+		{BikeStatusAvailable, noRenter, BikeEventRent, BikeStatusRented, nil},
+		{BikeStatusAvailable, noRenter, BikeEventReturn, "", apperrors.ErrBikeAvailable},
+		{BikeStatusAvailable, noRenter, BikeEventReturnForRepair, "", apperrors.ErrBikeAvailable},
+		{BikeStatusAvailable, noRenter, BikeEventReport, BikeStatusMaintenance, nil},
+		{BikeStatusAvailable, noRenter, BikeEventRepair, "", apperrors.ErrInvalidBikeTransition},
+		{BikeStatusAvailable, noRenter, BikeEventRetire, "", apperrors.ErrInvalidBikeTransition},
+
+		{BikeStatusRented, renterAlice, BikeEventRent, "", apperrors.ErrBikeRented},
+		{BikeStatusRented, renterAlice, BikeEventReturn, BikeStatusAvailable, nil},
+		{BikeStatusRented, renterAlice, BikeEventReturnForRepair, BikeStatusMaintenance, nil},
+		{BikeStatusRented, renterAlice, BikeEventReport, "", apperrors.ErrInvalidBikeTransition},
+		{BikeStatusRented, renterAlice, BikeEventRepair, "", apperrors.ErrInvalidBikeTransition},
+		{BikeStatusRented, renterAlice, BikeEventRetire, "", apperrors.ErrInvalidBikeTransition},
+
+		{BikeStatusMaintenance, noRenter, BikeEventRent, "", apperrors.ErrBikeOutOfService},
+		{BikeStatusMaintenance, noRenter, BikeEventReturn, "", apperrors.ErrBikeNotYours},
+		{BikeStatusMaintenance, noRenter, BikeEventReturnForRepair, "", apperrors.ErrBikeNotYours},
+		{BikeStatusMaintenance, noRenter, BikeEventReport, "", apperrors.ErrInvalidBikeTransition},
+		{BikeStatusMaintenance, noRenter, BikeEventRepair, BikeStatusAvailable, nil},
+		{BikeStatusMaintenance, noRenter, BikeEventRetire, BikeStatusRetired, nil},
+
+		{BikeStatusRetired, noRenter, BikeEventRent, "", apperrors.ErrBikeOutOfService},
+		{BikeStatusRetired, noRenter, BikeEventReturn, "", apperrors.ErrBikeNotYours},
+		{BikeStatusRetired, noRenter, BikeEventReturnForRepair, "", apperrors.ErrBikeNotYours},
+		{BikeStatusRetired, noRenter, BikeEventReport, "", apperrors.ErrInvalidBikeTransition},
+		{BikeStatusRetired, noRenter, BikeEventRepair, "", apperrors.ErrInvalidBikeTransition},
+		{BikeStatusRetired, noRenter, BikeEventRetire, "", apperrors.ErrInvalidBikeTransition},
+	}
+	s.Len(cases, len(BikeStatuses)*len(BikeEvents))
+	for _, c := range cases {
+		bike := &Bike{ID: 7, Status: c.status, UserID: c.userID}
+		next, err := bike.Next(c.event, 1)
+		if c.err == nil {
+			s.Nil(err, "%s on %s", c.event, c.status)
+			s.Equal(c.next, next, "%s on %s", c.event, c.status)
+			continue
+		}
+		s.ErrorIs(err, c.err, "%s on %s", c.event, c.status)
+		s.Equal(c.status, next, "%s on %s", c.event, c.status)
+		transitionErr := &TransitionError{}
+		s.True(errors.As(err, &transitionErr))
+		s.Equal(TransitionError{BikeID: 7, From: c.status, Event: c.event, Err: c.err}, *transitionErr)
+	}
+}
+
+func (s *BikeStateDomainTestSuite) TestNext_Guards() {
+	cases := []struct {
+		name    string
+		bike    Bike
+		event   BikeEvent
+		actorID int64
+		err     error
+	}{
+		{"rent without renter", Bike{Status: BikeStatusAvailable}, BikeEventRent, 0, apperrors.ErrUserNotExisted},
+		{"return by someone else", Bike{Status: BikeStatusRented, UserID: renterAlice}, BikeEventReturn, 2, apperrors.ErrBikeNotYours},
+		{"return for repair by someone else", Bike{Status: BikeStatusRented, UserID: renterAlice}, BikeEventReturnForRepair, 2, apperrors.ErrBikeNotYours},
+		{"report by anyone", Bike{Status: BikeStatusAvailable}, BikeEventReport, 2, nil},
+		{"repair by staff", Bike{Status: BikeStatusMaintenance}, BikeEventRepair, 0, nil},
+	}
+	for _, c := range cases {
+		_, err := c.bike.Next(c.event, c.actorID)
+		if c.err == nil {
+			s.Nil(err, c.name)
+			continue
+		}
+		s.ErrorIs(err, c.err, c.name)
+	}
+}
+
+func (s *BikeStateDomainTestSuite) TestNext_RentedWithoutRenterCanBeRentedAgain() {
+	bike := &Bike{ID: 7, Status: BikeStatusRented, UserID: noRenter}
+	s.Equal(BikeStatusAvailable, bike.State())
+	next, err := bike.Next(BikeEventRent, 2)
+	s.Nil(err)
+	s.Equal(BikeStatusRented, next)
+	_, err = bike.Next(BikeEventReturn, 2)
+	s.ErrorIs(err, apperrors.ErrBikeAvailable)
+}
+
+func (s *BikeStateDomainTestSuite) TestNext_UnknownStatus() {
+	bike := &Bike{ID: 7, Status: BikeStatus("lost")}
+	for _, event := range []BikeEvent{BikeEventReport, BikeEventRepair, BikeEventRetire} {
+		_, err := bike.Next(event, 1)
+		s.ErrorIs(err, apperrors.ErrInvalidBikeTransition)
+	}
+	_, err := bike.Next(BikeEventRent, 1)
+	s.ErrorIs(err, apperrors.ErrBikeOutOfService)
+}
+
+func (s *BikeStateDomainTestSuite) TestState_Success() {
+	for _, status := range BikeStatuses {
+		bike := &Bike{Status: status, UserID: renterAlice}
+		s.Equal(status, bike.State())
+	}
+}
+
+func (s *BikeStateDomainTestSuite) TestIsValid_Success() {
+	for _, status := range BikeStatuses {
+		s.True(status.IsValid())
+	}
+	s.False(BikeStatus("lost").IsValid())
+	s.False(BikeStatus("").IsValid())
+}
+
+func (s *BikeStateDomainTestSuite) TestTransitionError_Error() {
+	err := &TransitionError{BikeID: 7, From: BikeStatusRented, Event: BikeEventRent, Err: apperrors.ErrBikeRented}
+	s.Equal("bike 7 cannot rent while rented: e4000 cannot rent because the bike is rented", err.Error())
+}
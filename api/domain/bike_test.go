@@ -2,6 +2,7 @@ package domain
 
 import (
 	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 
@@ -93,3 +94,157 @@ func (s *BikeDomainTestSuite) TestToDTO_SuccessWithUserID() {
 	}
 	s.Equal(expected, actual)
 }
+
+func (s *BikeDomainTestSuite) TestIsOutOfService_Success() {
+	s.False(s.bike.IsOutOfService())
+	s.bike.Status = BikeStatusMaintenance
+	s.True(s.bike.IsOutOfService())
+	s.False(s.bike.IsAvailable())
+	s.bike.Status = BikeStatusRetired
+	s.True(s.bike.IsOutOfService())
+}
+
+func (s *BikeDomainTestSuite) TestToDTO_SuccessWithoutLocation() {
+	s.bike.Lat = nil
+	s.bike.Long = nil
+	s.False(s.bike.HasLocation())
+	actual := s.bike.ToDTO()
+	expected := BikeDTO{
+		ID:     s.bike.ID,
+		Status: s.bike.Status,
+	}
+	s.Equal(expected, actual)
+}
+
+func (s *BikeDomainTestSuite) TestHasLocation_Success() {
+	s.True(s.bike.HasLocation())
+	s.bike.Long = nil
+	s.False(s.bike.HasLocation())
+}
+
+func (s *BikeDomainTestSuite) TestToDTO_SuccessWithStationID() {
+	s.bike.StationID = sql.NullInt64{Valid: true, Int64: 3}
+	actual := s.bike.ToDTO()
+	s.Equal(int64(3), actual.StationID)
+}
+
+func (s *BikeDomainTestSuite) TestToDTO_SuccessWithTelemetry() {
+	recordedAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	s.bike.LastSeenAt = sql.NullTime{Valid: true, Time: recordedAt}
+	s.bike.Battery = sql.NullInt64{Valid: true, Int64: 87}
+	s.bike.LockState = LockStateLocked
+	actual := s.bike.ToDTO()
+	battery := int64(87)
+	s.Equal(&BikeTelemetryDTO{Battery: &battery, LockState: LockStateLocked, RecordedAt: recordedAt}, actual.Telemetry)
+}
+
+func (s *BikeDomainTestSuite) TestToDTO_SuccessWithoutTelemetry() {
+	s.bike.Battery = sql.NullInt64{Valid: true, Int64: 87}
+	actual := s.bike.ToDTO()
+	s.Nil(actual.Telemetry)
+}
+
+func (s *BikeDomainTestSuite) TestBatteryBelow_Success() {
+	s.False(s.bike.BatteryBelow(20))
+	s.bike.Battery = sql.NullInt64{Valid: true, Int64: 10}
+	s.False(s.bike.BatteryBelow(20))
+	s.bike.Type = BikeTypeEBike
+	s.True(s.bike.BatteryBelow(20))
+	s.False(s.bike.BatteryBelow(10))
+	s.bike.Battery = sql.NullInt64{}
+	s.False(s.bike.BatteryBelow(20))
+}
+
+func (s *BikeDomainTestSuite) TestToDTO_BatteryOnlyForEBikes() {
+	s.bike.Battery = sql.NullInt64{Valid: true, Int64: 80}
+	s.Nil(s.bike.ToDTO().Battery)
+	s.bike.Type = BikeTypeEBike
+	actual := s.bike.ToDTO()
+	s.Equal(BikeTypeEBike, actual.Type)
+	s.Equal(int64(80), *actual.Battery)
+}
+
+func (s *BikeDomainTestSuite) TestToDTO_SuccessWithVersion() {
+	s.bike.Version = 3
+	s.Equal(int64(3), s.bike.ToDTO().Version)
+}
+
+func (s *BikeDomainTestSuite) TestBikeETag_Success() {
+	s.Equal(`"3"`, BikeETag(3))
+}
+
+func (s *BikeDomainTestSuite) TestBikeReadETag_Success() {
+	seenAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	s.Equal(`W/"3"`, BikeReadETag(BikeDTO{ID: 1, Version: 3}))
+	s.Equal(fmt.Sprintf(`W/"3-%d"`, seenAt.UnixNano()), BikeReadETag(BikeDTO{ID: 1, Version: 3, Telemetry: &BikeTelemetryDTO{RecordedAt: seenAt}}))
+	s.NotEqual(BikeReadETag(BikeDTO{Version: 3, Telemetry: &BikeTelemetryDTO{RecordedAt: seenAt}}),
+		BikeReadETag(BikeDTO{Version: 3, Telemetry: &BikeTelemetryDTO{RecordedAt: seenAt.Add(time.Second)}}))
+}
+
+func (s *BikeDomainTestSuite) TestParseBikeIfMatch() {
+	testCases := []struct {
+		header  string
+		version int64
+		ok      bool
+	}{
+		{header: "", version: 0, ok: true},
+		{header: "*", version: 0, ok: true},
+		{header: `"3"`, version: 3, ok: true},
+		{header: ` "12" `, version: 12, ok: true},
+		{header: `W/"3"`, ok: false},
+		{header: "3", ok: false},
+		{header: `""`, ok: false},
+		{header: `"0"`, ok: false},
+		{header: `"abc"`, ok: false},
+		{header: `"3", "4"`, ok: false},
+	}
+	for _, testCase := range testCases {
+		version, ok := ParseBikeIfMatch(testCase.header)
+		s.Equal(testCase.ok, ok, testCase.header)
+		s.Equal(testCase.version, version, testCase.header)
+	}
+}
+
+func (s *BikeDomainTestSuite) TestToDTO_SuccessWithLastModified() {
+	updatedAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	s.bike.UpdatedAt = updatedAt
+	s.Equal(updatedAt, s.bike.ToDTO().LastModified)
+}
+
+func (s *BikeDomainTestSuite) TestBikeListETag_Success() {
+	bikes := []BikeDTO{{ID: 1, Version: 1}, {ID: 2, Version: 5}}
+	etag := BikeListETag(bikes)
+	s.Regexp(`^W/"[0-9a-f]{32}"$`, etag)
+	s.Equal(etag, BikeListETag([]BikeDTO{{ID: 1, Version: 1, Name: "renamed"}, {ID: 2, Version: 5}}))
+	s.NotEqual(etag, BikeListETag([]BikeDTO{{ID: 1, Version: 2}, {ID: 2, Version: 5}}))
+	s.NotEqual(etag, BikeListETag([]BikeDTO{{ID: 1, Version: 1, Telemetry: &BikeTelemetryDTO{RecordedAt: time.Now()}}, {ID: 2, Version: 5}}))
+	s.NotEqual(etag, BikeListETag([]BikeDTO{{ID: 1, Version: 1}}))
+	s.NotEqual(etag, BikeListETag([]BikeDTO{}))
+}
+
+func (s *BikeDomainTestSuite) TestBikeListLastModified_Success() {
+	earlier := time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC)
+	later := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	s.Equal(later, BikeListLastModified([]BikeDTO{{LastModified: earlier}, {LastModified: later}}))
+	s.True(BikeListLastModified([]BikeDTO{}).IsZero())
+}
+
+func (s *BikeDomainTestSuite) TestVisibleTo() {
+	rented := BikeDTO{ID: 1, Status: BikeStatusRented, UserID: 2, NameOfRenter: "Bob"}
+	hidden := BikeDTO{ID: 1, Status: BikeStatusRented}
+	s.Equal(rented, rented.VisibleTo(&Claims{ID: 2}))
+	s.Equal(rented, rented.VisibleTo(&Claims{ID: 3, Permissions: []Permission{PermissionBikesRead}}))
+	s.Equal(hidden, rented.VisibleTo(&Claims{ID: 3}))
+	s.Equal(hidden, rented.VisibleTo(&Claims{ID: 3, Permissions: []Permission{PermissionUsersRead}}))
+	s.Equal(hidden, rented.VisibleTo(nil))
+	available := BikeDTO{ID: 4, Status: BikeStatusAvailable}
+	s.Equal(available, available.VisibleTo(nil))
+}
+
+func (s *BikeDomainTestSuite) TestBikesVisibleTo() {
+	bikes := []BikeDTO{{ID: 1, UserID: 2, NameOfRenter: "Bob"}, {ID: 2, UserID: 3, NameOfRenter: "Alice"}}
+	actual := BikesVisibleTo(bikes, &Claims{ID: 3})
+	s.Equal([]BikeDTO{{ID: 1}, {ID: 2, UserID: 3, NameOfRenter: "Alice"}}, actual)
+	s.Equal("Bob", bikes[0].NameOfRenter)
+	s.Equal([]BikeDTO{}, BikesVisibleTo(nil, nil))
+}
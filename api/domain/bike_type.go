@@ -0,0 +1,53 @@
+package domain
+
+import "github.com/shopspring/decimal"
+
+type BikeType string
+
+var (
+	BikeTypeClassic BikeType = "classic"
+	BikeTypeEBike   BikeType = "ebike"
+	BikeTypeCargo   BikeType = "cargo"
+)
+
+// BikeTypeAttributes are the properties every bike of a type shares.
+type BikeTypeAttributes struct {
+	Electric  bool
+	MaxLoadKg int64
+	// PriceMultiplier scales every amount charged for a ride on the type, such as return surcharges.
+	PriceMultiplier decimal.Decimal
+}
+
+var bikeTypeAttributes = map[BikeType]BikeTypeAttributes{
+	BikeTypeClassic: {Electric: false, MaxLoadKg: 120, PriceMultiplier: decimal.NewFromInt(1)},
+	BikeTypeEBike:   {Electric: true, MaxLoadKg: 120, PriceMultiplier: decimal.RequireFromString("1.5")},
+	BikeTypeCargo:   {Electric: false, MaxLoadKg: 200, PriceMultiplier: decimal.NewFromInt(2)},
+}
+
+func (t BikeType) IsValid() bool {
+	_, ok := bikeTypeAttributes[t]
+	return ok
+}
+
+// Attributes returns the attributes of the type, a row without a known type is treated as a classic bike.
+func (t BikeType) Attributes() BikeTypeAttributes {
+	if attributes, ok := bikeTypeAttributes[t]; ok {
+		return attributes
+	}
+	return bikeTypeAttributes[BikeTypeClassic]
+}
+
+func (t BikeType) IsElectric() bool {
+	return t.Attributes().Electric
+}
+
+// BikeQuery is the query string of GET /bikes.
+type BikeQuery struct {
+	Type       string `query:"type"`
+	MinBattery string `query:"minBattery"`
+}
+
+type BikeFilter struct {
+	Type       BikeType
+	MinBattery *int64
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+)
+
+type BikeTypeDomainTestSuite struct {
+	suite.Suite
+}
+
+func TestBikeTypeDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(BikeTypeDomainTestSuite))
+}
+
+func (s *BikeTypeDomainTestSuite) TestIsValid_Success() {
+	s.True(BikeTypeClassic.IsValid())
+	s.True(BikeTypeEBike.IsValid())
+	s.True(BikeTypeCargo.IsValid())
+	s.False(BikeType("tandem").IsValid())
+	s.False(BikeType("").IsValid())
+}
+
+func (s *BikeTypeDomainTestSuite) TestIsElectric_Success() {
+	s.True(BikeTypeEBike.IsElectric())
+	s.False(BikeTypeClassic.IsElectric())
+	s.False(BikeTypeCargo.IsElectric())
+}
+
+func (s *BikeTypeDomainTestSuite) TestAttributes_UnknownTypeIsClassic() {
+	s.Equal(BikeTypeClassic.Attributes(), BikeType("").Attributes())
+	s.True(BikeTypeCargo.Attributes().PriceMultiplier.Equal(decimal.NewFromInt(2)))
+	s.Greater(BikeTypeCargo.Attributes().MaxLoadKg, BikeTypeClassic.Attributes().MaxLoadKg)
+}
@@ -1,6 +1,12 @@
 package domain
 
-import "github.com/golang-jwt/jwt"
+import (
+	"context"
+
+	"shared-bike/apperrors"
+
+	"github.com/golang-jwt/jwt"
+)
 
 var (
 	UserIDKey   = "id"
@@ -8,9 +14,49 @@ var (
 	NameKey     = "name"
 )
 
+type claimsContextKey struct{}
+
 type Claims struct {
-	ID       int64  `json:"id"`
-	Username string `json:"username"`
-	Name     string `json:"name"`
+	ID          int64        `json:"id"`
+	Username    string       `json:"username"`
+	Name        string       `json:"name"`
+	Permissions []Permission `json:"permissions"`
 	jwt.StandardClaims
 }
+
+func (c *Claims) HasPermission(permission Permission) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize returns apperrors.ErrForbidden unless the claims hold every given permission.
+func (c *Claims) Authorize(permissions ...Permission) error {
+	for _, permission := range permissions {
+		if !c.HasPermission(permission) {
+			return apperrors.ErrForbidden
+		}
+	}
+	return nil
+}
+
+func NewContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*Claims)
+	return claims, ok && claims != nil
+}
+
+// Authorize checks the claims stored in ctx, so use cases can guard themselves without knowing about echo.
+func Authorize(ctx context.Context, permissions ...Permission) error {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return apperrors.ErrUnauthorizeError
+	}
+	return claims.Authorize(permissions...)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"shared-bike/apperrors"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClaimsDomainTestSuite struct {
+	suite.Suite
+	claims *Claims
+}
+
+func (s *ClaimsDomainTestSuite) SetupTest() {
+	s.claims = &Claims{
+		ID:          1,
+		Username:    "testUsername",
+		Name:        "testName",
+		Permissions: []Permission{PermissionBikesRead, PermissionBikesMaintain},
+	}
+}
+
+func TestClaimsDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(ClaimsDomainTestSuite))
+}
+
+func (s *ClaimsDomainTestSuite) TestHasPermission_True() {
+	s.True(s.claims.HasPermission(PermissionBikesMaintain))
+}
+
+func (s *ClaimsDomainTestSuite) TestHasPermission_False() {
+	s.False(s.claims.HasPermission(PermissionRolesManage))
+}
+
+func (s *ClaimsDomainTestSuite) TestAuthorize_Success() {
+	s.Nil(s.claims.Authorize(PermissionBikesRead, PermissionBikesMaintain))
+}
+
+func (s *ClaimsDomainTestSuite) TestAuthorize_Forbidden() {
+	s.Equal(apperrors.ErrForbidden, s.claims.Authorize(PermissionBikesRead, PermissionRolesManage))
+}
+
+func (s *ClaimsDomainTestSuite) TestAuthorizeContext_Success() {
+	ctx := NewContextWithClaims(context.TODO(), s.claims)
+	s.Nil(Authorize(ctx, PermissionBikesRead))
+}
+
+func (s *ClaimsDomainTestSuite) TestAuthorizeContext_Forbidden() {
+	ctx := NewContextWithClaims(context.TODO(), s.claims)
+	s.Equal(apperrors.ErrForbidden, Authorize(ctx, PermissionUsersManage))
+}
+
+func (s *ClaimsDomainTestSuite) TestAuthorizeContext_Unauthorized() {
+	s.Equal(apperrors.ErrUnauthorizeError, Authorize(context.TODO(), PermissionBikesRead))
+}
+
+func (s *ClaimsDomainTestSuite) TestClaimsFromContext_Success() {
+	ctx := NewContextWithClaims(context.TODO(), s.claims)
+	actual, ok := ClaimsFromContext(ctx)
+	s.True(ok)
+	s.Equal(s.claims, actual)
+}
+
+func (s *ClaimsDomainTestSuite) TestRoleToDTO_EmptyPermissions() {
+	role := Role{ID: 1, Name: "support", Description: "Helps riders"}
+	s.Equal(RoleDTO{ID: 1, Name: "support", Description: "Helps riders", Permissions: []Permission{}}, role.ToDTO())
+	s.Equal("role", role.TableName())
+	s.Equal("user_role", UserRole{}.TableName())
+}
@@ -0,0 +1,89 @@
+package domain
+
+// AnomalyCategory is a way a bike row can contradict itself or the user table.
+type AnomalyCategory string
+
+var (
+	AnomalyUnknownStatus       AnomalyCategory = "unknown_status"
+	AnomalyRentedWithoutRenter AnomalyCategory = "rented_without_renter"
+	AnomalyRenterDeleted       AnomalyCategory = "renter_deleted"
+	AnomalyIdleWithRenter      AnomalyCategory = "idle_with_renter"
+)
+
+var AnomalyCategories = []AnomalyCategory{
+	AnomalyUnknownStatus,
+	AnomalyRentedWithoutRenter,
+	AnomalyRenterDeleted,
+	AnomalyIdleWithRenter,
+}
+
+type BikeAnomaly struct {
+	Category AnomalyCategory `json:"category" example:"rented_without_renter"`
+	BikeID   int64           `json:"bikeId" example:"1"`
+	Status   BikeStatus      `json:"status" example:"rented"`
+	UserID   int64           `json:"userId,omitempty" example:"2"`
+	// RepairStatus is the status a repair sets along with clearing the renter, empty when a human has to decide.
+	RepairStatus BikeStatus `json:"repairStatus,omitempty" example:"available"`
+	Repaired     bool       `json:"repaired"`
+}
+
+func (a *BikeAnomaly) Repairable() bool {
+	return a.RepairStatus != ""
+}
+
+// CheckBike returns what is wrong with a bike, or nil. renter is the user the bike points at including a
+// soft-deleted one, nil when the bike has no renter or the user row is gone.
+func CheckBike(b *Bike, renter *User) *BikeAnomaly {
+	anomaly := &BikeAnomaly{
+		BikeID: b.ID,
+		Status: b.Status,
+		UserID: b.UserID.Int64,
+	}
+	switch {
+	case !b.Status.IsValid():
+		anomaly.Category = AnomalyUnknownStatus
+	case b.Status == BikeStatusRented && !b.UserID.Valid:
+		anomaly.Category = AnomalyRentedWithoutRenter
+		anomaly.RepairStatus = BikeStatusAvailable
+	case b.UserID.Valid && (renter == nil || renter.DeletedAt.Valid):
+		anomaly.Category = AnomalyRenterDeleted
+		anomaly.RepairStatus = b.Status
+		// nobody can return the bike any more, it stays withdrawn until a mechanic has found it
+		if b.Status == BikeStatusRented {
+			anomaly.RepairStatus = BikeStatusMaintenance
+		}
+	case b.Status != BikeStatusRented && b.UserID.Valid:
+		anomaly.Category = AnomalyIdleWithRenter
+		anomaly.RepairStatus = b.Status
+	default:
+		return nil
+	}
+	return anomaly
+}
+
+// ConsistencyReport is the result of one scan of the fleet, Anomalies lists every bike found and Counts sums them up
+// by category.
+type ConsistencyReport struct {
+	DryRun    bool                    `json:"dryRun" example:"true"`
+	Scanned   int64                   `json:"scanned" example:"120"`
+	Counts    map[AnomalyCategory]int `json:"counts"`
+	Repaired  int                     `json:"repaired" example:"0"`
+	Anomalies []BikeAnomaly           `json:"anomalies"`
+}
+
+func NewConsistencyReport(dryRun bool) ConsistencyReport {
+	counts := map[AnomalyCategory]int{}
+	for _, category := range AnomalyCategories {
+		counts[category] = 0
+	}
+	return ConsistencyReport{
+		DryRun:    dryRun,
+		Counts:    counts,
+		Anomalies: []BikeAnomaly{},
+	}
+}
+
+// ConsistencyCheckBody leaves the fleet untouched unless dryRun is explicitly false.
+type ConsistencyCheckBody struct {
+	DryRun *bool `json:"dryRun" example:"false"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ConsistencyDomainTestSuite struct {
+	suite.Suite
+}
+
+func TestConsistencyDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsistencyDomainTestSuite))
+}
+
+func (s *ConsistencyDomainTestSuite) TestCheckBike() {
+	var (
+		renter       = sql.NullInt64{Valid: true, Int64: 2}
+		activeUser   = &User{ID: 2}
+		deletedUser  = &User{ID: 2, DeletedAt: gorm.DeletedAt{Valid: true, Time: time.Now()}}
+		repairNeeded = func(category AnomalyCategory, status, repair BikeStatus, userID int64) *BikeAnomaly {
+			return &BikeAnomaly{Category: category, BikeID: 1, Status: status, UserID: userID, RepairStatus: repair}
+		}
+	)
+	cases := []struct {
+		name     string
+		bike     Bike
+		renter   *User
+		expected *BikeAnomaly
+	}{
+		{"available", Bike{ID: 1, Status: BikeStatusAvailable}, nil, nil},
+		{"rented", Bike{ID: 1, Status: BikeStatusRented, UserID: renter}, activeUser, nil},
+		{"maintenance", Bike{ID: 1, Status: BikeStatusMaintenance}, nil, nil},
+		{"retired", Bike{ID: 1, Status: BikeStatusRetired}, nil, nil},
+		{"unknown status", Bike{ID: 1, Status: BikeStatus("lost")}, nil, repairNeeded(AnomalyUnknownStatus, "lost", "", 0)},
+		{"rented without renter", Bike{ID: 1, Status: BikeStatusRented}, nil, repairNeeded(AnomalyRentedWithoutRenter, BikeStatusRented, BikeStatusAvailable, 0)},
+		{"rented by deleted user", Bike{ID: 1, Status: BikeStatusRented, UserID: renter}, deletedUser, repairNeeded(AnomalyRenterDeleted, BikeStatusRented, BikeStatusMaintenance, 2)},
+		{"rented by missing user", Bike{ID: 1, Status: BikeStatusRented, UserID: renter}, nil, repairNeeded(AnomalyRenterDeleted, BikeStatusRented, BikeStatusMaintenance, 2)},
+		{"available with deleted renter", Bike{ID: 1, Status: BikeStatusAvailable, UserID: renter}, deletedUser, repairNeeded(AnomalyRenterDeleted, BikeStatusAvailable, BikeStatusAvailable, 2)},
+		{"available with renter", Bike{ID: 1, Status: BikeStatusAvailable, UserID: renter}, activeUser, repairNeeded(AnomalyIdleWithRenter, BikeStatusAvailable, BikeStatusAvailable, 2)},
+		{"maintenance with renter", Bike{ID: 1, Status: BikeStatusMaintenance, UserID: renter}, activeUser, repairNeeded(AnomalyIdleWithRenter, BikeStatusMaintenance, BikeStatusMaintenance, 2)},
+	}
+	for _, c := range cases {
+		s.Equal(c.expected, CheckBike(&c.bike, c.renter), c.name)
+	}
+}
+
+func (s *ConsistencyDomainTestSuite) TestRepairable() {
+	s.True((&BikeAnomaly{RepairStatus: BikeStatusAvailable}).Repairable())
+	s.False((&BikeAnomaly{Category: AnomalyUnknownStatus}).Repairable())
+}
+
+func (s *ConsistencyDomainTestSuite) TestNewConsistencyReport() {
+	report := NewConsistencyReport(true)
+	s.True(report.DryRun)
+	s.Len(report.Counts, len(AnomalyCategories))
+	s.Equal(0, report.Counts[AnomalyRenterDeleted])
+	s.Equal([]BikeAnomaly{}, report.Anomalies)
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ContextDomainTestSuite struct {
+	suite.Suite
+}
+
+func TestContextDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(ContextDomainTestSuite))
+}
+
+func (s *ContextDomainTestSuite) TestDetach_OutlivesCancelledParent() {
+	parent, cancel := context.WithCancel(NewContextWithClaims(context.Background(), &Claims{ID: 1}))
+	cancel()
+	ctx, cancelDetached := Detach(parent, time.Minute)
+	defer cancelDetached()
+	s.Equal(context.Canceled, parent.Err())
+	s.Nil(ctx.Err())
+	claims, ok := ClaimsFromContext(ctx)
+	s.True(ok)
+	s.Equal(int64(1), claims.ID)
+}
+
+func (s *ContextDomainTestSuite) TestDetach_Timeout() {
+	ctx, cancel := Detach(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	s.Equal(context.DeadlineExceeded, ctx.Err())
+}
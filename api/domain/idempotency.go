@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// IdempotentRequest remembers the first response to a request sent with an Idempotency-Key header, so a client
+// retrying after a lost response gets the same answer instead of running the action twice.
+type IdempotentRequest struct {
+	ID             int64     `json:"id"`
+	UserID         int64     `json:"userId"`
+	IdempotencyKey string    `json:"idempotencyKey"`
+	Fingerprint    string    `json:"fingerprint"`
+	StatusCode     int       `json:"statusCode"`
+	ContentType    string    `json:"contentType"`
+	ETag           string    `json:"etag" gorm:"column:etag"`
+	Body           []byte    `json:"body"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+	// LockedUntil is when a request still running is presumed dead and a retry may take its key over. It also tells
+	// the attempt holding the key apart from one that lost it.
+	LockedUntil time.Time `json:"lockedUntil"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// IsCompleted reports whether the response has been stored, until then the first request is still running.
+func (r *IdempotentRequest) IsCompleted() bool {
+	return r.StatusCode != 0
+}
+
+func (IdempotentRequest) TableName() string {
+	return "idempotent_request"
+}
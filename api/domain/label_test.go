@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LabelDomainTestSuite struct {
+	suite.Suite
+}
+
+func TestLabelDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(LabelDomainTestSuite))
+}
+
+func (s *LabelDomainTestSuite) TestNormalizeBikeCode_Success() {
+	code, ok := NormalizeBikeCode(" 7f3k9q2m\n")
+	s.True(ok)
+	s.Equal("7F3K9Q2M", code)
+}
+
+func (s *LabelDomainTestSuite) TestNormalizeBikeCode_Invalid() {
+	for _, code := range []string{"", "ABC12", "7F3K-9Q2M", "0123456789ABCDEFG", "7F3K9Q2M%"} {
+		_, ok := NormalizeBikeCode(code)
+		s.False(ok, code)
+	}
+}
+
+func (s *LabelDomainTestSuite) TestNewBikeCode() {
+	first, err := NewBikeCode()
+	s.Nil(err)
+	second, err := NewBikeCode()
+	s.Nil(err)
+	s.Regexp(`^[0-9A-F]{12}$`, first)
+	s.NotEqual(first, second)
+	_, ok := NormalizeBikeCode(first)
+	s.True(ok)
+}
+
+func (s *LabelDomainTestSuite) TestBikeBeforeCreate() {
+	bike := Bike{Name: "henry"}
+	s.Nil(bike.BeforeCreate(nil))
+	s.Regexp(`^[0-9A-F]{12}$`, bike.Code)
+	bike = Bike{Name: "henry", Code: "7F3K9Q2M"}
+	s.Nil(bike.BeforeCreate(nil))
+	s.Equal("7F3K9Q2M", bike.Code)
+}
+
+func (s *LabelDomainTestSuite) TestLabelFormat_Success() {
+	s.True(LabelFormatSVG.IsValid())
+	s.True(LabelFormatPNG.IsValid())
+	s.False(LabelFormat("pdf").IsValid())
+	s.Equal("image/svg+xml", LabelFormatSVG.ContentType())
+	s.Equal("image/png", LabelFormatPNG.ContentType())
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"math"
+
+	"shared-bike/apperrors"
+
+	"github.com/shopspring/decimal"
+)
+
+// coordinatePrecision matches the scale of the decimal(8,6) and decimal(9,6) bike.lat and bike.long columns.
+const (
+	coordinatePrecision = 6
+	earthRadiusMeters   = 6371000
+)
+
+var (
+	maxLatitude  = decimal.NewFromInt(90)
+	maxLongitude = decimal.NewFromInt(180)
+)
+
+// ValidateCoordinates rejects missing or out of range coordinates and ones with more decimals than the columns store,
+// so a location is never silently rounded on the way into the database.
+func ValidateCoordinates(lat, long *decimal.Decimal) error {
+	if lat == nil || long == nil {
+		return apperrors.ErrInvalidLocation
+	}
+	if lat.Abs().GreaterThan(maxLatitude) || long.Abs().GreaterThan(maxLongitude) {
+		return apperrors.ErrInvalidLocation
+	}
+	if !lat.Equal(lat.Round(coordinatePrecision)) || !long.Equal(long.Round(coordinatePrecision)) {
+		return apperrors.ErrInvalidLocation
+	}
+	return nil
+}
+
+// DistanceInMeters is the great-circle distance between two points using the haversine formula.
+func DistanceInMeters(fromLat, fromLong, toLat, toLong decimal.Decimal) float64 {
+	lat1 := toRadians(fromLat)
+	lat2 := toRadians(toLat)
+	deltaLat := lat2 - lat1
+	deltaLong := toRadians(toLong) - toRadians(fromLong)
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(deltaLong/2)*math.Sin(deltaLong/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func toRadians(degrees decimal.Decimal) float64 {
+	value, _ := degrees.Float64()
+	return value * math.Pi / 180
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"shared-bike/apperrors"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocationDomainTestSuite struct {
+	suite.Suite
+}
+
+func TestLocationDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(LocationDomainTestSuite))
+}
+
+func (s *LocationDomainTestSuite) TestValidateCoordinates_Success() {
+	lat := decimal.RequireFromString("-90")
+	long := decimal.RequireFromString("179.999999")
+	s.Nil(ValidateCoordinates(&lat, &long))
+}
+
+func (s *LocationDomainTestSuite) TestValidateCoordinates_Failed() {
+	valid := decimal.RequireFromString("50.119504")
+	outOfRangeLat := decimal.RequireFromString("90.000001")
+	outOfRangeLong := decimal.RequireFromString("-180.5")
+	tooPrecise := decimal.RequireFromString("8.6381371")
+	s.Equal(apperrors.ErrInvalidLocation, ValidateCoordinates(nil, &valid))
+	s.Equal(apperrors.ErrInvalidLocation, ValidateCoordinates(&valid, nil))
+	s.Equal(apperrors.ErrInvalidLocation, ValidateCoordinates(&outOfRangeLat, &valid))
+	s.Equal(apperrors.ErrInvalidLocation, ValidateCoordinates(&valid, &outOfRangeLong))
+	s.Equal(apperrors.ErrInvalidLocation, ValidateCoordinates(&valid, &tooPrecise))
+}
+
+func (s *LocationDomainTestSuite) TestDistanceInMeters_Success() {
+	frankfurtLat := decimal.RequireFromString("50.110924")
+	frankfurtLong := decimal.RequireFromString("8.682127")
+	berlinLat := decimal.RequireFromString("52.520008")
+	berlinLong := decimal.RequireFromString("13.404954")
+	s.InDelta(423000, DistanceInMeters(frankfurtLat, frankfurtLong, berlinLat, berlinLong), 2000)
+	s.Equal(float64(0), DistanceInMeters(berlinLat, berlinLong, berlinLat, berlinLong))
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+type TicketStatus string
+
+var (
+	TicketStatusOpen       TicketStatus = "open"
+	TicketStatusInProgress TicketStatus = "in_progress"
+	TicketStatusResolved   TicketStatus = "resolved"
+)
+
+type ReportCategory string
+
+var (
+	ReportCategoryFlatTire ReportCategory = "flat_tire"
+	ReportCategoryBrakes   ReportCategory = "brakes"
+	ReportCategoryChain    ReportCategory = "chain"
+	ReportCategoryLights   ReportCategory = "lights"
+	ReportCategoryLock     ReportCategory = "lock"
+	ReportCategoryFrame    ReportCategory = "frame"
+	ReportCategoryOther    ReportCategory = "other"
+)
+
+var reportCategories = []ReportCategory{
+	ReportCategoryFlatTire,
+	ReportCategoryBrakes,
+	ReportCategoryChain,
+	ReportCategoryLights,
+	ReportCategoryLock,
+	ReportCategoryFrame,
+	ReportCategoryOther,
+}
+
+var ticketTransitions = map[TicketStatus][]TicketStatus{
+	TicketStatusOpen:       {TicketStatusInProgress, TicketStatusResolved},
+	TicketStatusInProgress: {TicketStatusOpen, TicketStatusResolved},
+}
+
+func (c ReportCategory) IsValid() bool {
+	for _, category := range reportCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+type MaintenanceTicket struct {
+	ID         int64          `json:"id"`
+	BikeID     int64          `json:"bikeId"`
+	ReporterID int64          `json:"reporterId"`
+	Category   ReportCategory `json:"category"`
+	Note       string         `json:"note"`
+	Status     TicketStatus   `json:"status"`
+	ResolvedAt sql.NullTime   `json:"resolvedAt"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+}
+
+func (t *MaintenanceTicket) ToDTO() MaintenanceTicketDTO {
+	ticketDTO := MaintenanceTicketDTO{
+		ID:         t.ID,
+		BikeID:     t.BikeID,
+		ReporterID: t.ReporterID,
+		Category:   t.Category,
+		Note:       t.Note,
+		Status:     t.Status,
+		CreatedAt:  t.CreatedAt,
+	}
+	if t.ResolvedAt.Valid {
+		resolvedAt := t.ResolvedAt.Time
+		ticketDTO.ResolvedAt = &resolvedAt
+	}
+	return ticketDTO
+}
+
+func (t *MaintenanceTicket) IsActive() bool {
+	return t.Status == TicketStatusOpen || t.Status == TicketStatusInProgress
+}
+
+func (t *MaintenanceTicket) CanTransitionTo(status TicketStatus) bool {
+	for _, next := range ticketTransitions[t.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (MaintenanceTicket) TableName() string {
+	return "maintenance_ticket"
+}
+
+type ReportBikeBody struct {
+	Category ReportCategory `json:"category" example:"brakes"`
+	Note     string         `json:"note" example:"front brake does not stop the bike"`
+}
+
+type ReportBikeRequestPayload struct {
+	BikeID     int64
+	ReporterID int64
+	Category   ReportCategory
+	Note       string
+}
+
+type UpdateTicketBody struct {
+	Status     TicketStatus `json:"status" example:"resolved"`
+	RetireBike bool         `json:"retireBike" example:"false"`
+}
+
+type UpdateTicketRequestPayload struct {
+	ID         int64
+	Status     TicketStatus
+	RetireBike bool
+	// ActorID is the staff member moving the ticket, recorded in the audit log.
+	ActorID int64
+}
+
+type TicketFilter struct {
+	Status TicketStatus `query:"status"`
+	BikeID int64        `query:"bikeId"`
+}
+
+type MaintenanceTicketDTO struct {
+	ID         int64          `json:"id" example:"1"`
+	BikeID     int64          `json:"bikeId" example:"1"`
+	ReporterID int64          `json:"reporterId" example:"1"`
+	Category   ReportCategory `json:"category" example:"brakes"`
+	Note       string         `json:"note" example:"front brake does not stop the bike"`
+	Status     TicketStatus   `json:"status" example:"open"`
+	ResolvedAt *time.Time     `json:"resolvedAt,omitempty" example:"2022-07-06T18:51:44Z"`
+	CreatedAt  time.Time      `json:"createdAt" example:"2022-07-06T18:51:44Z"`
+}
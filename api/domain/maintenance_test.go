@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MaintenanceDomainTestSuite struct {
+	suite.Suite
+	ticket *MaintenanceTicket
+}
+
+func (s *MaintenanceDomainTestSuite) SetupTest() {
+	s.ticket = &MaintenanceTicket{
+		ID:         1,
+		BikeID:     2,
+		ReporterID: 3,
+		Category:   ReportCategoryBrakes,
+		Note:       "front brake",
+		Status:     TicketStatusOpen,
+	}
+}
+
+func TestMaintenanceDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceDomainTestSuite))
+}
+
+func (s *MaintenanceDomainTestSuite) TestToDTO_Success() {
+	expected := MaintenanceTicketDTO{
+		ID:         1,
+		BikeID:     2,
+		ReporterID: 3,
+		Category:   ReportCategoryBrakes,
+		Note:       "front brake",
+		Status:     TicketStatusOpen,
+	}
+	s.Equal(expected, s.ticket.ToDTO())
+}
+
+func (s *MaintenanceDomainTestSuite) TestToDTO_SuccessResolved() {
+	resolvedAt := time.Date(2022, 7, 6, 18, 51, 44, 0, time.UTC)
+	s.ticket.Status = TicketStatusResolved
+	s.ticket.ResolvedAt = sql.NullTime{Valid: true, Time: resolvedAt}
+	actual := s.ticket.ToDTO()
+	s.Equal(&resolvedAt, actual.ResolvedAt)
+}
+
+func (s *MaintenanceDomainTestSuite) TestTableName_Success() {
+	s.Equal("maintenance_ticket", s.ticket.TableName())
+}
+
+func (s *MaintenanceDomainTestSuite) TestIsActive_Success() {
+	s.True(s.ticket.IsActive())
+	s.ticket.Status = TicketStatusInProgress
+	s.True(s.ticket.IsActive())
+	s.ticket.Status = TicketStatusResolved
+	s.False(s.ticket.IsActive())
+}
+
+func (s *MaintenanceDomainTestSuite) TestCanTransitionTo_Success() {
+	s.True(s.ticket.CanTransitionTo(TicketStatusInProgress))
+	s.True(s.ticket.CanTransitionTo(TicketStatusResolved))
+	s.False(s.ticket.CanTransitionTo(TicketStatusOpen))
+	s.ticket.Status = TicketStatusInProgress
+	s.True(s.ticket.CanTransitionTo(TicketStatusOpen))
+	s.ticket.Status = TicketStatusResolved
+	s.False(s.ticket.CanTransitionTo(TicketStatusOpen))
+	s.False(s.ticket.CanTransitionTo(TicketStatusInProgress))
+}
+
+func (s *MaintenanceDomainTestSuite) TestReportCategoryIsValid_Success() {
+	s.True(ReportCategoryFlatTire.IsValid())
+	s.True(ReportCategoryOther.IsValid())
+	s.False(ReportCategory("wobbly").IsValid())
+}
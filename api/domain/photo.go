@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"time"
+)
+
+type PhotoKind string
+
+var (
+	PhotoKindReport PhotoKind = "report"
+	PhotoKindReturn PhotoKind = "return"
+)
+
+type PhotoVariant string
+
+var (
+	PhotoVariantOriginal  PhotoVariant = "original"
+	PhotoVariantThumbnail PhotoVariant = "thumbnail"
+)
+
+func (v PhotoVariant) IsValid() bool {
+	return v == PhotoVariantOriginal || v == PhotoVariantThumbnail
+}
+
+// PhotoDownloadPath is the path a variant of a photo is downloaded from, it only works signed.
+func PhotoDownloadPath(id int64, variant PhotoVariant) string {
+	return fmt.Sprintf("/api/v1/photos/%d/%s", id, variant)
+}
+
+type BikePhoto struct {
+	ID           int64         `json:"id"`
+	BikeID       int64         `json:"bikeId"`
+	TicketID     sql.NullInt64 `json:"ticketId"`
+	UploaderID   int64         `json:"uploaderId"`
+	Kind         PhotoKind     `json:"kind"`
+	ContentType  string        `json:"contentType"`
+	Size         int64         `json:"size"`
+	StorageKey   string        `json:"-"`
+	ThumbnailKey string        `json:"-"`
+	CreatedAt    time.Time     `json:"createdAt"`
+}
+
+func (p *BikePhoto) ToDTO() PhotoDTO {
+	photoDTO := PhotoDTO{
+		ID:          p.ID,
+		BikeID:      p.BikeID,
+		UploaderID:  p.UploaderID,
+		Kind:        p.Kind,
+		ContentType: p.ContentType,
+		Size:        p.Size,
+		CreatedAt:   p.CreatedAt,
+	}
+	if p.TicketID.Valid {
+		photoDTO.TicketID = p.TicketID.Int64
+	}
+	return photoDTO
+}
+
+// Key returns the storage key of the requested variant.
+func (p *BikePhoto) Key(variant PhotoVariant) string {
+	if variant == PhotoVariantThumbnail {
+		return p.ThumbnailKey
+	}
+	return p.StorageKey
+}
+
+func (BikePhoto) TableName() string {
+	return "bike_photo"
+}
+
+type UploadPhotoRequestPayload struct {
+	BikeID     int64
+	TicketID   int64
+	UploaderID int64
+	Kind       PhotoKind
+	Data       []byte
+}
+
+type DownloadPhotoRequestPayload struct {
+	ID        int64
+	Variant   PhotoVariant
+	Path      string
+	Expires   int64  `query:"expires"`
+	Signature string `query:"signature"`
+}
+
+type PhotoContent struct {
+	ContentType string
+	Body        io.ReadCloser
+}
+
+type PhotoDTO struct {
+	ID           int64     `json:"id" example:"1"`
+	BikeID       int64     `json:"bikeId" example:"1"`
+	TicketID     int64     `json:"ticketId,omitempty" example:"1"`
+	UploaderID   int64     `json:"uploaderId" example:"1"`
+	Kind         PhotoKind `json:"kind" example:"report"`
+	ContentType  string    `json:"contentType" example:"image/jpeg"`
+	Size         int64     `json:"size" example:"204800"`
+	URL          string    `json:"url" example:"/api/v1/photos/1/original?expires=1657133504&signature=5d41402abc4b2a76b9719d911017c592"`
+	ThumbnailURL string    `json:"thumbnailUrl" example:"/api/v1/photos/1/thumbnail?expires=1657133504&signature=5d41402abc4b2a76b9719d911017c592"`
+	CreatedAt    time.Time `json:"createdAt" example:"2022-07-06T18:51:44Z"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PhotoDomainTestSuite struct {
+	suite.Suite
+	photo *BikePhoto
+}
+
+func (s *PhotoDomainTestSuite) SetupTest() {
+	s.photo = &BikePhoto{
+		ID:           4,
+		BikeID:       1,
+		TicketID:     sql.NullInt64{Valid: true, Int64: 2},
+		UploaderID:   3,
+		Kind:         PhotoKindReport,
+		ContentType:  "image/png",
+		Size:         100,
+		StorageKey:   "bikes/1/abc.png",
+		ThumbnailKey: "bikes/1/abc_thumb.jpg",
+	}
+}
+
+func TestPhotoDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(PhotoDomainTestSuite))
+}
+
+func (s *PhotoDomainTestSuite) TestToDTO_Success() {
+	expected := PhotoDTO{
+		ID:          4,
+		BikeID:      1,
+		TicketID:    2,
+		UploaderID:  3,
+		Kind:        PhotoKindReport,
+		ContentType: "image/png",
+		Size:        100,
+	}
+	s.Equal(expected, s.photo.ToDTO())
+}
+
+func (s *PhotoDomainTestSuite) TestKey_Success() {
+	s.Equal("bikes/1/abc.png", s.photo.Key(PhotoVariantOriginal))
+	s.Equal("bikes/1/abc_thumb.jpg", s.photo.Key(PhotoVariantThumbnail))
+}
+
+func (s *PhotoDomainTestSuite) TestTableName_Success() {
+	s.Equal("bike_photo", s.photo.TableName())
+}
+
+func (s *PhotoDomainTestSuite) TestPhotoVariantIsValid_Success() {
+	s.True(PhotoVariantOriginal.IsValid())
+	s.True(PhotoVariantThumbnail.IsValid())
+	s.False(PhotoVariant("huge").IsValid())
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// auditPersonalKeys are the fields of audited payloads that identify a person. Everything else, like the bike,
+// the times and the surcharge of a return, is kept as the financial record of the ride. Payloads about a user also
+// carry its username and name, while name means the bike name everywhere else. The note of a ticket is free text
+// written by its reporter.
+var (
+	auditPersonalKeys       = []string{"nameOfRenter"}
+	auditUserPersonalKeys   = []string{"nameOfRenter", "username", "name"}
+	auditTicketPersonalKeys = []string{"nameOfRenter", "note"}
+)
+
+type ErasureBody struct {
+	Password string `json:"password" example:"mypassword"`
+}
+
+type ErasureRequestPayload struct {
+	UserID   int64
+	Password string
+}
+
+type UserExport struct {
+	ExportedAt   time.Time              `json:"exportedAt" example:"2026-10-19T14:00:00Z"`
+	Profile      UserExportProfile      `json:"profile"`
+	Rides        []RideExport           `json:"rides"`
+	Payments     []PaymentExport        `json:"payments"`
+	Reports      []MaintenanceTicketDTO `json:"reports"`
+	Photos       []PhotoDTO             `json:"photos"`
+	AuditEntries []AuditEventDTO        `json:"auditEntries"`
+}
+
+type UserExportProfile struct {
+	ID        int64     `json:"id" example:"1"`
+	Username  string    `json:"username" example:"myusername"`
+	Name      string    `json:"name" example:"myname"`
+	Roles     []string  `json:"roles" example:"support"`
+	CreatedAt time.Time `json:"createdAt" example:"2022-07-06T18:51:44Z"`
+	UpdatedAt time.Time `json:"updatedAt" example:"2022-07-06T18:51:44Z"`
+}
+
+// RideExport is one rental as told by the audit trail, RentedAt is empty for a ride rented before auditing started
+// and ReturnedAt is empty while the bike is still rented.
+type RideExport struct {
+	BikeID     int64      `json:"bikeId" example:"1"`
+	RentedAt   *time.Time `json:"rentedAt" example:"2026-10-19T13:00:00Z"`
+	ReturnedAt *time.Time `json:"returnedAt" example:"2026-10-19T14:00:00Z"`
+	Surcharge  string     `json:"surcharge,omitempty" example:"5.00"`
+}
+
+type PaymentExport struct {
+	BikeID    int64     `json:"bikeId" example:"1"`
+	Reason    string    `json:"reason" example:"return_surcharge"`
+	Amount    string    `json:"amount" example:"5.00"`
+	ChargedAt time.Time `json:"chargedAt" example:"2026-10-19T14:00:00Z"`
+}
+
+var PaymentReasonReturnSurcharge = "return_surcharge"
+
+// BuildRideHistory pairs the rent and return events of a user, given oldest first, into rides and collects the
+// surcharges of the returns as payments.
+func BuildRideHistory(userID int64, events []AuditEvent) ([]RideExport, []PaymentExport) {
+	rides := []RideExport{}
+	payments := []PaymentExport{}
+	open := map[int64]int{}
+	for _, event := range events {
+		if !event.ActorID.Valid || event.ActorID.Int64 != userID || event.TargetType != AuditTargetBike {
+			continue
+		}
+		at := event.CreatedAt
+		switch event.Action {
+		case AuditActionBikeRent:
+			rides = append(rides, RideExport{BikeID: event.TargetID, RentedAt: &at})
+			open[event.TargetID] = len(rides) - 1
+		case AuditActionBikeReturn:
+			index, ok := open[event.TargetID]
+			if !ok {
+				rides = append(rides, RideExport{BikeID: event.TargetID})
+				index = len(rides) - 1
+			}
+			delete(open, event.TargetID)
+			rides[index].ReturnedAt = &at
+			if surcharge := auditSurcharge(event.After); surcharge != "" {
+				rides[index].Surcharge = surcharge
+				payments = append(payments, PaymentExport{
+					BikeID:    event.TargetID,
+					Reason:    PaymentReasonReturnSurcharge,
+					Amount:    surcharge,
+					ChargedAt: at,
+				})
+			}
+		}
+	}
+	return rides, payments
+}
+
+func auditSurcharge(after sql.NullString) string {
+	if !after.Valid {
+		return ""
+	}
+	payload := struct {
+		Surcharge string `json:"surcharge"`
+	}{}
+	if err := json.Unmarshal([]byte(after.String), &payload); err != nil {
+		return ""
+	}
+	return payload.Surcharge
+}
+
+// ScrubAuditEvent removes the personal fields of the erased user from an audit event and reports whether anything
+// changed. The IP is only cleared on the user's own events, on the others it belongs to the staff member who acted.
+// Notes are only cleared on the tickets the user reported, reportedTicketIDs. Payloads that are not JSON objects are
+// left alone.
+func ScrubAuditEvent(event *AuditEvent, userID int64, reportedTicketIDs []int64) bool {
+	keys := auditPersonalKeys
+	if event.TargetType == AuditTargetUser {
+		keys = auditUserPersonalKeys
+	}
+	if event.TargetType == AuditTargetTicket {
+		for _, ticketID := range reportedTicketIDs {
+			if ticketID == event.TargetID {
+				keys = auditTicketPersonalKeys
+			}
+		}
+	}
+	changed := false
+	if event.ActorID.Valid && event.ActorID.Int64 == userID && event.IP != "" {
+		event.IP = ""
+		changed = true
+	}
+	for _, payload := range []*sql.NullString{&event.Before, &event.After} {
+		if scrubbed, ok := scrubAuditPayload(*payload, keys); ok {
+			*payload = scrubbed
+			changed = true
+		}
+	}
+	return changed
+}
+
+func scrubAuditPayload(payload sql.NullString, keys []string) (sql.NullString, bool) {
+	if !payload.Valid {
+		return payload, false
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(payload.String), &fields); err != nil {
+		return payload, false
+	}
+	changed := false
+	for _, key := range keys {
+		if _, ok := fields[key]; ok {
+			delete(fields, key)
+			changed = true
+		}
+	}
+	if !changed {
+		return payload, false
+	}
+	scrubbed, err := json.Marshal(fields)
+	if err != nil {
+		return payload, false
+	}
+	return sql.NullString{Valid: true, String: string(scrubbed)}, true
+}
+
+// ErasedUsername keeps usernames unique after erasure and frees the old one for a new registration.
+func ErasedUsername(id int64) string {
+	return fmt.Sprintf("erased-%d", id)
+}
+
+// Anonymize clears every personal field of the user and deletes it, the id stays so rides and payments still add up.
+// The empty password never matches, so the account cannot log in again.
+func (u *User) Anonymize(now time.Time) {
+	u.Username = ErasedUsername(u.ID)
+	u.Name = ""
+	u.Password = ""
+	u.DeletedAt = gorm.DeletedAt{Valid: true, Time: now}
+}
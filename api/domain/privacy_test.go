@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PrivacyDomainTestSuite struct {
+	suite.Suite
+}
+
+func TestPrivacyDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(PrivacyDomainTestSuite))
+}
+
+func auditAt(id int64, actorID int64, action AuditAction, targetType string, targetID int64, after string, at time.Time) AuditEvent {
+	event := AuditEvent{
+		ID:         id,
+		ActorID:    sql.NullInt64{Valid: true, Int64: actorID},
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		CreatedAt:  at,
+	}
+	if after != "" {
+		event.After = sql.NullString{Valid: true, String: after}
+	}
+	return event
+}
+
+func (s *PrivacyDomainTestSuite) TestBuildRideHistory() {
+	var (
+		t1     = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+		t2     = t1.Add(time.Hour)
+		t3     = t2.Add(time.Hour)
+		t4     = t3.Add(time.Hour)
+		t5     = t4.Add(time.Hour)
+		events = []AuditEvent{
+			auditAt(1, 2, AuditActionBikeReturn, AuditTargetBike, 7, `{"id":7}`, t1),
+			auditAt(2, 2, AuditActionBikeRent, AuditTargetBike, 1, `{"id":1,"nameOfRenter":"Bob"}`, t2),
+			auditAt(3, 9, AuditActionBikeRent, AuditTargetBike, 3, "", t2),
+			auditAt(4, 2, AuditActionBikeReturn, AuditTargetBike, 1, `{"id":1,"surcharge":"7.50"}`, t3),
+			auditAt(5, 2, AuditActionBikeReport, AuditTargetTicket, 1, "", t3),
+			auditAt(6, 2, AuditActionBikeRent, AuditTargetBike, 4, "", t4),
+			auditAt(7, 2, AuditActionUserRegister, AuditTargetUser, 2, `{"surcharge":"1.00"}`, t5),
+		}
+	)
+	rides, payments := BuildRideHistory(2, events)
+	s.Equal([]RideExport{
+		{BikeID: 7, ReturnedAt: &t1},
+		{BikeID: 1, RentedAt: &t2, ReturnedAt: &t3, Surcharge: "7.50"},
+		{BikeID: 4, RentedAt: &t4},
+	}, rides)
+	s.Equal([]PaymentExport{
+		{BikeID: 1, Reason: PaymentReasonReturnSurcharge, Amount: "7.50", ChargedAt: t3},
+	}, payments)
+}
+
+func (s *PrivacyDomainTestSuite) TestBuildRideHistory_Empty() {
+	rides, payments := BuildRideHistory(2, nil)
+	s.Equal([]RideExport{}, rides)
+	s.Equal([]PaymentExport{}, payments)
+}
+
+func (s *PrivacyDomainTestSuite) TestScrubAuditEvent_OwnBikeEvent() {
+	event := auditAt(1, 2, AuditActionBikeReturn, AuditTargetBike, 1, `{"id":1,"name":"henry","nameOfRenter":"Bob","surcharge":"5.00"}`, time.Time{})
+	event.Before = sql.NullString{Valid: true, String: `{"id":1,"name":"henry","nameOfRenter":"Bob"}`}
+	event.IP = "10.0.0.1"
+	s.True(ScrubAuditEvent(&event, 2, nil))
+	s.Equal(`{"id":1,"name":"henry"}`, event.Before.String)
+	s.Equal(`{"id":1,"name":"henry","surcharge":"5.00"}`, event.After.String)
+	s.Equal("", event.IP)
+}
+
+func (s *PrivacyDomainTestSuite) TestScrubAuditEvent_UserEventByStaff() {
+	event := auditAt(1, 1, AuditActionRoleAssign, AuditTargetUser, 2, `{"id":2,"username":"bob","name":"Bob","roleId":3}`, time.Time{})
+	event.IP = "10.0.0.9"
+	s.True(ScrubAuditEvent(&event, 2, nil))
+	s.Equal(`{"id":2,"roleId":3}`, event.After.String)
+	s.Equal("10.0.0.9", event.IP)
+}
+
+func (s *PrivacyDomainTestSuite) TestScrubAuditEvent_NothingPersonal() {
+	event := auditAt(1, 2, AuditActionBikeReport, AuditTargetTicket, 1, `[1,2]`, time.Time{})
+	s.False(ScrubAuditEvent(&event, 2, nil))
+	s.Equal(`[1,2]`, event.After.String)
+	s.False(event.Before.Valid)
+}
+
+func (s *PrivacyDomainTestSuite) TestScrubAuditEvent_ReportedTicketNote() {
+	event := auditAt(1, 1, AuditActionTicketUpdate, AuditTargetTicket, 4, `{"id":4,"note":"my phone number is 0170 1234567","status":"resolved"}`, time.Time{})
+	s.True(ScrubAuditEvent(&event, 2, []int64{4}))
+	s.Equal(`{"id":4,"status":"resolved"}`, event.After.String)
+}
+
+func (s *PrivacyDomainTestSuite) TestScrubAuditEvent_OtherTicketNote() {
+	event := auditAt(1, 2, AuditActionTicketUpdate, AuditTargetTicket, 5, `{"id":5,"note":"brake","status":"resolved"}`, time.Time{})
+	s.False(ScrubAuditEvent(&event, 2, []int64{4}))
+	s.Equal(`{"id":5,"note":"brake","status":"resolved"}`, event.After.String)
+}
+
+func (s *PrivacyDomainTestSuite) TestAnonymize() {
+	now := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	user := User{ID: 2, Username: "bob", Name: "Bob", Password: "hash"}
+	user.Anonymize(now)
+	s.Equal("erased-2", user.Username)
+	s.Equal("", user.Name)
+	s.Equal("", user.Password)
+	s.True(user.DeletedAt.Valid)
+	s.Equal(now, user.DeletedAt.Time)
+	s.False(user.ValidatePassword(""))
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+)
+
+type Permission string
+
+var (
+	PermissionBikesRead     Permission = "bikes:read"
+	PermissionBikesManage   Permission = "bikes:manage"
+	PermissionBikesMaintain Permission = "bikes:maintain"
+	PermissionUsersRead     Permission = "users:read"
+	PermissionUsersManage   Permission = "users:manage"
+	PermissionRidesRead     Permission = "rides:read"
+	PermissionRidesManage   Permission = "rides:manage"
+	PermissionPaymentsRead  Permission = "payments:read"
+	PermissionRolesManage   Permission = "roles:manage"
+	PermissionAuditRead     Permission = "audit:read"
+	PermissionZonesManage   Permission = "zones:manage"
+)
+
+type Role struct {
+	ID          int64        `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions" gorm:"-"`
+	CreatedAt   time.Time    `json:"-"`
+	UpdatedAt   time.Time    `json:"-"`
+}
+
+func (r *Role) ToDTO() RoleDTO {
+	permissions := r.Permissions
+	if permissions == nil {
+		permissions = []Permission{}
+	}
+	return RoleDTO{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: permissions,
+	}
+}
+
+func (Role) TableName() string {
+	return "role"
+}
+
+type UserRole struct {
+	UserID    int64     `json:"userId"`
+	RoleID    int64     `json:"roleId"`
+	CreatedAt time.Time `json:"-"`
+}
+
+func (UserRole) TableName() string {
+	return "user_role"
+}
+
+type AssignRoleBody struct {
+	RoleID int64 `json:"roleId" example:"2"`
+}
+
+type AssignRoleRequestPayload struct {
+	UserID int64 `json:"userId"`
+	RoleID int64 `json:"roleId"`
+}
+
+type RoleDTO struct {
+	ID          int64        `json:"id" example:"2"`
+	Name        string       `json:"name" example:"mechanic"`
+	Description string       `json:"description" example:"Repairs and maintains bikes"`
+	Permissions []Permission `json:"permissions" example:"bikes:read,bikes:maintain"`
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// StationDockRadiusMeters is how far from its station a lock may report a docked bike before the bike counts as taken
+// out of the dock. It leaves room for the drift of the lock's GPS.
+const StationDockRadiusMeters = 100
+
+type ReturnMode string
+
+var (
+	// ReturnModeFreeFloating lets riders drop a bike anywhere, the drop-off coordinates become its position.
+	ReturnModeFreeFloating ReturnMode = "free_floating"
+	// ReturnModeStation only accepts returns into a station with a free dock.
+	ReturnModeStation ReturnMode = "station"
+)
+
+func (m ReturnMode) IsValid() bool {
+	return m == ReturnModeFreeFloating || m == ReturnModeStation
+}
+
+type Station struct {
+	ID        int64            `json:"id"`
+	Name      string           `json:"name"`
+	Lat       *decimal.Decimal `json:"lat"`
+	Long      *decimal.Decimal `json:"long"`
+	Capacity  int64            `json:"capacity"`
+	CreatedAt time.Time        `json:"-"`
+	UpdatedAt time.Time        `json:"-"`
+	DeletedAt gorm.DeletedAt   `json:"-"`
+}
+
+func (Station) TableName() string {
+	return "station"
+}
+
+// StationOccupancy is a station together with the bikes currently docked at it.
+type StationOccupancy struct {
+	Station
+	DockedBikes    int64 `json:"dockedBikes"`
+	AvailableBikes int64 `json:"availableBikes"`
+}
+
+func (s *StationOccupancy) ToDTO() StationDTO {
+	freeDocks := s.Capacity - s.DockedBikes
+	if freeDocks < 0 {
+		freeDocks = 0
+	}
+	stationDTO := StationDTO{
+		ID:             s.ID,
+		Name:           s.Name,
+		Capacity:       s.Capacity,
+		AvailableBikes: s.AvailableBikes,
+		FreeDocks:      freeDocks,
+	}
+	if s.Lat != nil && s.Long != nil {
+		stationDTO.Lat = s.Lat.String()
+		stationDTO.Long = s.Long.String()
+	}
+	return stationDTO
+}
+
+type StationDTO struct {
+	ID             int64  `json:"id" example:"1"`
+	Name           string `json:"name" example:"Hauptbahnhof"`
+	Lat            string `json:"lat" example:"50.107145"`
+	Long           string `json:"long" example:"8.663789"`
+	Capacity       int64  `json:"capacity" example:"20"`
+	AvailableBikes int64  `json:"availableBikes" example:"7"`
+	FreeDocks      int64  `json:"freeDocks" example:"11"`
+}
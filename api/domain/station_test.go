@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+)
+
+type StationDomainTestSuite struct {
+	suite.Suite
+	station *StationOccupancy
+}
+
+func (s *StationDomainTestSuite) SetupTest() {
+	lat := decimal.NewFromFloat(50.107145)
+	long := decimal.NewFromFloat(8.663789)
+	s.station = &StationOccupancy{
+		Station: Station{
+			ID:       1,
+			Name:     "Hauptbahnhof",
+			Lat:      &lat,
+			Long:     &long,
+			Capacity: 20,
+		},
+		DockedBikes:    9,
+		AvailableBikes: 7,
+	}
+}
+
+func TestStationDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(StationDomainTestSuite))
+}
+
+func (s *StationDomainTestSuite) TestTableName_Success() {
+	s.Equal("station", s.station.TableName())
+}
+
+func (s *StationDomainTestSuite) TestToDTO_Success() {
+	expected := StationDTO{
+		ID:             1,
+		Name:           "Hauptbahnhof",
+		Lat:            "50.107145",
+		Long:           "8.663789",
+		Capacity:       20,
+		AvailableBikes: 7,
+		FreeDocks:      11,
+	}
+	s.Equal(expected, s.station.ToDTO())
+}
+
+func (s *StationDomainTestSuite) TestToDTO_SuccessWhenOverCapacity() {
+	s.station.Capacity = 5
+	s.Equal(int64(0), s.station.ToDTO().FreeDocks)
+}
+
+func (s *StationDomainTestSuite) TestReturnModeIsValid_Success() {
+	s.True(ReturnModeFreeFloating.IsValid())
+	s.True(ReturnModeStation.IsValid())
+	s.False(ReturnMode("").IsValid())
+	s.False(ReturnMode("docked").IsValid())
+}
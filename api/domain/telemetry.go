@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type LockState string
+
+var (
+	LockStateLocked   LockState = "locked"
+	LockStateUnlocked LockState = "unlocked"
+)
+
+func (s LockState) IsValid() bool {
+	return s == LockStateLocked || s == LockStateUnlocked
+}
+
+type TelemetryStatus string
+
+var (
+	// TelemetryStatusApplied is a reading newer than anything seen before, it moved the bike.
+	TelemetryStatusApplied TelemetryStatus = "applied"
+	// TelemetryStatusStale is a reading that arrived after a newer one, it is kept in the history only.
+	TelemetryStatusStale TelemetryStatus = "stale"
+	// TelemetryStatusDuplicate is a reading the device already delivered, a retry after a lost response.
+	TelemetryStatusDuplicate TelemetryStatus = "duplicate"
+)
+
+// Device is the lock mounted on a bike, it signs its messages with Secret.
+type Device struct {
+	ID        string         `json:"id"`
+	BikeID    int64          `json:"bikeId"`
+	Secret    string         `json:"-"`
+	CreatedAt time.Time      `json:"-"`
+	UpdatedAt time.Time      `json:"-"`
+	DeletedAt gorm.DeletedAt `json:"-"`
+}
+
+func (Device) TableName() string {
+	return "device"
+}
+
+type TelemetryReading struct {
+	ID         int64            `json:"id"`
+	DeviceID   string           `json:"deviceId"`
+	BikeID     int64            `json:"bikeId"`
+	RecordedAt time.Time        `json:"recordedAt"`
+	Lat        *decimal.Decimal `json:"lat"`
+	Long       *decimal.Decimal `json:"long"`
+	Battery    sql.NullInt64    `json:"battery"`
+	LockState  LockState        `json:"lockState"`
+	CreatedAt  time.Time        `json:"-"`
+}
+
+func (TelemetryReading) TableName() string {
+	return "telemetry_reading"
+}
+
+type TelemetryBody struct {
+	RecordedAt time.Time        `json:"recordedAt" example:"2026-10-19T14:00:00Z"`
+	Lat        *decimal.Decimal `json:"lat" swaggertype:"string" example:"50.119504"`
+	Long       *decimal.Decimal `json:"long" swaggertype:"string" example:"8.638137"`
+	Battery    *int64           `json:"battery" example:"87"`
+	LockState  LockState        `json:"lockState" example:"locked"`
+}
+
+// DeviceSignature is what a device sends next to a telemetry body to prove who it is.
+type DeviceSignature struct {
+	DeviceID  string
+	Timestamp string
+	Signature string
+}
+
+type TelemetryResultDTO struct {
+	Status TelemetryStatus `json:"status" example:"applied"`
+}
+
+// BikeTelemetryDTO is the newest reading the lock of a bike delivered.
+type BikeTelemetryDTO struct {
+	Battery    *int64    `json:"battery,omitempty" example:"87"`
+	LockState  LockState `json:"lockState,omitempty" example:"locked"`
+	RecordedAt time.Time `json:"recordedAt" example:"2026-10-19T14:00:00Z"`
+}
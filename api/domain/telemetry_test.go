@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TelemetryDomainTestSuite struct {
+	suite.Suite
+}
+
+func TestTelemetryDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(TelemetryDomainTestSuite))
+}
+
+func (s *TelemetryDomainTestSuite) TestLockStateIsValid_Success() {
+	s.True(LockStateLocked.IsValid())
+	s.True(LockStateUnlocked.IsValid())
+	s.False(LockState("jammed").IsValid())
+	s.False(LockState("").IsValid())
+}
+
+func (s *TelemetryDomainTestSuite) TestTableName_Success() {
+	s.Equal("device", Device{}.TableName())
+	s.Equal("telemetry_reading", TelemetryReading{}.TableName())
+}
@@ -57,9 +57,10 @@ func (User) TableName() string {
 }
 
 type UserDTO struct {
-	ID       int64  `json:"id"`
-	Username string `json:"username"`
-	Name     string `json:"name"`
+	ID          int64        `json:"id"`
+	Username    string       `json:"username"`
+	Name        string       `json:"name"`
+	Permissions []Permission `json:"permissions,omitempty"`
 }
 
 type Credentials struct {
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type ZoneKind string
+
+var (
+	// ZoneKindServiceArea is where bikes may be returned, a return outside every service area breaks its policy.
+	ZoneKindServiceArea ZoneKind = "service_area"
+	// ZoneKindNoParking is where bikes must not be returned, like pedestrian zones.
+	ZoneKindNoParking ZoneKind = "no_parking"
+)
+
+func (k ZoneKind) IsValid() bool {
+	return k == ZoneKindServiceArea || k == ZoneKindNoParking
+}
+
+type ZonePolicy string
+
+var (
+	ZonePolicyReject    ZonePolicy = "reject"
+	ZonePolicySurcharge ZonePolicy = "surcharge"
+)
+
+func (p ZonePolicy) IsValid() bool {
+	return p == ZonePolicyReject || p == ZonePolicySurcharge
+}
+
+const (
+	GeoJSONFeature           = "Feature"
+	GeoJSONFeatureCollection = "FeatureCollection"
+)
+
+type Zone struct {
+	ID        int64           `json:"id"`
+	Name      string          `json:"name"`
+	Kind      ZoneKind        `json:"kind"`
+	Policy    ZonePolicy      `json:"policy"`
+	Surcharge decimal.Decimal `json:"surcharge"`
+	Geometry  string          `json:"geometry"`
+	CreatedAt time.Time       `json:"-"`
+	UpdatedAt time.Time       `json:"-"`
+	DeletedAt gorm.DeletedAt  `json:"-"`
+}
+
+func (z *Zone) ToFeature() ZoneFeature {
+	return ZoneFeature{
+		Type: GeoJSONFeature,
+		ID:   z.ID,
+		Properties: ZoneProperties{
+			Name:      z.Name,
+			Kind:      z.Kind,
+			Policy:    z.Policy,
+			Surcharge: z.Surcharge.StringFixed(2),
+		},
+		Geometry: json.RawMessage(z.Geometry),
+	}
+}
+
+func (Zone) TableName() string {
+	return "zone"
+}
+
+type ZoneProperties struct {
+	Name      string     `json:"name" example:"Zeil"`
+	Kind      ZoneKind   `json:"kind" example:"no_parking"`
+	Policy    ZonePolicy `json:"policy" example:"surcharge"`
+	Surcharge string     `json:"surcharge" example:"5.00"`
+}
+
+// ZoneFeature is a GeoJSON feature, the geometry is a Polygon or MultiPolygon in longitude, latitude order.
+type ZoneFeature struct {
+	Type       string          `json:"type" example:"Feature"`
+	ID         int64           `json:"id,omitempty" example:"1"`
+	Properties ZoneProperties  `json:"properties"`
+	Geometry   json.RawMessage `json:"geometry" swaggertype:"object"`
+}
+
+type ZoneFeatureCollection struct {
+	Type     string        `json:"type" example:"FeatureCollection"`
+	Features []ZoneFeature `json:"features"`
+}
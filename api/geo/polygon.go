@@ -0,0 +1,118 @@
+// Package geo parses GeoJSON polygons and answers whether a point lies inside them.
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+const (
+	TypePolygon      = "Polygon"
+	TypeMultiPolygon = "MultiPolygon"
+)
+
+var ErrInvalidGeometry = errors.New("geometry must be a GeoJSON Polygon or MultiPolygon with closed rings")
+
+// Point follows the GeoJSON position order, longitude first.
+type Point [2]float64
+
+func (p Point) Long() float64 { return p[0] }
+func (p Point) Lat() float64  { return p[1] }
+
+// Polygon is an outer ring followed by optional holes.
+type Polygon [][]Point
+
+type MultiPolygon []Polygon
+
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// ParseGeometry accepts a GeoJSON geometry object and turns a Polygon into a single element MultiPolygon,
+// so callers only deal with one shape.
+func ParseGeometry(raw []byte) (MultiPolygon, error) {
+	geometry := Geometry{}
+	if err := json.Unmarshal(raw, &geometry); err != nil {
+		return nil, ErrInvalidGeometry
+	}
+	var multiPolygon MultiPolygon
+	switch geometry.Type {
+	case TypePolygon:
+		polygon := Polygon{}
+		if err := json.Unmarshal(geometry.Coordinates, &polygon); err != nil {
+			return nil, ErrInvalidGeometry
+		}
+		multiPolygon = MultiPolygon{polygon}
+	case TypeMultiPolygon:
+		if err := json.Unmarshal(geometry.Coordinates, &multiPolygon); err != nil {
+			return nil, ErrInvalidGeometry
+		}
+	default:
+		return nil, ErrInvalidGeometry
+	}
+	if err := multiPolygon.validate(); err != nil {
+		return nil, err
+	}
+	return multiPolygon, nil
+}
+
+func (m MultiPolygon) validate() error {
+	if len(m) == 0 {
+		return ErrInvalidGeometry
+	}
+	for _, polygon := range m {
+		if len(polygon) == 0 {
+			return ErrInvalidGeometry
+		}
+		for _, ring := range polygon {
+			// a closed ring repeats its first point, so a triangle already needs four positions
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+				return ErrInvalidGeometry
+			}
+			for _, point := range ring {
+				if point.Lat() < -90 || point.Lat() > 90 || point.Long() < -180 || point.Long() > 180 {
+					return ErrInvalidGeometry
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (m MultiPolygon) Contains(lat, long float64) bool {
+	for _, polygon := range m {
+		if polygon.Contains(lat, long) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains is true inside the outer ring and outside every hole. Points exactly on an edge may fall on either side.
+func (p Polygon) Contains(lat, long float64) bool {
+	if len(p) == 0 || !ringContains(p[0], lat, long) {
+		return false
+	}
+	for _, hole := range p[1:] {
+		if ringContains(hole, lat, long) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains casts a ray towards increasing longitude and counts the edges it crosses.
+func ringContains(ring []Point, lat, long float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Lat() > lat) != (b.Lat() > lat) {
+			crossLong := (b.Long()-a.Long())*(lat-a.Lat())/(b.Lat()-a.Lat()) + a.Long()
+			if long < crossLong {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
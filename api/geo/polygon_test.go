@@ -0,0 +1,81 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PolygonTestSuite struct {
+	suite.Suite
+}
+
+func TestPolygonTestSuite(t *testing.T) {
+	suite.Run(t, new(PolygonTestSuite))
+}
+
+const squareWithHole = `{
+	"type": "Polygon",
+	"coordinates": [
+		[[8.0, 50.0], [9.0, 50.0], [9.0, 51.0], [8.0, 51.0], [8.0, 50.0]],
+		[[8.4, 50.4], [8.6, 50.4], [8.6, 50.6], [8.4, 50.6], [8.4, 50.4]]
+	]
+}`
+
+func (s *PolygonTestSuite) TestParseGeometry_Polygon() {
+	actual, err := ParseGeometry([]byte(squareWithHole))
+	s.Nil(err)
+	s.Len(actual, 1)
+	s.Len(actual[0], 2)
+	s.Equal(Point{8.0, 50.0}, actual[0][0][0])
+}
+
+func (s *PolygonTestSuite) TestParseGeometry_MultiPolygon() {
+	raw := `{"type":"MultiPolygon","coordinates":[
+		[[[0,0],[1,0],[1,1],[0,1],[0,0]]],
+		[[[5,5],[6,5],[6,6],[5,6],[5,5]]]
+	]}`
+	actual, err := ParseGeometry([]byte(raw))
+	s.Nil(err)
+	s.Len(actual, 2)
+	s.True(actual.Contains(5.5, 5.5))
+	s.True(actual.Contains(0.5, 0.5))
+	s.False(actual.Contains(3, 3))
+}
+
+func (s *PolygonTestSuite) TestParseGeometry_Invalid() {
+	for _, raw := range []string{
+		`not json`,
+		`{"type":"Point","coordinates":[8.0,50.0]}`,
+		`{"type":"Polygon","coordinates":[]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1]]]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[0,0]]]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,95],[0,0]]]}`,
+		`{"type":"MultiPolygon","coordinates":[[]]}`,
+		`{"type":"Polygon","coordinates":"square"}`,
+	} {
+		actual, err := ParseGeometry([]byte(raw))
+		s.Nil(actual, raw)
+		s.Equal(ErrInvalidGeometry, err, raw)
+	}
+}
+
+func (s *PolygonTestSuite) TestContains() {
+	polygon, err := ParseGeometry([]byte(squareWithHole))
+	s.Nil(err)
+	s.True(polygon.Contains(50.2, 8.2))
+	s.True(polygon.Contains(50.9, 8.9))
+	s.False(polygon.Contains(50.5, 8.5), "inside the hole")
+	s.False(polygon.Contains(51.5, 8.5), "north of the square")
+	s.False(polygon.Contains(50.5, 7.5), "west of the square")
+}
+
+func (s *PolygonTestSuite) TestContains_Concave() {
+	// an L shape, the notch at the top right is outside
+	raw := `{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,1],[1,1],[1,2],[0,2],[0,0]]]}`
+	polygon, err := ParseGeometry([]byte(raw))
+	s.Nil(err)
+	s.True(polygon.Contains(0.5, 1.5))
+	s.True(polygon.Contains(1.5, 0.5))
+	s.False(polygon.Contains(1.5, 1.5))
+}
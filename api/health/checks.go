@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const migrationTable = "goose_db_version"
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_.+\.sql$`)
+
+// PingCheck fails while the database does not answer.
+func PingCheck(db *sql.DB) Check {
+	return func(ctx context.Context) error {
+		if err := db.PingContext(ctx); err != nil {
+			return &CheckError{Message: "database is unreachable", Cause: err}
+		}
+		return nil
+	}
+}
+
+// MigrationCheck fails while a migration of dir in files is not applied to the database, so an instance built from
+// a newer tree does not serve before goose ran. Every version is looked up because the seeders share the goose
+// table and their versions interleave with the migrations, so the highest version proves nothing.
+func MigrationCheck(db *sql.DB, files fs.FS, dir string) (Check, error) {
+	names, err := fs.Glob(files, path.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, err
+	}
+	versions := []int64{}
+	for _, name := range names {
+		match := migrationFileName.FindStringSubmatch(path.Base(name))
+		if match == nil {
+			return nil, fmt.Errorf("migration %s has no version", name)
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no version: %w", name, err)
+		}
+		versions = append(versions, version)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no migration found in %s", dir)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(versions)), ",")
+	query := "SELECT DISTINCT version_id FROM " + migrationTable + " WHERE is_applied = 1 AND version_id IN (" + placeholders + ")"
+	args := make([]interface{}, 0, len(versions))
+	for _, version := range versions {
+		args = append(args, version)
+	}
+	return func(ctx context.Context) error {
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return &CheckError{Message: "migration versions are unreadable", Cause: err}
+		}
+		defer rows.Close()
+		applied := map[int64]bool{}
+		for rows.Next() {
+			var version int64
+			if err := rows.Scan(&version); err != nil {
+				return &CheckError{Message: "migration versions are unreadable", Cause: err}
+			}
+			applied[version] = true
+		}
+		if err := rows.Err(); err != nil {
+			return &CheckError{Message: "migration versions are unreadable", Cause: err}
+		}
+		missing := []string{}
+		for _, version := range versions {
+			if !applied[version] {
+				missing = append(missing, strconv.FormatInt(version, 10))
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("migrations not applied: %s", strings.Join(missing, ", "))
+		}
+		return nil
+	}, nil
+}
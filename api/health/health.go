@@ -0,0 +1,157 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	StatusOK       = "ok"
+	StatusFailing  = "failing"
+	StatusDraining = "draining"
+)
+
+// Check reports whether one dependency works. It must give up when ctx is done.
+type Check func(ctx context.Context) error
+
+// CheckError is a failure with a message safe to show on the public health endpoints, the cause is only logged.
+type CheckError struct {
+	Message string
+	Cause   error
+}
+
+func (e *CheckError) Error() string {
+	return e.Message
+}
+
+func (e *CheckError) Unwrap() error {
+	return e.Cause
+}
+
+type CheckResult struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Registry runs the readiness checks of the API. Readiness takes the instance out of the load balancer, like when the
+// database is down or the API shuts down. Liveness tells the orchestrator to restart the process, nothing the API runs
+// gets fixed by a restart, so it holds no check and passes while the process answers.
+type Registry struct {
+	logger   ILogger
+	timeout  time.Duration
+	mu       sync.RWMutex
+	ready    []namedCheck
+	draining int32
+}
+
+// NewRegistry gives every check timeout to answer, a check taking longer counts as failing.
+func NewRegistry(logger ILogger, timeout time.Duration) *Registry {
+	return &Registry{
+		logger:  logger,
+		timeout: timeout,
+	}
+}
+
+func (r *Registry) AddReadiness(name string, check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ready = append(r.ready, namedCheck{name: name, check: check})
+}
+
+// Drain fails readiness from now on, so the load balancer stops sending requests before the server shuts down.
+func (r *Registry) Drain() {
+	atomic.StoreInt32(&r.draining, 1)
+}
+
+// Live answers 200 as long as the process serves requests.
+func (r *Registry) Live(c echo.Context) error {
+	return c.JSON(http.StatusOK, Report{Status: StatusOK, Checks: map[string]CheckResult{}})
+}
+
+// Ready answers 503 when a readiness check fails or the API drains.
+func (r *Registry) Ready(c echo.Context) error {
+	r.mu.RLock()
+	checks := r.ready
+	r.mu.RUnlock()
+	report := r.run(c.Request().Context(), "Ready", checks)
+	if atomic.LoadInt32(&r.draining) == 1 {
+		report.Status = StatusDraining
+	}
+	return c.JSON(statusCode(report), report)
+}
+
+// run runs the checks in parallel, so the slowest check bounds the answer time and not their sum.
+func (r *Registry) run(ctx context.Context, probe string, checks []namedCheck) Report {
+	report := Report{Status: StatusOK, Checks: map[string]CheckResult{}}
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check namedCheck) {
+			defer wg.Done()
+			results[i] = r.runCheck(ctx, probe, check)
+		}(i, check)
+	}
+	wg.Wait()
+	for i, check := range checks {
+		report.Checks[check.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFailing
+		}
+	}
+	return report
+}
+
+func (r *Registry) runCheck(ctx context.Context, probe string, check namedCheck) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.check(ctx)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// a check ignoring ctx must not hold the probe, its late answer is dropped
+		err = &CheckError{Message: fmt.Sprintf("no answer within %s", r.timeout), Cause: ctx.Err()}
+	}
+	result := CheckResult{Status: StatusOK, DurationMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		cause := err
+		var checkError *CheckError
+		if errors.As(err, &checkError) && checkError.Cause != nil {
+			cause = checkError.Cause
+		}
+		r.logger.Error(fmt.Sprintf("[Health.%s] check %s failed", probe, check.name), cause)
+		result.Status = StatusFailing
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func statusCode(report Report) int {
+	if report.Status != StatusOK {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
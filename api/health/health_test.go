@@ -0,0 +1,201 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"shared-bike/health/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+type HealthTestSuite struct {
+	suite.Suite
+	logger   *mocks.ILogger
+	registry *Registry
+	echo     *echo.Echo
+}
+
+func (s *HealthTestSuite) SetupTest() {
+	s.logger = &mocks.ILogger{}
+	s.registry = NewRegistry(s.logger, 50*time.Millisecond)
+	s.echo = echo.New()
+	s.echo.GET("/health/live", s.registry.Live)
+	s.echo.GET("/health/ready", s.registry.Ready)
+}
+
+func (s *HealthTestSuite) TearDownTest() {
+	s.logger.AssertExpectations(s.T())
+}
+
+func TestHealthTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
+
+func (s *HealthTestSuite) serve(target string) (int, Report) {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	report := Report{}
+	s.Nil(json.Unmarshal(rec.Body.Bytes(), &report))
+	// durations vary between runs
+	for name, result := range report.Checks {
+		result.DurationMs = 0
+		report.Checks[name] = result
+	}
+	return rec.Code, report
+}
+
+func (s *HealthTestSuite) TestLive_NoCheck() {
+	code, report := s.serve("/health/live")
+	s.Equal(http.StatusOK, code)
+	s.Equal(Report{Status: StatusOK, Checks: map[string]CheckResult{}}, report)
+}
+
+func (s *HealthTestSuite) TestLive_IgnoresReadiness() {
+	s.registry.AddReadiness("db", func(ctx context.Context) error { return errors.New("down") })
+	s.registry.Drain()
+	code, report := s.serve("/health/live")
+	s.Equal(http.StatusOK, code)
+	s.Equal(StatusOK, report.Status)
+}
+
+func (s *HealthTestSuite) TestReady_Success() {
+	s.registry.AddReadiness("db", func(ctx context.Context) error { return nil })
+	code, report := s.serve("/health/ready")
+	s.Equal(http.StatusOK, code)
+	s.Equal(StatusOK, report.Status)
+	s.Equal(map[string]CheckResult{"db": {Status: StatusOK}}, report.Checks)
+}
+
+func (s *HealthTestSuite) TestReady_OneCheckFailing() {
+	cause := errors.New("dial tcp 10.0.0.5:3306: connect: connection refused")
+	s.logger.On("Error", "[Health.Ready] check db failed", cause).Return()
+	s.registry.AddReadiness("db", func(ctx context.Context) error {
+		return &CheckError{Message: "database is unreachable", Cause: cause}
+	})
+	s.registry.AddReadiness("migrations", func(ctx context.Context) error { return nil })
+	code, report := s.serve("/health/ready")
+	s.Equal(http.StatusServiceUnavailable, code)
+	s.Equal(StatusFailing, report.Status)
+	s.Equal(CheckResult{Status: StatusFailing, Error: "database is unreachable"}, report.Checks["db"])
+	s.Equal(CheckResult{Status: StatusOK}, report.Checks["migrations"])
+}
+
+func (s *HealthTestSuite) TestReady_CheckTimeout() {
+	s.logger.On("Error", "[Health.Ready] check db failed", context.DeadlineExceeded).Return()
+	release := make(chan struct{})
+	defer close(release)
+	s.registry.AddReadiness("db", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	start := time.Now()
+	code, report := s.serve("/health/ready")
+	s.Less(time.Since(start), time.Second)
+	s.Equal(http.StatusServiceUnavailable, code)
+	s.Equal(StatusFailing, report.Status)
+	s.Equal(StatusFailing, report.Checks["db"].Status)
+	s.Equal("no answer within 50ms", report.Checks["db"].Error)
+}
+
+func (s *HealthTestSuite) TestReady_ChecksRunInParallel() {
+	for _, name := range []string{"db", "migrations", "storage"} {
+		s.registry.AddReadiness(name, func(ctx context.Context) error {
+			time.Sleep(30 * time.Millisecond)
+			return nil
+		})
+	}
+	start := time.Now()
+	code, _ := s.serve("/health/ready")
+	s.Equal(http.StatusOK, code)
+	s.Less(time.Since(start), 80*time.Millisecond)
+}
+
+func (s *HealthTestSuite) TestReady_Draining() {
+	s.registry.AddReadiness("db", func(ctx context.Context) error { return nil })
+	s.registry.Drain()
+	code, report := s.serve("/health/ready")
+	s.Equal(http.StatusServiceUnavailable, code)
+	s.Equal(StatusDraining, report.Status)
+	s.Equal(StatusOK, report.Checks["db"].Status)
+	code, _ = s.serve("/health/live")
+	s.Equal(http.StatusOK, code)
+}
+
+type ChecksTestSuite struct {
+	suite.Suite
+	db     *sql.DB
+	mockDB sqlmock.Sqlmock
+	check  Check
+	ping   Check
+}
+
+var migrationQuery = regexp.QuoteMeta("SELECT DISTINCT version_id FROM goose_db_version WHERE is_applied = 1 AND version_id IN (?,?)")
+
+func (s *ChecksTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.Error(err, "Failed to open mock sql db, got error")
+	}
+	s.db = db
+	s.mockDB = mock
+	s.ping = PingCheck(db)
+	s.check, err = MigrationCheck(db, fstest.MapFS{
+		"sql/migrations/20220704135747_create-table-bike.sql": {},
+		"sql/migrations/20220704135733_create-table-user.sql": {},
+		"sql/seeders/20220704135750_seeders.sql":              {},
+	}, "sql/migrations")
+	s.Nil(err)
+}
+
+func TestChecksTestSuite(t *testing.T) {
+	suite.Run(t, new(ChecksTestSuite))
+}
+
+func (s *ChecksTestSuite) TestPingCheck_Success() {
+	s.Nil(s.ping(context.Background()))
+}
+
+func (s *ChecksTestSuite) TestPingCheck_Failed() {
+	s.mockDB.ExpectClose()
+	s.Nil(s.db.Close())
+	err := s.ping(context.Background())
+	s.EqualError(err, "database is unreachable")
+	s.EqualError(errors.Unwrap(err), "sql: database is closed")
+}
+
+func (s *ChecksTestSuite) TestMigrationCheck_Success() {
+	s.mockDB.ExpectQuery(migrationQuery).
+		WithArgs(int64(20220704135733), int64(20220704135747)).
+		WillReturnRows(sqlmock.NewRows([]string{"version_id"}).AddRow(20220704135733).AddRow(20220704135747))
+	s.Nil(s.check(context.Background()))
+}
+
+func (s *ChecksTestSuite) TestMigrationCheck_Missing() {
+	s.mockDB.ExpectQuery(migrationQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"version_id"}).AddRow(20220704135733))
+	s.EqualError(s.check(context.Background()), "migrations not applied: 20220704135747")
+}
+
+func (s *ChecksTestSuite) TestMigrationCheck_QueryFailed() {
+	s.mockDB.ExpectQuery(migrationQuery).WillReturnError(errors.New("table goose_db_version doesn't exist"))
+	s.EqualError(s.check(context.Background()), "migration versions are unreadable")
+}
+
+func (s *ChecksTestSuite) TestMigrationCheck_Invalid() {
+	_, err := MigrationCheck(nil, fstest.MapFS{}, "sql/migrations")
+	s.EqualError(err, "no migration found in sql/migrations")
+	_, err = MigrationCheck(nil, fstest.MapFS{"sql/migrations/create-table-user.sql": {}}, "sql/migrations")
+	s.EqualError(err, "migration sql/migrations/create-table-user.sql has no version")
+}
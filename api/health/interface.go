@@ -0,0 +1,7 @@
+package health
+
+//go:generate mockery --name ILogger --output mocks --case underscore
+
+type ILogger interface {
+	Error(i ...interface{})
+}
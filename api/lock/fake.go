@@ -0,0 +1,98 @@
+package lock
+
+import (
+	"context"
+	"sync"
+
+	"shared-bike/domain"
+)
+
+// FakeLock is an in-process fleet of locks. It applies every command at most once per command ID and can be
+// told to lose acknowledgements or refuse commands, which is how the retry and rollback paths are exercised.
+type FakeLock struct {
+	mu       sync.Mutex
+	states   map[int64]domain.LockState
+	acks     map[string]Ack
+	commands []Command
+	dropAcks int
+	reject   string
+}
+
+func NewFakeLock() *FakeLock {
+	return &FakeLock{
+		states: map[int64]domain.LockState{},
+		acks:   map[string]Ack{},
+	}
+}
+
+// DropAcks makes the next n commands take effect without an acknowledgement, as if the reply got lost.
+func (f *FakeLock) DropAcks(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dropAcks = n
+}
+
+// Reject makes every new command fail with reason, an empty reason accepts commands again.
+func (f *FakeLock) Reject(reason string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reject = reason
+}
+
+// State returns the state of the lock of a bike, locks start locked.
+func (f *FakeLock) State(bikeID int64) domain.LockState {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if state, ok := f.states[bikeID]; ok {
+		return state
+	}
+	return domain.LockStateLocked
+}
+
+// Commands returns every command received, retries included.
+func (f *FakeLock) Commands() []Command {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Command{}, f.commands...)
+}
+
+func (f *FakeLock) Send(ctx context.Context, command Command) (Ack, error) {
+	ack, dropped := f.Apply(command)
+	if dropped {
+		<-ctx.Done()
+		return Ack{}, ctx.Err()
+	}
+	return ack, nil
+}
+
+// Apply executes a command and reports whether its acknowledgement should be dropped.
+func (f *FakeLock) Apply(command Command) (Ack, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commands = append(f.commands, command)
+	ack, seen := f.acks[command.ID]
+	if !seen {
+		ack = f.execute(command)
+		f.acks[command.ID] = ack
+	}
+	if f.dropAcks > 0 {
+		f.dropAcks--
+		return Ack{}, true
+	}
+	return ack, false
+}
+
+func (f *FakeLock) execute(command Command) Ack {
+	if f.reject != "" {
+		return Ack{CommandID: command.ID, Reason: f.reject}
+	}
+	switch command.Action {
+	case ActionUnlock:
+		f.states[command.BikeID] = domain.LockStateUnlocked
+	case ActionLock:
+		f.states[command.BikeID] = domain.LockStateLocked
+	default:
+		return Ack{CommandID: command.ID, Reason: "unknown action"}
+	}
+	return Ack{CommandID: command.ID, OK: true}
+}
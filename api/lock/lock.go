@@ -0,0 +1,116 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Action string
+
+var (
+	ActionUnlock Action = "UNLOCK"
+	ActionLock   Action = "LOCK"
+)
+
+var (
+	ErrNotConfirmed = errors.New("lock did not confirm the command")
+	ErrRejected     = errors.New("lock rejected the command")
+	ErrMalformed    = errors.New("malformed lock message")
+)
+
+// Command asks the lock of a bike to change state. The ID stays the same across retries so a lock that
+// already applied the command only acknowledges it again.
+type Command struct {
+	ID     string
+	BikeID int64
+	Action Action
+}
+
+type Ack struct {
+	CommandID string
+	OK        bool
+	Reason    string
+}
+
+// Transport delivers a command to the lock of a bike and waits for its acknowledgement until ctx is done.
+// FakeLock delivers in process, TCPTransport talks the line protocol of Server.
+type Transport interface {
+	Send(ctx context.Context, command Command) (Ack, error)
+}
+
+// LockController physically opens and closes the lock of a bike. Both calls block until the lock confirms.
+type LockController interface {
+	Unlock(ctx context.Context, bikeID int64) error
+	Lock(ctx context.Context, bikeID int64) error
+}
+
+type Config struct {
+	// Timeout bounds a single attempt.
+	Timeout time.Duration
+	// Retries is the number of attempts after the first one.
+	Retries int
+	// Backoff is the pause between attempts.
+	Backoff time.Duration
+}
+
+type Controller struct {
+	transport Transport
+	config    Config
+}
+
+func NewController(transport Transport, config Config) *Controller {
+	return &Controller{
+		transport: transport,
+		config:    config,
+	}
+}
+
+func (c *Controller) Unlock(ctx context.Context, bikeID int64) error {
+	return c.send(ctx, Command{ID: newCommandID(), BikeID: bikeID, Action: ActionUnlock})
+}
+
+func (c *Controller) Lock(ctx context.Context, bikeID int64) error {
+	return c.send(ctx, Command{ID: newCommandID(), BikeID: bikeID, Action: ActionLock})
+}
+
+// send retries until the lock acknowledges. A rejection is an answer and is not retried.
+func (c *Controller) send(ctx context.Context, command Command) error {
+	var lastErr error
+	for attempt := 0; attempt <= c.config.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w: command %s: %v", ErrNotConfirmed, command.ID, ctx.Err())
+			case <-time.After(c.config.Backoff):
+			}
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+		ack, err := c.transport.Send(attemptCtx, command)
+		cancel()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if ack.CommandID != command.ID {
+			lastErr = fmt.Errorf("%w: ack for command %s", ErrMalformed, ack.CommandID)
+			continue
+		}
+		if !ack.OK {
+			return fmt.Errorf("%w: command %s: %s", ErrRejected, command.ID, ack.Reason)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: command %s after %d attempts: %v", ErrNotConfirmed, command.ID, c.config.Retries+1, lastErr)
+}
+
+func newCommandID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,89 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ControllerTestSuite struct {
+	suite.Suite
+	fake       *FakeLock
+	controller *Controller
+}
+
+func (s *ControllerTestSuite) SetupTest() {
+	s.fake = NewFakeLock()
+	s.controller = NewController(s.fake, Config{Timeout: 20 * time.Millisecond, Retries: 2})
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ControllerTestSuite))
+}
+
+func (s *ControllerTestSuite) TestUnlock_Success() {
+	err := s.controller.Unlock(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal(domain.LockStateUnlocked, s.fake.State(1))
+	s.Len(s.fake.Commands(), 1)
+}
+
+func (s *ControllerTestSuite) TestLock_Success() {
+	s.Nil(s.controller.Unlock(context.TODO(), 1))
+	err := s.controller.Lock(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal(domain.LockStateLocked, s.fake.State(1))
+}
+
+func (s *ControllerTestSuite) TestUnlock_RetriesWithSameCommandID() {
+	s.fake.DropAcks(2)
+	err := s.controller.Unlock(context.TODO(), 1)
+	s.Nil(err)
+	commands := s.fake.Commands()
+	s.Len(commands, 3)
+	s.Equal(commands[0].ID, commands[1].ID)
+	s.Equal(commands[0].ID, commands[2].ID)
+	s.Equal(domain.LockStateUnlocked, s.fake.State(1))
+}
+
+func (s *ControllerTestSuite) TestUnlock_NotConfirmed() {
+	s.fake.DropAcks(3)
+	err := s.controller.Unlock(context.TODO(), 1)
+	s.True(errors.Is(err, ErrNotConfirmed))
+	s.Len(s.fake.Commands(), 3)
+}
+
+func (s *ControllerTestSuite) TestUnlock_RejectedIsNotRetried() {
+	s.fake.Reject("jammed")
+	err := s.controller.Unlock(context.TODO(), 1)
+	s.True(errors.Is(err, ErrRejected))
+	s.Len(s.fake.Commands(), 1)
+	s.Equal(domain.LockStateLocked, s.fake.State(1))
+}
+
+func (s *ControllerTestSuite) TestUnlock_CancelledContext() {
+	s.fake.DropAcks(3)
+	s.controller.config.Backoff = time.Second
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+	err := s.controller.Unlock(ctx, 1)
+	s.True(errors.Is(err, ErrNotConfirmed))
+	s.Len(s.fake.Commands(), 1)
+}
+
+type wrongAckTransport struct{}
+
+func (wrongAckTransport) Send(ctx context.Context, command Command) (Ack, error) {
+	return Ack{CommandID: "other", OK: true}, nil
+}
+
+func (s *ControllerTestSuite) TestUnlock_IgnoresAckOfOtherCommand() {
+	controller := NewController(wrongAckTransport{}, Config{Timeout: time.Millisecond})
+	err := controller.Unlock(context.TODO(), 1)
+	s.True(errors.Is(err, ErrNotConfirmed))
+}
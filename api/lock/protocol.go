@@ -0,0 +1,54 @@
+package lock
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The line protocol is plain text, one message per line:
+//
+//	CMD <command id> <UNLOCK|LOCK> <bike id>
+//	ACK <command id> OK
+//	ACK <command id> ERR <reason>
+
+func formatCommand(command Command) string {
+	return fmt.Sprintf("CMD %s %s %d\n", command.ID, command.Action, command.BikeID)
+}
+
+func parseCommand(line string) (Command, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 4 || fields[0] != "CMD" {
+		return Command{}, ErrMalformed
+	}
+	bikeID, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil {
+		return Command{}, ErrMalformed
+	}
+	return Command{ID: fields[1], Action: Action(fields[2]), BikeID: bikeID}, nil
+}
+
+func formatAck(ack Ack) string {
+	if ack.OK {
+		return fmt.Sprintf("ACK %s OK\n", ack.CommandID)
+	}
+	return fmt.Sprintf("ACK %s ERR %s\n", ack.CommandID, ack.Reason)
+}
+
+func parseAck(line string) (Ack, error) {
+	fields := strings.SplitN(strings.TrimSpace(line), " ", 4)
+	if len(fields) < 3 || fields[0] != "ACK" {
+		return Ack{}, ErrMalformed
+	}
+	switch fields[2] {
+	case "OK":
+		return Ack{CommandID: fields[1], OK: true}, nil
+	case "ERR":
+		ack := Ack{CommandID: fields[1]}
+		if len(fields) == 4 {
+			ack.Reason = fields[3]
+		}
+		return ack, nil
+	}
+	return Ack{}, ErrMalformed
+}
@@ -0,0 +1,48 @@
+package lock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ProtocolTestSuite struct {
+	suite.Suite
+}
+
+func TestProtocolTestSuite(t *testing.T) {
+	suite.Run(t, new(ProtocolTestSuite))
+}
+
+func (s *ProtocolTestSuite) TestCommand_RoundTrip() {
+	command := Command{ID: "abc", BikeID: 12, Action: ActionUnlock}
+	s.Equal("CMD abc UNLOCK 12\n", formatCommand(command))
+	actual, err := parseCommand(formatCommand(command))
+	s.Equal(command, actual)
+	s.Nil(err)
+}
+
+func (s *ProtocolTestSuite) TestParseCommand_Malformed() {
+	for _, line := range []string{"", "CMD abc UNLOCK", "ACK abc OK", "CMD abc UNLOCK twelve"} {
+		_, err := parseCommand(line)
+		s.Equal(ErrMalformed, err, line)
+	}
+}
+
+func (s *ProtocolTestSuite) TestAck_RoundTrip() {
+	for _, ack := range []Ack{
+		{CommandID: "abc", OK: true},
+		{CommandID: "abc", Reason: "battery empty"},
+	} {
+		actual, err := parseAck(formatAck(ack))
+		s.Equal(ack, actual)
+		s.Nil(err)
+	}
+}
+
+func (s *ProtocolTestSuite) TestParseAck_Malformed() {
+	for _, line := range []string{"", "ACK abc", "ERR malformed lock message", "ACK abc MAYBE"} {
+		_, err := parseAck(line)
+		s.Equal(ErrMalformed, err, line)
+	}
+}
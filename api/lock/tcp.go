@@ -0,0 +1,129 @@
+package lock
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"sync"
+)
+
+// TCPTransport sends each command over a fresh connection to a lock gateway speaking the line protocol.
+type TCPTransport struct {
+	addr   string
+	dialer net.Dialer
+}
+
+func NewTCPTransport(addr string) *TCPTransport {
+	return &TCPTransport{
+		addr: addr,
+	}
+}
+
+func (t *TCPTransport) Send(ctx context.Context, command Command) (Ack, error) {
+	conn, err := t.dialer.DialContext(ctx, "tcp", t.addr)
+	if err != nil {
+		return Ack{}, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return Ack{}, err
+		}
+	}
+	if _, err := conn.Write([]byte(formatCommand(command))); err != nil {
+		return Ack{}, err
+	}
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return Ack{}, err
+	}
+	return parseAck(line)
+}
+
+// Server is a stand-in for the lock gateway, it answers the line protocol with a FakeLock so the whole
+// rent and return flow can run locally. A dropped acknowledgement leaves the connection silent.
+type Server struct {
+	// Logf, when set, is called for every command received.
+	Logf     func(format string, v ...interface{})
+	lock     *FakeLock
+	mu       sync.Mutex
+	listener net.Listener
+	closed   bool
+	conns    map[net.Conn]struct{}
+}
+
+func NewServer(lock *FakeLock) *Server {
+	return &Server{
+		lock:  lock,
+		conns: map[net.Conn]struct{}{},
+	}
+}
+
+// Serve accepts connections until Close is called.
+func (s *Server) Serve(listener net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return listener.Close()
+	}
+	s.listener = listener
+	s.mu.Unlock()
+	for {
+		conn, err := listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.track(conn, true)
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Close()
+}
+
+func (s *Server) track(conn net.Conn, add bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if add {
+		s.conns[conn] = struct{}{}
+		return
+	}
+	delete(s.conns, conn)
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer s.track(conn, false)
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		command, err := parseCommand(scanner.Text())
+		if err != nil {
+			conn.Write([]byte("ERR " + err.Error() + "\n"))
+			continue
+		}
+		ack, dropped := s.lock.Apply(command)
+		if s.Logf != nil {
+			s.Logf("%s bike %d command %s ok=%t dropped=%t %s", command.Action, command.BikeID, command.ID, ack.OK, dropped, ack.Reason)
+		}
+		if dropped {
+			continue
+		}
+		if _, err := conn.Write([]byte(formatAck(ack))); err != nil {
+			return
+		}
+	}
+}
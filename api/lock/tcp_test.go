@@ -0,0 +1,63 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TCPTestSuite struct {
+	suite.Suite
+	fake       *FakeLock
+	server     *Server
+	controller *Controller
+}
+
+func (s *TCPTestSuite) SetupTest() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	s.fake = NewFakeLock()
+	s.server = NewServer(s.fake)
+	go s.server.Serve(listener)
+	s.controller = NewController(NewTCPTransport(listener.Addr().String()), Config{Timeout: 100 * time.Millisecond, Retries: 1})
+}
+
+func (s *TCPTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func TestTCPTestSuite(t *testing.T) {
+	suite.Run(t, new(TCPTestSuite))
+}
+
+func (s *TCPTestSuite) TestUnlock_Success() {
+	err := s.controller.Unlock(context.TODO(), 4)
+	s.Nil(err)
+	s.Equal(domain.LockStateUnlocked, s.fake.State(4))
+}
+
+func (s *TCPTestSuite) TestUnlock_RetriedAfterLostAck() {
+	s.fake.DropAcks(1)
+	err := s.controller.Unlock(context.TODO(), 4)
+	s.Nil(err)
+	s.Len(s.fake.Commands(), 2)
+}
+
+func (s *TCPTestSuite) TestUnlock_Rejected() {
+	s.fake.Reject("battery empty")
+	err := s.controller.Unlock(context.TODO(), 4)
+	s.True(errors.Is(err, ErrRejected))
+	s.Contains(err.Error(), "battery empty")
+}
+
+func (s *TCPTestSuite) TestUnlock_ServerDown() {
+	s.server.Close()
+	err := s.controller.Unlock(context.TODO(), 4)
+	s.True(errors.Is(err, ErrNotConfirmed))
+}
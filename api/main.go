@@ -2,31 +2,35 @@ package main
 
 import (
 	"context"
+	"embed"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
+	"shared-bike/app"
+	"shared-bike/blobstore"
 	"shared-bike/customlogger"
 	docs "shared-bike/docs"
-	"shared-bike/domain"
-	customMiddleware "shared-bike/middleware"
-	"shared-bike/pkg/bike"
-	"shared-bike/pkg/user"
+	"shared-bike/health"
+	"shared-bike/metrics"
+	"shared-bike/server"
+	"shared-bike/tracing"
 
-	"github.com/gorilla/sessions"
 	"github.com/joho/godotenv"
-	"github.com/labstack/echo-contrib/session"
-	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
 	"github.com/labstack/gommon/log"
-	swagger "github.com/swaggo/echo-swagger"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
-const defaultPort = "8080"
+const healthCheckTimeout = 2 * time.Second
+
+// migrationFiles are the migrations this build expects, readiness fails until goose applied all of them.
+//
+//go:embed sql/migrations/*.sql
+var migrationFiles embed.FS
 
 // @title                      Shared Bike API
 // @version                    1.0
@@ -40,85 +44,83 @@ const defaultPort = "8080"
 // @name Authorization
 func main() {
 	godotenv.Load()
-	port := os.Getenv("PORT")
-	connectionString := os.Getenv("DB_CONNECTION_STRING")
-	tls := os.Getenv("TLS")
-	baseURl := os.Getenv("BASE_URL")
-	docs.SwaggerInfo.Schemes = []string{tls}
-	docs.SwaggerInfo.Host = baseURl
-	db, err := gorm.Open(mysql.Open(connectionString), &gorm.Config{})
+	logger := log.New("shared-bike")
+	logger.SetLevel(log.INFO)
+	config, err := app.LoadConfig(os.Getenv)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	docs.SwaggerInfo.Schemes = []string{config.SwaggerScheme}
+	docs.SwaggerInfo.Host = config.SwaggerHost
+	shutdownTracing, err := tracing.Setup(config.Tracing, os.Stdout)
+	if err != nil {
+		logger.Fatal(fmt.Errorf("setup tracing error: %w", err))
+	}
+	db, err := gorm.Open(mysql.Open(config.DBConnectionString), &gorm.Config{})
 	if err != nil {
 		panic(err)
 	}
-	if port == "" {
-		port = defaultPort
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		logger.Fatal(fmt.Errorf("setup query tracing error: %w", err))
 	}
-	// Setup
-	e := echo.New()
-	e.Logger.SetPrefix("shared-bike")
-	e.Logger.SetLevel(log.INFO)
-	contextLogger := customlogger.NewContextLogger(e.Logger)
-	secret := os.Getenv("SECRET")
-	e.Use(
-		session.Middleware(sessions.NewCookieStore([]byte(secret))),
-		middleware.GzipWithConfig(middleware.GzipConfig{
-			Level: 5,
-		}),
-		middleware.RequestID(),
-		customMiddleware.AddLoggerContext(contextLogger),
-		middleware.Logger(),
-		middleware.CORSWithConfig(middleware.CORSConfig{
-			AllowOrigins:     []string{"http://localhost:3000"},
-			AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, echo.HeaderAuthorization},
-			AllowCredentials: true,
-		}),
-		middleware.JWTWithConfig(middleware.JWTConfig{
-			SigningKey:              []byte(secret),
-			Claims:                  &domain.Claims{},
-			ErrorHandlerWithContext: customMiddleware.CustomJWTError,
-			TokenLookup:             "header:" + echo.HeaderAuthorization,
-			Skipper:                 customMiddleware.WhiteListAPI,
-		}),
-	)
 	dbInstance, _ := db.DB()
 	if err := dbInstance.Ping(); err != nil {
-		e.Logger.Fatal(fmt.Errorf("connect db error: %w", err))
+		logger.Fatal(fmt.Errorf("connect db error: %w", err))
+	}
+	lockController, err := app.NewLockController(config)
+	if err != nil {
+		logger.Fatal(fmt.Errorf("setup lock controller error: %w", err))
+	}
+	photoStore, err := blobstore.NewLocalStore(config.PhotoStorageDir)
+	if err != nil {
+		logger.Fatal(fmt.Errorf("open photo storage error: %w", err))
 	}
-	e.GET("/health", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, "OK")
+	healthRegistry := health.NewRegistry(customlogger.NewContextLogger(logger), healthCheckTimeout)
+	healthRegistry.AddReadiness("db", health.PingCheck(dbInstance))
+	migrationCheck, err := health.MigrationCheck(dbInstance, migrationFiles, "sql/migrations")
+	if err != nil {
+		logger.Fatal(fmt.Errorf("setup migration check error: %w", err))
+	}
+	healthRegistry.AddReadiness("migrations", migrationCheck)
+	e, err := app.New(config, app.Dependencies{
+		DB:             db,
+		Logger:         logger,
+		LockController: lockController,
+		PhotoStore:     photoStore,
+		Metrics:        metrics.New(),
+		Health:         healthRegistry,
 	})
-	e.GET("/swagger/*", swagger.WrapHandler)
-	root := e.Group("/api/v1")
-	userRepo := user.NewRepository(db)
-	userUseCase := user.NewUseCase(contextLogger, userRepo)
-	userHandler := user.NewHandler(userUseCase)
-	userAPIs := root.Group("/users")
-	userAPIs.POST("/login", userHandler.Login)
-	userAPIs.POST("/register", userHandler.Register)
-
-	bikeRepo := bike.NewRepository(db)
-	bikeUseCase := bike.NewUseCase(contextLogger, bikeRepo, userRepo)
-	bikeHandler := bike.NewHandler(bikeUseCase)
-	bikeAPIs := root.Group("/bikes")
-	bikeAPIs.GET("", bikeHandler.GetAllBike)
-	bikeAPIs.PATCH("/:id/rent", bikeHandler.Rent)
-	bikeAPIs.PATCH("/:id/return", bikeHandler.Return)
+	if err != nil {
+		logger.Fatal(fmt.Errorf("setup api error: %w", err))
+	}
 
 	// Start server
+	srv, err := server.New(e, config.Server, logger, e.StdLogger)
+	if err != nil {
+		logger.Fatal(fmt.Errorf("setup server error: %w", err))
+	}
 	go func() {
-		if err := e.Start(":8000"); err != nil && err != http.ErrServerClosed {
-			e.Logger.Fatal("shutting down the server")
+		logger.Info(fmt.Sprintf("listening on %s, TLS %t", srv.Addr, srv.TLSConfig != nil))
+		if err := server.ListenAndServe(srv); err != nil && err != http.ErrServerClosed {
+			logger.Fatal(fmt.Errorf("shutting down the server: %w", err))
 		}
 	}()
 
-	// Wait for interrupt signal to gracefully shutdown the server with a timeout of 10 seconds.
+	// Wait for an interrupt, or the SIGTERM a container runtime sends, to gracefully shutdown the server.
 	// Use a buffered channel to avoid missing signals as recommended for signal.Notify
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// fail readiness first and keep serving while the load balancer notices, then stop taking connections
+	healthRegistry.Drain()
+	time.Sleep(config.DrainDelay)
+	// Shutdown waits for the requests in flight, so a rent or a return the lock is confirming still completes
+	ctx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
 	defer cancel()
-	if err := e.Shutdown(ctx); err != nil {
-		e.Logger.Fatal(err)
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error("requests still running at shutdown timeout", err)
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("flush spans error", err)
 	}
 }
@@ -0,0 +1,211 @@
+package metrics
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"shared-bike/apperrors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "shared_bike"
+	// unmatchedRoute labels requests no echo route matched, so probing random paths cannot grow the label set.
+	unmatchedRoute = "unmatched"
+	// countTimeout bounds the database query behind a gauge, a slow database must not hang the scrape.
+	countTimeout = 2 * time.Second
+)
+
+// Metrics owns the Prometheus registry of the API and the collectors the application reports to.
+type Metrics struct {
+	registry       *prometheus.Registry
+	requests       *prometheus.CounterVec
+	duration       *prometheus.HistogramVec
+	rentalsStarted prometheus.Counter
+	rentalsEnded   prometheus.Counter
+	rentalFailures *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, echo route and status code.",
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method and echo route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		rentalsStarted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rentals_started_total",
+			Help:      "Bikes rented.",
+		}),
+		rentalsEnded: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rentals_ended_total",
+			Help:      "Bikes returned.",
+		}),
+		rentalFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rental_failures_total",
+			Help:      "Refused or failed rents and returns by action and error code.",
+		}, []string{"action", "code"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.duration,
+		m.rentalsStarted,
+		m.rentalsEnded,
+		m.rentalFailures,
+	)
+	return m
+}
+
+// RegisterDB exports the connection pool stats of db.
+func (m *Metrics) RegisterDB(db *sql.DB) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, namespace))
+}
+
+// RegisterRentedBikes exports the number of bikes rented right now. It is read from the database on every scrape,
+// so it is right for the whole fleet no matter how many API instances run or when they started.
+func (m *Metrics) RegisterRentedBikes(count func(ctx context.Context) (int64, error)) {
+	m.registry.MustRegister(&countCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "bikes_rented"),
+			"Bikes rented right now.",
+			nil, nil,
+		),
+		count: count,
+	})
+}
+
+// CacheStats are the counters of a cache as RegisterUserCache exports them.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+}
+
+// RegisterUserCache exports the counters of the renter cache, stats is read on every scrape.
+func (m *Metrics) RegisterUserCache(stats func() CacheStats) {
+	m.registry.MustRegister(
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "user_cache_hits_total",
+			Help:      "Renter lookups answered from the cache.",
+		}, func() float64 { return float64(stats().Hits) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "user_cache_misses_total",
+			Help:      "Renter lookups that had to query the database.",
+		}, func() float64 { return float64(stats().Misses) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "user_cache_evictions_total",
+			Help:      "Users dropped from the cache to stay within its size.",
+		}, func() float64 { return float64(stats().Evictions) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "user_cache_entries",
+			Help:      "Users in the cache.",
+		}, func() float64 { return float64(stats().Entries) }),
+	)
+}
+
+func (m *Metrics) RentalStarted() {
+	m.rentalsStarted.Inc()
+}
+
+func (m *Metrics) RentalEnded() {
+	m.rentalsEnded.Inc()
+}
+
+func (m *Metrics) RentalFailed(action string, err error) {
+	m.rentalFailures.WithLabelValues(action, apperrors.Code(err)).Inc()
+}
+
+// Middleware counts and times every request by its echo route, like /api/v1/bikes/:id, never by the raw path.
+func (m *Metrics) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			route := c.Path()
+			// echo reports the raw path when no route matches
+			if route == "" || errors.Is(err, echo.ErrNotFound) {
+				route = unmatchedRoute
+			}
+			method := c.Request().Method
+			m.requests.WithLabelValues(method, route, strconv.Itoa(responseStatus(c, err))).Inc()
+			m.duration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
+
+// Handler serves the metrics to callers presenting token as bearer token. The endpoint is skipped by the JWT
+// middleware, scrapers cannot log in.
+func (m *Metrics) Handler(token string) echo.HandlerFunc {
+	handler := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		// echo's gzip middleware already compresses the response
+		DisableCompression: true,
+	})
+	expected := []byte("Bearer " + token)
+	return func(c echo.Context) error {
+		if subtle.ConstantTimeCompare([]byte(c.Request().Header.Get(echo.HeaderAuthorization)), expected) != 1 {
+			c.Logger().Error("[Metrics.Handler] invalid metrics token", apperrors.ErrUnauthorizeError)
+			return c.JSON(apperrors.GetStatusCode(apperrors.ErrUnauthorizeError), apperrors.ErrUnauthorizeError.Error())
+		}
+		handler.ServeHTTP(c.Response(), c.Request())
+		return nil
+	}
+}
+
+// responseStatus is the status the client gets, a returned error is only written by echo after the middleware.
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) {
+		return httpError.Code
+	}
+	return http.StatusInternalServerError
+}
+
+type countCollector struct {
+	desc  *prometheus.Desc
+	count func(ctx context.Context) (int64, error)
+}
+
+func (c *countCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *countCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), countTimeout)
+	defer cancel()
+	total, err := c.count(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(total))
+}
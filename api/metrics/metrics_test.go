@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"shared-bike/apperrors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+	metrics *Metrics
+	echo    *echo.Echo
+}
+
+func (s *MetricsTestSuite) SetupTest() {
+	s.metrics = New()
+	s.echo = echo.New()
+	s.echo.Use(s.metrics.Middleware())
+	s.echo.GET("/api/v1/bikes/:id", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, "OK")
+	})
+	s.echo.PATCH("/api/v1/bikes/:id/rent", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusConflict)
+	})
+	s.echo.GET("/panic", func(c echo.Context) error {
+		return errors.New("boom")
+	})
+	s.echo.GET("/metrics", s.metrics.Handler("secret"))
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+func (s *MetricsTestSuite) serve(method, target, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	if authorization != "" {
+		req.Header.Set(echo.HeaderAuthorization, authorization)
+	}
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *MetricsTestSuite) TestMiddleware_LabelsByRoute() {
+	s.serve(http.MethodGet, "/api/v1/bikes/1", "")
+	s.serve(http.MethodGet, "/api/v1/bikes/2", "")
+	s.Equal(float64(2), testutil.ToFloat64(s.metrics.requests.WithLabelValues(http.MethodGet, "/api/v1/bikes/:id", "200")))
+	s.Equal(1, testutil.CollectAndCount(s.metrics.duration))
+}
+
+func (s *MetricsTestSuite) TestMiddleware_StatusOfReturnedError() {
+	s.serve(http.MethodPatch, "/api/v1/bikes/1/rent", "")
+	s.serve(http.MethodGet, "/panic", "")
+	s.Equal(float64(1), testutil.ToFloat64(s.metrics.requests.WithLabelValues(http.MethodPatch, "/api/v1/bikes/:id/rent", "409")))
+	s.Equal(float64(1), testutil.ToFloat64(s.metrics.requests.WithLabelValues(http.MethodGet, "/panic", "500")))
+}
+
+func (s *MetricsTestSuite) TestMiddleware_Unmatched() {
+	s.serve(http.MethodGet, "/wp-login.php", "")
+	s.serve(http.MethodGet, "/.env", "")
+	s.Equal(float64(2), testutil.ToFloat64(s.metrics.requests.WithLabelValues(http.MethodGet, "unmatched", "404")))
+}
+
+func (s *MetricsTestSuite) TestHandler_Success() {
+	s.serve(http.MethodGet, "/api/v1/bikes/1", "")
+	rec := s.serve(http.MethodGet, "/metrics", "Bearer secret")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Contains(rec.Body.String(), `shared_bike_http_requests_total{method="GET",route="/api/v1/bikes/:id",status="200"} 1`)
+	s.Contains(rec.Body.String(), "go_goroutines")
+}
+
+func (s *MetricsTestSuite) TestHandler_Unauthorized() {
+	for _, authorization := range []string{"", "Bearer wrong", "secret", "Bearer secret2"} {
+		rec := s.serve(http.MethodGet, "/metrics", authorization)
+		s.Equal(http.StatusUnauthorized, rec.Code, authorization)
+		s.Equal("\"e4010 unauthorized\"\n", rec.Body.String())
+	}
+}
+
+func (s *MetricsTestSuite) TestRentals() {
+	s.metrics.RentalStarted()
+	s.metrics.RentalStarted()
+	s.metrics.RentalEnded()
+	s.metrics.RentalFailed("rent", apperrors.ErrUserHasBikeAlready)
+	s.metrics.RentalFailed("return", errors.New("boom"))
+	s.Equal(float64(2), testutil.ToFloat64(s.metrics.rentalsStarted))
+	s.Equal(float64(1), testutil.ToFloat64(s.metrics.rentalsEnded))
+	s.Equal(float64(1), testutil.ToFloat64(s.metrics.rentalFailures.WithLabelValues("rent", "e4001")))
+	s.Equal(float64(1), testutil.ToFloat64(s.metrics.rentalFailures.WithLabelValues("return", apperrors.CodeUnknown)))
+}
+
+func (s *MetricsTestSuite) TestRegisterRentedBikes_Success() {
+	s.metrics.RegisterRentedBikes(func(ctx context.Context) (int64, error) {
+		_, hasDeadline := ctx.Deadline()
+		s.True(hasDeadline)
+		return 3, nil
+	})
+	expected := `
+# HELP shared_bike_bikes_rented Bikes rented right now.
+# TYPE shared_bike_bikes_rented gauge
+shared_bike_bikes_rented 3
+`
+	s.NoError(testutil.GatherAndCompare(s.metrics.registry, strings.NewReader(expected), "shared_bike_bikes_rented"))
+}
+
+func (s *MetricsTestSuite) TestRegisterRentedBikes_Failed() {
+	s.metrics.RegisterRentedBikes(func(ctx context.Context) (int64, error) {
+		return 0, errors.New("db down")
+	})
+	_, err := s.metrics.registry.Gather()
+	s.ErrorContains(err, "db down")
+}
+
+func (s *MetricsTestSuite) TestRegisterUserCache() {
+	s.metrics.RegisterUserCache(func() CacheStats {
+		return CacheStats{Hits: 1, Misses: 1, Entries: 1}
+	})
+	expected := `
+# HELP shared_bike_user_cache_entries Users in the cache.
+# TYPE shared_bike_user_cache_entries gauge
+shared_bike_user_cache_entries 1
+# HELP shared_bike_user_cache_hits_total Renter lookups answered from the cache.
+# TYPE shared_bike_user_cache_hits_total counter
+shared_bike_user_cache_hits_total 1
+# HELP shared_bike_user_cache_misses_total Renter lookups that had to query the database.
+# TYPE shared_bike_user_cache_misses_total counter
+shared_bike_user_cache_misses_total 1
+`
+	s.NoError(testutil.GatherAndCompare(s.metrics.registry, strings.NewReader(expected),
+		"shared_bike_user_cache_entries", "shared_bike_user_cache_hits_total", "shared_bike_user_cache_misses_total"))
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"shared-bike/apperrors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// BodyLimit refuses a request whose body is larger than limit, like "8M", with ErrRequestBodyTooLarge. A body
+// announced small that turns out larger stops being read at limit, the handler then fails to bind it.
+func BodyLimit(limit string) echo.MiddlewareFunc {
+	bodyLimit := middleware.BodyLimit(limit)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		limited := bodyLimit(next)
+		return func(c echo.Context) error {
+			err := limited(c)
+			if err == echo.ErrStatusRequestEntityTooLarge {
+				c.Logger().Error("[BodyLimit] request body is too large", c.Request().ContentLength)
+				return c.JSON(apperrors.GetStatusCode(apperrors.ErrRequestBodyTooLarge), apperrors.ErrRequestBodyTooLarge.Error())
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"shared-bike/apperrors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type BodyLimitTestSuite struct {
+	suite.Suite
+	echo *echo.Echo
+}
+
+func (s *BodyLimitTestSuite) SetupTest() {
+	s.echo = echo.New()
+	s.echo.Use(BodyLimit("16B"))
+	s.echo.POST("/echo", func(c echo.Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBody), apperrors.ErrInvalidBody.Error())
+		}
+		return c.String(http.StatusOK, string(body))
+	})
+}
+
+func TestBodyLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(BodyLimitTestSuite))
+}
+
+func (s *BodyLimitTestSuite) TestBodyLimit_Success() {
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"id":1}`))
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(`{"id":1}`, rec.Body.String())
+}
+
+func (s *BodyLimitTestSuite) TestBodyLimit_TooLarge() {
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"name":"a long name"}`))
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	s.Equal(http.StatusRequestEntityTooLarge, rec.Code)
+	s.Equal("\"e4131 request body is too large\"\n", rec.Body.String())
+}
+
+func (s *BodyLimitTestSuite) TestBodyLimit_TooLargeWithoutLength() {
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"name":"a long name"}`))
+	req.ContentLength = -1
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4005 invalid body\"\n", rec.Body.String())
+}
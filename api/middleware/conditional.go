@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NotModified sets the validators of a response and reports whether the copy the client already has is still current,
+// in which case the handler answers 304 without building the body. If-None-Match wins over If-Modified-Since.
+// Responses depend on who asks, so they may only be kept by the client and must be revalidated.
+func NotModified(c echo.Context, etag string, lastModified time.Time) bool {
+	header := c.Response().Header()
+	header.Set(echo.HeaderCacheControl, "private, no-cache")
+	header.Set(echo.HeaderVary, echo.HeaderAuthorization)
+	header.Set(HeaderETag, etag)
+	if !lastModified.IsZero() {
+		header.Set(echo.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+	req := c.Request()
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	if ifNoneMatch := req.Header.Get(HeaderIfNoneMatch); ifNoneMatch != "" {
+		return etagListContains(ifNoneMatch, etag)
+	}
+	if ifModifiedSince := req.Header.Get(echo.HeaderIfModifiedSince); ifModifiedSince != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		return err == nil && !lastModified.Truncate(time.Second).After(since)
+	}
+	return false
+}
+
+// etagListContains compares weakly, as If-None-Match does, so W/"1" matches "1".
+func etagListContains(list, etag string) bool {
+	if strings.TrimSpace(list) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(list, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
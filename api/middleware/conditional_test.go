@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConditionalTestSuite struct {
+	suite.Suite
+	echo *echo.Echo
+}
+
+var mockLastModified = time.Date(2026, 10, 19, 14, 0, 0, 500, time.UTC)
+
+func (s *ConditionalTestSuite) SetupTest() {
+	s.echo = echo.New()
+}
+
+func TestConditionalTestSuite(t *testing.T) {
+	suite.Run(t, new(ConditionalTestSuite))
+}
+
+func (s *ConditionalTestSuite) notModified(method string, headers map[string]string, etag string) (bool, http.Header) {
+	req := httptest.NewRequest(method, "/bikes/1", nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	actual := NotModified(c, etag, mockLastModified)
+	return actual, rec.Header()
+}
+
+func (s *ConditionalTestSuite) TestNotModified_SetsValidators() {
+	actual, header := s.notModified(http.MethodGet, nil, `"3"`)
+	s.False(actual)
+	s.Equal(`"3"`, header.Get(HeaderETag))
+	s.Equal("Mon, 19 Oct 2026 14:00:00 GMT", header.Get(echo.HeaderLastModified))
+	s.Equal("private, no-cache", header.Get(echo.HeaderCacheControl))
+	s.Equal(echo.HeaderAuthorization, header.Get(echo.HeaderVary))
+}
+
+func (s *ConditionalTestSuite) TestNotModified_IfNoneMatch() {
+	testCases := []struct {
+		ifNoneMatch string
+		etag        string
+		expected    bool
+	}{
+		{ifNoneMatch: `"3"`, etag: `"3"`, expected: true},
+		{ifNoneMatch: `"2"`, etag: `"3"`, expected: false},
+		{ifNoneMatch: `"1", W/"3"`, etag: `"3"`, expected: true},
+		{ifNoneMatch: `"abc"`, etag: `W/"abc"`, expected: true},
+		{ifNoneMatch: `*`, etag: `"3"`, expected: true},
+	}
+	for _, testCase := range testCases {
+		actual, _ := s.notModified(http.MethodGet, map[string]string{HeaderIfNoneMatch: testCase.ifNoneMatch}, testCase.etag)
+		s.Equal(testCase.expected, actual, testCase.ifNoneMatch)
+	}
+}
+
+func (s *ConditionalTestSuite) TestNotModified_IfNoneMatchWinsOverIfModifiedSince() {
+	actual, _ := s.notModified(http.MethodGet, map[string]string{
+		HeaderIfNoneMatch:          `"2"`,
+		echo.HeaderIfModifiedSince: "Mon, 19 Oct 2026 15:00:00 GMT",
+	}, `"3"`)
+	s.False(actual)
+}
+
+func (s *ConditionalTestSuite) TestNotModified_IfModifiedSince() {
+	actual, _ := s.notModified(http.MethodGet, map[string]string{echo.HeaderIfModifiedSince: "Mon, 19 Oct 2026 14:00:00 GMT"}, `"3"`)
+	s.True(actual)
+	actual, _ = s.notModified(http.MethodGet, map[string]string{echo.HeaderIfModifiedSince: "Mon, 19 Oct 2026 13:59:59 GMT"}, `"3"`)
+	s.False(actual)
+	actual, _ = s.notModified(http.MethodGet, map[string]string{echo.HeaderIfModifiedSince: "yesterday"}, `"3"`)
+	s.False(actual)
+}
+
+func (s *ConditionalTestSuite) TestNotModified_OnlyForReads() {
+	actual, _ := s.notModified(http.MethodPatch, map[string]string{HeaderIfNoneMatch: `"3"`}, `"3"`)
+	s.False(actual)
+}
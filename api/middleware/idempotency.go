@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// rent and return bodies are a few coordinates, anything larger is not worth fingerprinting
+	maxIdempotentBodySize = 64 << 10
+	// bounds storing the outcome, which runs even when the client is gone
+	idempotencyStoreTimeout = 5 * time.Second
+)
+
+// now is replaced in tests to expire stored responses.
+var now = time.Now
+
+// Idempotency stores the first response to a request carrying an Idempotency-Key header per user and key for ttl,
+// and replays it to retries. Reusing a key for a different request is rejected, and so is a retry that arrives
+// while the first request is still running, for up to lease, after which the first request is presumed dead and the
+// retry runs instead. Requests without the header or without a logged-in user pass through.
+func Idempotency(store IdempotencyStore, ttl, lease time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderIdempotencyKey)
+			if key == "" {
+				return next(c)
+			}
+			claims, err := GetClaims(c)
+			if err != nil {
+				return next(c)
+			}
+			if !isValidIdempotencyKey(key) {
+				c.Logger().Error("[Idempotency] invalid key", apperrors.ErrInvalidIdempotencyKey)
+				return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidIdempotencyKey), apperrors.ErrInvalidIdempotencyKey.Error())
+			}
+			fingerprint, err := fingerprintRequest(c)
+			if err != nil {
+				c.Logger().Error("[Idempotency] read body failed", err)
+				return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBody), apperrors.ErrInvalidBody.Error())
+			}
+			ctx := c.Request().Context()
+			record := &domain.IdempotentRequest{
+				UserID:         claims.ID,
+				IdempotencyKey: key,
+				Fingerprint:    fingerprint,
+				ExpiresAt:      now().Add(ttl),
+				// the column keeps microseconds, the value has to match it to identify this attempt later
+				LockedUntil: now().Add(lease).Truncate(time.Microsecond),
+			}
+			reserved, err := store.Reserve(ctx, record, now())
+			if err != nil {
+				c.Logger().Error("[Idempotency] reserve failed", err)
+				return c.JSON(apperrors.GetStatusCode(apperrors.ErrInternalServerError), apperrors.ErrInternalServerError.Error())
+			}
+			if !reserved {
+				return replay(c, store, record)
+			}
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				// a failed or panicking attempt changed nothing we can vouch for, so the client may run it again
+				ctx, cancel := domain.Detach(ctx, idempotencyStoreTimeout)
+				defer cancel()
+				if err := store.Release(ctx, record); err != nil {
+					c.Logger().Error("[Idempotency] release failed", err)
+				}
+			}()
+			recorder := &bodyRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			err = next(c)
+			res := c.Response()
+			if err != nil || !res.Committed || res.Status >= http.StatusInternalServerError {
+				return err
+			}
+			record.StatusCode = res.Status
+			record.ContentType = res.Header().Get(echo.HeaderContentType)
+			record.ETag = res.Header().Get(HeaderETag)
+			record.Body = recorder.body.Bytes()
+			completed = true
+			// the response is on its way, store it even if the client hung up meanwhile
+			ctx, cancel := domain.Detach(ctx, idempotencyStoreTimeout)
+			defer cancel()
+			if err := store.Complete(ctx, record); err != nil {
+				c.Logger().Error("[Idempotency] complete failed", err)
+			}
+			return nil
+		}
+	}
+}
+
+func replay(c echo.Context, store IdempotencyStore, record *domain.IdempotentRequest) error {
+	stored, err := store.Get(c.Request().Context(), record.UserID, record.IdempotencyKey)
+	if err != nil {
+		// the first attempt may have been released between our reserve and this read, let the client retry
+		c.Logger().Error("[Idempotency] get failed", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrIdempotencyKeyInProgress), apperrors.ErrIdempotencyKeyInProgress.Error())
+	}
+	if stored.Fingerprint != record.Fingerprint {
+		c.Logger().Error("[Idempotency] key reused", apperrors.ErrIdempotencyKeyReused, record.UserID)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrIdempotencyKeyReused), apperrors.ErrIdempotencyKeyReused.Error())
+	}
+	if !stored.IsCompleted() {
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrIdempotencyKeyInProgress), apperrors.ErrIdempotencyKeyInProgress.Error())
+	}
+	c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+	if stored.ETag != "" {
+		c.Response().Header().Set(HeaderETag, stored.ETag)
+	}
+	return c.Blob(stored.StatusCode, stored.ContentType, stored.Body)
+}
+
+func isValidIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] <= ' ' || key[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// fingerprintRequest hashes method, path and body so a key reused for another bike or another action is detected.
+// The body is put back for the handler.
+func fingerprintRequest(c echo.Context) (string, error) {
+	req := c.Request()
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(io.LimitReader(req.Body, maxIdempotentBodySize+1))
+		if err != nil {
+			return "", err
+		}
+		if len(body) > maxIdempotentBodySize {
+			return "", errors.New("request body too large")
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	hash := sha256.New()
+	hash.Write([]byte(req.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(req.URL.Path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// bodyRecorder keeps a copy of the response body while writing it through.
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *bodyRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *bodyRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return r.ResponseWriter.(http.Hijacker).Hijack()
+}
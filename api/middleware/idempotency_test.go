@@ -0,0 +1,282 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"shared-bike/apperrors"
+	"shared-bike/customlogger"
+	"shared-bike/domain"
+	"shared-bike/middleware/mocks"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type IdempotencyTestSuite struct {
+	suite.Suite
+	echo      *echo.Echo
+	mockStore *mocks.IdempotencyStore
+	calls     int
+}
+
+var mockIdempotencyNow = time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+
+func (s *IdempotencyTestSuite) SetupTest() {
+	s.echo = echo.New()
+	s.echo.Logger = customlogger.NewContextLogger(s.echo.Logger)
+	s.mockStore = &mocks.IdempotencyStore{}
+	s.calls = 0
+	now = func() time.Time { return mockIdempotencyNow }
+}
+
+func (s *IdempotencyTestSuite) TearDownTest() {
+	now = time.Now
+}
+
+func TestIdempotencyTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyTestSuite))
+}
+
+func (s *IdempotencyTestSuite) context(key, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/bikes/1/return", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(UserKey, &jwt.Token{Valid: true, Claims: &domain.Claims{ID: 1}})
+	return c, rec
+}
+
+func (s *IdempotencyTestSuite) serve(key, body string, status int) *httptest.ResponseRecorder {
+	c, rec := s.context(key, body)
+	handler := Idempotency(s.mockStore, time.Hour, time.Minute)(func(c echo.Context) error {
+		s.calls++
+		payload := map[string]string{}
+		s.Nil(c.Bind(&payload))
+		c.Response().Header().Set(HeaderETag, `"4"`)
+		return c.JSON(status, payload)
+	})
+	s.Nil(handler(c))
+	return rec
+}
+
+func (s *IdempotencyTestSuite) reservation(fingerprint string) *domain.IdempotentRequest {
+	return &domain.IdempotentRequest{
+		UserID:         1,
+		IdempotencyKey: "key-1",
+		Fingerprint:    fingerprint,
+		ExpiresAt:      mockIdempotencyNow.Add(time.Hour),
+		LockedUntil:    mockIdempotencyNow.Add(time.Minute),
+	}
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_PassThroughWithoutKey() {
+	rec := s.serve("", `{"lat":"1"}`, http.StatusOK)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(1, s.calls)
+	s.mockStore.AssertNotCalled(s.T(), "Reserve", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_PassThroughWithoutClaims() {
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/bikes/1/rent", nil)
+	req.Header.Set(HeaderIdempotencyKey, "key-1")
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	handler := Idempotency(s.mockStore, time.Hour, time.Minute)(func(c echo.Context) error {
+		return c.JSON(http.StatusOK, "ok")
+	})
+	s.Nil(handler(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.mockStore.AssertNotCalled(s.T(), "Reserve", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_InvalidKey() {
+	rec := s.serve("key with space", `{}`, http.StatusOK)
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e40024 invalid idempotency key, expected 1 to 255 visible characters\"\n", rec.Body.String())
+	s.Equal(0, s.calls)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_KeyTooLong() {
+	rec := s.serve(strings.Repeat("k", 256), `{}`, http.StatusOK)
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(0, s.calls)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_BodyTooLarge() {
+	rec := s.serve("key-1", strings.Repeat("a", maxIdempotentBodySize+1), http.StatusOK)
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4005 invalid body\"\n", rec.Body.String())
+	s.Equal(0, s.calls)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_FirstRequestStored() {
+	var reserved *domain.IdempotentRequest
+	s.mockStore.On("Reserve", mock.Anything, mock.Anything, mockIdempotencyNow).Run(func(args mock.Arguments) {
+		reserved = args.Get(1).(*domain.IdempotentRequest)
+	}).Return(true, nil)
+	s.mockStore.On("Complete", mock.Anything, mock.Anything).Return(nil)
+	rec := s.serve("key-1", `{"lat":"1"}`, http.StatusOK)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("{\"lat\":\"1\"}\n", rec.Body.String())
+	s.Empty(rec.Header().Get(HeaderIdempotentReplayed))
+	s.Equal(1, s.calls)
+	s.Equal(int64(1), reserved.UserID)
+	s.Equal("key-1", reserved.IdempotencyKey)
+	s.Len(reserved.Fingerprint, 64)
+	s.Equal(mockIdempotencyNow.Add(time.Hour), reserved.ExpiresAt)
+	s.Equal(mockIdempotencyNow.Add(time.Minute), reserved.LockedUntil)
+	s.Equal(http.StatusOK, reserved.StatusCode)
+	s.Equal(echo.MIMEApplicationJSONCharsetUTF8, reserved.ContentType)
+	s.Equal(`"4"`, reserved.ETag)
+	s.Equal("{\"lat\":\"1\"}\n", string(reserved.Body))
+	s.mockStore.AssertCalled(s.T(), "Complete", mock.Anything, reserved)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_ClientErrorStored() {
+	s.mockStore.On("Reserve", mock.Anything, mock.Anything, mockIdempotencyNow).Return(true, nil)
+	s.mockStore.On("Complete", mock.Anything, mock.Anything).Return(nil)
+	rec := s.serve("key-1", `{}`, http.StatusBadRequest)
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.mockStore.AssertCalled(s.T(), "Complete", mock.Anything, mock.Anything)
+	s.mockStore.AssertNotCalled(s.T(), "Release", mock.Anything, mock.Anything)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_ServerErrorReleased() {
+	s.mockStore.On("Reserve", mock.Anything, mock.Anything, mockIdempotencyNow).Return(true, nil)
+	s.mockStore.On("Release", mock.Anything, mock.Anything).Return(nil)
+	rec := s.serve("key-1", `{}`, http.StatusInternalServerError)
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.mockStore.AssertCalled(s.T(), "Release", mock.Anything, mock.Anything)
+	s.mockStore.AssertNotCalled(s.T(), "Complete", mock.Anything, mock.Anything)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_PanicReleased() {
+	s.mockStore.On("Reserve", mock.Anything, mock.Anything, mockIdempotencyNow).Return(true, nil)
+	s.mockStore.On("Release", mock.Anything, mock.Anything).Return(nil)
+	c, _ := s.context("key-1", `{}`)
+	handler := Idempotency(s.mockStore, time.Hour, time.Minute)(func(c echo.Context) error {
+		panic("mock panic")
+	})
+	s.Panics(func() { _ = handler(c) })
+	s.mockStore.AssertCalled(s.T(), "Release", mock.Anything, mock.Anything)
+	s.mockStore.AssertNotCalled(s.T(), "Complete", mock.Anything, mock.Anything)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_StoredAfterClientGone() {
+	live := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil })
+	for _, status := range []int{http.StatusOK, http.StatusGatewayTimeout} {
+		s.mockStore = &mocks.IdempotencyStore{}
+		s.mockStore.On("Reserve", mock.Anything, mock.Anything, mockIdempotencyNow).Return(true, nil)
+		s.mockStore.On("Complete", live, mock.Anything).Return(nil)
+		s.mockStore.On("Release", live, mock.Anything).Return(nil)
+		c, rec := s.context("key-1", `{}`)
+		ctx, cancel := context.WithCancel(c.Request().Context())
+		c.SetRequest(c.Request().WithContext(ctx))
+		handler := Idempotency(s.mockStore, time.Hour, time.Minute)(func(c echo.Context) error {
+			cancel()
+			return c.JSON(status, "done")
+		})
+		s.Nil(handler(c))
+		s.Equal(status, rec.Code)
+		// the matcher checked the context when the call was made, the deadline is cancelled by now
+		if status == http.StatusOK {
+			s.mockStore.AssertCalled(s.T(), "Complete", mock.Anything, mock.Anything)
+		} else {
+			s.mockStore.AssertCalled(s.T(), "Release", mock.Anything, mock.Anything)
+		}
+	}
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_CompleteFailedStillResponds() {
+	s.mockStore.On("Reserve", mock.Anything, mock.Anything, mockIdempotencyNow).Return(true, nil)
+	s.mockStore.On("Complete", mock.Anything, mock.Anything).Return(errors.New("mock error"))
+	rec := s.serve("key-1", `{}`, http.StatusOK)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(1, s.calls)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_ReserveFailed() {
+	s.mockStore.On("Reserve", mock.Anything, mock.Anything, mockIdempotencyNow).Return(false, errors.New("mock error"))
+	rec := s.serve("key-1", `{}`, http.StatusOK)
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Equal("\"e5000 internal server error\"\n", rec.Body.String())
+	s.Equal(0, s.calls)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_Replayed() {
+	var fingerprint string
+	s.mockStore.On("Reserve", mock.Anything, mock.Anything, mockIdempotencyNow).Run(func(args mock.Arguments) {
+		fingerprint = args.Get(1).(*domain.IdempotentRequest).Fingerprint
+	}).Return(false, nil)
+	s.mockStore.On("Get", mock.Anything, int64(1), "key-1").Return(func(_ context.Context, _ int64, _ string) *domain.IdempotentRequest {
+		stored := s.reservation(fingerprint)
+		stored.StatusCode = http.StatusOK
+		stored.ContentType = echo.MIMEApplicationJSONCharsetUTF8
+		stored.ETag = `"4"`
+		stored.Body = []byte("{\"id\":1}\n")
+		return stored
+	}, nil)
+	rec := s.serve("key-1", `{"lat":"1"}`, http.StatusOK)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("{\"id\":1}\n", rec.Body.String())
+	s.Equal("true", rec.Header().Get(HeaderIdempotentReplayed))
+	s.Equal(echo.MIMEApplicationJSONCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+	s.Equal(`"4"`, rec.Header().Get(HeaderETag))
+	s.Equal(0, s.calls)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_KeyReusedForDifferentRequest() {
+	s.mockStore.On("Reserve", mock.Anything, mock.Anything, mockIdempotencyNow).Return(false, nil)
+	stored := s.reservation("other")
+	stored.StatusCode = http.StatusOK
+	s.mockStore.On("Get", mock.Anything, int64(1), "key-1").Return(stored, nil)
+	rec := s.serve("key-1", `{"lat":"1"}`, http.StatusOK)
+	s.Equal(http.StatusUnprocessableEntity, rec.Code)
+	s.Equal("\"e4220 the idempotency key was already used for a different request\"\n", rec.Body.String())
+	s.Equal(0, s.calls)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_InProgress() {
+	var fingerprint string
+	s.mockStore.On("Reserve", mock.Anything, mock.Anything, mockIdempotencyNow).Run(func(args mock.Arguments) {
+		fingerprint = args.Get(1).(*domain.IdempotentRequest).Fingerprint
+	}).Return(false, nil)
+	s.mockStore.On("Get", mock.Anything, int64(1), "key-1").Return(func(_ context.Context, _ int64, _ string) *domain.IdempotentRequest {
+		return s.reservation(fingerprint)
+	}, nil)
+	rec := s.serve("key-1", `{}`, http.StatusOK)
+	s.Equal(http.StatusConflict, rec.Code)
+	s.Equal("\"e4096 a request with this idempotency key is still in progress\"\n", rec.Body.String())
+	s.Equal(0, s.calls)
+}
+
+func (s *IdempotencyTestSuite) TestIdempotency_ReleasedBeforeReplay() {
+	s.mockStore.On("Reserve", mock.Anything, mock.Anything, mockIdempotencyNow).Return(false, nil)
+	s.mockStore.On("Get", mock.Anything, int64(1), "key-1").Return(nil, errors.New("record not found"))
+	rec := s.serve("key-1", `{}`, http.StatusOK)
+	s.Equal(apperrors.GetStatusCode(apperrors.ErrIdempotencyKeyInProgress), rec.Code)
+	s.Equal(0, s.calls)
+}
+
+func (s *IdempotencyTestSuite) TestFingerprintRequest_DiffersByPathAndBody() {
+	fingerprint := func(path, body string) string {
+		req := httptest.NewRequest(http.MethodPatch, path, strings.NewReader(body))
+		c := s.echo.NewContext(req, httptest.NewRecorder())
+		actual, err := fingerprintRequest(c)
+		s.Nil(err)
+		return actual
+	}
+	s.Equal(fingerprint("/api/v1/bikes/1/rent", ""), fingerprint("/api/v1/bikes/1/rent", ""))
+	s.NotEqual(fingerprint("/api/v1/bikes/1/rent", ""), fingerprint("/api/v1/bikes/2/rent", ""))
+	s.NotEqual(fingerprint("/api/v1/bikes/1/return", `{"lat":"1"}`), fingerprint("/api/v1/bikes/1/return", `{"lat":"2"}`))
+}
@@ -1,13 +1,19 @@
 package middleware
 
 import (
+	"context"
 	"io"
+	"shared-bike/domain"
+	"time"
 
+	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
 )
 
+//go:generate mockery --name IdempotencyStore --output mocks --case underscore
+
 type CustomLogger interface {
-	SetRequestID(requestID string)
+	With(requestID, traceID string) echo.Logger
 	Output() io.Writer
 	SetOutput(w io.Writer)
 	Prefix() string
@@ -37,3 +43,11 @@ type CustomLogger interface {
 	Panicj(j log.JSON)
 	Panicf(format string, args ...interface{})
 }
+
+// IdempotencyStore keeps the first response per user and Idempotency-Key, see idempotency.NewRepository.
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, record *domain.IdempotentRequest, now time.Time) (bool, error)
+	Get(ctx context.Context, userID int64, key string) (*domain.IdempotentRequest, error)
+	Complete(ctx context.Context, record *domain.IdempotentRequest) error
+	Release(ctx context.Context, record *domain.IdempotentRequest) error
+}
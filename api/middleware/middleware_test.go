@@ -1,13 +1,21 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"regexp"
 	"shared-bike/apperrors"
 	"shared-bike/customlogger"
+	"shared-bike/domain"
+	"strings"
 	"testing"
 
+	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -53,6 +61,25 @@ func (s *BikeHandlerTestSuite) TestAddLoggerContext_Success() {
 	s.Equal(respBody, rec.Body.String())
 }
 
+func (s *BikeHandlerTestSuite) TestAddLoggerContext_LoggerPerRequest() {
+	output := &bytes.Buffer{}
+	s.echo.Logger.SetOutput(output)
+	s.echo.Logger.SetLevel(log.INFO)
+	s.echo.GET("/log", func(c echo.Context) error {
+		c.Logger().Info("[Test] handled")
+		return c.NoContent(http.StatusNoContent)
+	})
+	for _, id := range []string{"first", "second"} {
+		req := httptest.NewRequest(http.MethodGet, "/log", nil)
+		req.Header.Set(echo.HeaderXRequestID, id)
+		s.echo.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	s.Require().Len(lines, 2)
+	s.Contains(lines[0], `"id":"first"`)
+	s.Contains(lines[1], `"id":"second"`)
+}
+
 func (s *BikeHandlerTestSuite) TestWhiteListAPI_False() {
 	req := httptest.NewRequest(http.MethodGet, "/api/v2", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -64,6 +91,35 @@ func (s *BikeHandlerTestSuite) TestWhiteListAPI_False() {
 	s.False(result)
 }
 
+// TestWhiteListAPI_MatchesSwagger keeps the Swagger page honest: a route asks for a bearer token there exactly when
+// the JWT middleware does not skip it.
+func (s *BikeHandlerTestSuite) TestWhiteListAPI_MatchesSwagger() {
+	content, err := os.ReadFile("../docs/swagger.json")
+	s.Require().Nil(err)
+	spec := struct {
+		BasePath string `json:"basePath"`
+		Paths    map[string]map[string]struct {
+			Security []map[string][]string `json:"security"`
+		} `json:"paths"`
+	}{}
+	s.Require().Nil(json.Unmarshal(content, &spec))
+	s.Require().NotEmpty(spec.Paths)
+	pathParam := regexp.MustCompile(`\{[a-zA-Z]+\}`)
+	for path, operations := range spec.Paths {
+		requestPath := spec.BasePath + pathParam.ReplaceAllStringFunc(path, func(param string) string {
+			if param == "{variant}" {
+				return "thumbnail"
+			}
+			return "1"
+		})
+		for method, operation := range operations {
+			req := httptest.NewRequest(http.MethodGet, requestPath, nil)
+			c := s.echo.NewContext(req, httptest.NewRecorder())
+			s.Equal(len(operation.Security) == 0, WhiteListAPI(c), method+" "+path)
+		}
+	}
+}
+
 func (s *BikeHandlerTestSuite) TestWhiteListAPI_TrueLogin() {
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/login", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -97,6 +153,16 @@ func (s *BikeHandlerTestSuite) TestWhiteListAPI_TrueHealth() {
 	s.True(result)
 }
 
+func (s *BikeHandlerTestSuite) TestWhiteListAPI_TrueProbes() {
+	for _, target := range []string{"/health/live", "/health/ready"} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+		c := s.echo.NewContext(req, rec)
+		c.SetPath(target)
+		s.True(WhiteListAPI(c), target)
+	}
+}
+
 func (s *BikeHandlerTestSuite) TestWhiteListAPI_TrueSwagger() {
 	req := httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -108,6 +174,42 @@ func (s *BikeHandlerTestSuite) TestWhiteListAPI_TrueSwagger() {
 	s.True(result)
 }
 
+func (s *BikeHandlerTestSuite) TestWhiteListAPI_TruePhotoDownload() {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/photos/4/thumbnail?expires=1657133504&signature=abc", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/api/v1/photos/4/thumbnail")
+	result := WhiteListAPI(c)
+	s.True(result)
+}
+
+func (s *BikeHandlerTestSuite) TestWhiteListAPI_TrueDeviceTelemetry() {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/devices/telemetry", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/api/v1/devices/telemetry")
+	result := WhiteListAPI(c)
+	s.True(result)
+}
+
+func (s *BikeHandlerTestSuite) TestWhiteListAPI_TrueMetrics() {
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/metrics")
+	result := WhiteListAPI(c)
+	s.True(result)
+}
+
+func (s *BikeHandlerTestSuite) TestWhiteListAPI_FalsePhotoList() {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/bikes/1/photos", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/api/v1/bikes/1/photos")
+	result := WhiteListAPI(c)
+	s.False(result)
+}
+
 func (s *BikeHandlerTestSuite) TestCustomJWTError_Success() {
 	req := httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -118,3 +220,109 @@ func (s *BikeHandlerTestSuite) TestCustomJWTError_Success() {
 	s.echo.ServeHTTP(rec, req)
 	s.Equal(http.StatusUnauthorized, rec.Code)
 }
+
+func (s *BikeHandlerTestSuite) TestRequirePermission_Success() {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/roles", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(UserKey, &jwt.Token{
+		Valid:  true,
+		Claims: &domain.Claims{ID: 1, Permissions: []domain.Permission{domain.PermissionRolesManage}},
+	})
+	handler := RequirePermission(domain.PermissionRolesManage)(func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	s.NoError(handler(c))
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *BikeHandlerTestSuite) TestRequirePermission_Forbidden() {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/roles", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(UserKey, &jwt.Token{
+		Valid:  true,
+		Claims: &domain.Claims{ID: 1, Permissions: []domain.Permission{domain.PermissionBikesRead}},
+	})
+	handler := RequirePermission(domain.PermissionRolesManage)(func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	s.NoError(handler(c))
+	s.Equal(http.StatusForbidden, rec.Code)
+	s.Equal("\"e4030 you do not have permission to perform this action\"\n", rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestRequirePermission_Unauthorized() {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/roles", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	handler := RequirePermission(domain.PermissionRolesManage)(func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	s.NoError(handler(c))
+	s.Equal(http.StatusUnauthorized, rec.Code)
+}
+
+func (s *BikeHandlerTestSuite) TestGetClaims_Success() {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/bikes", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	claims := &domain.Claims{ID: 1}
+	c.Set(UserKey, &jwt.Token{Valid: true, Claims: claims})
+	actual, err := GetClaims(c)
+	s.Nil(err)
+	s.Equal(claims, actual)
+}
+
+func (s *BikeHandlerTestSuite) TestGetClaims_Unauthorized() {
+	testCases := []struct {
+		name  string
+		value interface{}
+	}{
+		{"no token", nil},
+		{"not a token", "token"},
+		{"nil token", (*jwt.Token)(nil)},
+		{"other claims", &jwt.Token{Valid: true, Claims: jwt.MapClaims{"id": 1}}},
+		{"nil claims", &jwt.Token{Valid: true, Claims: (*domain.Claims)(nil)}},
+	}
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/bikes", nil)
+		rec := httptest.NewRecorder()
+		c := s.echo.NewContext(req, rec)
+		if tc.value != nil {
+			c.Set(UserKey, tc.value)
+		}
+		actual, err := GetClaims(c)
+		s.Nil(actual, tc.name)
+		s.Equal(apperrors.ErrUnauthorizeError, err, tc.name)
+	}
+}
+
+func (s *BikeHandlerTestSuite) TestAddClaimsContext_Success() {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/bikes", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	claims := &domain.Claims{ID: 1}
+	c.Set(UserKey, &jwt.Token{Valid: true, Claims: claims})
+	handler := AddClaimsContext(func(c echo.Context) error {
+		actual, ok := domain.ClaimsFromContext(c.Request().Context())
+		s.True(ok)
+		s.Equal(claims, actual)
+		return nil
+	})
+	s.NoError(handler(c))
+}
+
+func (s *BikeHandlerTestSuite) TestAddRequestMetadata_Success() {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/bikes", nil)
+	req.Header.Set(echo.HeaderXRealIP, "10.0.0.1")
+	rec := httptest.NewRecorder()
+	rec.Header().Set(echo.HeaderXRequestID, "request-id")
+	c := s.echo.NewContext(req, rec)
+	handler := AddRequestMetadata(func(c echo.Context) error {
+		actual := domain.RequestMetadataFromContext(c.Request().Context())
+		s.Equal(domain.RequestMetadata{RequestID: "request-id", IP: "10.0.0.1"}, actual)
+		return nil
+	})
+	s.NoError(handler(c))
+}
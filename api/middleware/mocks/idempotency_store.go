@@ -0,0 +1,104 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IdempotencyStore is an autogenerated mock type for the IdempotencyStore type
+type IdempotencyStore struct {
+	mock.Mock
+}
+
+// Complete provides a mock function with given fields: ctx, record
+func (_m *IdempotencyStore) Complete(ctx context.Context, record *domain.IdempotentRequest) error {
+	ret := _m.Called(ctx, record)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotentRequest) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, userID, key
+func (_m *IdempotencyStore) Get(ctx context.Context, userID int64, key string) (*domain.IdempotentRequest, error) {
+	ret := _m.Called(ctx, userID, key)
+
+	var r0 *domain.IdempotentRequest
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *domain.IdempotentRequest); ok {
+		r0 = rf(ctx, userID, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IdempotentRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Release provides a mock function with given fields: ctx, record
+func (_m *IdempotencyStore) Release(ctx context.Context, record *domain.IdempotentRequest) error {
+	ret := _m.Called(ctx, record)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotentRequest) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reserve provides a mock function with given fields: ctx, record, now
+func (_m *IdempotencyStore) Reserve(ctx context.Context, record *domain.IdempotentRequest, now time.Time) (bool, error) {
+	ret := _m.Called(ctx, record, now)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotentRequest, time.Time) bool); ok {
+		r0 = rf(ctx, record, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.IdempotentRequest, time.Time) error); ok {
+		r1 = rf(ctx, record, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIdempotencyStore interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIdempotencyStore creates a new instance of IdempotencyStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIdempotencyStore(t mockConstructorTestingTNewIdempotencyStore) *IdempotencyStore {
+	mock := &IdempotencyStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
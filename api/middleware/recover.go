@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+
+	"shared-bike/apperrors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const recoverStackSize = 4 << 10
+
+// Recover turns a panic of the next handlers into a 500 carrying the internal server error code, the panic and the
+// stack are logged with the request ID. It goes after AddLoggerContext so the log line reaches the context logger.
+func Recover(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			// the handler aborts the response on purpose, net/http handles it
+			if r == http.ErrAbortHandler {
+				panic(r)
+			}
+			stack := make([]byte, recoverStackSize)
+			stack = stack[:runtime.Stack(stack, false)]
+			requestID := c.Request().Header.Get(echo.HeaderXRequestID)
+			if requestID == "" {
+				requestID = c.Response().Header().Get(echo.HeaderXRequestID)
+			}
+			c.Logger().Error(fmt.Sprintf("[Recover] request %s panicked: %v", requestID, r), string(stack))
+			if c.Response().Committed {
+				err = nil
+				return
+			}
+			err = c.JSON(apperrors.GetStatusCode(apperrors.ErrInternalServerError), apperrors.ErrInternalServerError.Error())
+		}()
+		return next(c)
+	}
+}
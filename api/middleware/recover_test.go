@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecoverTestSuite struct {
+	suite.Suite
+	echo   *echo.Echo
+	output *bytes.Buffer
+}
+
+func (s *RecoverTestSuite) SetupTest() {
+	s.output = &bytes.Buffer{}
+	s.echo = echo.New()
+	s.echo.Logger.SetOutput(s.output)
+	s.echo.Logger.SetLevel(log.INFO)
+	s.echo.Use(Recover)
+	s.echo.GET("/ok", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	s.echo.GET("/panic", func(c echo.Context) error {
+		var claims map[string]int64
+		claims["id"] = 1
+		return nil
+	})
+	s.echo.GET("/panic-after-write", func(c echo.Context) error {
+		c.String(http.StatusAccepted, "partial")
+		panic(errors.New("boom"))
+	})
+	s.echo.GET("/abort", func(c echo.Context) error {
+		panic(http.ErrAbortHandler)
+	})
+}
+
+func TestRecoverTestSuite(t *testing.T) {
+	suite.Run(t, new(RecoverTestSuite))
+}
+
+func (s *RecoverTestSuite) serve(target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set(echo.HeaderXRequestID, "request-id")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *RecoverTestSuite) TestRecover_NoPanic() {
+	rec := s.serve("/ok")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("ok", rec.Body.String())
+	s.Empty(s.output.String())
+}
+
+func (s *RecoverTestSuite) TestRecover_Panic() {
+	rec := s.serve("/panic")
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Equal("\"e5000 internal server error\"\n", rec.Body.String())
+	s.Contains(s.output.String(), "[Recover] request request-id panicked: assignment to entry in nil map")
+	s.Contains(s.output.String(), "recover_test.go")
+}
+
+func (s *RecoverTestSuite) TestRecover_PanicAfterWrite() {
+	rec := s.serve("/panic-after-write")
+	s.Equal(http.StatusAccepted, rec.Code)
+	s.Equal("partial", rec.Body.String())
+	s.Contains(s.output.String(), "[Recover] request request-id panicked: boom")
+}
+
+func (s *RecoverTestSuite) TestRecover_Abort() {
+	s.PanicsWithValue(http.ErrAbortHandler, func() {
+		s.serve("/abort")
+	})
+}
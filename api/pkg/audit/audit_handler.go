@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"net/http"
+	"time"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"github.com/labstack/echo/v4"
+)
+
+type handlerImpl struct {
+	useCase IUseCase
+}
+
+func NewHandler(useCase IUseCase) *handlerImpl {
+	return &handlerImpl{
+		useCase: useCase,
+	}
+}
+
+// GetList godoc
+// @Summary      Get audit events
+// @Description  API for querying the audit log of state-changing actions, newest first
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        actorId     query     int     false  "user who performed the action"
+// @Param        action      query     string  false  "action, e.g. bike.rent"
+// @Param        targetType  query     string  false  "target type, e.g. bike"
+// @Param        targetId    query     int     false  "target id"
+// @Param        requestId   query     string  false  "request id"
+// @Param        from        query     string  false  "inclusive lower bound in RFC3339"
+// @Param        to          query     string  false  "exclusive upper bound in RFC3339"
+// @Param        limit       query     int     false  "page size, default 50, max 200"
+// @Param        offset      query     int     false  "page offset"
+// @Success      200  {array}   []domain.AuditEventDTO "Success"
+// @Failure      400  {string}  string 	"invalid audit query"
+// @Failure      403  {string}  string 	"you do not have permission to perform this action"
+// @Failure      500  {string}  string 	"internal server error"
+// @Security     BearerAuth
+// @Router       /admin/audit [get]
+func (h *handlerImpl) GetList(c echo.Context) error {
+	span := tracing.StartHandler(c, "AuditHandler.GetList")
+	defer span.End()
+	c.Logger().Info("[AuditHandler.GetList] starting")
+	ctx := c.Request().Context()
+	query := domain.AuditQuery{}
+	if err := c.Bind(&query); err != nil {
+		c.Logger().Error("[AuditHandler.GetList] invalid query", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidAuditQuery), apperrors.ErrInvalidAuditQuery.Error())
+	}
+	filter, err := h.toFilter(query)
+	if err != nil {
+		c.Logger().Error("[AuditHandler.GetList] invalid query", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidAuditQuery), apperrors.ErrInvalidAuditQuery.Error())
+	}
+	events, err := h.useCase.GetList(ctx, filter)
+	if err != nil {
+		c.Logger().Error("[AuditHandler.GetList] cannot get audit events", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info("[AuditHandler.GetList] success")
+	return c.JSON(http.StatusOK, events)
+}
+
+func (h *handlerImpl) toFilter(query domain.AuditQuery) (domain.AuditFilter, error) {
+	if query.Limit < 0 || query.Offset < 0 {
+		return domain.AuditFilter{}, apperrors.ErrInvalidAuditQuery
+	}
+	filter := domain.AuditFilter{
+		ActorID:    query.ActorID,
+		Action:     domain.AuditAction(query.Action),
+		TargetType: query.TargetType,
+		TargetID:   query.TargetID,
+		RequestID:  query.RequestID,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	}
+	if query.From != "" {
+		from, err := time.Parse(time.RFC3339, query.From)
+		if err != nil {
+			return domain.AuditFilter{}, err
+		}
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, err := time.Parse(time.RFC3339, query.To)
+		if err != nil {
+			return domain.AuditFilter{}, err
+		}
+		filter.To = &to
+	}
+	return filter, nil
+}
@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/audit/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditHandlerTestSuite struct {
+	suite.Suite
+	mockUseCase *mocks.IUseCase
+	echo        *echo.Echo
+	handlerImpl *handlerImpl
+}
+
+func (s *AuditHandlerTestSuite) SetupTest() {
+	mockUseCase := &mocks.IUseCase{}
+	s.mockUseCase = mockUseCase
+	s.echo = echo.New()
+	s.handlerImpl = NewHandler(mockUseCase)
+}
+
+func TestAuditHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditHandlerTestSuite))
+}
+
+func (s *AuditHandlerTestSuite) TestGetList_Success() {
+	from := time.Date(2022, 7, 6, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 7, 7, 0, 0, 0, 0, time.UTC)
+	mockFilter := domain.AuditFilter{
+		ActorID:    1,
+		Action:     domain.AuditActionBikeRent,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   2,
+		From:       &from,
+		To:         &to,
+		Limit:      10,
+	}
+	mockResult := []domain.AuditEventDTO{
+		{
+			ID:         1,
+			ActorID:    1,
+			Action:     domain.AuditActionBikeRent,
+			TargetType: domain.AuditTargetBike,
+			TargetID:   2,
+			After:      []byte(`{"id":2}`),
+			RequestID:  "request-id",
+			IP:         "127.0.0.1",
+			CreatedAt:  from,
+		},
+	}
+	s.mockUseCase.On("GetList", context.Background(), mockFilter).Return(mockResult, nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit?actorId=1&action=bike.rent&targetType=bike&targetId=2&from=2022-07-06T00:00:00Z&to=2022-07-07T00:00:00Z&limit=10", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	respBody := `[{"id":1,"actorId":1,"action":"bike.rent","targetType":"bike","targetId":2,"before":null,"after":{"id":2},"requestId":"request-id","ip":"127.0.0.1","createdAt":"2022-07-06T00:00:00Z"}]
+`
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(respBody, rec.Body.String())
+}
+
+func (s *AuditHandlerTestSuite) TestGetList_FailedBind() {
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit?actorId=abc", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4009 invalid audit query\"\n", rec.Body.String())
+}
+
+func (s *AuditHandlerTestSuite) TestGetList_FailedTime() {
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit?from=yesterday", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *AuditHandlerTestSuite) TestGetList_FailedToTime() {
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit?to=tomorrow", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *AuditHandlerTestSuite) TestGetList_FailedNegativeLimit() {
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit?limit=-1", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *AuditHandlerTestSuite) TestGetList_FailedUseCase() {
+	s.mockUseCase.On("GetList", context.Background(), domain.AuditFilter{}).Return(nil, apperrors.ErrForbidden)
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"context"
+
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repositoryImpl {
+	return &repositoryImpl{
+		db: db,
+	}
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, body *domain.AuditEvent) error {
+	ctx, span := tracing.Start(ctx, "AuditRepository.Create")
+	defer span.End()
+	err := r.db.WithContext(ctx).Create(body).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repositoryImpl) GetList(ctx context.Context, filter domain.AuditFilter) (*[]domain.AuditEvent, error) {
+	ctx, span := tracing.Start(ctx, "AuditRepository.GetList")
+	defer span.End()
+	events := []domain.AuditEvent{}
+	query := r.db.WithContext(ctx).Model(&domain.AuditEvent{})
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != 0 {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	err := query.Order("id DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return &events, nil
+}
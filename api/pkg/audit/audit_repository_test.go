@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type AuditRepositoryTestSuite struct {
+	suite.Suite
+	mockDB         sqlmock.Sqlmock
+	repositoryImpl *repositoryImpl
+}
+
+func (s *AuditRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.Error(err, "Failed to open mock sql db, got error")
+	}
+
+	if db == nil {
+		s.Error(nil, "mock db is null")
+	}
+
+	if mock == nil {
+		s.Error(nil, "sqlmock is null")
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		s.Error(err, "gormDB init err")
+	}
+	s.mockDB = mock
+	repositoryImpl := NewRepository(gormDB)
+	s.repositoryImpl = repositoryImpl
+}
+
+func TestAuditRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditRepositoryTestSuite))
+}
+
+func (s *AuditRepositoryTestSuite) TestCreate_Success() {
+	mockEvent := domain.AuditEvent{
+		ActorID:    sql.NullInt64{Valid: true, Int64: 1},
+		Action:     domain.AuditActionBikeRent,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   1,
+		After:      sql.NullString{Valid: true, String: `{"id":1}`},
+		RequestID:  "request-id",
+		IP:         "127.0.0.1",
+	}
+	query := regexp.QuoteMeta("INSERT INTO `audit_event` (`actor_id`,`action`,`target_type`,`target_id`,`before`,`after`,`request_id`,`ip`,`created_at`) VALUES (?,?,?,?,?,?,?,?,?)")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mockDB.ExpectCommit()
+	err := s.repositoryImpl.Create(context.TODO(), &mockEvent)
+	s.Nil(err)
+	s.Equal(int64(1), mockEvent.ID)
+}
+
+func (s *AuditRepositoryTestSuite) TestCreate_Failed() {
+	query := regexp.QuoteMeta("INSERT INTO `audit_event`")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	err := s.repositoryImpl.Create(context.TODO(), &domain.AuditEvent{Action: domain.AuditActionBikeRent})
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *AuditRepositoryTestSuite) TestGetList_SuccessWithoutFilter() {
+	mockTime := time.Time{}
+	rows := sqlmock.NewRows([]string{"id", "actor_id", "action", "target_type", "target_id", "before", "after", "request_id", "ip", "created_at"}).
+		AddRow(2, 1, domain.AuditActionBikeReturn, domain.AuditTargetBike, 1, nil, `{"id":1}`, "request-id", "127.0.0.1", mockTime)
+	query := regexp.QuoteMeta("SELECT * FROM `audit_event` ORDER BY id DESC LIMIT 50")
+	s.mockDB.ExpectQuery(query).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetList(context.TODO(), domain.AuditFilter{Limit: 50})
+	s.Nil(err)
+	s.Equal([]domain.AuditEvent{
+		{
+			ID:         2,
+			ActorID:    sql.NullInt64{Valid: true, Int64: 1},
+			Action:     domain.AuditActionBikeReturn,
+			TargetType: domain.AuditTargetBike,
+			TargetID:   1,
+			After:      sql.NullString{Valid: true, String: `{"id":1}`},
+			RequestID:  "request-id",
+			IP:         "127.0.0.1",
+			CreatedAt:  mockTime,
+		},
+	}, *actual)
+}
+
+func (s *AuditRepositoryTestSuite) TestGetList_SuccessWithFilter() {
+	from := time.Date(2022, 7, 6, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	rows := sqlmock.NewRows([]string{"id"})
+	query := regexp.QuoteMeta("SELECT * FROM `audit_event` WHERE actor_id = ? AND action = ? AND target_type = ? AND target_id = ? AND request_id = ? AND created_at >= ? AND created_at < ? ORDER BY id DESC LIMIT 10 OFFSET 20")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1), domain.AuditActionBikeRent, domain.AuditTargetBike, int64(3), "request-id", from, to).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetList(context.TODO(), domain.AuditFilter{
+		ActorID:    1,
+		Action:     domain.AuditActionBikeRent,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   3,
+		RequestID:  "request-id",
+		From:       &from,
+		To:         &to,
+		Limit:      10,
+		Offset:     20,
+	})
+	s.Nil(err)
+	s.Equal([]domain.AuditEvent{}, *actual)
+}
+
+func (s *AuditRepositoryTestSuite) TestGetList_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `audit_event` ORDER BY id DESC LIMIT 50")
+	s.mockDB.ExpectQuery(query).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetList(context.TODO(), domain.AuditFilter{Limit: 50})
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
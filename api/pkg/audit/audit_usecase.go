@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+type useCaseImpl struct {
+	repository IRepository
+	logger     ILogger
+}
+
+func NewUseCase(logger ILogger, repository IRepository) *useCaseImpl {
+	return &useCaseImpl{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// Record stores an audit event, taking the request ID and IP from ctx and falling back to the caller's claims when no actor is given.
+func (u *useCaseImpl) Record(ctx context.Context, body domain.AuditRecord) error {
+	ctx, span := tracing.Start(ctx, "AuditUseCase.Record")
+	defer span.End()
+	metadata := domain.RequestMetadataFromContext(ctx)
+	event := &domain.AuditEvent{
+		Action:     body.Action,
+		TargetType: body.TargetType,
+		TargetID:   body.TargetID,
+		RequestID:  metadata.RequestID,
+		IP:         metadata.IP,
+	}
+	actorID := body.ActorID
+	if claims, ok := domain.ClaimsFromContext(ctx); ok && actorID == 0 {
+		actorID = claims.ID
+	}
+	if actorID != 0 {
+		event.ActorID = sql.NullInt64{Valid: true, Int64: actorID}
+	}
+	var err error
+	if event.Before, err = u.marshalSnapshot(body.Before); err != nil {
+		u.logger.Error(fmt.Sprintf("[AuditUseCase.Record] marshal before snapshot of %s %d failed", body.TargetType, body.TargetID), err)
+		return apperrors.ErrInternalServerError
+	}
+	if event.After, err = u.marshalSnapshot(body.After); err != nil {
+		u.logger.Error(fmt.Sprintf("[AuditUseCase.Record] marshal after snapshot of %s %d failed", body.TargetType, body.TargetID), err)
+		return apperrors.ErrInternalServerError
+	}
+	if err := u.repository.Create(ctx, event); err != nil {
+		u.logger.Error(fmt.Sprintf("[AuditUseCase.Record] record %s on %s %d failed", body.Action, body.TargetType, body.TargetID), err)
+		return apperrors.ErrInternalServerError
+	}
+	u.logger.Info(fmt.Sprintf("[AuditUseCase.Record] record %s on %s %d success", body.Action, body.TargetType, body.TargetID))
+	return nil
+}
+
+func (u *useCaseImpl) GetList(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEventDTO, error) {
+	ctx, span := tracing.Start(ctx, "AuditUseCase.GetList")
+	defer span.End()
+	u.logger.Info("[AuditUseCase.GetList] fetching audit events")
+	if err := domain.Authorize(ctx, domain.PermissionAuditRead); err != nil {
+		u.logger.Error("[AuditUseCase.GetList] permission denied", err)
+		return []domain.AuditEventDTO{}, err
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLimit
+	}
+	if filter.Limit > maxLimit {
+		filter.Limit = maxLimit
+	}
+	events, err := u.repository.GetList(ctx, filter)
+	if err != nil {
+		u.logger.Error("[AuditUseCase.GetList] fetch audit events failed", err)
+		return []domain.AuditEventDTO{}, apperrors.ErrInternalServerError
+	}
+	results := []domain.AuditEventDTO{}
+	for _, event := range *events {
+		results = append(results, event.ToDTO())
+	}
+	u.logger.Info("[AuditUseCase.GetList] fetch audit events success")
+	return results, nil
+}
+
+func (u *useCaseImpl) marshalSnapshot(snapshot interface{}) (sql.NullString, error) {
+	if snapshot == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{Valid: true, String: string(data)}, nil
+}
@@ -0,0 +1,156 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/audit/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type AuditUseCaseTestSuite struct {
+	suite.Suite
+	mockRepository *mocks.IRepository
+	mockLogger     *mocks.ILogger
+	useCaseImpl    *useCaseImpl
+	requestContext context.Context
+}
+
+func (s *AuditUseCaseTestSuite) SetupTest() {
+	mockRepository := &mocks.IRepository{}
+	s.mockRepository = mockRepository
+	mockLogger := &mocks.ILogger{}
+	s.mockLogger = mockLogger
+	s.mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+	s.mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+	s.useCaseImpl = NewUseCase(mockLogger, mockRepository)
+	ctx := domain.NewContextWithRequestMetadata(context.TODO(), domain.RequestMetadata{RequestID: "request-id", IP: "127.0.0.1"})
+	s.requestContext = domain.NewContextWithClaims(ctx, &domain.Claims{ID: 7, Permissions: []domain.Permission{domain.PermissionAuditRead}})
+}
+
+func TestAuditUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditUseCaseTestSuite))
+}
+
+func (s *AuditUseCaseTestSuite) TestRecord_Success() {
+	mockEvent := &domain.AuditEvent{
+		ActorID:    sql.NullInt64{Valid: true, Int64: 1},
+		Action:     domain.AuditActionBikeRent,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   2,
+		Before:     sql.NullString{Valid: true, String: `{"id":2,"name":"","lat":"","long":"","status":"available","userId":0,"nameOfRenter":"","version":0}`},
+		After:      sql.NullString{Valid: true, String: `{"id":2,"name":"","lat":"","long":"","status":"rented","userId":1,"nameOfRenter":"","version":0}`},
+		RequestID:  "request-id",
+		IP:         "127.0.0.1",
+	}
+	s.mockRepository.On("Create", s.requestContext, mockEvent).Return(nil)
+	err := s.useCaseImpl.Record(s.requestContext, domain.AuditRecord{
+		ActorID:    1,
+		Action:     domain.AuditActionBikeRent,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   2,
+		Before:     domain.BikeDTO{ID: 2, Status: domain.BikeStatusAvailable},
+		After:      domain.BikeDTO{ID: 2, Status: domain.BikeStatusRented, UserID: 1},
+	})
+	s.Nil(err)
+}
+
+func (s *AuditUseCaseTestSuite) TestRecord_SuccessActorFromClaims() {
+	mockEvent := &domain.AuditEvent{
+		ActorID:    sql.NullInt64{Valid: true, Int64: 7},
+		Action:     domain.AuditActionRoleAssign,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   2,
+		After:      sql.NullString{Valid: true, String: `[]`},
+		RequestID:  "request-id",
+		IP:         "127.0.0.1",
+	}
+	s.mockRepository.On("Create", s.requestContext, mockEvent).Return(nil)
+	err := s.useCaseImpl.Record(s.requestContext, domain.AuditRecord{
+		Action:     domain.AuditActionRoleAssign,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   2,
+		After:      []domain.RoleDTO{},
+	})
+	s.Nil(err)
+}
+
+func (s *AuditUseCaseTestSuite) TestRecord_SuccessWithoutActor() {
+	mockEvent := &domain.AuditEvent{
+		Action:     domain.AuditActionUserRegister,
+		TargetType: domain.AuditTargetUser,
+	}
+	s.mockRepository.On("Create", context.TODO(), mockEvent).Return(nil)
+	err := s.useCaseImpl.Record(context.TODO(), domain.AuditRecord{
+		Action:     domain.AuditActionUserRegister,
+		TargetType: domain.AuditTargetUser,
+	})
+	s.Nil(err)
+}
+
+func (s *AuditUseCaseTestSuite) TestRecord_FailedMarshal() {
+	err := s.useCaseImpl.Record(s.requestContext, domain.AuditRecord{
+		Action: domain.AuditActionBikeRent,
+		Before: make(chan int),
+	})
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *AuditUseCaseTestSuite) TestRecord_FailedMarshalAfter() {
+	err := s.useCaseImpl.Record(s.requestContext, domain.AuditRecord{
+		Action: domain.AuditActionBikeRent,
+		After:  make(chan int),
+	})
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *AuditUseCaseTestSuite) TestRecord_FailedCreate() {
+	s.mockRepository.On("Create", s.requestContext, mock.Anything).Return(gorm.ErrInvalidDB)
+	err := s.useCaseImpl.Record(s.requestContext, domain.AuditRecord{Action: domain.AuditActionBikeReturn})
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *AuditUseCaseTestSuite) TestGetList_Success() {
+	mockEvents := []domain.AuditEvent{
+		{
+			ID:         1,
+			ActorID:    sql.NullInt64{Valid: true, Int64: 1},
+			Action:     domain.AuditActionBikeRent,
+			TargetType: domain.AuditTargetBike,
+			TargetID:   2,
+			After:      sql.NullString{Valid: true, String: `{"id":2}`},
+		},
+	}
+	s.mockRepository.On("GetList", s.requestContext, domain.AuditFilter{Limit: 50}).Return(&mockEvents, nil)
+	actual, err := s.useCaseImpl.GetList(s.requestContext, domain.AuditFilter{})
+	s.Nil(err)
+	s.Equal([]domain.AuditEventDTO{mockEvents[0].ToDTO()}, actual)
+}
+
+func (s *AuditUseCaseTestSuite) TestGetList_SuccessCapLimit() {
+	mockEvents := []domain.AuditEvent{}
+	s.mockRepository.On("GetList", s.requestContext, domain.AuditFilter{Limit: 200, Offset: 10}).Return(&mockEvents, nil)
+	actual, err := s.useCaseImpl.GetList(s.requestContext, domain.AuditFilter{Limit: 1000, Offset: 10})
+	s.Nil(err)
+	s.Equal([]domain.AuditEventDTO{}, actual)
+}
+
+func (s *AuditUseCaseTestSuite) TestGetList_FailedForbidden() {
+	ctx := domain.NewContextWithClaims(context.TODO(), &domain.Claims{ID: 1})
+	actual, err := s.useCaseImpl.GetList(ctx, domain.AuditFilter{})
+	s.Equal(apperrors.ErrForbidden, err)
+	s.Equal([]domain.AuditEventDTO{}, actual)
+}
+
+func (s *AuditUseCaseTestSuite) TestGetList_FailedRepository() {
+	s.mockRepository.On("GetList", s.requestContext, domain.AuditFilter{Limit: 50}).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.GetList(s.requestContext, domain.AuditFilter{})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal([]domain.AuditEventDTO{}, actual)
+}
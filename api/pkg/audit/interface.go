@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+
+	"shared-bike/domain"
+)
+
+type IRepository interface {
+	Create(ctx context.Context, body *domain.AuditEvent) error
+	GetList(ctx context.Context, filter domain.AuditFilter) (*[]domain.AuditEvent, error)
+}
+
+type ILogger interface {
+	Info(i ...interface{})
+	Warn(i ...interface{})
+	Error(i ...interface{})
+}
+
+type IUseCase interface {
+	Record(ctx context.Context, body domain.AuditRecord) error
+	GetList(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEventDTO, error)
+}
+
+//go:generate mockery --name IRepository --output mocks --case underscore
+//go:generate mockery --name ILogger --output mocks --case underscore
+//go:generate mockery --name IUseCase --output mocks --case underscore
@@ -0,0 +1,67 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IUseCase is an autogenerated mock type for the IUseCase type
+type IUseCase struct {
+	mock.Mock
+}
+
+// GetList provides a mock function with given fields: ctx, filter
+func (_m *IUseCase) GetList(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEventDTO, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []domain.AuditEventDTO
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditFilter) []domain.AuditEventDTO); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditEventDTO)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AuditFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Record provides a mock function with given fields: ctx, body
+func (_m *IUseCase) Record(ctx context.Context, body domain.AuditRecord) error {
+	ret := _m.Called(ctx, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditRecord) error); ok {
+		r0 = rf(ctx, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIUseCase creates a new instance of IUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIUseCase(t mockConstructorTestingTNewIUseCase) *IUseCase {
+	mock := &IUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -8,8 +8,8 @@ import (
 	"shared-bike/apperrors"
 	"shared-bike/domain"
 	"shared-bike/middleware"
+	"shared-bike/tracing"
 
-	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 )
 
@@ -25,25 +25,115 @@ func NewHandler(useCase IUseCase) *handlerImpl {
 
 // GetAllBike godoc
 // @Summary      Get all bikes
-// @Description  API for getting all bikes
+// @Description  API for getting all bikes, optionally only of one type or e-bikes charged at least minBattery percent. userId and nameOfRenter of a rented bike are only filled for its renter and for staff with bikes:read.
 // @Tags         bikes
 // @Accept       json
 // @Produce      json
+// @Param        type        query     string  false  "bike type"  Enums(classic, ebike, cargo)
+// @Param        minBattery  query     int     false  "minimum battery percentage"
+// @Param        If-None-Match      header    string  false  "ETag of the list the client has"
+// @Param        If-Modified-Since  header    string  false  "Last-Modified of the list the client has"
 // @Success      200  {array}   []domain.BikeDTO "Success"
+// @Header       200  {string}  ETag           "weak tag over the ids and versions of the listed bikes"
+// @Header       200  {string}  Last-Modified  "latest change among the listed bikes"
+// @Success      304  "Not modified"
+// @Failure      400  {string}  string 	"invalid bike query"
 // @Failure      500  {string}  string 	"internal server error"
+// @Security     BearerAuth
 // @Router       /bikes [get]
 func (h *handlerImpl) GetAllBike(c echo.Context) error {
+	span := tracing.StartHandler(c, "BikeHandler.GetAllBike")
+	defer span.End()
 	c.Logger().Info("[BikeHandler.GetAllBike] starting")
 	ctx := c.Request().Context()
-	bikes, err := h.useCase.GetAllBike(ctx)
+	query := domain.BikeQuery{}
+	if err := c.Bind(&query); err != nil {
+		c.Logger().Error("[BikeHandler.GetAllBike] invalid query", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBikeQuery), apperrors.ErrInvalidBikeQuery.Error())
+	}
+	filter, err := h.toFilter(query)
+	if err != nil {
+		c.Logger().Error("[BikeHandler.GetAllBike] invalid query", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	bikes, err := h.useCase.GetAllBike(ctx, filter)
 	if err != nil {
 		c.Logger().Error("[BikeHandler.GetAllBike] cannot get all bikes", err)
 		return c.JSON(apperrors.GetStatusCode(err), err.Error())
 	}
+	claims, _ := domain.ClaimsFromContext(ctx)
+	bikes = domain.BikesVisibleTo(bikes, claims)
+	if middleware.NotModified(c, domain.BikeListETag(bikes), domain.BikeListLastModified(bikes)) {
+		c.Logger().Info("[BikeHandler.GetAllBike] not modified")
+		return c.NoContent(http.StatusNotModified)
+	}
 	c.Logger().Info("[BikeHandler.GetAllBike] success")
 	return c.JSON(http.StatusOK, bikes)
 }
 
+// GetByID godoc
+// @Summary      Get a bike
+// @Description  API for getting one bike. userId and nameOfRenter are only filled for its renter and for staff with bikes:read. Its ETag is weak and changes with the version and every lock reading, If-Match on rent and return takes the version in quotes.
+// @Tags         bikes
+// @Accept       json
+// @Produce      json
+// @Param 			 id 	path  		string 		true 								"bike id"
+// @Param        If-None-Match      header    string  false  "ETag of the bike the client has"
+// @Param        If-Modified-Since  header    string  false  "Last-Modified of the bike the client has"
+// @Success      200  {object}  domain.BikeDTO 							  "Success"
+// @Header       200  {string}  ETag 												"weak tag over the version and the newest lock reading"
+// @Header       200  {string}  Last-Modified 							"last change of the bike"
+// @Success      304  "Not modified"
+// @Failure      400  {string}  string 												"invalid bike id"
+// @Failure      404  {string}  string 												"bike not found"
+// @Failure      500  {string}  string 												"internal server error"
+// @Security     BearerAuth
+// @Router       /bikes/{id} [get]
+func (h *handlerImpl) GetByID(c echo.Context) error {
+	span := tracing.StartHandler(c, "BikeHandler.GetByID")
+	defer span.End()
+	var (
+		ctx    = c.Request().Context()
+		bikeID int64
+		err    error
+	)
+	bikeIDStr := c.Param("id")
+	if bikeID, err = strconv.ParseInt(bikeIDStr, 10, 64); err != nil {
+		c.Logger().Error(fmt.Sprintf("[BikeHandler.GetByID] invalid bike id %s", bikeIDStr), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBikeID), apperrors.ErrInvalidBikeID.Error())
+	}
+	bike, err := h.useCase.GetByID(ctx, bikeID)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[BikeHandler.GetByID] get bike %d failed", bikeID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	claims, _ := domain.ClaimsFromContext(ctx)
+	bike = bike.VisibleTo(claims)
+	if middleware.NotModified(c, domain.BikeReadETag(bike), bike.LastModified) {
+		c.Logger().Info(fmt.Sprintf("[BikeHandler.GetByID] bike %d not modified", bikeID))
+		return c.NoContent(http.StatusNotModified)
+	}
+	c.Logger().Info(fmt.Sprintf("[BikeHandler.GetByID] get bike %d success", bikeID))
+	return c.JSON(http.StatusOK, bike)
+}
+
+func (h *handlerImpl) toFilter(query domain.BikeQuery) (domain.BikeFilter, error) {
+	filter := domain.BikeFilter{
+		Type: domain.BikeType(query.Type),
+	}
+	if filter.Type != "" && !filter.Type.IsValid() {
+		return domain.BikeFilter{}, apperrors.ErrInvalidBikeQuery
+	}
+	if query.MinBattery != "" {
+		minBattery, err := strconv.ParseInt(query.MinBattery, 10, 64)
+		if err != nil || minBattery < 0 || minBattery > 100 {
+			return domain.BikeFilter{}, apperrors.ErrInvalidBikeQuery
+		}
+		filter.MinBattery = &minBattery
+	}
+	return filter, nil
+}
+
 // Rent godoc
 // @Summary      Rent a bike
 // @Description  API for renting a bike
@@ -51,11 +141,20 @@ func (h *handlerImpl) GetAllBike(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Param 			 id 	path  		string 		true 								"bike id"
+// @Param 			 Idempotency-Key 	header  		string 		false 								"client generated key, retries with the same key replay the first response"
+// @Param 			 If-Match 	header  		string 		false 								"ETag of the bike as read, the action fails with 409 if the bike changed since"
 // @Success      200  {object}  domain.BikeDTO 							  "Success"
-// @Failure      400  {string}  string 												"invalid bike id | cannot rent because you have already rented a bike | user not exists or inactive | bike not found | cannot rent because bike is rented"
+// @Header       200  {string}  ETag 												"version of the bike after the action"
+// @Failure      400  {string}  string 												"invalid bike id | invalid idempotency key | invalid If-Match header | cannot rent because you have already rented a bike | user not exists or inactive | bike not found | cannot rent because bike is rented"
+// @Failure      409  {string}  string 												"cannot rent because the bike is out of service | cannot rent because the e-bike battery is too low | a request with this idempotency key is still in progress | the bike was changed by someone else, reload it and try again"
+// @Failure      422  {string}  string 												"the idempotency key was already used for a different request"
 // @Failure      500  {string}  string 												"internal server error"
+// @Failure      504  {string}  string 												"the bike lock did not confirm, please try again"
+// @Security     BearerAuth
 // @Router       /bikes/{id}/rent [patch]
 func (h *handlerImpl) Rent(c echo.Context) error {
+	span := tracing.StartHandler(c, "BikeHandler.Rent")
+	defer span.End()
 	var (
 		ctx    = c.Request().Context()
 		bikeID int64
@@ -66,12 +165,21 @@ func (h *handlerImpl) Rent(c echo.Context) error {
 		c.Logger().Error(fmt.Sprintf("[BikeHandler.Rent] invalid bike %s", bikeIDStr), err)
 		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBikeID), apperrors.ErrInvalidBikeID.Error())
 	}
-	user := c.Get(middleware.UserKey).(*jwt.Token)
-	claims := user.Claims.(*domain.Claims)
+	claims, err := middleware.GetClaims(c)
+	if err != nil {
+		c.Logger().Error("[BikeHandler.Rent] missing claims", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
 	userID := claims.ID
+	version, ok := domain.ParseBikeIfMatch(c.Request().Header.Get(middleware.HeaderIfMatch))
+	if !ok {
+		c.Logger().Error("[BikeHandler.Rent] invalid If-Match", apperrors.ErrInvalidIfMatch)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidIfMatch), apperrors.ErrInvalidIfMatch.Error())
+	}
 	request := domain.RentOrReturnRequestPayload{
-		ID:     bikeID,
-		UserID: userID,
+		ID:      bikeID,
+		UserID:  userID,
+		Version: version,
 	}
 	c.Logger().Info(fmt.Sprintf("[BikeHandler.Rent] user %d is renting bike %s", userID, bikeIDStr))
 	bikes, err := h.useCase.Rent(ctx, request)
@@ -80,21 +188,79 @@ func (h *handlerImpl) Rent(c echo.Context) error {
 		return c.JSON(apperrors.GetStatusCode(err), err.Error())
 	}
 	c.Logger().Info(fmt.Sprintf("[BikeHandler.Rent] user %d rent bike %s success", userID, bikeIDStr))
+	c.Response().Header().Set(middleware.HeaderETag, domain.BikeETag(bikes.Version))
 	return c.JSON(http.StatusOK, bikes)
 }
 
+// RentByCode godoc
+// @Summary      Rent a bike by its label code
+// @Description  API for renting the bike whose QR label carries the code, lowercase codes are accepted
+// @Tags         bikes
+// @Accept       json
+// @Produce      json
+// @Param 			 code 	path  		string 		true 								"bike short code"
+// @Param 			 Idempotency-Key 	header  		string 		false 								"client generated key, retries with the same key replay the first response"
+// @Param 			 If-Match 	header  		string 		false 								"ETag of the bike as read, the action fails with 409 if the bike changed since"
+// @Success      200  {object}  domain.BikeDTO 							  "Success"
+// @Header       200  {string}  ETag 												"version of the bike after the action"
+// @Failure      400  {string}  string 												"invalid bike code | invalid idempotency key | invalid If-Match header | cannot rent because you have already rented a bike | user not exists or inactive | cannot rent because bike is rented"
+// @Failure      404  {string}  string 												"bike not found"
+// @Failure      409  {string}  string 												"cannot rent because the bike is out of service | cannot rent because the e-bike battery is too low | a request with this idempotency key is still in progress | the bike was changed by someone else, reload it and try again"
+// @Failure      422  {string}  string 												"the idempotency key was already used for a different request"
+// @Failure      500  {string}  string 												"internal server error"
+// @Failure      504  {string}  string 												"the bike lock did not confirm, please try again"
+// @Security     BearerAuth
+// @Router       /bikes/by-code/{code}/rent [patch]
+func (h *handlerImpl) RentByCode(c echo.Context) error {
+	span := tracing.StartHandler(c, "BikeHandler.RentByCode")
+	defer span.End()
+	ctx := c.Request().Context()
+	code := c.Param("code")
+	claims, err := middleware.GetClaims(c)
+	if err != nil {
+		c.Logger().Error("[BikeHandler.RentByCode] missing claims", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	userID := claims.ID
+	version, ok := domain.ParseBikeIfMatch(c.Request().Header.Get(middleware.HeaderIfMatch))
+	if !ok {
+		c.Logger().Error("[BikeHandler.RentByCode] invalid If-Match", apperrors.ErrInvalidIfMatch)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidIfMatch), apperrors.ErrInvalidIfMatch.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[BikeHandler.RentByCode] user %d is renting bike with code %s", userID, code))
+	bike, err := h.useCase.RentByCode(ctx, code, userID, version)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[BikeHandler.RentByCode] user %d rent bike with code %s failed", userID, code), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[BikeHandler.RentByCode] user %d rent bike with code %s success", userID, code))
+	c.Response().Header().Set(middleware.HeaderETag, domain.BikeETag(bike.Version))
+	return c.JSON(http.StatusOK, bike)
+}
+
 // Return godoc
 // @Summary      Return a bike
-// @Description  API for returning a bike
+// @Description  API for returning a bike, lat and long are required in free-floating mode and stationId in station mode. A return in a zone charging extra carries the surcharge.
 // @Tags         bikes
 // @Accept       json
 // @Produce      json
 // @Param 			 id 	path  		string 		true 								"bike id"
-// @Success      200  {object}  []domain.BikeDTO 							"Success"
-// @Failure      400  {string}  string 												"invalid bike id | bike not found | cannot return because bike is available | cannot return because bike is not yours"
+// @Param 			 Idempotency-Key 	header  		string 		false 								"client generated key, retries with the same key replay the first response"
+// @Param 			 If-Match 	header  		string 		false 								"ETag of the bike as read, the action fails with 409 if the bike changed since"
+// @Param    		 request  body      domain.ReturnBikeBody  true  "Drop-off location or station"
+// @Success      200  {object}  domain.BikeDTO 							  "Success"
+// @Header       200  {string}  ETag 												"version of the bike after the action"
+// @Failure      400  {string}  string 												"invalid bike id | invalid body | invalid idempotency key | invalid If-Match header | invalid drop-off location | cannot return because the drop-off location is too far from the bike | a station id is required to return a bike | cannot return outside the service area | cannot return inside a no-parking zone | bike not found | cannot return because bike is available | cannot return because bike is not yours"
+// @Failure      404  {string}  string 												"station not found"
+// @Failure      409  {string}  string 												"cannot return because the station has no free dock | a request with this idempotency key is still in progress | the bike was changed by someone else, reload it and try again"
+// @Failure      422  {string}  string 												"the idempotency key was already used for a different request"
 // @Failure      500  {string}  string 												"internal server error"
+// @Failure      504  {string}  string 												"the bike lock did not confirm, please try again"
+// @Security     BearerAuth
 // @Router       /bikes/{id}/return [patch]
 func (h *handlerImpl) Return(c echo.Context) error {
+	span := tracing.StartHandler(c, "BikeHandler.Return")
+	defer span.End()
 	var (
 		ctx    = c.Request().Context()
 		bikeID int64
@@ -105,12 +271,29 @@ func (h *handlerImpl) Return(c echo.Context) error {
 		c.Logger().Error(fmt.Sprintf("[BikeHandler.Return] invalid bike id %s", bikeIDStr), err)
 		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBikeID), apperrors.ErrInvalidBikeID.Error())
 	}
-	user := c.Get(middleware.UserKey).(*jwt.Token)
-	claims := user.Claims.(*domain.Claims)
+	body := domain.ReturnBikeBody{}
+	if err := c.Bind(&body); err != nil {
+		c.Logger().Error("[BikeHandler.Return] invalid body", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBody), apperrors.ErrInvalidBody.Error())
+	}
+	claims, err := middleware.GetClaims(c)
+	if err != nil {
+		c.Logger().Error("[BikeHandler.Return] missing claims", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
 	userID := claims.ID
+	version, ok := domain.ParseBikeIfMatch(c.Request().Header.Get(middleware.HeaderIfMatch))
+	if !ok {
+		c.Logger().Error("[BikeHandler.Return] invalid If-Match", apperrors.ErrInvalidIfMatch)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidIfMatch), apperrors.ErrInvalidIfMatch.Error())
+	}
 	request := domain.RentOrReturnRequestPayload{
-		ID:     bikeID,
-		UserID: userID,
+		ID:        bikeID,
+		UserID:    userID,
+		Lat:       body.Lat,
+		Long:      body.Long,
+		StationID: body.StationID,
+		Version:   version,
 	}
 	c.Logger().Info(fmt.Sprintf("[BikeHandler.Return] user %d is returning bike %s", userID, bikeIDStr))
 	bikes, err := h.useCase.Return(ctx, request)
@@ -119,5 +302,6 @@ func (h *handlerImpl) Return(c echo.Context) error {
 		return c.JSON(apperrors.GetStatusCode(err), err.Error())
 	}
 	c.Logger().Info(fmt.Sprintf("[BikeHandler.Return] user %d return bike %s success", userID, bikeIDStr))
+	c.Response().Header().Set(middleware.HeaderETag, domain.BikeETag(bikes.Version))
 	return c.JSON(http.StatusOK, bikes)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +15,8 @@ import (
 
 	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 )
@@ -39,7 +42,7 @@ func TestBikeHandlerTestSuite(t *testing.T) {
 
 func (s *BikeHandlerTestSuite) TestGetAll_Success() {
 	var (
-		mockContext    = context.TODO()
+		mockContext    = domain.NewContextWithClaims(context.Background(), &domain.Claims{ID: 9, Permissions: []domain.Permission{domain.PermissionBikesRead}})
 		mockTime       = time.Time{}
 		mockUserID     = int64(1)
 		mockUserResult = []domain.User{
@@ -84,12 +87,12 @@ func (s *BikeHandlerTestSuite) TestGetAll_Success() {
 			},
 		}
 	)
-	s.mockUseCase.On("GetAllBike", mockContext).Return(mockResult, nil)
-	req := httptest.NewRequest(http.MethodGet, "/bikes", nil)
+	s.mockUseCase.On("GetAllBike", mockContext, domain.BikeFilter{}).Return(mockResult, nil)
+	req := httptest.NewRequest(http.MethodGet, "/bikes", nil).WithContext(mockContext)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := s.echo.NewContext(req, rec)
-	respBody := `[{"id":1,"name":"testName","lat":"50.119504","long":"8.638137","status":"available","userId":0,"nameOfRenter":""},{"id":1,"name":"testName","lat":"50.119229","long":"8.640020","status":"rented","userId":1,"nameOfRenter":"testName"},{"id":1,"name":"testName","lat":"50.120452","long":"8.650507","status":"available","userId":0,"nameOfRenter":""}]
+	respBody := `[{"id":1,"name":"testName","lat":"50.119504","long":"8.638137","status":"available","userId":0,"nameOfRenter":"","version":0},{"id":1,"name":"testName","lat":"50.119229","long":"8.640020","status":"rented","userId":1,"nameOfRenter":"testName","version":0},{"id":1,"name":"testName","lat":"50.120452","long":"8.650507","status":"available","userId":0,"nameOfRenter":"","version":0}]
 `
 	c.SetPath("/bikes")
 	s.NoError(s.handlerImpl.GetAllBike(c))
@@ -99,9 +102,9 @@ func (s *BikeHandlerTestSuite) TestGetAll_Success() {
 
 func (s *BikeHandlerTestSuite) TestGetAll_Failed() {
 	var (
-		mockContext = context.TODO()
+		mockContext = context.Background()
 	)
-	s.mockUseCase.On("GetAllBike", mockContext).Return(nil, apperrors.ErrInternalServerError)
+	s.mockUseCase.On("GetAllBike", mockContext, domain.BikeFilter{}).Return(nil, apperrors.ErrInternalServerError)
 	req := httptest.NewRequest(http.MethodGet, "/bikes", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
@@ -121,7 +124,7 @@ func (s *BikeHandlerTestSuite) TestGetAll_Failed() {
 func (s *BikeHandlerTestSuite) TestRent_Success() {
 	var (
 		userID      = int64(1)
-		mockContext = context.TODO()
+		mockContext = context.Background()
 		name        = "mockName"
 		mockResult  = domain.BikeDTO{
 			ID:           1,
@@ -146,7 +149,7 @@ func (s *BikeHandlerTestSuite) TestRent_Success() {
 		Valid:  true,
 		Claims: &domain.Claims{ID: 1, Name: "TestUser", Username: "TestUserName"},
 	})
-	respBody := `{"id":1,"name":"testName","lat":"50.119504","long":"8.638137","status":"rented","userId":1,"nameOfRenter":"mockName"}
+	respBody := `{"id":1,"name":"testName","lat":"50.119504","long":"8.638137","status":"rented","userId":1,"nameOfRenter":"mockName","version":0}
 `
 	c.SetPath("/bikes/:id/rent")
 	c.SetParamNames("id")
@@ -158,7 +161,7 @@ func (s *BikeHandlerTestSuite) TestRent_Success() {
 
 func (s *BikeHandlerTestSuite) TestRent_FailedUseCase() {
 	var (
-		mockContext = context.TODO()
+		mockContext = context.Background()
 		mockResult  = domain.BikeDTO{}
 		mockInput   = domain.RentOrReturnRequestPayload{
 			UserID: 1,
@@ -205,7 +208,7 @@ func (s *BikeHandlerTestSuite) TestRent_FailedParams() {
 
 func (s *BikeHandlerTestSuite) TestReturn_Success() {
 	var (
-		mockContext = context.TODO()
+		mockContext = context.Background()
 		mockResult  = domain.BikeDTO{
 			ID:           1,
 			Lat:          "50.119504",
@@ -214,13 +217,17 @@ func (s *BikeHandlerTestSuite) TestReturn_Success() {
 			UserID:       0,
 			NameOfRenter: "",
 		}
+		lat       = decimal.RequireFromString("50.119504")
+		long      = decimal.RequireFromString("8.638137")
 		mockInput = domain.RentOrReturnRequestPayload{
 			UserID: 1,
 			ID:     1,
+			Lat:    &lat,
+			Long:   &long,
 		}
 	)
 	s.mockUseCase.On("Return", mockContext, mockInput).Return(mockResult, nil)
-	req := httptest.NewRequest(http.MethodPatch, "/bikes/1/return", nil)
+	req := httptest.NewRequest(http.MethodPatch, "/bikes/1/return", strings.NewReader(`{"lat":"50.119504","long":"8.638137"}`))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := s.echo.NewContext(req, rec)
@@ -228,7 +235,7 @@ func (s *BikeHandlerTestSuite) TestReturn_Success() {
 		Valid:  true,
 		Claims: &domain.Claims{ID: 1, Name: "TestUser", Username: "TestUserName"},
 	})
-	respBody := `{"id":1,"name":"","lat":"50.119504","long":"8.638137","status":"available","userId":0,"nameOfRenter":""}
+	respBody := `{"id":1,"name":"","lat":"50.119504","long":"8.638137","status":"available","userId":0,"nameOfRenter":"","version":0}
 `
 	c.SetPath("/bikes/:id/return")
 	c.SetParamNames("id")
@@ -240,7 +247,7 @@ func (s *BikeHandlerTestSuite) TestReturn_Success() {
 
 func (s *BikeHandlerTestSuite) TestReturn_FailedUseCase() {
 	var (
-		mockContext = context.TODO()
+		mockContext = context.Background()
 		mockResult  = domain.BikeDTO{}
 		mockInput   = domain.RentOrReturnRequestPayload{
 			UserID: 1,
@@ -284,3 +291,387 @@ func (s *BikeHandlerTestSuite) TestReturn_FailedParams() {
 	s.Equal(http.StatusBadRequest, rec.Code)
 	s.Equal(respBody, rec.Body.String())
 }
+
+func (s *BikeHandlerTestSuite) TestReturn_FailedBody() {
+	req := httptest.NewRequest(http.MethodPatch, "/bikes/1/return", strings.NewReader(`{"lat":`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(middleware.UserKey, &jwt.Token{
+		Valid:  true,
+		Claims: &domain.Claims{ID: 1, Name: "TestUser", Username: "TestUserName"},
+	})
+	respBody := `"e4005 invalid body"
+`
+	c.SetPath("/bikes/:id/return")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.Return(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(respBody, rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestReturn_SuccessToStation() {
+	var (
+		mockContext = context.Background()
+		mockResult  = domain.BikeDTO{
+			ID:        1,
+			Lat:       "50.107145",
+			Long:      "8.663789",
+			Status:    domain.BikeStatusAvailable,
+			StationID: 3,
+		}
+		mockInput = domain.RentOrReturnRequestPayload{
+			UserID:    1,
+			ID:        1,
+			StationID: 3,
+		}
+	)
+	s.mockUseCase.On("Return", mockContext, mockInput).Return(mockResult, nil)
+	req := httptest.NewRequest(http.MethodPatch, "/bikes/1/return", strings.NewReader(`{"stationId":3}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(middleware.UserKey, &jwt.Token{
+		Valid:  true,
+		Claims: &domain.Claims{ID: 1, Name: "TestUser", Username: "TestUserName"},
+	})
+	respBody := `{"id":1,"name":"","lat":"50.107145","long":"8.663789","status":"available","userId":0,"nameOfRenter":"","stationId":3,"version":0}
+`
+	c.SetPath("/bikes/:id/return")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.Return(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(respBody, rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestGetAll_SuccessWithFilter() {
+	minBattery := int64(50)
+	s.mockUseCase.On("GetAllBike", context.Background(), domain.BikeFilter{Type: domain.BikeTypeEBike, MinBattery: &minBattery}).Return([]domain.BikeDTO{}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/bikes?type=ebike&minBattery=50", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes")
+	s.NoError(s.handlerImpl.GetAllBike(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("[]\n", rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestGetAll_FailedByInvalidFilter() {
+	for _, query := range []string{"type=tandem", "minBattery=abc", "minBattery=101", "minBattery=-1"} {
+		req := httptest.NewRequest(http.MethodGet, "/bikes?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := s.echo.NewContext(req, rec)
+		c.SetPath("/bikes")
+		s.NoError(s.handlerImpl.GetAllBike(c))
+		s.Equal(http.StatusBadRequest, rec.Code, query)
+		s.Equal("\"e40021 invalid bike query\"\n", rec.Body.String(), query)
+	}
+	s.mockUseCase.AssertNotCalled(s.T(), "GetAllBike", mock.Anything, mock.Anything)
+}
+
+func (s *BikeHandlerTestSuite) TestRentByCode_Success() {
+	var (
+		mockContext = context.Background()
+		mockResult  = domain.BikeDTO{
+			ID:           1,
+			Name:         "testName",
+			Code:         "7F3K9Q2M",
+			Status:       domain.BikeStatusRented,
+			UserID:       1,
+			NameOfRenter: "mockName",
+		}
+	)
+	s.mockUseCase.On("RentByCode", mockContext, "7f3k9q2m", int64(1), int64(0)).Return(mockResult, nil)
+	req := httptest.NewRequest(http.MethodPatch, "/bikes/by-code/7f3k9q2m/rent", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(middleware.UserKey, &jwt.Token{
+		Valid:  true,
+		Claims: &domain.Claims{ID: 1, Name: "TestUser", Username: "TestUserName"},
+	})
+	respBody := `{"id":1,"name":"testName","code":"7F3K9Q2M","lat":"","long":"","status":"rented","userId":1,"nameOfRenter":"mockName","version":0}
+`
+	c.SetPath("/bikes/by-code/:code/rent")
+	c.SetParamNames("code")
+	c.SetParamValues("7f3k9q2m")
+	s.NoError(s.handlerImpl.RentByCode(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(respBody, rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestRentByCode_FailedUseCase() {
+	mockContext := context.Background()
+	s.mockUseCase.On("RentByCode", mockContext, "bad", int64(1), int64(0)).Return(domain.BikeDTO{}, apperrors.ErrInvalidBikeCode)
+	req := httptest.NewRequest(http.MethodPatch, "/bikes/by-code/bad/rent", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(middleware.UserKey, &jwt.Token{
+		Valid:  true,
+		Claims: &domain.Claims{ID: 1, Name: "TestUser", Username: "TestUserName"},
+	})
+	respBody := "\"" + apperrors.ErrInvalidBikeCode.Error() + "\"\n"
+	c.SetPath("/bikes/by-code/:code/rent")
+	c.SetParamNames("code")
+	c.SetParamValues("bad")
+	s.NoError(s.handlerImpl.RentByCode(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(respBody, rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestRent_SuccessWithIfMatch() {
+	mockContext := context.Background()
+	mockInput := domain.RentOrReturnRequestPayload{
+		UserID:  1,
+		ID:      1,
+		Version: 3,
+	}
+	s.mockUseCase.On("Rent", mockContext, mockInput).Return(domain.BikeDTO{ID: 1, Status: domain.BikeStatusRented, UserID: 1, Version: 4}, nil)
+	req := httptest.NewRequest(http.MethodPatch, "/bikes/1/rent", nil)
+	req.Header.Set(middleware.HeaderIfMatch, `"3"`)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(middleware.UserKey, &jwt.Token{
+		Valid:  true,
+		Claims: &domain.Claims{ID: 1},
+	})
+	c.SetPath("/bikes/:id/rent")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.Rent(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(`"4"`, rec.Header().Get(middleware.HeaderETag))
+}
+
+func (s *BikeHandlerTestSuite) TestRent_FailedInvalidIfMatch() {
+	req := httptest.NewRequest(http.MethodPatch, "/bikes/1/rent", nil)
+	req.Header.Set(middleware.HeaderIfMatch, `W/"3"`)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(middleware.UserKey, &jwt.Token{
+		Valid:  true,
+		Claims: &domain.Claims{ID: 1},
+	})
+	c.SetPath("/bikes/:id/rent")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.Rent(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e40025 invalid If-Match header, expected the ETag of the bike\"\n", rec.Body.String())
+	s.mockUseCase.AssertNotCalled(s.T(), "Rent", mock.Anything, mock.Anything)
+}
+
+func (s *BikeHandlerTestSuite) TestRentByCode_FailedInvalidIfMatch() {
+	req := httptest.NewRequest(http.MethodPatch, "/bikes/by-code/7F3K9Q2M/rent", nil)
+	req.Header.Set(middleware.HeaderIfMatch, "3")
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(middleware.UserKey, &jwt.Token{
+		Valid:  true,
+		Claims: &domain.Claims{ID: 1},
+	})
+	c.SetPath("/bikes/by-code/:code/rent")
+	c.SetParamNames("code")
+	c.SetParamValues("7F3K9Q2M")
+	s.NoError(s.handlerImpl.RentByCode(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.mockUseCase.AssertNotCalled(s.T(), "RentByCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *BikeHandlerTestSuite) TestReturn_ConflictWithIfMatch() {
+	mockContext := context.Background()
+	lat := decimal.RequireFromString("50.119504")
+	long := decimal.RequireFromString("8.638137")
+	mockInput := domain.RentOrReturnRequestPayload{
+		UserID:  1,
+		ID:      1,
+		Lat:     &lat,
+		Long:    &long,
+		Version: 2,
+	}
+	s.mockUseCase.On("Return", mockContext, mockInput).Return(domain.BikeDTO{}, apperrors.ErrBikeVersionConflict)
+	req := httptest.NewRequest(http.MethodPatch, "/bikes/1/return", strings.NewReader(`{"lat":"50.119504","long":"8.638137"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(middleware.HeaderIfMatch, `"2"`)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(middleware.UserKey, &jwt.Token{
+		Valid:  true,
+		Claims: &domain.Claims{ID: 1},
+	})
+	c.SetPath("/bikes/:id/return")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.Return(c))
+	s.Equal(http.StatusConflict, rec.Code)
+	s.Equal("\"e4097 the bike was changed by someone else, reload it and try again\"\n", rec.Body.String())
+	s.Empty(rec.Header().Get(middleware.HeaderETag))
+}
+
+func (s *BikeHandlerTestSuite) getByID(ctx context.Context, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/bikes/1", nil).WithContext(ctx)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.GetByID(c))
+	return rec
+}
+
+func (s *BikeHandlerTestSuite) TestGetByID_Success() {
+	mockResult := domain.BikeDTO{
+		ID:           1,
+		Name:         "henry",
+		Status:       domain.BikeStatusRented,
+		UserID:       2,
+		NameOfRenter: "Bob",
+		Version:      3,
+		LastModified: time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC),
+	}
+	ctx := domain.NewContextWithClaims(context.Background(), &domain.Claims{ID: 2})
+	s.mockUseCase.On("GetByID", ctx, int64(1)).Return(mockResult, nil)
+	rec := s.getByID(ctx, nil)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(`{"id":1,"name":"henry","lat":"","long":"","status":"rented","userId":2,"nameOfRenter":"Bob","version":3}
+`, rec.Body.String())
+	s.Equal(`W/"3"`, rec.Header().Get(middleware.HeaderETag))
+	s.Equal("Mon, 19 Oct 2026 14:00:00 GMT", rec.Header().Get(echo.HeaderLastModified))
+}
+
+func (s *BikeHandlerTestSuite) TestGetByID_NotModified() {
+	s.mockUseCase.On("GetByID", context.Background(), int64(1)).Return(domain.BikeDTO{ID: 1, Version: 3}, nil)
+	rec := s.getByID(context.Background(), map[string]string{middleware.HeaderIfNoneMatch: `W/"3"`})
+	s.Equal(http.StatusNotModified, rec.Code)
+	s.Empty(rec.Body.String())
+	s.Equal(`W/"3"`, rec.Header().Get(middleware.HeaderETag))
+}
+
+func (s *BikeHandlerTestSuite) TestGetByID_ModifiedByNewReading() {
+	seenAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	before := domain.BikeReadETag(domain.BikeDTO{ID: 1, Version: 3, Telemetry: &domain.BikeTelemetryDTO{RecordedAt: seenAt}})
+	mockResult := domain.BikeDTO{ID: 1, Version: 3, Telemetry: &domain.BikeTelemetryDTO{RecordedAt: seenAt.Add(time.Second)}}
+	s.mockUseCase.On("GetByID", context.Background(), int64(1)).Return(mockResult, nil)
+	rec := s.getByID(context.Background(), map[string]string{middleware.HeaderIfNoneMatch: before})
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(domain.BikeReadETag(mockResult), rec.Header().Get(middleware.HeaderETag))
+}
+
+func (s *BikeHandlerTestSuite) TestGetByID_ModifiedSinceOlderVersion() {
+	s.mockUseCase.On("GetByID", context.Background(), int64(1)).Return(domain.BikeDTO{ID: 1, Version: 4}, nil)
+	rec := s.getByID(context.Background(), map[string]string{middleware.HeaderIfNoneMatch: `"3"`})
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *BikeHandlerTestSuite) TestGetByID_FailedInvalidID() {
+	req := httptest.NewRequest(http.MethodGet, "/bikes/abc", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	s.NoError(s.handlerImpl.GetByID(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4006 invalid bike id\"\n", rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestGetByID_FailedUseCase() {
+	s.mockUseCase.On("GetByID", context.Background(), int64(1)).Return(domain.BikeDTO{}, apperrors.ErrBikeNotFound)
+	rec := s.getByID(context.Background(), nil)
+	s.Equal(http.StatusNotFound, rec.Code)
+	s.Equal("\"e4040 bike not found\"\n", rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestGetAll_NotModified() {
+	bikes := []domain.BikeDTO{{ID: 1, Version: 2}, {ID: 2, Version: 1}}
+	s.mockUseCase.On("GetAllBike", context.Background(), domain.BikeFilter{}).Return(bikes, nil)
+	req := httptest.NewRequest(http.MethodGet, "/bikes", nil)
+	req.Header.Set(middleware.HeaderIfNoneMatch, domain.BikeListETag(bikes))
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes")
+	s.NoError(s.handlerImpl.GetAllBike(c))
+	s.Equal(http.StatusNotModified, rec.Code)
+	s.Empty(rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestGetAll_SetsValidators() {
+	lastModified := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	bikes := []domain.BikeDTO{{ID: 1, Version: 2, LastModified: lastModified}}
+	s.mockUseCase.On("GetAllBike", context.Background(), domain.BikeFilter{}).Return(bikes, nil)
+	req := httptest.NewRequest(http.MethodGet, "/bikes", nil)
+	req.Header.Set(echo.HeaderIfModifiedSince, "Mon, 19 Oct 2026 13:00:00 GMT")
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes")
+	s.NoError(s.handlerImpl.GetAllBike(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(domain.BikeListETag(bikes), rec.Header().Get(middleware.HeaderETag))
+	s.Equal("Mon, 19 Oct 2026 14:00:00 GMT", rec.Header().Get(echo.HeaderLastModified))
+}
+
+func (s *BikeHandlerTestSuite) TestGetAll_HidesRenterFromRiders() {
+	ctx := domain.NewContextWithClaims(context.Background(), &domain.Claims{ID: 2})
+	s.mockUseCase.On("GetAllBike", ctx, domain.BikeFilter{}).Return([]domain.BikeDTO{
+		{ID: 1, Status: domain.BikeStatusRented, UserID: 1, NameOfRenter: "Bob"},
+		{ID: 2, Status: domain.BikeStatusRented, UserID: 2, NameOfRenter: "Alice"},
+	}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/bikes", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes")
+	s.NoError(s.handlerImpl.GetAllBike(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(`[{"id":1,"name":"","lat":"","long":"","status":"rented","userId":0,"nameOfRenter":"","version":0},{"id":2,"name":"","lat":"","long":"","status":"rented","userId":2,"nameOfRenter":"Alice","version":0}]
+`, rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestGetByID_HidesRenterWithoutClaims() {
+	s.mockUseCase.On("GetByID", context.Background(), int64(1)).Return(domain.BikeDTO{ID: 1, Status: domain.BikeStatusRented, UserID: 2, NameOfRenter: "Bob"}, nil)
+	rec := s.getByID(context.Background(), nil)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(`{"id":1,"name":"","lat":"","long":"","status":"rented","userId":0,"nameOfRenter":"","version":0}
+`, rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestRent_Unauthorized() {
+	req := httptest.NewRequest(http.MethodPatch, "/bikes/1/rent", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes/:id/rent")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.Rent(c))
+	s.Equal(http.StatusUnauthorized, rec.Code)
+	s.Equal("\"e4010 unauthorized\"\n", rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestRentByCode_Unauthorized() {
+	req := httptest.NewRequest(http.MethodPatch, "/bikes/by-code/ab12cd/rent", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes/by-code/:code/rent")
+	c.SetParamNames("code")
+	c.SetParamValues("ab12cd")
+	c.Set(middleware.UserKey, &jwt.Token{Valid: true, Claims: jwt.MapClaims{"id": 1}})
+	s.NoError(s.handlerImpl.RentByCode(c))
+	s.Equal(http.StatusUnauthorized, rec.Code)
+	s.Equal("\"e4010 unauthorized\"\n", rec.Body.String())
+}
+
+func (s *BikeHandlerTestSuite) TestReturn_Unauthorized() {
+	req := httptest.NewRequest(http.MethodPatch, "/bikes/1/return", strings.NewReader(`{"lat":"50.119504","long":"8.638137"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes/:id/return")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.Return(c))
+	s.Equal(http.StatusUnauthorized, rec.Code)
+	s.Equal("\"e4010 unauthorized\"\n", rec.Body.String())
+}
@@ -2,12 +2,19 @@ package bike
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
 	"shared-bike/domain"
+	"shared-bike/tracing"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// errNoFreeDock is returned by UpdateStatusAndDock when the station is full.
+var errNoFreeDock = errors.New("station has no free dock")
+
 type repositoryImpl struct {
 	db *gorm.DB
 }
@@ -18,9 +25,18 @@ func NewRepository(db *gorm.DB) *repositoryImpl {
 	}
 }
 
-func (r *repositoryImpl) GetList(ctx context.Context) (*[]domain.Bike, error) {
+func (r *repositoryImpl) GetList(ctx context.Context, filter domain.BikeFilter) (*[]domain.Bike, error) {
+	ctx, span := tracing.Start(ctx, "BikeRepository.GetList")
+	defer span.End()
 	bikes := []domain.Bike{}
-	err := r.db.Find(&bikes).Error
+	query := r.db.WithContext(ctx).Model(&domain.Bike{})
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.MinBattery != nil {
+		query = query.Where("battery >= ?", *filter.MinBattery)
+	}
+	err := query.Find(&bikes).Error
 	if err != nil {
 		return nil, err
 	}
@@ -28,27 +44,198 @@ func (r *repositoryImpl) GetList(ctx context.Context) (*[]domain.Bike, error) {
 }
 
 func (r *repositoryImpl) GetByID(ctx context.Context, id int64) (*domain.Bike, error) {
+	ctx, span := tracing.Start(ctx, "BikeRepository.GetByID")
+	defer span.End()
+	bike := domain.Bike{}
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&bike).Error
+	if err != nil {
+		return nil, err
+	}
+	return &bike, nil
+}
+
+// GetByCode looks a bike up by the short code printed on its label.
+func (r *repositoryImpl) GetByCode(ctx context.Context, code string) (*domain.Bike, error) {
+	ctx, span := tracing.Start(ctx, "BikeRepository.GetByCode")
+	defer span.End()
 	bike := domain.Bike{}
-	err := r.db.Where("id = ?", id).First(&bike).Error
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&bike).Error
 	if err != nil {
 		return nil, err
 	}
 	return &bike, nil
 }
 
+func (r *repositoryImpl) GetListByIDs(ctx context.Context, IDs []int64) (*[]domain.Bike, error) {
+	ctx, span := tracing.Start(ctx, "BikeRepository.GetListByIDs")
+	defer span.End()
+	bikes := []domain.Bike{}
+	err := r.db.WithContext(ctx).Where("id IN (?)", IDs).Order("id").Find(&bikes).Error
+	if err != nil {
+		return nil, err
+	}
+	return &bikes, nil
+}
+
 func (r *repositoryImpl) CountByUserID(ctx context.Context, id int64) (int64, error) {
+	ctx, span := tracing.Start(ctx, "BikeRepository.CountByUserID")
+	defer span.End()
 	var total int64
-	err := r.db.Model(domain.Bike{}).Where("user_id = ?", id).Count(&total).Error
+	err := r.db.WithContext(ctx).Model(domain.Bike{}).Where("user_id = ?", id).Count(&total).Error
 	if err != nil {
 		return 0, err
 	}
 	return total, nil
 }
 
-func (r *repositoryImpl) UpdateStatusAndUserID(ctx context.Context, body *domain.Bike) error {
-	err := r.db.Select("status", "user_id").Where("id = ?", body.ID).Updates(body).Error
+func (r *repositoryImpl) CountByStatus(ctx context.Context, status domain.BikeStatus) (int64, error) {
+	ctx, span := tracing.Start(ctx, "BikeRepository.CountByStatus")
+	defer span.End()
+	var total int64
+	err := r.db.WithContext(ctx).Model(domain.Bike{}).Where("status = ?", status).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// UpdateStatusAndUserID writes the status and renter if the bike is still at the version it was read with,
+// and reports false when someone else changed it in between. body.Version becomes the new version.
+func (r *repositoryImpl) UpdateStatusAndUserID(ctx context.Context, body *domain.Bike) (bool, error) {
+	ctx, span := tracing.Start(ctx, "BikeRepository.UpdateStatusAndUserID")
+	defer span.End()
+	return updateVersioned(r.db.WithContext(ctx), body, map[string]interface{}{
+		"status":  body.Status,
+		"user_id": body.UserID,
+	})
+}
+
+// UpdateStatusAndLocation writes the status, renter, position and dock in one statement so a returned bike never shows
+// up as available at its old spot. Like UpdateStatusAndUserID it only applies to the version the bike was read with.
+func (r *repositoryImpl) UpdateStatusAndLocation(ctx context.Context, body *domain.Bike) (bool, error) {
+	ctx, span := tracing.Start(ctx, "BikeRepository.UpdateStatusAndLocation")
+	defer span.End()
+	return updateVersioned(r.db.WithContext(ctx), body, locationUpdates(body))
+}
+
+// UpdateStatusAndDock is UpdateStatusAndLocation for a bike docked at body.StationID. The station row stays locked
+// from counting its bikes to writing this one, so riders racing for the last dock take turns and the late one gets
+// errNoFreeDock instead of overfilling the station.
+func (r *repositoryImpl) UpdateStatusAndDock(ctx context.Context, body *domain.Bike) (bool, error) {
+	ctx, span := tracing.Start(ctx, "BikeRepository.UpdateStatusAndDock")
+	defer span.End()
+	updated := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		station := domain.Station{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", body.StationID.Int64).First(&station).Error
+		if err != nil {
+			return err
+		}
+		var docked int64
+		if err := tx.Model(&domain.Bike{}).Where("station_id = ? AND id <> ?", station.ID, body.ID).Count(&docked).Error; err != nil {
+			return err
+		}
+		if docked >= station.Capacity {
+			return errNoFreeDock
+		}
+		updated, err = updateVersioned(tx, body, locationUpdates(body))
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return updated, nil
+}
+
+// locationUpdates keeps the position of a lock reading newer than the one body was read with, readings do not bump the
+// version so the version check alone would let an older drop-off or rollback position win.
+func locationUpdates(body *domain.Bike) map[string]interface{} {
+	return map[string]interface{}{
+		"status":     body.Status,
+		"user_id":    body.UserID,
+		"lat":        unlessNewerReading("`lat`", body.Lat, body.LastSeenAt),
+		"long":       unlessNewerReading("`long`", body.Long, body.LastSeenAt),
+		"station_id": body.StationID,
+	}
+}
+
+func unlessNewerReading(column string, value interface{}, lastSeenAt sql.NullTime) clause.Expr {
+	if !lastSeenAt.Valid {
+		return gorm.Expr("CASE WHEN last_seen_at IS NULL THEN ? ELSE "+column+" END", value)
+	}
+	return gorm.Expr("CASE WHEN last_seen_at IS NULL OR last_seen_at <= ? THEN ? ELSE "+column+" END", lastSeenAt.Time, value)
+}
+
+func updateVersioned(db *gorm.DB, body *domain.Bike, updates map[string]interface{}) (bool, error) {
+	updates["version"] = gorm.Expr("version + 1")
+	result := db.Model(&domain.Bike{}).Where("id = ? AND version = ?", body.ID, body.Version).Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	body.Version++
+	return true, nil
+}
+
+// UpdateTelemetry copies a lock reading onto the bike unless a newer reading already did, and reports whether it did.
+// Comparing against last_seen_at in the same statement keeps out-of-order readings from moving the bike back. Readings
+// leave the version alone, so a lock reporting every few seconds does not fail every If-Match of riders and staff.
+// A reading far from the station the bike is docked at also takes the bike out of that dock, see undockIfAway.
+func (r *repositoryImpl) UpdateTelemetry(ctx context.Context, reading *domain.TelemetryReading) (bool, error) {
+	ctx, span := tracing.Start(ctx, "BikeRepository.UpdateTelemetry")
+	defer span.End()
+	updates := map[string]interface{}{
+		"last_seen_at": reading.RecordedAt,
+	}
+	if reading.Lat != nil && reading.Long != nil {
+		updates["lat"] = reading.Lat
+		updates["long"] = reading.Long
+	}
+	if reading.Battery.Valid {
+		updates["battery"] = reading.Battery.Int64
+	}
+	if reading.LockState != "" {
+		updates["lock_state"] = reading.LockState
+	}
+	applied := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Bike{}).
+			Where("id = ? AND (last_seen_at IS NULL OR last_seen_at < ?)", reading.BikeID, reading.RecordedAt).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		applied = result.RowsAffected > 0
+		if !applied || reading.Lat == nil || reading.Long == nil {
+			return nil
+		}
+		return undockIfAway(tx, reading)
+	})
+	if err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+// undockIfAway clears the dock of a bike whose lock reports it further than domain.StationDockRadiusMeters from its
+// station, so a bike taken out of its dock no longer holds the dock or shows up as available there. The bike row is
+// already locked by the reading, so a return docking it again waits for this to finish.
+func undockIfAway(tx *gorm.DB, reading *domain.TelemetryReading) error {
+	station := domain.Station{}
+	err := tx.Joins("JOIN `bike` ON `bike`.`station_id` = `station`.`id`").Where("`bike`.`id` = ?", reading.BikeID).First(&station).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
-	return nil
+	if station.Lat == nil || station.Long == nil {
+		return nil
+	}
+	if domain.DistanceInMeters(*station.Lat, *station.Long, *reading.Lat, *reading.Long) <= domain.StationDockRadiusMeters {
+		return nil
+	}
+	return tx.Model(&domain.Bike{}).Where("id = ?", reading.BikeID).Update("station_id", nil).Error
 }
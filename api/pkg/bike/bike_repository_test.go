@@ -116,7 +116,7 @@ func (s *BikeRepositoryTestSuite) TestGetList_Success() {
 	query := regexp.QuoteMeta("SELECT * FROM `bike`")
 
 	s.mockDB.ExpectQuery(query).WillReturnRows(rows)
-	actual, err := s.repositoryImpl.GetList(context.TODO())
+	actual, err := s.repositoryImpl.GetList(context.TODO(), domain.BikeFilter{})
 	s.Equal(mockBikes, *actual)
 	s.Nil(err)
 }
@@ -125,7 +125,7 @@ func (s *BikeRepositoryTestSuite) TestGetList_Failed() {
 	query := regexp.QuoteMeta("SELECT * FROM `bike`")
 
 	s.mockDB.ExpectQuery(query).WillReturnError(gorm.ErrRecordNotFound)
-	actual, err := s.repositoryImpl.GetList(context.TODO())
+	actual, err := s.repositoryImpl.GetList(context.TODO(), domain.BikeFilter{})
 	s.Nil(actual)
 	s.Equal(gorm.ErrRecordNotFound, err)
 }
@@ -175,15 +175,36 @@ func (s *BikeRepositoryTestSuite) TestUpdate_Success() {
 		Int64: 1,
 	}
 	updatedVariables := domain.Bike{
-		Status: domain.BikeStatusRented,
-		UserID: mockUserID,
+		ID:      1,
+		Status:  domain.BikeStatusRented,
+		UserID:  mockUserID,
+		Version: 3,
 	}
-	query := regexp.QuoteMeta("UPDATE `bike` SET `status`=?,`user_id`=?,`updated_at`=? WHERE id = ?")
+	query := regexp.QuoteMeta("UPDATE `bike` SET `status`=?,`user_id`=?,`version`=version + 1,`updated_at`=? WHERE (id = ? AND version = ?) AND `bike`.`deleted_at` IS NULL")
 	s.mockDB.ExpectBegin()
-	s.mockDB.ExpectExec(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mockDB.ExpectExec(query).WithArgs(domain.BikeStatusRented, int64(1), sqlmock.AnyArg(), int64(1), int64(3)).WillReturnResult(sqlmock.NewResult(1, 1))
 	s.mockDB.ExpectCommit()
-	err := s.repositoryImpl.UpdateStatusAndUserID(context.TODO(), &updatedVariables)
+	actual, err := s.repositoryImpl.UpdateStatusAndUserID(context.TODO(), &updatedVariables)
 	s.Nil(err)
+	s.True(actual)
+	s.Equal(int64(4), updatedVariables.Version)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdate_VersionChanged() {
+	updatedVariables := domain.Bike{
+		ID:      1,
+		Status:  domain.BikeStatusRented,
+		Version: 3,
+	}
+	query := regexp.QuoteMeta("UPDATE `bike` SET `status`=?,`user_id`=?,`version`=version + 1,`updated_at`=? WHERE (id = ? AND version = ?)")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1), int64(3)).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	actual, err := s.repositoryImpl.UpdateStatusAndUserID(context.TODO(), &updatedVariables)
+	s.Nil(err)
+	s.False(actual)
+	s.Equal(int64(3), updatedVariables.Version)
 }
 
 func (s *BikeRepositoryTestSuite) TestUpdate_Failed() {
@@ -195,11 +216,12 @@ func (s *BikeRepositoryTestSuite) TestUpdate_Failed() {
 		Status: domain.BikeStatusRented,
 		UserID: mockUserID,
 	}
-	query := regexp.QuoteMeta("UPDATE `bike` SET `status`=?,`user_id`=?,`updated_at`=? WHERE id = ?")
+	query := regexp.QuoteMeta("UPDATE `bike` SET `status`=?,`user_id`=?,`version`=version + 1,`updated_at`=? WHERE (id = ? AND version = ?)")
 	s.mockDB.ExpectBegin()
-	s.mockDB.ExpectExec(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnError(gorm.ErrRecordNotFound)
+	s.mockDB.ExpectExec(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnError(gorm.ErrRecordNotFound)
 	s.mockDB.ExpectRollback()
-	err := s.repositoryImpl.UpdateStatusAndUserID(context.TODO(), &updatedVariables)
+	actual, err := s.repositoryImpl.UpdateStatusAndUserID(context.TODO(), &updatedVariables)
+	s.False(actual)
 	s.Equal(gorm.ErrRecordNotFound, err)
 }
 
@@ -220,3 +242,298 @@ func (s *BikeRepositoryTestSuite) TestCountByUserID_Failed() {
 	s.Equal(int64(0), actual)
 	s.Equal(gorm.ErrRecordNotFound, err)
 }
+
+func (s *BikeRepositoryTestSuite) TestCountByStatus_Success() {
+	query := regexp.QuoteMeta("SELECT count(*) FROM `bike` WHERE status = ? AND `bike`.`deleted_at` IS NULL")
+	row := sqlmock.NewRows([]string{"count"}).
+		AddRow(int64(4))
+	s.mockDB.ExpectQuery(query).WithArgs(domain.BikeStatusRented).WillReturnRows(row)
+	actual, err := s.repositoryImpl.CountByStatus(context.TODO(), domain.BikeStatusRented)
+	s.Equal(int64(4), actual)
+	s.Nil(err)
+}
+
+func (s *BikeRepositoryTestSuite) TestCountByStatus_Failed() {
+	query := regexp.QuoteMeta("SELECT count(*) FROM `bike` WHERE status = ?")
+	s.mockDB.ExpectQuery(query).WithArgs(domain.BikeStatusRented).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.CountByStatus(context.TODO(), domain.BikeStatusRented)
+	s.Equal(int64(0), actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdateStatusAndLocation_Success() {
+	lat := decimal.NewFromFloat(50.119504)
+	long := decimal.NewFromFloat(8.638137)
+	updatedVariables := domain.Bike{
+		ID:      1,
+		Lat:     &lat,
+		Long:    &long,
+		Status:  domain.BikeStatusAvailable,
+		Version: 1,
+	}
+	query := regexp.QuoteMeta("UPDATE `bike` SET `lat`=CASE WHEN last_seen_at IS NULL THEN ? ELSE `lat` END,`long`=CASE WHEN last_seen_at IS NULL THEN ? ELSE `long` END,`station_id`=?,`status`=?,`user_id`=?,`version`=version + 1,`updated_at`=? WHERE (id = ? AND version = ?) AND `bike`.`deleted_at` IS NULL")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, domain.BikeStatusAvailable, nil, sqlmock.AnyArg(), int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mockDB.ExpectCommit()
+	actual, err := s.repositoryImpl.UpdateStatusAndLocation(context.TODO(), &updatedVariables)
+	s.Nil(err)
+	s.True(actual)
+	s.Equal(int64(2), updatedVariables.Version)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdateStatusAndLocation_KeepsNewerReading() {
+	lat := decimal.NewFromFloat(50.119504)
+	long := decimal.NewFromFloat(8.638137)
+	seenAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	updatedVariables := domain.Bike{
+		ID:         1,
+		Lat:        &lat,
+		Long:       &long,
+		Status:     domain.BikeStatusAvailable,
+		LastSeenAt: sql.NullTime{Valid: true, Time: seenAt},
+		Version:    1,
+	}
+	query := regexp.QuoteMeta("UPDATE `bike` SET `lat`=CASE WHEN last_seen_at IS NULL OR last_seen_at <= ? THEN ? ELSE `lat` END,`long`=CASE WHEN last_seen_at IS NULL OR last_seen_at <= ? THEN ? ELSE `long` END,`station_id`=?,`status`=?,`user_id`=?,`version`=version + 1,`updated_at`=? WHERE (id = ? AND version = ?)")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WithArgs(seenAt, sqlmock.AnyArg(), seenAt, sqlmock.AnyArg(), nil, domain.BikeStatusAvailable, nil, sqlmock.AnyArg(), int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mockDB.ExpectCommit()
+	actual, err := s.repositoryImpl.UpdateStatusAndLocation(context.TODO(), &updatedVariables)
+	s.Nil(err)
+	s.True(actual)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdateStatusAndLocation_VersionChanged() {
+	updatedVariables := domain.Bike{
+		ID:      1,
+		Status:  domain.BikeStatusAvailable,
+		Version: 1,
+	}
+	query := regexp.QuoteMeta("UPDATE `bike` SET `lat`=CASE WHEN last_seen_at IS NULL THEN ? ELSE `lat` END,`long`=CASE WHEN last_seen_at IS NULL THEN ? ELSE `long` END,`station_id`=?,`status`=?,`user_id`=?,`version`=version + 1,`updated_at`=? WHERE (id = ? AND version = ?)")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	actual, err := s.repositoryImpl.UpdateStatusAndLocation(context.TODO(), &updatedVariables)
+	s.Nil(err)
+	s.False(actual)
+	s.Equal(int64(1), updatedVariables.Version)
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdateStatusAndLocation_Failed() {
+	updatedVariables := domain.Bike{
+		ID:     1,
+		Status: domain.BikeStatusAvailable,
+	}
+	query := regexp.QuoteMeta("UPDATE `bike` SET `lat`=CASE WHEN last_seen_at IS NULL THEN ? ELSE `lat` END,`long`=CASE WHEN last_seen_at IS NULL THEN ? ELSE `long` END,`station_id`=?,`status`=?,`user_id`=?,`version`=version + 1,`updated_at`=? WHERE (id = ? AND version = ?)")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	actual, err := s.repositoryImpl.UpdateStatusAndLocation(context.TODO(), &updatedVariables)
+	s.False(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *BikeRepositoryTestSuite) dockedBike() *domain.Bike {
+	return &domain.Bike{
+		ID:        1,
+		Status:    domain.BikeStatusAvailable,
+		StationID: sql.NullInt64{Valid: true, Int64: 3},
+		Version:   1,
+	}
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdateStatusAndDock_Success() {
+	stationQuery := regexp.QuoteMeta("SELECT * FROM `station` WHERE id = ? AND `station`.`deleted_at` IS NULL ORDER BY `station`.`id` LIMIT 1 FOR UPDATE")
+	countQuery := regexp.QuoteMeta("SELECT count(*) FROM `bike` WHERE (station_id = ? AND id <> ?) AND `bike`.`deleted_at` IS NULL")
+	updateQuery := regexp.QuoteMeta("UPDATE `bike` SET `lat`=CASE WHEN last_seen_at IS NULL THEN ? ELSE `lat` END,`long`=CASE WHEN last_seen_at IS NULL THEN ? ELSE `long` END,`station_id`=?,`status`=?,`user_id`=?,`version`=version + 1,`updated_at`=? WHERE (id = ? AND version = ?) AND `bike`.`deleted_at` IS NULL")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(stationQuery).WithArgs(int64(3)).WillReturnRows(sqlmock.NewRows([]string{"id", "capacity"}).AddRow(3, 20))
+	s.mockDB.ExpectQuery(countQuery).WithArgs(int64(3), int64(1)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(19))
+	s.mockDB.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mockDB.ExpectCommit()
+	bike := s.dockedBike()
+	actual, err := s.repositoryImpl.UpdateStatusAndDock(context.TODO(), bike)
+	s.Nil(err)
+	s.True(actual)
+	s.Equal(int64(2), bike.Version)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdateStatusAndDock_StationFull() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `station`")).WillReturnRows(sqlmock.NewRows([]string{"id", "capacity"}).AddRow(3, 20))
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `bike`")).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(20))
+	s.mockDB.ExpectRollback()
+	bike := s.dockedBike()
+	actual, err := s.repositoryImpl.UpdateStatusAndDock(context.TODO(), bike)
+	s.Equal(errNoFreeDock, err)
+	s.False(actual)
+	s.Equal(int64(1), bike.Version)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdateStatusAndDock_VersionChanged() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `station`")).WillReturnRows(sqlmock.NewRows([]string{"id", "capacity"}).AddRow(3, 20))
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `bike`")).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	s.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `bike`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	actual, err := s.repositoryImpl.UpdateStatusAndDock(context.TODO(), s.dockedBike())
+	s.Nil(err)
+	s.False(actual)
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdateStatusAndDock_Failed() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `station`")).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	actual, err := s.repositoryImpl.UpdateStatusAndDock(context.TODO(), s.dockedBike())
+	s.Equal(gorm.ErrInvalidDB, err)
+	s.False(actual)
+}
+
+var stationOfBikeQuery = regexp.QuoteMeta("FROM `station` JOIN `bike` ON `bike`.`station_id` = `station`.`id` WHERE `bike`.`id` = ? AND `station`.`deleted_at` IS NULL")
+
+func (s *BikeRepositoryTestSuite) TestUpdateTelemetry_Applied() {
+	lat := decimal.NewFromFloat(50.119504)
+	long := decimal.NewFromFloat(8.638137)
+	recordedAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	reading := domain.TelemetryReading{
+		BikeID:     1,
+		RecordedAt: recordedAt,
+		Lat:        &lat,
+		Long:       &long,
+		Battery:    sql.NullInt64{Int64: 87, Valid: true},
+		LockState:  domain.LockStateLocked,
+	}
+	query := regexp.QuoteMeta("UPDATE `bike` SET `battery`=?,`last_seen_at`=?,`lat`=?,`lock_state`=?,`long`=?,`updated_at`=? WHERE (id = ? AND (last_seen_at IS NULL OR last_seen_at < ?)) AND `bike`.`deleted_at` IS NULL")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WithArgs(int64(87), recordedAt, &lat, domain.LockStateLocked, &long, sqlmock.AnyArg(), int64(1), recordedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectQuery(stationOfBikeQuery).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	s.mockDB.ExpectCommit()
+	actual, err := s.repositoryImpl.UpdateTelemetry(context.TODO(), &reading)
+	s.True(actual)
+	s.Nil(err)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdateTelemetry_AwayFromStationUndocks() {
+	lat := decimal.NewFromFloat(50.119504)
+	long := decimal.NewFromFloat(8.638137)
+	recordedAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	reading := domain.TelemetryReading{
+		BikeID:     1,
+		RecordedAt: recordedAt,
+		Lat:        &lat,
+		Long:       &long,
+	}
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `bike` SET `last_seen_at`=?,`lat`=?,`long`=?")).WillReturnResult(sqlmock.NewResult(0, 1))
+	rows := sqlmock.NewRows([]string{"id", "lat", "long"}).AddRow(3, "50.110924", "8.682127")
+	s.mockDB.ExpectQuery(stationOfBikeQuery).WithArgs(int64(1)).WillReturnRows(rows)
+	undock := regexp.QuoteMeta("UPDATE `bike` SET `station_id`=?,`updated_at`=? WHERE id = ? AND `bike`.`deleted_at` IS NULL")
+	s.mockDB.ExpectExec(undock).WithArgs(nil, sqlmock.AnyArg(), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectCommit()
+	actual, err := s.repositoryImpl.UpdateTelemetry(context.TODO(), &reading)
+	s.True(actual)
+	s.Nil(err)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdateTelemetry_NearStationStaysDocked() {
+	lat := decimal.NewFromFloat(50.110930)
+	long := decimal.NewFromFloat(8.682120)
+	recordedAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	reading := domain.TelemetryReading{
+		BikeID:     1,
+		RecordedAt: recordedAt,
+		Lat:        &lat,
+		Long:       &long,
+	}
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `bike` SET `last_seen_at`=?,`lat`=?,`long`=?")).WillReturnResult(sqlmock.NewResult(0, 1))
+	rows := sqlmock.NewRows([]string{"id", "lat", "long"}).AddRow(3, "50.110924", "8.682127")
+	s.mockDB.ExpectQuery(stationOfBikeQuery).WithArgs(int64(1)).WillReturnRows(rows)
+	s.mockDB.ExpectCommit()
+	actual, err := s.repositoryImpl.UpdateTelemetry(context.TODO(), &reading)
+	s.True(actual)
+	s.Nil(err)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdateTelemetry_StaleKeepsBike() {
+	recordedAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	reading := domain.TelemetryReading{
+		BikeID:     1,
+		RecordedAt: recordedAt,
+	}
+	query := regexp.QuoteMeta("UPDATE `bike` SET `last_seen_at`=?,`updated_at`=? WHERE (id = ? AND (last_seen_at IS NULL OR last_seen_at < ?)) AND `bike`.`deleted_at` IS NULL")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WithArgs(recordedAt, sqlmock.AnyArg(), int64(1), recordedAt).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	actual, err := s.repositoryImpl.UpdateTelemetry(context.TODO(), &reading)
+	s.False(actual)
+	s.Nil(err)
+}
+
+func (s *BikeRepositoryTestSuite) TestUpdateTelemetry_Failed() {
+	reading := domain.TelemetryReading{
+		BikeID:     1,
+		RecordedAt: time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC),
+	}
+	query := regexp.QuoteMeta("UPDATE `bike` SET")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	actual, err := s.repositoryImpl.UpdateTelemetry(context.TODO(), &reading)
+	s.False(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *BikeRepositoryTestSuite) TestGetList_SuccessWithFilter() {
+	minBattery := int64(50)
+	query := regexp.QuoteMeta("SELECT * FROM `bike` WHERE type = ? AND battery >= ? AND `bike`.`deleted_at` IS NULL")
+	rows := sqlmock.NewRows([]string{"id", "type", "battery"}).AddRow(3, "ebike", 80)
+	s.mockDB.ExpectQuery(query).WithArgs(domain.BikeTypeEBike, minBattery).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetList(context.TODO(), domain.BikeFilter{Type: domain.BikeTypeEBike, MinBattery: &minBattery})
+	s.Equal(&[]domain.Bike{{ID: 3, Type: domain.BikeTypeEBike, Battery: sql.NullInt64{Valid: true, Int64: 80}}}, actual)
+	s.Nil(err)
+}
+
+func (s *BikeRepositoryTestSuite) TestGetByCode_Success() {
+	query := regexp.QuoteMeta("SELECT * FROM `bike` WHERE code = ? AND `bike`.`deleted_at` IS NULL ORDER BY `bike`.`id` LIMIT 1")
+	row := sqlmock.NewRows([]string{"id", "code", "status"}).
+		AddRow(1, "7F3K9Q2M", domain.BikeStatusAvailable)
+	s.mockDB.ExpectQuery(query).WithArgs("7F3K9Q2M").WillReturnRows(row)
+	actual, err := s.repositoryImpl.GetByCode(context.TODO(), "7F3K9Q2M")
+	s.Equal(&domain.Bike{ID: 1, Code: "7F3K9Q2M", Status: domain.BikeStatusAvailable}, actual)
+	s.Nil(err)
+}
+
+func (s *BikeRepositoryTestSuite) TestGetByCode_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `bike` WHERE code = ?")
+	s.mockDB.ExpectQuery(query).WithArgs("7F3K9Q2M").WillReturnError(gorm.ErrRecordNotFound)
+	actual, err := s.repositoryImpl.GetByCode(context.TODO(), "7F3K9Q2M")
+	s.Nil(actual)
+	s.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (s *BikeRepositoryTestSuite) TestGetListByIDs_Success() {
+	query := regexp.QuoteMeta("SELECT * FROM `bike` WHERE id IN (?,?) AND `bike`.`deleted_at` IS NULL ORDER BY id")
+	rows := sqlmock.NewRows([]string{"id", "code"}).
+		AddRow(1, "7F3K9Q2M").
+		AddRow(2, "A1B2C3D4")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1), int64(2)).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetListByIDs(context.TODO(), []int64{1, 2})
+	s.Equal(&[]domain.Bike{{ID: 1, Code: "7F3K9Q2M"}, {ID: 2, Code: "A1B2C3D4"}}, actual)
+	s.Nil(err)
+}
+
+func (s *BikeRepositoryTestSuite) TestGetListByIDs_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `bike` WHERE id IN (?,?)")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1), int64(2)).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetListByIDs(context.TODO(), []int64{1, 2})
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
@@ -5,30 +5,63 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"shared-bike/apperrors"
 	"shared-bike/domain"
+	"shared-bike/tracing"
 
 	"gorm.io/gorm"
 )
 
+// maxReturnDistanceMeters bounds how far from its last known position a bike can be dropped off,
+// anything further away is more likely a spoofed or broken location than a real ride.
+const maxReturnDistanceMeters = 30000
+
+// rollbackTimeout bounds restoring a bike whose lock did not follow, which runs even when the request is gone.
+const rollbackTimeout = 5 * time.Second
+
+// actions of the rental failure metric
+const (
+	metricsActionRent   = "rent"
+	metricsActionReturn = "return"
+)
+
 type useCaseImpl struct {
-	repository     IRepository
-	logger         ILogger
-	userRepository IUserRepository
+	repository        IRepository
+	logger            ILogger
+	userRepository    IUserRepository
+	ticketRepository  ITicketRepository
+	stationRepository IStationRepository
+	zoneChecker       IZoneChecker
+	lockController    ILockController
+	auditor           IAuditor
+	metrics           IMetrics
+	returnMode        domain.ReturnMode
+	minRentBattery    int64
 }
 
-func NewUseCase(logger ILogger, repository IRepository, userRepository IUserRepository) *useCaseImpl {
+func NewUseCase(logger ILogger, repository IRepository, userRepository IUserRepository, ticketRepository ITicketRepository, stationRepository IStationRepository, zoneChecker IZoneChecker, lockController ILockController, auditor IAuditor, metrics IMetrics, returnMode domain.ReturnMode, minRentBattery int64) *useCaseImpl {
 	return &useCaseImpl{
-		repository:     repository,
-		logger:         logger,
-		userRepository: userRepository,
+		repository:        repository,
+		logger:            logger,
+		userRepository:    userRepository,
+		ticketRepository:  ticketRepository,
+		stationRepository: stationRepository,
+		zoneChecker:       zoneChecker,
+		lockController:    lockController,
+		auditor:           auditor,
+		metrics:           metrics,
+		returnMode:        returnMode,
+		minRentBattery:    minRentBattery,
 	}
 }
 
-func (u *useCaseImpl) GetAllBike(ctx context.Context) ([]domain.BikeDTO, error) {
+func (u *useCaseImpl) GetAllBike(ctx context.Context, filter domain.BikeFilter) ([]domain.BikeDTO, error) {
+	ctx, span := tracing.Start(ctx, "BikeUseCase.GetAllBike")
+	defer span.End()
 	u.logger.Info("[BikeUseCase.GetAllBike] fetching all bikes")
-	bikes, err := u.repository.GetList(ctx)
+	bikes, err := u.repository.GetList(ctx, filter)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return []domain.BikeDTO{}, nil
 	}
@@ -47,6 +80,35 @@ func (u *useCaseImpl) GetAllBike(ctx context.Context) ([]domain.BikeDTO, error)
 	return result, nil
 }
 
+// GetByID fetches one bike with the name of its renter.
+func (u *useCaseImpl) GetByID(ctx context.Context, id int64) (domain.BikeDTO, error) {
+	ctx, span := tracing.Start(ctx, "BikeUseCase.GetByID")
+	defer span.End()
+	u.logger.Info(fmt.Sprintf("[BikeUseCase.GetByID] fetching bike %d", id))
+	bike, err := u.repository.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.GetByID] cannot find bike %d", id))
+		return domain.BikeDTO{}, apperrors.ErrBikeNotFound
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[BikeUseCase.GetByID] fetch bike %d failed", id), err)
+		return domain.BikeDTO{}, apperrors.ErrInternalServerError
+	}
+	result := bike.ToDTO()
+	if bike.UserID.Valid {
+		renter, err := u.userRepository.GetByID(ctx, bike.UserID.Int64)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			u.logger.Error(fmt.Sprintf("[BikeUseCase.GetByID] fetch renter %d of bike %d failed", bike.UserID.Int64, id), err)
+			return domain.BikeDTO{}, apperrors.ErrInternalServerError
+		}
+		if renter != nil {
+			result.NameOfRenter = renter.Name
+		}
+	}
+	u.logger.Info(fmt.Sprintf("[BikeUseCase.GetByID] fetch bike %d success", id))
+	return result, nil
+}
+
 func (u *useCaseImpl) transformBikeDTOList(bikes *[]domain.Bike, usersMap map[int64]domain.User) []domain.BikeDTO {
 	results := []domain.BikeDTO{}
 	for _, bike := range *bikes {
@@ -60,6 +122,9 @@ func (u *useCaseImpl) transformBikeDTOList(bikes *[]domain.Bike, usersMap map[in
 }
 
 func (u *useCaseImpl) fetchMapUsersByID(ctx context.Context, userIDs []int64) (map[int64]domain.User, error) {
+	if len(userIDs) == 0 {
+		return map[int64]domain.User{}, nil
+	}
 	u.logger.Info(fmt.Sprintf("[BikeUseCase.fetchUsers] fetch all user by IDs failed %d", userIDs))
 	users, err := u.userRepository.GetListByIDs(ctx, userIDs)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -101,6 +166,14 @@ func (u *useCaseImpl) checkRented(ctx context.Context, userID int64) (bool, erro
 }
 
 func (u *useCaseImpl) Rent(ctx context.Context, body domain.RentOrReturnRequestPayload) (domain.BikeDTO, error) {
+	ctx, span := tracing.Start(ctx, "BikeUseCase.Rent")
+	defer span.End()
+	bike, err := u.rent(ctx, body)
+	u.recordRent(err)
+	return bike, err
+}
+
+func (u *useCaseImpl) rent(ctx context.Context, body domain.RentOrReturnRequestPayload) (domain.BikeDTO, error) {
 	u.logger.Info(fmt.Sprintf("[BikeUseCase.Rent] user %d is renting bike %d", body.UserID, body.ID))
 	isRented, err := u.checkRented(ctx, body.UserID)
 	if err != nil {
@@ -129,35 +202,111 @@ func (u *useCaseImpl) Rent(ctx context.Context, body domain.RentOrReturnRequestP
 		u.logger.Error(fmt.Sprintf("[BikeUseCase.Rent] fetch current bike %d failed", body.ID), err)
 		return domain.BikeDTO{}, apperrors.ErrInternalServerError
 	}
-	if currentBike.IsRented() {
-		u.logger.Info("[BikeUseCase.Rent] cannot rent because bike is rented")
-		return domain.BikeDTO{}, apperrors.ErrBikeRented
+	if body.Version != 0 && body.Version != currentBike.Version {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Rent] bike %d is at version %d, the user read version %d", body.ID, currentBike.Version, body.Version))
+		return domain.BikeDTO{}, apperrors.ErrBikeVersionConflict
+	}
+	nextStatus, err := currentBike.Next(domain.BikeEventRent, body.UserID)
+	if err != nil {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Rent] %s", err.Error()))
+		return domain.BikeDTO{}, errors.Unwrap(err)
+	}
+	if currentBike.BatteryBelow(u.minRentBattery) {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Rent] cannot rent because bike %d is charged %d%%", body.ID, currentBike.Battery.Int64))
+		return domain.BikeDTO{}, apperrors.ErrBatteryTooLow
 	}
 	updatedBike := &domain.Bike{
 		ID:     currentBike.ID,
 		Name:   currentBike.Name,
+		Type:   currentBike.Type,
+		Code:   currentBike.Code,
 		Lat:    currentBike.Lat,
 		Long:   currentBike.Long,
-		Status: domain.BikeStatusRented,
+		Status: nextStatus,
 		UserID: sql.NullInt64{
 			Valid: true,
 			Int64: body.UserID,
 		},
+		Battery:    currentBike.Battery,
+		LockState:  currentBike.LockState,
+		LastSeenAt: currentBike.LastSeenAt,
+		Version:    currentBike.Version,
 	}
-	err = u.repository.UpdateStatusAndUserID(ctx, updatedBike)
+	// a rented bike leaves its dock, which frees the dock for other returns
+	updated, err := u.repository.UpdateStatusAndLocation(ctx, updatedBike)
 	if err != nil {
 		u.logger.Error(fmt.Sprintf("[BikeUseCase.Rent] user %d rent bike %d failed", body.UserID, body.ID), err)
 		return domain.BikeDTO{}, apperrors.ErrInternalServerError
 	}
+	if !updated {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Rent] bike %d changed since version %d was read", body.ID, currentBike.Version))
+		return domain.BikeDTO{}, apperrors.ErrBikeVersionConflict
+	}
+	if err := u.lockController.Unlock(ctx, currentBike.ID); err != nil {
+		u.logger.Error(fmt.Sprintf("[BikeUseCase.Rent] lock of bike %d did not confirm the unlock, rolling back", body.ID), err)
+		u.rollback(ctx, currentBike, updatedBike.Version)
+		return domain.BikeDTO{}, apperrors.ErrLockNotConfirmed
+	}
 	u.logger.Info(fmt.Sprintf("[BikeUseCase.Rent] user %d rent bike %d success", body.UserID, body.ID))
 	result := updatedBike.ToDTO()
 	if currentUser != nil {
 		result.NameOfRenter = currentUser.Name
 	}
+	u.audit(ctx, domain.AuditRecord{
+		ActorID:    body.UserID,
+		Action:     domain.AuditActionBikeRent,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   body.ID,
+		Before:     currentBike.ToDTO(),
+		After:      result,
+	})
 	return result, nil
 }
 
+// RentByCode rents the bike whose label carries code, so riders never need to know the internal id.
+func (u *useCaseImpl) RentByCode(ctx context.Context, code string, userID int64, version int64) (domain.BikeDTO, error) {
+	ctx, span := tracing.Start(ctx, "BikeUseCase.RentByCode")
+	defer span.End()
+	bike, err := u.rentByCode(ctx, code, userID, version)
+	u.recordRent(err)
+	return bike, err
+}
+
+func (u *useCaseImpl) rentByCode(ctx context.Context, code string, userID int64, version int64) (domain.BikeDTO, error) {
+	normalized, ok := domain.NormalizeBikeCode(code)
+	if !ok {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.RentByCode] invalid bike code %q", code))
+		return domain.BikeDTO{}, apperrors.ErrInvalidBikeCode
+	}
+	currentBike, err := u.repository.GetByCode(ctx, normalized)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.RentByCode] cannot find bike with code %s", normalized))
+		return domain.BikeDTO{}, apperrors.ErrBikeNotFound
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[BikeUseCase.RentByCode] fetch bike with code %s failed", normalized), err)
+		return domain.BikeDTO{}, apperrors.ErrInternalServerError
+	}
+	return u.rent(ctx, domain.RentOrReturnRequestPayload{
+		ID:      currentBike.ID,
+		UserID:  userID,
+		Version: version,
+	})
+}
+
 func (u *useCaseImpl) Return(ctx context.Context, body domain.RentOrReturnRequestPayload) (domain.BikeDTO, error) {
+	ctx, span := tracing.Start(ctx, "BikeUseCase.Return")
+	defer span.End()
+	bike, err := u.returnBike(ctx, body)
+	u.recordReturn(err)
+	return bike, err
+}
+
+func (u *useCaseImpl) returnBike(ctx context.Context, body domain.RentOrReturnRequestPayload) (domain.BikeDTO, error) {
+	if err := u.validateDropOff(body); err != nil {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] invalid drop-off of bike %d", body.ID))
+		return domain.BikeDTO{}, err
+	}
 	currentBike, err := u.repository.GetByID(ctx, body.ID)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] cannot find bike %d", body.ID))
@@ -168,32 +317,170 @@ func (u *useCaseImpl) Return(ctx context.Context, body domain.RentOrReturnReques
 		return domain.BikeDTO{}, apperrors.ErrInternalServerError
 	}
 	u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] user %d is returning bike %d", currentBike.UserID.Int64, body.ID))
-	if currentBike.IsAvailable() {
-		u.logger.Info("[BikeUseCase.Return] cannot return because bike is available")
-		return domain.BikeDTO{}, apperrors.ErrBikeAvailable
+	if body.Version != 0 && body.Version != currentBike.Version {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] bike %d is at version %d, the user read version %d", body.ID, currentBike.Version, body.Version))
+		return domain.BikeDTO{}, apperrors.ErrBikeVersionConflict
 	}
-	if body.UserID != currentBike.UserID.Int64 {
-		u.logger.Info("[BikeUseCase.Return] cannot return because bike is not yours")
-		return domain.BikeDTO{}, apperrors.ErrBikeNotYours
+	if _, err := currentBike.Next(domain.BikeEventReturn, body.UserID); err != nil {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] %s", err.Error()))
+		return domain.BikeDTO{}, errors.Unwrap(err)
+	}
+	lat, long, stationID := body.Lat, body.Long, sql.NullInt64{}
+	if u.returnMode == domain.ReturnModeStation {
+		station, err := u.findStation(ctx, body.StationID)
+		if err != nil {
+			return domain.BikeDTO{}, err
+		}
+		lat, long = station.Lat, station.Long
+		stationID = sql.NullInt64{Valid: true, Int64: station.ID}
+	}
+	if currentBike.HasLocation() {
+		distance := domain.DistanceInMeters(*currentBike.Lat, *currentBike.Long, *lat, *long)
+		if distance > maxReturnDistanceMeters {
+			u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] drop-off of bike %d is %.0fm away from its last position", body.ID, distance))
+			return domain.BikeDTO{}, apperrors.ErrReturnTooFar
+		}
+	}
+	surcharge, err := u.zoneChecker.CheckReturn(ctx, *lat, *long)
+	if err != nil {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] drop-off of bike %d is not allowed in its zone", body.ID))
+		return domain.BikeDTO{}, err
+	}
+	confirmedTickets, err := u.ticketRepository.CountConfirmedByBikeID(ctx, body.ID)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[BikeUseCase.Return] count confirmed tickets of bike %d failed", body.ID), err)
+		return domain.BikeDTO{}, apperrors.ErrInternalServerError
+	}
+	event := domain.BikeEventReturn
+	if confirmedTickets > 0 {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] bike %d has confirmed problems and goes to maintenance", body.ID))
+		event = domain.BikeEventReturnForRepair
+	}
+	nextStatus, err := currentBike.Next(event, body.UserID)
+	if err != nil {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] %s", err.Error()))
+		return domain.BikeDTO{}, errors.Unwrap(err)
 	}
-
 	updatedBike := &domain.Bike{
 		ID:     currentBike.ID,
 		Name:   currentBike.Name,
-		Lat:    currentBike.Lat,
-		Long:   currentBike.Long,
-		Status: domain.BikeStatusAvailable,
+		Type:   currentBike.Type,
+		Code:   currentBike.Code,
+		Lat:    lat,
+		Long:   long,
+		Status: nextStatus,
 		UserID: sql.NullInt64{
 			Valid: false,
 			Int64: 0,
 		},
+		StationID:  stationID,
+		Battery:    currentBike.Battery,
+		LockState:  currentBike.LockState,
+		LastSeenAt: currentBike.LastSeenAt,
+		Version:    currentBike.Version,
+	}
+	updated, err := u.writeReturn(ctx, updatedBike)
+	if errors.Is(err, errNoFreeDock) {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] station %d is full", stationID.Int64))
+		return domain.BikeDTO{}, apperrors.ErrStationFull
 	}
-	err = u.repository.UpdateStatusAndUserID(ctx, updatedBike)
 	if err != nil {
 		u.logger.Error(fmt.Sprintf("[BikeUseCase.Return] user %d is return bike %d failed", currentBike.UserID.Int64, body.ID), err)
 		return domain.BikeDTO{}, apperrors.ErrInternalServerError
 	}
+	if !updated {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] bike %d changed since version %d was read", body.ID, currentBike.Version))
+		return domain.BikeDTO{}, apperrors.ErrBikeVersionConflict
+	}
+	if err := u.lockController.Lock(ctx, currentBike.ID); err != nil {
+		u.logger.Error(fmt.Sprintf("[BikeUseCase.Return] lock of bike %d did not confirm the lock, rolling back", body.ID), err)
+		u.rollback(ctx, currentBike, updatedBike.Version)
+		return domain.BikeDTO{}, apperrors.ErrLockNotConfirmed
+	}
 	u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] user %d is return bike %d failed", currentBike.UserID.Int64, body.ID))
 	result := updatedBike.ToDTO()
+	if surcharge.IsPositive() {
+		result.Surcharge = surcharge.Mul(currentBike.Type.Attributes().PriceMultiplier).StringFixed(2)
+	}
+	u.audit(ctx, domain.AuditRecord{
+		ActorID:    body.UserID,
+		Action:     domain.AuditActionBikeReturn,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   body.ID,
+		Before:     currentBike.ToDTO(),
+		After:      result,
+	})
 	return result, nil
 }
+
+func (u *useCaseImpl) validateDropOff(body domain.RentOrReturnRequestPayload) error {
+	if u.returnMode == domain.ReturnModeStation {
+		if body.StationID <= 0 {
+			return apperrors.ErrStationRequired
+		}
+		return nil
+	}
+	return domain.ValidateCoordinates(body.Lat, body.Long)
+}
+
+// findStation looks up the station a bike is returned to, whether it has a free dock is checked with the write.
+func (u *useCaseImpl) findStation(ctx context.Context, stationID int64) (*domain.Station, error) {
+	station, err := u.stationRepository.GetByID(ctx, stationID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info(fmt.Sprintf("[BikeUseCase.Return] cannot find station %d", stationID))
+		return nil, apperrors.ErrStationNotFound
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[BikeUseCase.Return] fetch station %d failed", stationID), err)
+		return nil, apperrors.ErrInternalServerError
+	}
+	return station, nil
+}
+
+func (u *useCaseImpl) writeReturn(ctx context.Context, bike *domain.Bike) (bool, error) {
+	if bike.StationID.Valid {
+		return u.repository.UpdateStatusAndDock(ctx, bike)
+	}
+	return u.repository.UpdateStatusAndLocation(ctx, bike)
+}
+
+// rollback writes back the bike as it was read when its lock did not follow a rent or return, on top of the version
+// the rent or return wrote. A lock that did act but whose acknowledgement got lost reports its real state through telemetry.
+// A cancelled request is the usual reason the lock failed, so the write does not stop with ctx.
+func (u *useCaseImpl) rollback(ctx context.Context, bike *domain.Bike, version int64) {
+	ctx, cancel := domain.Detach(ctx, rollbackTimeout)
+	defer cancel()
+	restored := *bike
+	restored.Version = version
+	updated, err := u.repository.UpdateStatusAndLocation(ctx, &restored)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[BikeUseCase.rollback] restore bike %d failed", bike.ID), err)
+		return
+	}
+	if !updated {
+		u.logger.Error(fmt.Sprintf("[BikeUseCase.rollback] bike %d changed again since version %d, not restored", bike.ID, version), apperrors.ErrBikeVersionConflict)
+	}
+}
+
+// audit never fails the caller because the bike row has already been written.
+func (u *useCaseImpl) audit(ctx context.Context, record domain.AuditRecord) {
+	if err := u.auditor.Record(ctx, record); err != nil {
+		u.logger.Error(fmt.Sprintf("[BikeUseCase.audit] record %s on bike %d failed", record.Action, record.TargetID), err)
+	}
+}
+
+func (u *useCaseImpl) recordRent(err error) {
+	if err != nil {
+		u.metrics.RentalFailed(metricsActionRent, err)
+		return
+	}
+	u.metrics.RentalStarted()
+}
+
+func (u *useCaseImpl) recordReturn(err error) {
+	if err != nil {
+		u.metrics.RentalFailed(metricsActionReturn, err)
+		return
+	}
+	u.metrics.RentalEnded()
+}
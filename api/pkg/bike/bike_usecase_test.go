@@ -3,6 +3,7 @@ package bike
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
 	"time"
 
@@ -21,6 +22,12 @@ type BikeUseCaseTestSuite struct {
 	mockRepository     *mocks.IRepository
 	mockUserRepository *mocks.IUserRepository
 	mockLogger         *mocks.ILogger
+	mockTicketRepo     *mocks.ITicketRepository
+	mockStationRepo    *mocks.IStationRepository
+	mockZoneChecker    *mocks.IZoneChecker
+	mockLock           *mocks.ILockController
+	mockAuditor        *mocks.IAuditor
+	mockMetrics        *mocks.IMetrics
 	useCaseImpl        *useCaseImpl
 }
 
@@ -33,9 +40,30 @@ func (s *BikeUseCaseTestSuite) SetupTest() {
 	s.mockUserRepository = mockUserRepository
 	s.mockLogger.On("Info", mock.Anything, mock.Anything).Return()
 	s.mockLogger.On("Error", mock.Anything, mock.Anything).Return()
-	useCase := NewUseCase(mockLogger, mockRepository, mockUserRepository)
+	mockAuditor := &mocks.IAuditor{}
+	s.mockAuditor = mockAuditor
+	mockTicketRepo := &mocks.ITicketRepository{}
+	s.mockTicketRepo = mockTicketRepo
+	mockStationRepo := &mocks.IStationRepository{}
+	s.mockStationRepo = mockStationRepo
+	mockZoneChecker := &mocks.IZoneChecker{}
+	s.mockZoneChecker = mockZoneChecker
+	mockLock := &mocks.ILockController{}
+	s.mockLock = mockLock
+	mockMetrics := &mocks.IMetrics{}
+	mockMetrics.On("RentalStarted").Return()
+	mockMetrics.On("RentalEnded").Return()
+	mockMetrics.On("RentalFailed", mock.Anything, mock.Anything).Return()
+	s.mockMetrics = mockMetrics
+	useCase := NewUseCase(mockLogger, mockRepository, mockUserRepository, mockTicketRepo, mockStationRepo, mockZoneChecker, mockLock, mockAuditor, mockMetrics, domain.ReturnModeFreeFloating, 20)
 	s.useCaseImpl = useCase
 }
+
+var (
+	mockDropOffLat  = decimal.NewFromFloat(50.119504)
+	mockDropOffLong = decimal.NewFromFloat(8.638137)
+)
+
 func TestBikeUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(BikeUseCaseTestSuite))
 }
@@ -121,9 +149,9 @@ func (s *BikeUseCaseTestSuite) TestGetAllBike_Success() {
 			},
 		}
 	)
-	s.mockRepository.On("GetList", mockContext).Return(&mockBike, nil)
+	s.mockRepository.On("GetList", mockContext, domain.BikeFilter{}).Return(&mockBike, nil)
 	s.mockUserRepository.On("GetListByIDs", mockContext, []int64{1}).Return(&mockUserResult, nil)
-	actual, err := s.useCaseImpl.GetAllBike(mockContext)
+	actual, err := s.useCaseImpl.GetAllBike(mockContext, domain.BikeFilter{})
 	s.Equal(mockResult, actual)
 	s.Nil(err)
 }
@@ -197,9 +225,9 @@ func (s *BikeUseCaseTestSuite) TestGetAllBike_FetchUserListEmpty() {
 			},
 		}
 	)
-	s.mockRepository.On("GetList", mockContext).Return(&mockBike, nil)
+	s.mockRepository.On("GetList", mockContext, domain.BikeFilter{}).Return(&mockBike, nil)
 	s.mockUserRepository.On("GetListByIDs", mockContext, []int64{1}).Return(nil, gorm.ErrRecordNotFound)
-	actual, err := s.useCaseImpl.GetAllBike(mockContext)
+	actual, err := s.useCaseImpl.GetAllBike(mockContext, domain.BikeFilter{})
 	s.Equal(mockResult, actual)
 	s.Nil(err)
 }
@@ -244,9 +272,9 @@ func (s *BikeUseCaseTestSuite) TestGetAllBike_CannotFetchUserList() {
 			},
 		}
 	)
-	s.mockRepository.On("GetList", mockContext).Return(&mockBike, nil)
+	s.mockRepository.On("GetList", mockContext, domain.BikeFilter{}).Return(&mockBike, nil)
 	s.mockUserRepository.On("GetListByIDs", mockContext, []int64{1}).Return(nil, gorm.ErrDryRunModeUnsupported)
-	actual, err := s.useCaseImpl.GetAllBike(mockContext)
+	actual, err := s.useCaseImpl.GetAllBike(mockContext, domain.BikeFilter{})
 	s.Equal([]domain.BikeDTO{}, actual)
 	s.Error(apperrors.ErrInternalServerError, err)
 }
@@ -256,8 +284,8 @@ func (s *BikeUseCaseTestSuite) TestGetAllBike_RecordNotFound() {
 		mockContext = context.TODO()
 		mockResult  = []domain.BikeDTO{}
 	)
-	s.mockRepository.On("GetList", mockContext).Return(nil, gorm.ErrRecordNotFound)
-	actual, err := s.useCaseImpl.GetAllBike(mockContext)
+	s.mockRepository.On("GetList", mockContext, domain.BikeFilter{}).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.GetAllBike(mockContext, domain.BikeFilter{})
 	s.Equal(mockResult, actual)
 	s.Nil(err)
 }
@@ -267,8 +295,8 @@ func (s *BikeUseCaseTestSuite) TestGetAllBike_InternalServerError() {
 		mockContext = context.TODO()
 		mockResult  = []domain.BikeDTO{}
 	)
-	s.mockRepository.On("GetList", mockContext).Return(nil, gorm.ErrInvalidData)
-	actual, err := s.useCaseImpl.GetAllBike(mockContext)
+	s.mockRepository.On("GetList", mockContext, domain.BikeFilter{}).Return(nil, gorm.ErrInvalidData)
+	actual, err := s.useCaseImpl.GetAllBike(mockContext, domain.BikeFilter{})
 	s.Equal(mockResult, actual)
 	s.Equal(apperrors.ErrInternalServerError, err)
 }
@@ -329,10 +357,22 @@ func (s *BikeUseCaseTestSuite) TestRent_Success() {
 	s.mockRepository.On("CountByUserID", mockContext, mockInput.UserID).Return(int64(0), nil)
 	s.mockUserRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockUserResult, nil)
 	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
-	s.mockRepository.On("UpdateStatusAndUserID", mockContext, &mockUpdateInput).Return(nil)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockUpdateInput).Return(true, nil)
+	s.mockLock.On("Unlock", mockContext, int64(1)).Return(nil)
+	s.mockAuditor.On("Record", mockContext, domain.AuditRecord{
+		ActorID:    mockInput.UserID,
+		Action:     domain.AuditActionBikeRent,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   mockInput.ID,
+		Before:     mockExistRecord.ToDTO(),
+		After:      expected,
+	}).Return(nil)
 	actual, err := s.useCaseImpl.Rent(mockContext, mockInput)
 	s.Equal(expected, actual)
 	s.Nil(err)
+	s.mockAuditor.AssertExpectations(s.T())
+	s.mockMetrics.AssertCalled(s.T(), "RentalStarted")
+	s.mockMetrics.AssertNotCalled(s.T(), "RentalFailed", mock.Anything, mock.Anything)
 }
 
 func (s *BikeUseCaseTestSuite) TestRent_FailedByAlreadyRented() {
@@ -347,6 +387,8 @@ func (s *BikeUseCaseTestSuite) TestRent_FailedByAlreadyRented() {
 	actual, err := s.useCaseImpl.Rent(mockContext, mockInput)
 	s.Equal(domain.BikeDTO{}, actual)
 	s.Equal(apperrors.ErrUserHasBikeAlready, err)
+	s.mockMetrics.AssertCalled(s.T(), "RentalFailed", "rent", apperrors.ErrUserHasBikeAlready)
+	s.mockMetrics.AssertNotCalled(s.T(), "RentalStarted")
 }
 
 func (s *BikeUseCaseTestSuite) TestRent_FailedByCountByUserID() {
@@ -532,7 +574,7 @@ func (s *BikeUseCaseTestSuite) TestRent_InternalServerErrorWhenUpdate() {
 	s.mockRepository.On("CountByUserID", mockContext, mockInput.UserID).Return(int64(0), nil)
 	s.mockUserRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockUserResult, nil)
 	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
-	s.mockRepository.On("UpdateStatusAndUserID", mockContext, &mockUpdateInput).Return(gorm.ErrInvalidData)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockUpdateInput).Return(false, gorm.ErrInvalidData)
 	actual, err := s.useCaseImpl.Rent(mockContext, mockInput)
 	s.Equal(domain.BikeDTO{}, actual)
 	s.Equal(apperrors.ErrInternalServerError, err)
@@ -552,6 +594,8 @@ func (s *BikeUseCaseTestSuite) TestReturn_Success() {
 		mockInput   = domain.RentOrReturnRequestPayload{
 			ID:     1,
 			UserID: 1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
 		}
 		lat             = decimal.NewFromFloat(50.119504)
 		long            = decimal.NewFromFloat(8.638137)
@@ -573,7 +617,58 @@ func (s *BikeUseCaseTestSuite) TestReturn_Success() {
 		}
 	)
 	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
-	s.mockRepository.On("UpdateStatusAndUserID", mockContext, &mockResult).Return(nil)
+	s.mockZoneChecker.On("CheckReturn", mockContext, mock.Anything, mock.Anything).Return(decimal.Zero, nil)
+	s.mockTicketRepo.On("CountConfirmedByBikeID", mockContext, mockInput.ID).Return(int64(0), nil)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockResult).Return(true, nil)
+	s.mockLock.On("Lock", mockContext, int64(1)).Return(nil)
+	s.mockAuditor.On("Record", mockContext, domain.AuditRecord{
+		ActorID:    mockInput.UserID,
+		Action:     domain.AuditActionBikeReturn,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   mockInput.ID,
+		Before:     mockExistRecord.ToDTO(),
+		After:      mockResult.ToDTO(),
+	}).Return(nil)
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal(mockResult.ToDTO(), actual)
+	s.Nil(err)
+	s.mockAuditor.AssertExpectations(s.T())
+	s.mockMetrics.AssertCalled(s.T(), "RentalEnded")
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_SuccessWhenAuditFailed() {
+	var (
+		mockContext = context.TODO()
+		mockInput   = domain.RentOrReturnRequestPayload{
+			ID:     1,
+			UserID: 1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
+		}
+		lat             = decimal.NewFromFloat(50.119504)
+		long            = decimal.NewFromFloat(8.638137)
+		mockExistRecord = domain.Bike{
+			ID:     1,
+			Lat:    &lat,
+			Long:   &long,
+			Name:   "testName",
+			Status: domain.BikeStatusRented,
+			UserID: sql.NullInt64{Valid: true, Int64: 1},
+		}
+		mockResult = domain.Bike{
+			ID:     1,
+			Lat:    &lat,
+			Long:   &long,
+			Name:   "testName",
+			Status: domain.BikeStatusAvailable,
+		}
+	)
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
+	s.mockZoneChecker.On("CheckReturn", mockContext, mock.Anything, mock.Anything).Return(decimal.Zero, nil)
+	s.mockTicketRepo.On("CountConfirmedByBikeID", mockContext, mockInput.ID).Return(int64(0), nil)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockResult).Return(true, nil)
+	s.mockLock.On("Lock", mockContext, int64(1)).Return(nil)
+	s.mockAuditor.On("Record", mockContext, mock.Anything).Return(apperrors.ErrInternalServerError)
 	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
 	s.Equal(mockResult.ToDTO(), actual)
 	s.Nil(err)
@@ -593,6 +688,8 @@ func (s *BikeUseCaseTestSuite) TestReturn_InternalServerErrorWhenUpdate() {
 		mockInput   = domain.RentOrReturnRequestPayload{
 			ID:     1,
 			UserID: 1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
 		}
 		lat             = decimal.NewFromFloat(50.119504)
 		long            = decimal.NewFromFloat(8.638137)
@@ -614,7 +711,9 @@ func (s *BikeUseCaseTestSuite) TestReturn_InternalServerErrorWhenUpdate() {
 		}
 	)
 	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
-	s.mockRepository.On("UpdateStatusAndUserID", mockContext, &mockResult).Return(gorm.ErrEmptySlice)
+	s.mockZoneChecker.On("CheckReturn", mockContext, mock.Anything, mock.Anything).Return(decimal.Zero, nil)
+	s.mockTicketRepo.On("CountConfirmedByBikeID", mockContext, mockInput.ID).Return(int64(0), nil)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockResult).Return(false, gorm.ErrEmptySlice)
 	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
 	s.Equal(domain.BikeDTO{}, actual)
 	s.Equal(apperrors.ErrInternalServerError, err)
@@ -626,6 +725,8 @@ func (s *BikeUseCaseTestSuite) TestReturn_InternalServerErrorWhenGetByID() {
 		mockInput   = domain.RentOrReturnRequestPayload{
 			ID:     1,
 			UserID: 1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
 		}
 	)
 	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(nil, gorm.ErrInvalidDB)
@@ -640,12 +741,16 @@ func (s *BikeUseCaseTestSuite) TestReturn_NotFoundBike() {
 		mockInput   = domain.RentOrReturnRequestPayload{
 			ID:     1,
 			UserID: 1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
 		}
 	)
 	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(nil, gorm.ErrRecordNotFound)
 	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
 	s.Equal(domain.BikeDTO{}, actual)
 	s.Equal(apperrors.ErrBikeNotFound, err)
+	s.mockMetrics.AssertCalled(s.T(), "RentalFailed", "return", apperrors.ErrBikeNotFound)
+	s.mockMetrics.AssertNotCalled(s.T(), "RentalEnded")
 }
 
 func (s *BikeUseCaseTestSuite) TestReturn_BikeNotAvailable() {
@@ -658,6 +763,8 @@ func (s *BikeUseCaseTestSuite) TestReturn_BikeNotAvailable() {
 		mockInput = domain.RentOrReturnRequestPayload{
 			ID:     1,
 			UserID: 1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
 		}
 		lat             = decimal.NewFromFloat(50.119504)
 		long            = decimal.NewFromFloat(8.638137)
@@ -686,6 +793,8 @@ func (s *BikeUseCaseTestSuite) TestReturn_BikeIsNotYours() {
 		mockInput   = domain.RentOrReturnRequestPayload{
 			ID:     1,
 			UserID: 2,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
 		}
 		lat             = decimal.NewFromFloat(50.119504)
 		long            = decimal.NewFromFloat(8.638137)
@@ -703,3 +812,754 @@ func (s *BikeUseCaseTestSuite) TestReturn_BikeIsNotYours() {
 	s.Equal(domain.BikeDTO{}, actual)
 	s.Equal(apperrors.ErrBikeNotYours, err)
 }
+
+func (s *BikeUseCaseTestSuite) TestRent_FailedByOutOfService() {
+	var (
+		mockContext = context.TODO()
+		mockInput   = domain.RentOrReturnRequestPayload{
+			ID:     1,
+			UserID: 1,
+		}
+		lat             = decimal.NewFromFloat(50.119504)
+		long            = decimal.NewFromFloat(8.638137)
+		mockExistRecord = domain.Bike{
+			ID:     1,
+			Lat:    &lat,
+			Long:   &long,
+			Name:   "testName",
+			Status: domain.BikeStatusMaintenance,
+		}
+	)
+	s.mockRepository.On("CountByUserID", mockContext, mockInput.UserID).Return(int64(0), nil)
+	s.mockUserRepository.On("GetByID", mockContext, mockInput.UserID).Return(&domain.User{ID: 1}, nil)
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
+	actual, err := s.useCaseImpl.Rent(mockContext, mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrBikeOutOfService, err)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_SuccessToMaintenance() {
+	var (
+		mockContext = context.TODO()
+		mockInput   = domain.RentOrReturnRequestPayload{
+			ID:     1,
+			UserID: 1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
+		}
+		lat             = decimal.NewFromFloat(50.119504)
+		long            = decimal.NewFromFloat(8.638137)
+		mockExistRecord = domain.Bike{
+			ID:     1,
+			Lat:    &lat,
+			Long:   &long,
+			Name:   "testName",
+			Status: domain.BikeStatusRented,
+			UserID: sql.NullInt64{Valid: true, Int64: 1},
+		}
+		mockResult = domain.Bike{
+			ID:     1,
+			Lat:    &lat,
+			Long:   &long,
+			Name:   "testName",
+			Status: domain.BikeStatusMaintenance,
+		}
+	)
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
+	s.mockZoneChecker.On("CheckReturn", mockContext, mock.Anything, mock.Anything).Return(decimal.Zero, nil)
+	s.mockTicketRepo.On("CountConfirmedByBikeID", mockContext, mockInput.ID).Return(int64(1), nil)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockResult).Return(true, nil)
+	s.mockLock.On("Lock", mockContext, int64(1)).Return(nil)
+	s.mockAuditor.On("Record", mockContext, mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal(mockResult.ToDTO(), actual)
+	s.Nil(err)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_InternalServerErrorWhenCountTickets() {
+	var (
+		mockContext = context.TODO()
+		mockInput   = domain.RentOrReturnRequestPayload{
+			ID:     1,
+			UserID: 1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
+		}
+		mockExistRecord = domain.Bike{
+			ID:     1,
+			Status: domain.BikeStatusRented,
+			UserID: sql.NullInt64{Valid: true, Int64: 1},
+		}
+	)
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
+	s.mockZoneChecker.On("CheckReturn", mockContext, mock.Anything, mock.Anything).Return(decimal.Zero, nil)
+	s.mockTicketRepo.On("CountConfirmedByBikeID", mockContext, mockInput.ID).Return(int64(0), gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_InvalidLocation() {
+	var (
+		mockContext = context.TODO()
+		outOfRange  = decimal.NewFromFloat(91)
+		tooPrecise  = decimal.NewFromFloat(8.6381371)
+	)
+	for _, input := range []domain.RentOrReturnRequestPayload{
+		{ID: 1, UserID: 1},
+		{ID: 1, UserID: 1, Lat: &mockDropOffLat},
+		{ID: 1, UserID: 1, Lat: &outOfRange, Long: &mockDropOffLong},
+		{ID: 1, UserID: 1, Lat: &mockDropOffLat, Long: &tooPrecise},
+	} {
+		actual, err := s.useCaseImpl.Return(mockContext, input)
+		s.Equal(domain.BikeDTO{}, actual)
+		s.Equal(apperrors.ErrInvalidLocation, err)
+	}
+	s.mockRepository.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_TooFarFromLastPosition() {
+	var (
+		mockContext = context.TODO()
+		farLat      = decimal.NewFromFloat(52.520008)
+		farLong     = decimal.NewFromFloat(13.404954)
+		mockInput   = domain.RentOrReturnRequestPayload{
+			ID:     1,
+			UserID: 1,
+			Lat:    &farLat,
+			Long:   &farLong,
+		}
+		lat             = decimal.NewFromFloat(50.119504)
+		long            = decimal.NewFromFloat(8.638137)
+		mockExistRecord = domain.Bike{
+			ID:     1,
+			Lat:    &lat,
+			Long:   &long,
+			Name:   "testName",
+			Status: domain.BikeStatusRented,
+			UserID: sql.NullInt64{Valid: true, Int64: 1},
+		}
+	)
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrReturnTooFar, err)
+	s.mockRepository.AssertNotCalled(s.T(), "UpdateStatusAndLocation", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_SuccessWithoutLastPosition() {
+	var (
+		mockContext = context.TODO()
+		mockInput   = domain.RentOrReturnRequestPayload{
+			ID:     1,
+			UserID: 1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
+		}
+		mockExistRecord = domain.Bike{
+			ID:     1,
+			Name:   "testName",
+			Status: domain.BikeStatusRented,
+			UserID: sql.NullInt64{Valid: true, Int64: 1},
+		}
+		mockResult = domain.Bike{
+			ID:     1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
+			Name:   "testName",
+			Status: domain.BikeStatusAvailable,
+		}
+	)
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
+	s.mockZoneChecker.On("CheckReturn", mockContext, mock.Anything, mock.Anything).Return(decimal.Zero, nil)
+	s.mockTicketRepo.On("CountConfirmedByBikeID", mockContext, mockInput.ID).Return(int64(0), nil)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockResult).Return(true, nil)
+	s.mockLock.On("Lock", mockContext, int64(1)).Return(nil)
+	s.mockAuditor.On("Record", mockContext, mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal(mockResult.ToDTO(), actual)
+	s.Nil(err)
+}
+
+func (s *BikeUseCaseTestSuite) stationMockInput() domain.RentOrReturnRequestPayload {
+	s.useCaseImpl.returnMode = domain.ReturnModeStation
+	return domain.RentOrReturnRequestPayload{
+		ID:        1,
+		UserID:    1,
+		StationID: 3,
+	}
+}
+
+func (s *BikeUseCaseTestSuite) rentedBike() *domain.Bike {
+	lat := decimal.NewFromFloat(50.119504)
+	long := decimal.NewFromFloat(8.638137)
+	return &domain.Bike{
+		ID:     1,
+		Lat:    &lat,
+		Long:   &long,
+		Name:   "testName",
+		Status: domain.BikeStatusRented,
+		UserID: sql.NullInt64{Valid: true, Int64: 1},
+	}
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_SuccessToStation() {
+	var (
+		mockContext = context.TODO()
+		mockInput   = s.stationMockInput()
+		stationLat  = decimal.NewFromFloat(50.107145)
+		stationLong = decimal.NewFromFloat(8.663789)
+		mockStation = domain.Station{
+			ID:       3,
+			Name:     "Hauptbahnhof",
+			Lat:      &stationLat,
+			Long:     &stationLong,
+			Capacity: 20,
+		}
+		mockResult = domain.Bike{
+			ID:        1,
+			Lat:       &stationLat,
+			Long:      &stationLong,
+			Name:      "testName",
+			Status:    domain.BikeStatusAvailable,
+			StationID: sql.NullInt64{Valid: true, Int64: 3},
+		}
+	)
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(s.rentedBike(), nil)
+	s.mockStationRepo.On("GetByID", mockContext, mockInput.StationID).Return(&mockStation, nil)
+	s.mockZoneChecker.On("CheckReturn", mockContext, mock.Anything, mock.Anything).Return(decimal.Zero, nil)
+	s.mockTicketRepo.On("CountConfirmedByBikeID", mockContext, mockInput.ID).Return(int64(0), nil)
+	s.mockRepository.On("UpdateStatusAndDock", mockContext, &mockResult).Return(true, nil)
+	s.mockLock.On("Lock", mockContext, int64(1)).Return(nil)
+	s.mockAuditor.On("Record", mockContext, mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal(mockResult.ToDTO(), actual)
+	s.Equal(int64(3), actual.StationID)
+	s.Nil(err)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_StationRequired() {
+	mockInput := s.stationMockInput()
+	mockInput.StationID = 0
+	actual, err := s.useCaseImpl.Return(context.TODO(), mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrStationRequired, err)
+	s.mockRepository.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_StationNotFound() {
+	mockContext := context.TODO()
+	mockInput := s.stationMockInput()
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(s.rentedBike(), nil)
+	s.mockStationRepo.On("GetByID", mockContext, mockInput.StationID).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrStationNotFound, err)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_InternalServerErrorWhenGetStation() {
+	mockContext := context.TODO()
+	mockInput := s.stationMockInput()
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(s.rentedBike(), nil)
+	s.mockStationRepo.On("GetByID", mockContext, mockInput.StationID).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_StationFull() {
+	mockContext := context.TODO()
+	mockInput := s.stationMockInput()
+	stationLat := decimal.NewFromFloat(50.119504)
+	stationLong := decimal.NewFromFloat(8.638137)
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(s.rentedBike(), nil)
+	s.mockStationRepo.On("GetByID", mockContext, mockInput.StationID).Return(&domain.Station{ID: 3, Lat: &stationLat, Long: &stationLong, Capacity: 20}, nil)
+	s.mockZoneChecker.On("CheckReturn", mockContext, mock.Anything, mock.Anything).Return(decimal.Zero, nil)
+	s.mockTicketRepo.On("CountConfirmedByBikeID", mockContext, mockInput.ID).Return(int64(0), nil)
+	s.mockRepository.On("UpdateStatusAndDock", mockContext, mock.Anything).Return(false, errNoFreeDock)
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrStationFull, err)
+	s.mockLock.AssertNotCalled(s.T(), "Lock", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_RejectedByZone() {
+	mockContext := context.TODO()
+	mockInput := domain.RentOrReturnRequestPayload{
+		ID:     1,
+		UserID: 1,
+		Lat:    &mockDropOffLat,
+		Long:   &mockDropOffLong,
+	}
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(s.rentedBike(), nil)
+	s.mockZoneChecker.On("CheckReturn", mockContext, mockDropOffLat, mockDropOffLong).Return(decimal.Zero, apperrors.ErrInNoParkingZone)
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrInNoParkingZone, err)
+	s.mockRepository.AssertNotCalled(s.T(), "UpdateStatusAndLocation", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_SuccessWithSurcharge() {
+	var (
+		mockContext = context.TODO()
+		mockInput   = domain.RentOrReturnRequestPayload{
+			ID:     1,
+			UserID: 1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
+		}
+		mockResult = domain.Bike{
+			ID:     1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
+			Name:   "testName",
+			Status: domain.BikeStatusAvailable,
+		}
+	)
+	expected := mockResult.ToDTO()
+	expected.Surcharge = "5.00"
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(s.rentedBike(), nil)
+	s.mockZoneChecker.On("CheckReturn", mockContext, mockDropOffLat, mockDropOffLong).Return(decimal.NewFromInt(5), nil)
+	s.mockTicketRepo.On("CountConfirmedByBikeID", mockContext, mockInput.ID).Return(int64(0), nil)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockResult).Return(true, nil)
+	s.mockLock.On("Lock", mockContext, int64(1)).Return(nil)
+	s.mockAuditor.On("Record", mockContext, mock.MatchedBy(func(record domain.AuditRecord) bool {
+		return record.After == expected
+	})).Return(nil)
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal(expected, actual)
+	s.Nil(err)
+	s.mockAuditor.AssertExpectations(s.T())
+}
+
+func (s *BikeUseCaseTestSuite) TestRent_LockNotConfirmedRollsBack() {
+	var (
+		mockContext = context.TODO()
+		mockInput   = domain.RentOrReturnRequestPayload{
+			ID:     1,
+			UserID: 1,
+		}
+		mockExistRecord = domain.Bike{
+			ID:        1,
+			Name:      "testName",
+			Status:    domain.BikeStatusAvailable,
+			StationID: sql.NullInt64{Valid: true, Int64: 2},
+		}
+		mockUpdateInput = domain.Bike{
+			ID:     1,
+			Name:   "testName",
+			Status: domain.BikeStatusRented,
+			UserID: sql.NullInt64{Valid: true, Int64: 1},
+		}
+	)
+	s.mockRepository.On("CountByUserID", mockContext, mockInput.UserID).Return(int64(0), nil)
+	s.mockUserRepository.On("GetByID", mockContext, mockInput.UserID).Return(&domain.User{ID: 1, Name: "testName"}, nil)
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockUpdateInput).Return(true, nil).Once()
+	s.mockLock.On("Unlock", mockContext, int64(1)).Return(errors.New("lock did not confirm the command"))
+	s.mockRepository.On("UpdateStatusAndLocation", mock.Anything, &mockExistRecord).Return(true, nil).Once()
+	actual, err := s.useCaseImpl.Rent(mockContext, mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrLockNotConfirmed, err)
+	s.mockRepository.AssertExpectations(s.T())
+	s.mockAuditor.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_LockNotConfirmedRollsBack() {
+	var (
+		mockContext = context.TODO()
+		mockInput   = domain.RentOrReturnRequestPayload{
+			ID:     1,
+			UserID: 1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
+		}
+		mockExistRecord = s.rentedBike()
+		mockResult      = domain.Bike{
+			ID:     1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
+			Name:   "testName",
+			Status: domain.BikeStatusAvailable,
+		}
+	)
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(mockExistRecord, nil)
+	s.mockZoneChecker.On("CheckReturn", mockContext, mockDropOffLat, mockDropOffLong).Return(decimal.Zero, nil)
+	s.mockTicketRepo.On("CountConfirmedByBikeID", mockContext, mockInput.ID).Return(int64(0), nil)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockResult).Return(true, nil).Once()
+	s.mockLock.On("Lock", mockContext, int64(1)).Return(errors.New("lock rejected the command"))
+	s.mockRepository.On("UpdateStatusAndLocation", mock.Anything, mockExistRecord).Return(false, gorm.ErrInvalidDB).Once()
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrLockNotConfirmed, err)
+	s.mockRepository.AssertExpectations(s.T())
+	s.mockAuditor.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestGetAllBike_PassesFilter() {
+	minBattery := int64(50)
+	filter := domain.BikeFilter{Type: domain.BikeTypeEBike, MinBattery: &minBattery}
+	s.mockRepository.On("GetList", context.TODO(), filter).Return(&[]domain.Bike{}, nil)
+	actual, err := s.useCaseImpl.GetAllBike(context.TODO(), filter)
+	s.Equal([]domain.BikeDTO{}, actual)
+	s.Nil(err)
+	s.mockRepository.AssertExpectations(s.T())
+}
+
+func (s *BikeUseCaseTestSuite) TestGetAllBike_NoRenterSkipsUserLookup() {
+	s.mockRepository.On("GetList", context.TODO(), domain.BikeFilter{}).Return(&[]domain.Bike{
+		{ID: 1, Status: domain.BikeStatusAvailable},
+		{ID: 2, Status: domain.BikeStatusMaintenance},
+	}, nil)
+	actual, err := s.useCaseImpl.GetAllBike(context.TODO(), domain.BikeFilter{})
+	s.Nil(err)
+	s.Len(actual, 2)
+	s.mockUserRepository.AssertNotCalled(s.T(), "GetListByIDs", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestRent_FailedByLowBattery() {
+	var (
+		mockContext = context.TODO()
+		mockInput   = domain.RentOrReturnRequestPayload{
+			ID:     1,
+			UserID: 1,
+		}
+		mockExistRecord = domain.Bike{
+			ID:      1,
+			Type:    domain.BikeTypeEBike,
+			Status:  domain.BikeStatusAvailable,
+			Battery: sql.NullInt64{Valid: true, Int64: 19},
+		}
+	)
+	s.mockRepository.On("CountByUserID", mockContext, mockInput.UserID).Return(int64(0), nil)
+	s.mockUserRepository.On("GetByID", mockContext, mockInput.UserID).Return(&domain.User{ID: 1}, nil)
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
+	actual, err := s.useCaseImpl.Rent(mockContext, mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrBatteryTooLow, err)
+	s.mockRepository.AssertNotCalled(s.T(), "UpdateStatusAndLocation", mock.Anything, mock.Anything)
+	s.mockLock.AssertNotCalled(s.T(), "Unlock", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_SurchargeScaledByBikeType() {
+	var (
+		mockContext = context.TODO()
+		mockInput   = domain.RentOrReturnRequestPayload{
+			ID:     1,
+			UserID: 1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
+		}
+		mockExistRecord = s.rentedBike()
+		mockResult      = domain.Bike{
+			ID:     1,
+			Lat:    &mockDropOffLat,
+			Long:   &mockDropOffLong,
+			Name:   "testName",
+			Type:   domain.BikeTypeCargo,
+			Status: domain.BikeStatusAvailable,
+		}
+	)
+	mockExistRecord.Type = domain.BikeTypeCargo
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(mockExistRecord, nil)
+	s.mockZoneChecker.On("CheckReturn", mockContext, mockDropOffLat, mockDropOffLong).Return(decimal.RequireFromString("2.50"), nil)
+	s.mockTicketRepo.On("CountConfirmedByBikeID", mockContext, mockInput.ID).Return(int64(0), nil)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockResult).Return(true, nil)
+	s.mockLock.On("Lock", mockContext, int64(1)).Return(nil)
+	s.mockAuditor.On("Record", mockContext, mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.Return(mockContext, mockInput)
+	s.Equal("5.00", actual.Surcharge)
+	s.Equal(domain.BikeTypeCargo, actual.Type)
+	s.Nil(err)
+}
+
+func (s *BikeUseCaseTestSuite) TestRentByCode_Success() {
+	var (
+		mockContext     = context.TODO()
+		mockExistRecord = domain.Bike{
+			ID:     1,
+			Name:   "testName",
+			Code:   "7F3K9Q2M",
+			Status: domain.BikeStatusAvailable,
+		}
+		mockUpdateInput = domain.Bike{
+			ID:     1,
+			Name:   "testName",
+			Code:   "7F3K9Q2M",
+			Status: domain.BikeStatusRented,
+			UserID: sql.NullInt64{Valid: true, Int64: 1},
+		}
+		expected = domain.BikeDTO{
+			ID:           1,
+			Name:         "testName",
+			Code:         "7F3K9Q2M",
+			Status:       domain.BikeStatusRented,
+			UserID:       1,
+			NameOfRenter: "Bob",
+		}
+	)
+	s.mockRepository.On("GetByCode", mockContext, "7F3K9Q2M").Return(&mockExistRecord, nil)
+	s.mockRepository.On("CountByUserID", mockContext, int64(1)).Return(int64(0), nil)
+	s.mockUserRepository.On("GetByID", mockContext, int64(1)).Return(&domain.User{ID: 1, Name: "Bob"}, nil)
+	s.mockRepository.On("GetByID", mockContext, int64(1)).Return(&mockExistRecord, nil)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockUpdateInput).Return(true, nil)
+	s.mockLock.On("Unlock", mockContext, int64(1)).Return(nil)
+	s.mockAuditor.On("Record", mockContext, mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.RentByCode(mockContext, " 7f3k9q2m ", 1, 0)
+	s.Equal(expected, actual)
+	s.Nil(err)
+	s.mockRepository.AssertExpectations(s.T())
+	s.mockMetrics.AssertNumberOfCalls(s.T(), "RentalStarted", 1)
+}
+
+func (s *BikeUseCaseTestSuite) TestRentByCode_InvalidCode() {
+	actual, err := s.useCaseImpl.RentByCode(context.TODO(), "bad-code", 1, 0)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrInvalidBikeCode, err)
+	s.mockMetrics.AssertCalled(s.T(), "RentalFailed", "rent", apperrors.ErrInvalidBikeCode)
+	s.mockRepository.AssertNotCalled(s.T(), "GetByCode", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestRentByCode_NotFound() {
+	s.mockRepository.On("GetByCode", context.TODO(), "7F3K9Q2M").Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.RentByCode(context.TODO(), "7F3K9Q2M", 1, 0)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrBikeNotFound, err)
+	s.mockRepository.AssertNotCalled(s.T(), "CountByUserID", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestRentByCode_InternalServerError() {
+	s.mockRepository.On("GetByCode", context.TODO(), "7F3K9Q2M").Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.RentByCode(context.TODO(), "7F3K9Q2M", 1, 0)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *BikeUseCaseTestSuite) TestRent_SuccessWhenRentedWithoutRenter() {
+	var (
+		mockContext = context.TODO()
+		mockInput   = domain.RentOrReturnRequestPayload{
+			ID:     1,
+			UserID: 1,
+		}
+		mockExistRecord = domain.Bike{
+			ID:     1,
+			Name:   "testName",
+			Status: domain.BikeStatusRented,
+		}
+		mockUpdateInput = domain.Bike{
+			ID:     1,
+			Name:   "testName",
+			Status: domain.BikeStatusRented,
+			UserID: sql.NullInt64{Valid: true, Int64: 1},
+		}
+	)
+	s.mockRepository.On("CountByUserID", mockContext, mockInput.UserID).Return(int64(0), nil)
+	s.mockUserRepository.On("GetByID", mockContext, mockInput.UserID).Return(&domain.User{ID: 1, Name: "Bob"}, nil)
+	s.mockRepository.On("GetByID", mockContext, mockInput.ID).Return(&mockExistRecord, nil)
+	s.mockRepository.On("UpdateStatusAndLocation", mockContext, &mockUpdateInput).Return(true, nil)
+	s.mockLock.On("Unlock", mockContext, int64(1)).Return(nil)
+	s.mockAuditor.On("Record", mockContext, mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.Rent(mockContext, mockInput)
+	s.Nil(err)
+	s.Equal(domain.BikeStatusRented, actual.Status)
+	s.Equal(int64(1), actual.UserID)
+	s.mockRepository.AssertExpectations(s.T())
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_FailedWhenRetired() {
+	mockInput := domain.RentOrReturnRequestPayload{
+		ID:     1,
+		UserID: 1,
+		Lat:    &mockDropOffLat,
+		Long:   &mockDropOffLong,
+	}
+	s.mockRepository.On("GetByID", context.TODO(), mockInput.ID).Return(&domain.Bike{ID: 1, Status: domain.BikeStatusRetired}, nil)
+	actual, err := s.useCaseImpl.Return(context.TODO(), mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrBikeNotYours, err)
+	s.mockRepository.AssertNotCalled(s.T(), "UpdateStatusAndLocation", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) bumpVersion(args mock.Arguments) {
+	args.Get(1).(*domain.Bike).Version++
+}
+
+func (s *BikeUseCaseTestSuite) TestRent_SuccessReturnsNewVersion() {
+	mockInput := domain.RentOrReturnRequestPayload{ID: 1, UserID: 1, Version: 3}
+	s.mockRepository.On("CountByUserID", context.TODO(), mockInput.UserID).Return(int64(0), nil)
+	s.mockUserRepository.On("GetByID", context.TODO(), mockInput.UserID).Return(&domain.User{ID: 1, Name: "Bob"}, nil)
+	s.mockRepository.On("GetByID", context.TODO(), mockInput.ID).Return(&domain.Bike{ID: 1, Status: domain.BikeStatusAvailable, Version: 3}, nil)
+	s.mockRepository.On("UpdateStatusAndLocation", context.TODO(), mock.MatchedBy(func(bike *domain.Bike) bool {
+		return bike.Version == 3
+	})).Run(s.bumpVersion).Return(true, nil)
+	s.mockLock.On("Unlock", context.TODO(), int64(1)).Return(nil)
+	s.mockAuditor.On("Record", context.TODO(), mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.Rent(context.TODO(), mockInput)
+	s.Nil(err)
+	s.Equal(int64(4), actual.Version)
+}
+
+func (s *BikeUseCaseTestSuite) TestRent_ConflictWhenIfMatchIsStale() {
+	mockInput := domain.RentOrReturnRequestPayload{ID: 1, UserID: 1, Version: 2}
+	s.mockRepository.On("CountByUserID", context.TODO(), mockInput.UserID).Return(int64(0), nil)
+	s.mockUserRepository.On("GetByID", context.TODO(), mockInput.UserID).Return(&domain.User{ID: 1, Name: "Bob"}, nil)
+	s.mockRepository.On("GetByID", context.TODO(), mockInput.ID).Return(&domain.Bike{ID: 1, Status: domain.BikeStatusAvailable, Version: 3}, nil)
+	actual, err := s.useCaseImpl.Rent(context.TODO(), mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrBikeVersionConflict, err)
+	s.mockRepository.AssertNotCalled(s.T(), "UpdateStatusAndLocation", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestRent_ConflictWhenChangedMeanwhile() {
+	mockInput := domain.RentOrReturnRequestPayload{ID: 1, UserID: 1}
+	s.mockRepository.On("CountByUserID", context.TODO(), mockInput.UserID).Return(int64(0), nil)
+	s.mockUserRepository.On("GetByID", context.TODO(), mockInput.UserID).Return(&domain.User{ID: 1, Name: "Bob"}, nil)
+	s.mockRepository.On("GetByID", context.TODO(), mockInput.ID).Return(&domain.Bike{ID: 1, Status: domain.BikeStatusAvailable, Version: 3}, nil)
+	s.mockRepository.On("UpdateStatusAndLocation", context.TODO(), mock.Anything).Return(false, nil)
+	actual, err := s.useCaseImpl.Rent(context.TODO(), mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrBikeVersionConflict, err)
+	s.mockLock.AssertNotCalled(s.T(), "Unlock", mock.Anything, mock.Anything)
+	s.mockAuditor.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestRent_LockNotConfirmedRollsBackOnWrittenVersion() {
+	mockInput := domain.RentOrReturnRequestPayload{ID: 1, UserID: 1}
+	mockExistRecord := &domain.Bike{ID: 1, Status: domain.BikeStatusAvailable, Version: 3}
+	s.mockRepository.On("CountByUserID", context.TODO(), mockInput.UserID).Return(int64(0), nil)
+	s.mockUserRepository.On("GetByID", context.TODO(), mockInput.UserID).Return(&domain.User{ID: 1, Name: "Bob"}, nil)
+	s.mockRepository.On("GetByID", context.TODO(), mockInput.ID).Return(mockExistRecord, nil)
+	s.mockRepository.On("UpdateStatusAndLocation", context.TODO(), mock.MatchedBy(func(bike *domain.Bike) bool {
+		return bike.Status == domain.BikeStatusRented
+	})).Run(s.bumpVersion).Return(true, nil).Once()
+	s.mockLock.On("Unlock", context.TODO(), int64(1)).Return(errors.New("lock did not confirm the command"))
+	s.mockRepository.On("UpdateStatusAndLocation", mock.Anything, &domain.Bike{ID: 1, Status: domain.BikeStatusAvailable, Version: 4}).Return(false, nil).Once()
+	actual, err := s.useCaseImpl.Rent(context.TODO(), mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrLockNotConfirmed, err)
+	s.Equal(int64(3), mockExistRecord.Version)
+	s.mockRepository.AssertExpectations(s.T())
+}
+
+func (s *BikeUseCaseTestSuite) TestRent_CancelledRequestStillRollsBack() {
+	ctx, cancel := context.WithCancel(context.TODO())
+	mockInput := domain.RentOrReturnRequestPayload{ID: 1, UserID: 1}
+	mockExistRecord := &domain.Bike{ID: 1, Status: domain.BikeStatusAvailable, Version: 3}
+	s.mockRepository.On("CountByUserID", ctx, mockInput.UserID).Return(int64(0), nil)
+	s.mockUserRepository.On("GetByID", ctx, mockInput.UserID).Return(&domain.User{ID: 1, Name: "Bob"}, nil)
+	s.mockRepository.On("GetByID", ctx, mockInput.ID).Return(mockExistRecord, nil)
+	s.mockRepository.On("UpdateStatusAndLocation", ctx, mock.MatchedBy(func(bike *domain.Bike) bool {
+		return bike.Status == domain.BikeStatusRented
+	})).Run(s.bumpVersion).Return(true, nil).Once()
+	s.mockLock.On("Unlock", ctx, int64(1)).Run(func(args mock.Arguments) {
+		cancel()
+	}).Return(context.Canceled)
+	s.mockRepository.On("UpdateStatusAndLocation", mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Err() == nil
+	}), &domain.Bike{ID: 1, Status: domain.BikeStatusAvailable, Version: 4}).Return(true, nil).Once()
+	actual, err := s.useCaseImpl.Rent(ctx, mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrLockNotConfirmed, err)
+	s.mockRepository.AssertExpectations(s.T())
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_ConflictWhenIfMatchIsStale() {
+	mockInput := domain.RentOrReturnRequestPayload{ID: 1, UserID: 1, Lat: &mockDropOffLat, Long: &mockDropOffLong, Version: 1}
+	mockExistRecord := s.rentedBike()
+	mockExistRecord.Version = 2
+	s.mockRepository.On("GetByID", context.TODO(), mockInput.ID).Return(mockExistRecord, nil)
+	actual, err := s.useCaseImpl.Return(context.TODO(), mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrBikeVersionConflict, err)
+	s.mockRepository.AssertNotCalled(s.T(), "UpdateStatusAndLocation", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestReturn_ConflictWhenChangedMeanwhile() {
+	mockInput := domain.RentOrReturnRequestPayload{ID: 1, UserID: 1, Lat: &mockDropOffLat, Long: &mockDropOffLong, Version: 2}
+	mockExistRecord := s.rentedBike()
+	mockExistRecord.Version = 2
+	s.mockRepository.On("GetByID", context.TODO(), mockInput.ID).Return(mockExistRecord, nil)
+	s.mockZoneChecker.On("CheckReturn", context.TODO(), mockDropOffLat, mockDropOffLong).Return(decimal.Zero, nil)
+	s.mockTicketRepo.On("CountConfirmedByBikeID", context.TODO(), mockInput.ID).Return(int64(0), nil)
+	s.mockRepository.On("UpdateStatusAndLocation", context.TODO(), mock.MatchedBy(func(bike *domain.Bike) bool {
+		return bike.Version == 2
+	})).Return(false, nil)
+	actual, err := s.useCaseImpl.Return(context.TODO(), mockInput)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrBikeVersionConflict, err)
+	s.mockLock.AssertNotCalled(s.T(), "Lock", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestRentByCode_PassesVersion() {
+	s.mockRepository.On("GetByCode", context.TODO(), "7F3K9Q2M").Return(&domain.Bike{ID: 1, Code: "7F3K9Q2M"}, nil)
+	s.mockRepository.On("CountByUserID", context.TODO(), int64(1)).Return(int64(0), nil)
+	s.mockUserRepository.On("GetByID", context.TODO(), int64(1)).Return(&domain.User{ID: 1, Name: "Bob"}, nil)
+	s.mockRepository.On("GetByID", context.TODO(), int64(1)).Return(&domain.Bike{ID: 1, Status: domain.BikeStatusAvailable, Version: 5}, nil)
+	actual, err := s.useCaseImpl.RentByCode(context.TODO(), "7F3K9Q2M", 1, 4)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrBikeVersionConflict, err)
+}
+
+func (s *BikeUseCaseTestSuite) TestGetByID_SuccessRented() {
+	updatedAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	s.mockRepository.On("GetByID", context.TODO(), int64(1)).Return(&domain.Bike{
+		ID:        1,
+		Name:      "henry",
+		Status:    domain.BikeStatusRented,
+		UserID:    sql.NullInt64{Valid: true, Int64: 2},
+		Version:   3,
+		UpdatedAt: updatedAt,
+	}, nil)
+	s.mockUserRepository.On("GetByID", context.TODO(), int64(2)).Return(&domain.User{ID: 2, Name: "Bob"}, nil)
+	actual, err := s.useCaseImpl.GetByID(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal(domain.BikeDTO{
+		ID:           1,
+		Name:         "henry",
+		Status:       domain.BikeStatusRented,
+		UserID:       2,
+		NameOfRenter: "Bob",
+		Version:      3,
+		LastModified: updatedAt,
+	}, actual)
+}
+
+func (s *BikeUseCaseTestSuite) TestGetByID_SuccessAvailable() {
+	s.mockRepository.On("GetByID", context.TODO(), int64(1)).Return(&domain.Bike{ID: 1, Status: domain.BikeStatusAvailable}, nil)
+	actual, err := s.useCaseImpl.GetByID(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal(domain.BikeDTO{ID: 1, Status: domain.BikeStatusAvailable}, actual)
+	s.mockUserRepository.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+}
+
+func (s *BikeUseCaseTestSuite) TestGetByID_SuccessRenterGone() {
+	s.mockRepository.On("GetByID", context.TODO(), int64(1)).Return(&domain.Bike{ID: 1, Status: domain.BikeStatusRented, UserID: sql.NullInt64{Valid: true, Int64: 2}}, nil)
+	s.mockUserRepository.On("GetByID", context.TODO(), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.GetByID(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal("", actual.NameOfRenter)
+	s.Equal(int64(2), actual.UserID)
+}
+
+func (s *BikeUseCaseTestSuite) TestGetByID_NotFound() {
+	s.mockRepository.On("GetByID", context.TODO(), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.GetByID(context.TODO(), 1)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrBikeNotFound, err)
+}
+
+func (s *BikeUseCaseTestSuite) TestGetByID_InternalServerError() {
+	s.mockRepository.On("GetByID", context.TODO(), int64(1)).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.GetByID(context.TODO(), 1)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *BikeUseCaseTestSuite) TestGetByID_InternalServerErrorWhenFetchRenter() {
+	s.mockRepository.On("GetByID", context.TODO(), int64(1)).Return(&domain.Bike{ID: 1, Status: domain.BikeStatusRented, UserID: sql.NullInt64{Valid: true, Int64: 2}}, nil)
+	s.mockUserRepository.On("GetByID", context.TODO(), int64(2)).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.GetByID(context.TODO(), 1)
+	s.Equal(domain.BikeDTO{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 
 	"shared-bike/domain"
+
+	"github.com/shopspring/decimal"
 )
 
 type IRepository interface {
-	GetList(ctx context.Context) (*[]domain.Bike, error)
+	GetList(ctx context.Context, filter domain.BikeFilter) (*[]domain.Bike, error)
 	GetByID(ctx context.Context, id int64) (*domain.Bike, error)
-	UpdateStatusAndUserID(ctx context.Context, body *domain.Bike) error
+	GetByCode(ctx context.Context, code string) (*domain.Bike, error)
+	UpdateStatusAndUserID(ctx context.Context, body *domain.Bike) (bool, error)
+	UpdateStatusAndLocation(ctx context.Context, body *domain.Bike) (bool, error)
+	UpdateStatusAndDock(ctx context.Context, body *domain.Bike) (bool, error)
 	CountByUserID(ctx context.Context, id int64) (int64, error)
 }
 
@@ -18,6 +23,35 @@ type IUserRepository interface {
 	GetByID(ctx context.Context, id int64) (*domain.User, error)
 }
 
+type ITicketRepository interface {
+	CountConfirmedByBikeID(ctx context.Context, bikeID int64) (int64, error)
+}
+
+// ILockController opens and closes the physical lock of a bike, see lock.LockController.
+type ILockController interface {
+	Unlock(ctx context.Context, bikeID int64) error
+	Lock(ctx context.Context, bikeID int64) error
+}
+
+type IStationRepository interface {
+	GetByID(ctx context.Context, id int64) (*domain.Station, error)
+}
+
+type IZoneChecker interface {
+	CheckReturn(ctx context.Context, lat, long decimal.Decimal) (decimal.Decimal, error)
+}
+
+type IAuditor interface {
+	Record(ctx context.Context, body domain.AuditRecord) error
+}
+
+// IMetrics counts rentals, see metrics.Metrics.
+type IMetrics interface {
+	RentalStarted()
+	RentalEnded()
+	RentalFailed(action string, err error)
+}
+
 type ILogger interface {
 	Info(i ...interface{})
 	Warn(i ...interface{})
@@ -25,12 +59,20 @@ type ILogger interface {
 }
 
 type IUseCase interface {
-	GetAllBike(ctx context.Context) ([]domain.BikeDTO, error)
+	GetAllBike(ctx context.Context, filter domain.BikeFilter) ([]domain.BikeDTO, error)
+	GetByID(ctx context.Context, id int64) (domain.BikeDTO, error)
 	Rent(ctx context.Context, body domain.RentOrReturnRequestPayload) (domain.BikeDTO, error)
+	RentByCode(ctx context.Context, code string, userID int64, version int64) (domain.BikeDTO, error)
 	Return(ctx context.Context, body domain.RentOrReturnRequestPayload) (domain.BikeDTO, error)
 }
 
 //go:generate mockery --name IRepository --output mocks --case underscore
+//go:generate mockery --name ITicketRepository --output mocks --case underscore
+//go:generate mockery --name ILockController --output mocks --case underscore
+//go:generate mockery --name IStationRepository --output mocks --case underscore
+//go:generate mockery --name IZoneChecker --output mocks --case underscore
+//go:generate mockery --name IAuditor --output mocks --case underscore
 //go:generate mockery --name ILogger --output mocks --case underscore
+//go:generate mockery --name IMetrics --output mocks --case underscore
 //go:generate mockery --name IUseCase --output mocks --case underscore
 //go:generate mockery --name IUserRepository --output mocks --case underscore
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ILockController is an autogenerated mock type for the ILockController type
+type ILockController struct {
+	mock.Mock
+}
+
+// Lock provides a mock function with given fields: ctx, bikeID
+func (_m *ILockController) Lock(ctx context.Context, bikeID int64) error {
+	ret := _m.Called(ctx, bikeID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, bikeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unlock provides a mock function with given fields: ctx, bikeID
+func (_m *ILockController) Unlock(ctx context.Context, bikeID int64) error {
+	ret := _m.Called(ctx, bikeID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, bikeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewILockController interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewILockController creates a new instance of ILockController. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewILockController(t mockConstructorTestingTNewILockController) *ILockController {
+	mock := &ILockController{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,40 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// IMetrics is an autogenerated mock type for the IMetrics type
+type IMetrics struct {
+	mock.Mock
+}
+
+// RentalEnded provides a mock function with given fields:
+func (_m *IMetrics) RentalEnded() {
+	_m.Called()
+}
+
+// RentalFailed provides a mock function with given fields: action, err
+func (_m *IMetrics) RentalFailed(action string, err error) {
+	_m.Called(action, err)
+}
+
+// RentalStarted provides a mock function with given fields:
+func (_m *IMetrics) RentalStarted() {
+	_m.Called()
+}
+
+type mockConstructorTestingTNewIMetrics interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIMetrics creates a new instance of IMetrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIMetrics(t mockConstructorTestingTNewIMetrics) *IMetrics {
+	mock := &IMetrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -35,6 +35,29 @@ func (_m *IRepository) CountByUserID(ctx context.Context, id int64) (int64, erro
 	return r0, r1
 }
 
+// GetByCode provides a mock function with given fields: ctx, code
+func (_m *IRepository) GetByCode(ctx context.Context, code string) (*domain.Bike, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *domain.Bike
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Bike); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Bike)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *IRepository) GetByID(ctx context.Context, id int64) (*domain.Bike, error) {
 	ret := _m.Called(ctx, id)
@@ -58,13 +81,13 @@ func (_m *IRepository) GetByID(ctx context.Context, id int64) (*domain.Bike, err
 	return r0, r1
 }
 
-// GetList provides a mock function with given fields: ctx
-func (_m *IRepository) GetList(ctx context.Context) (*[]domain.Bike, error) {
-	ret := _m.Called(ctx)
+// GetList provides a mock function with given fields: ctx, filter
+func (_m *IRepository) GetList(ctx context.Context, filter domain.BikeFilter) (*[]domain.Bike, error) {
+	ret := _m.Called(ctx, filter)
 
 	var r0 *[]domain.Bike
-	if rf, ok := ret.Get(0).(func(context.Context) *[]domain.Bike); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BikeFilter) *[]domain.Bike); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*[]domain.Bike)
@@ -72,8 +95,50 @@ func (_m *IRepository) GetList(ctx context.Context) (*[]domain.Bike, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BikeFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatusAndDock provides a mock function with given fields: ctx, body
+func (_m *IRepository) UpdateStatusAndDock(ctx context.Context, body *domain.Bike) (bool, error) {
+	ret := _m.Called(ctx, body)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bike) bool); ok {
+		r0 = rf(ctx, body)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Bike) error); ok {
+		r1 = rf(ctx, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatusAndLocation provides a mock function with given fields: ctx, body
+func (_m *IRepository) UpdateStatusAndLocation(ctx context.Context, body *domain.Bike) (bool, error) {
+	ret := _m.Called(ctx, body)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bike) bool); ok {
+		r0 = rf(ctx, body)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Bike) error); ok {
+		r1 = rf(ctx, body)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -82,17 +147,24 @@ func (_m *IRepository) GetList(ctx context.Context) (*[]domain.Bike, error) {
 }
 
 // UpdateStatusAndUserID provides a mock function with given fields: ctx, body
-func (_m *IRepository) UpdateStatusAndUserID(ctx context.Context, body *domain.Bike) error {
+func (_m *IRepository) UpdateStatusAndUserID(ctx context.Context, body *domain.Bike) (bool, error) {
 	ret := _m.Called(ctx, body)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bike) error); ok {
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bike) bool); ok {
 		r0 = rf(ctx, body)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Bike) error); ok {
+		r1 = rf(ctx, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 type mockConstructorTestingTNewIRepository interface {
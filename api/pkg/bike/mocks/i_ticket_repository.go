@@ -0,0 +1,50 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ITicketRepository is an autogenerated mock type for the ITicketRepository type
+type ITicketRepository struct {
+	mock.Mock
+}
+
+// CountConfirmedByBikeID provides a mock function with given fields: ctx, bikeID
+func (_m *ITicketRepository) CountConfirmedByBikeID(ctx context.Context, bikeID int64) (int64, error) {
+	ret := _m.Called(ctx, bikeID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, bikeID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, bikeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewITicketRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewITicketRepository creates a new instance of ITicketRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewITicketRepository(t mockConstructorTestingTNewITicketRepository) *ITicketRepository {
+	mock := &ITicketRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
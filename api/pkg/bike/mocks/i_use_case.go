@@ -14,13 +14,13 @@ type IUseCase struct {
 	mock.Mock
 }
 
-// GetAllBike provides a mock function with given fields: ctx
-func (_m *IUseCase) GetAllBike(ctx context.Context) ([]domain.BikeDTO, error) {
-	ret := _m.Called(ctx)
+// GetAllBike provides a mock function with given fields: ctx, filter
+func (_m *IUseCase) GetAllBike(ctx context.Context, filter domain.BikeFilter) ([]domain.BikeDTO, error) {
+	ret := _m.Called(ctx, filter)
 
 	var r0 []domain.BikeDTO
-	if rf, ok := ret.Get(0).(func(context.Context) []domain.BikeDTO); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BikeFilter) []domain.BikeDTO); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.BikeDTO)
@@ -28,8 +28,29 @@ func (_m *IUseCase) GetAllBike(ctx context.Context) ([]domain.BikeDTO, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BikeFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *IUseCase) GetByID(ctx context.Context, id int64) (domain.BikeDTO, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 domain.BikeDTO
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.BikeDTO); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.BikeDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -58,6 +79,27 @@ func (_m *IUseCase) Rent(ctx context.Context, body domain.RentOrReturnRequestPay
 	return r0, r1
 }
 
+// RentByCode provides a mock function with given fields: ctx, code, userID, version
+func (_m *IUseCase) RentByCode(ctx context.Context, code string, userID int64, version int64) (domain.BikeDTO, error) {
+	ret := _m.Called(ctx, code, userID, version)
+
+	var r0 domain.BikeDTO
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) domain.BikeDTO); ok {
+		r0 = rf(ctx, code, userID, version)
+	} else {
+		r0 = ret.Get(0).(domain.BikeDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) error); ok {
+		r1 = rf(ctx, code, userID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Return provides a mock function with given fields: ctx, body
 func (_m *IUseCase) Return(ctx context.Context, body domain.RentOrReturnRequestPayload) (domain.BikeDTO, error) {
 	ret := _m.Called(ctx, body)
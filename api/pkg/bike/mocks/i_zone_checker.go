@@ -0,0 +1,51 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	decimal "github.com/shopspring/decimal"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IZoneChecker is an autogenerated mock type for the IZoneChecker type
+type IZoneChecker struct {
+	mock.Mock
+}
+
+// CheckReturn provides a mock function with given fields: ctx, lat, long
+func (_m *IZoneChecker) CheckReturn(ctx context.Context, lat decimal.Decimal, long decimal.Decimal) (decimal.Decimal, error) {
+	ret := _m.Called(ctx, lat, long)
+
+	var r0 decimal.Decimal
+	if rf, ok := ret.Get(0).(func(context.Context, decimal.Decimal, decimal.Decimal) decimal.Decimal); ok {
+		r0 = rf(ctx, lat, long)
+	} else {
+		r0 = ret.Get(0).(decimal.Decimal)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, decimal.Decimal, decimal.Decimal) error); ok {
+		r1 = rf(ctx, lat, long)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIZoneChecker interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIZoneChecker creates a new instance of IZoneChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIZoneChecker(t mockConstructorTestingTNewIZoneChecker) *IZoneChecker {
+	mock := &IZoneChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,55 @@
+package consistency
+
+import (
+	"fmt"
+	"net/http"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"github.com/labstack/echo/v4"
+)
+
+type handlerImpl struct {
+	useCase IUseCase
+}
+
+func NewHandler(useCase IUseCase) *handlerImpl {
+	return &handlerImpl{
+		useCase: useCase,
+	}
+}
+
+// Check godoc
+// @Summary      Check the fleet for inconsistent bikes
+// @Description  API for scanning every bike for anomalies such as a rented bike without renter, reported by category. Runs dry unless dryRun is false, then repairable anomalies are repaired and audited as bike.repair.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param    		 request  body      domain.ConsistencyCheckBody  false  "Dry run or repair"
+// @Success      200  {object}  domain.ConsistencyReport "Success"
+// @Failure      400  {string}  string 	"invalid body"
+// @Failure      403  {string}  string 	"you do not have permission to perform this action"
+// @Failure      500  {string}  string 	"internal server error"
+// @Security     BearerAuth
+// @Router       /admin/consistency [post]
+func (h *handlerImpl) Check(c echo.Context) error {
+	span := tracing.StartHandler(c, "ConsistencyHandler.Check")
+	defer span.End()
+	ctx := c.Request().Context()
+	body := domain.ConsistencyCheckBody{}
+	if err := c.Bind(&body); err != nil {
+		c.Logger().Error("[ConsistencyHandler.Check] invalid body", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBody), apperrors.ErrInvalidBody.Error())
+	}
+	dryRun := body.DryRun == nil || *body.DryRun
+	c.Logger().Info(fmt.Sprintf("[ConsistencyHandler.Check] starting, dry run %t", dryRun))
+	report, err := h.useCase.Check(ctx, dryRun)
+	if err != nil {
+		c.Logger().Error("[ConsistencyHandler.Check] check failed", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info("[ConsistencyHandler.Check] success")
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,80 @@
+package consistency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/consistency/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConsistencyHandlerTestSuite struct {
+	suite.Suite
+	mockUseCase *mocks.IUseCase
+	echo        *echo.Echo
+	handlerImpl *handlerImpl
+}
+
+func (s *ConsistencyHandlerTestSuite) SetupTest() {
+	mockUseCase := &mocks.IUseCase{}
+	s.mockUseCase = mockUseCase
+	s.echo = echo.New()
+	s.handlerImpl = NewHandler(mockUseCase)
+}
+
+func TestConsistencyHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsistencyHandlerTestSuite))
+}
+
+func (s *ConsistencyHandlerTestSuite) TestCheck_DryRunByDefault() {
+	report := domain.NewConsistencyReport(true)
+	report.Scanned = 4
+	s.mockUseCase.On("Check", context.Background(), true).Return(report, nil)
+	req := httptest.NewRequest(http.MethodPost, "/admin/consistency", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.Check(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(`{"dryRun":true,"scanned":4,"counts":{"idle_with_renter":0,"rented_without_renter":0,"renter_deleted":0,"unknown_status":0},"repaired":0,"anomalies":[]}`+"\n", rec.Body.String())
+}
+
+func (s *ConsistencyHandlerTestSuite) TestCheck_Repair() {
+	report := domain.NewConsistencyReport(false)
+	s.mockUseCase.On("Check", context.Background(), false).Return(report, nil)
+	req := httptest.NewRequest(http.MethodPost, "/admin/consistency", strings.NewReader(`{"dryRun":false}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.Check(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.mockUseCase.AssertExpectations(s.T())
+}
+
+func (s *ConsistencyHandlerTestSuite) TestCheck_FailedBody() {
+	req := httptest.NewRequest(http.MethodPost, "/admin/consistency", strings.NewReader(`{"dryRun":"no"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.Check(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4005 invalid body\"\n", rec.Body.String())
+	s.mockUseCase.AssertNotCalled(s.T(), "Check", mock.Anything, mock.Anything)
+}
+
+func (s *ConsistencyHandlerTestSuite) TestCheck_FailedUseCase() {
+	s.mockUseCase.On("Check", context.Background(), true).Return(domain.ConsistencyReport{}, apperrors.ErrInternalServerError)
+	req := httptest.NewRequest(http.MethodPost, "/admin/consistency", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.Check(c))
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Equal("\"e5000 internal server error\"\n", rec.Body.String())
+}
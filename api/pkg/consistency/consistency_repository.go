@@ -0,0 +1,62 @@
+package consistency
+
+import (
+	"context"
+
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repositoryImpl {
+	return &repositoryImpl{
+		db: db,
+	}
+}
+
+// GetBikesAfter pages through the fleet by id so a scan never holds the whole table in memory.
+func (r *repositoryImpl) GetBikesAfter(ctx context.Context, afterID int64, limit int) (*[]domain.Bike, error) {
+	ctx, span := tracing.Start(ctx, "ConsistencyRepository.GetBikesAfter")
+	defer span.End()
+	bikes := []domain.Bike{}
+	err := r.db.WithContext(ctx).Where("id > ?", afterID).Order("id").Limit(limit).Find(&bikes).Error
+	if err != nil {
+		return nil, err
+	}
+	return &bikes, nil
+}
+
+// GetUsersByIDs includes soft-deleted users, a bike pointing at one of them is an anomaly to report.
+func (r *repositoryImpl) GetUsersByIDs(ctx context.Context, IDs []int64) (*[]domain.User, error) {
+	ctx, span := tracing.Start(ctx, "ConsistencyRepository.GetUsersByIDs")
+	defer span.End()
+	users := []domain.User{}
+	err := r.db.WithContext(ctx).Unscoped().Where("id IN (?)", IDs).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return &users, nil
+}
+
+// RepairBike sets status and clears the renter only if the bike still looks like before, so a rider renting or
+// returning it since the check wins. It reports whether the bike was repaired.
+func (r *repositoryImpl) RepairBike(ctx context.Context, before *domain.Bike, status domain.BikeStatus) (bool, error) {
+	ctx, span := tracing.Start(ctx, "ConsistencyRepository.RepairBike")
+	defer span.End()
+	result := r.db.WithContext(ctx).Model(&domain.Bike{}).
+		Where("id = ? AND status = ? AND user_id <=> ?", before.ID, before.Status, before.UserID).
+		Updates(map[string]interface{}{
+			"status":  status,
+			"user_id": nil,
+			"version": gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,117 @@
+package consistency
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type ConsistencyRepositoryTestSuite struct {
+	suite.Suite
+	mockDB         sqlmock.Sqlmock
+	repositoryImpl *repositoryImpl
+}
+
+func (s *ConsistencyRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.Error(err, "Failed to open mock sql db, got error")
+	}
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		s.Error(err, "gormDB init err")
+	}
+	s.mockDB = mock
+	s.repositoryImpl = NewRepository(gormDB)
+}
+
+func TestConsistencyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsistencyRepositoryTestSuite))
+}
+
+func (s *ConsistencyRepositoryTestSuite) TestGetBikesAfter_Success() {
+	query := regexp.QuoteMeta("SELECT * FROM `bike` WHERE id > ? AND `bike`.`deleted_at` IS NULL ORDER BY id LIMIT 2")
+	rows := sqlmock.NewRows([]string{"id", "status", "user_id"}).
+		AddRow(11, domain.BikeStatusRented, nil).
+		AddRow(12, domain.BikeStatusAvailable, 3)
+	s.mockDB.ExpectQuery(query).WithArgs(int64(10)).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetBikesAfter(context.TODO(), 10, 2)
+	s.Nil(err)
+	s.Equal(&[]domain.Bike{
+		{ID: 11, Status: domain.BikeStatusRented},
+		{ID: 12, Status: domain.BikeStatusAvailable, UserID: sql.NullInt64{Valid: true, Int64: 3}},
+	}, actual)
+}
+
+func (s *ConsistencyRepositoryTestSuite) TestGetBikesAfter_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `bike` WHERE id > ?")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(0)).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetBikesAfter(context.TODO(), 0, 500)
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *ConsistencyRepositoryTestSuite) TestGetUsersByIDs_IncludesDeleted() {
+	query := regexp.QuoteMeta("SELECT * FROM `user` WHERE id IN (?,?)")
+	rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "Bob").AddRow(3, "Alice")
+	s.mockDB.ExpectQuery(query+"$").WithArgs(int64(2), int64(3)).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetUsersByIDs(context.TODO(), []int64{2, 3})
+	s.Nil(err)
+	s.Equal(&[]domain.User{{ID: 2, Name: "Bob"}, {ID: 3, Name: "Alice"}}, actual)
+}
+
+func (s *ConsistencyRepositoryTestSuite) TestGetUsersByIDs_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `user` WHERE id IN (?)")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(2)).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetUsersByIDs(context.TODO(), []int64{2})
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *ConsistencyRepositoryTestSuite) TestRepairBike_Repaired() {
+	query := regexp.QuoteMeta("UPDATE `bike` SET `status`=?,`user_id`=?,`version`=version + 1,`updated_at`=? WHERE (id = ? AND status = ? AND user_id <=> ?) AND `bike`.`deleted_at` IS NULL")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).
+		WithArgs(domain.BikeStatusAvailable, nil, sqlmock.AnyArg(), int64(1), domain.BikeStatusRented, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectCommit()
+	before := &domain.Bike{ID: 1, Status: domain.BikeStatusRented}
+	actual, err := s.repositoryImpl.RepairBike(context.TODO(), before, domain.BikeStatusAvailable)
+	s.Nil(err)
+	s.True(actual)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *ConsistencyRepositoryTestSuite) TestRepairBike_ChangedMeanwhile() {
+	query := regexp.QuoteMeta("UPDATE `bike` SET `status`=?,`user_id`=?,`version`=version + 1,`updated_at`=? WHERE (id = ? AND status = ? AND user_id <=> ?)")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).
+		WithArgs(domain.BikeStatusAvailable, nil, sqlmock.AnyArg(), int64(1), domain.BikeStatusAvailable, int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	before := &domain.Bike{ID: 1, Status: domain.BikeStatusAvailable, UserID: sql.NullInt64{Valid: true, Int64: 2}}
+	actual, err := s.repositoryImpl.RepairBike(context.TODO(), before, domain.BikeStatusAvailable)
+	s.Nil(err)
+	s.False(actual)
+}
+
+func (s *ConsistencyRepositoryTestSuite) TestRepairBike_Failed() {
+	query := regexp.QuoteMeta("UPDATE `bike` SET")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	actual, err := s.repositoryImpl.RepairBike(context.TODO(), &domain.Bike{ID: 1}, domain.BikeStatusAvailable)
+	s.Equal(gorm.ErrInvalidDB, err)
+	s.False(actual)
+}
@@ -0,0 +1,124 @@
+package consistency
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+)
+
+const checkBatchSize = 500
+
+type useCaseImpl struct {
+	logger     ILogger
+	repository IRepository
+	auditor    IAuditor
+}
+
+func NewUseCase(logger ILogger, repository IRepository, auditor IAuditor) *useCaseImpl {
+	return &useCaseImpl{
+		logger:     logger,
+		repository: repository,
+		auditor:    auditor,
+	}
+}
+
+// Check scans the whole fleet for anomalies. Unless dryRun is set every repairable anomaly is repaired and audited.
+func (u *useCaseImpl) Check(ctx context.Context, dryRun bool) (domain.ConsistencyReport, error) {
+	ctx, span := tracing.Start(ctx, "ConsistencyUseCase.Check")
+	defer span.End()
+	u.logger.Info(fmt.Sprintf("[ConsistencyUseCase.Check] scanning the fleet, dry run %t", dryRun))
+	report := domain.NewConsistencyReport(dryRun)
+	afterID := int64(0)
+	for {
+		bikes, err := u.repository.GetBikesAfter(ctx, afterID, checkBatchSize)
+		if err != nil {
+			u.logger.Error(fmt.Sprintf("[ConsistencyUseCase.Check] fetch bikes after %d failed", afterID), err)
+			return domain.ConsistencyReport{}, apperrors.ErrInternalServerError
+		}
+		renters, err := u.fetchRenters(ctx, bikes)
+		if err != nil {
+			return domain.ConsistencyReport{}, err
+		}
+		for i := range *bikes {
+			bike := &(*bikes)[i]
+			afterID = bike.ID
+			report.Scanned++
+			anomaly := domain.CheckBike(bike, renters[bike.UserID.Int64])
+			if anomaly == nil {
+				continue
+			}
+			report.Counts[anomaly.Category]++
+			if !dryRun && anomaly.Repairable() {
+				if anomaly.Repaired, err = u.repair(ctx, bike, anomaly); err != nil {
+					return domain.ConsistencyReport{}, err
+				}
+				if anomaly.Repaired {
+					report.Repaired++
+				}
+			}
+			report.Anomalies = append(report.Anomalies, *anomaly)
+		}
+		if len(*bikes) < checkBatchSize {
+			break
+		}
+	}
+	u.logger.Info(fmt.Sprintf("[ConsistencyUseCase.Check] scanned %d bikes, found %d anomalies, repaired %d", report.Scanned, len(report.Anomalies), report.Repaired))
+	return report, nil
+}
+
+// fetchRenters maps the renters of a batch by id, skipping the query when nobody rents a bike of the batch.
+func (u *useCaseImpl) fetchRenters(ctx context.Context, bikes *[]domain.Bike) (map[int64]*domain.User, error) {
+	renters := map[int64]*domain.User{}
+	userIDs := []int64{}
+	for _, bike := range *bikes {
+		if bike.UserID.Valid {
+			userIDs = append(userIDs, bike.UserID.Int64)
+		}
+	}
+	if len(userIDs) == 0 {
+		return renters, nil
+	}
+	users, err := u.repository.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		u.logger.Error("[ConsistencyUseCase.fetchRenters] fetch renters failed", err)
+		return nil, apperrors.ErrInternalServerError
+	}
+	for i := range *users {
+		renters[(*users)[i].ID] = &(*users)[i]
+	}
+	return renters, nil
+}
+
+func (u *useCaseImpl) repair(ctx context.Context, bike *domain.Bike, anomaly *domain.BikeAnomaly) (bool, error) {
+	repaired, err := u.repository.RepairBike(ctx, bike, anomaly.RepairStatus)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[ConsistencyUseCase.repair] repair %s of bike %d failed", anomaly.Category, bike.ID), err)
+		return false, apperrors.ErrInternalServerError
+	}
+	if !repaired {
+		u.logger.Info(fmt.Sprintf("[ConsistencyUseCase.repair] bike %d changed since it was checked, leaving it", bike.ID))
+		return false, nil
+	}
+	after := *bike
+	after.Status = anomaly.RepairStatus
+	after.UserID = sql.NullInt64{}
+	u.audit(ctx, domain.AuditRecord{
+		Action:     domain.AuditActionBikeRepair,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   bike.ID,
+		Before:     bike.ToDTO(),
+		After:      after.ToDTO(),
+	})
+	return true, nil
+}
+
+// audit never fails the caller because the bike has already been repaired.
+func (u *useCaseImpl) audit(ctx context.Context, record domain.AuditRecord) {
+	if err := u.auditor.Record(ctx, record); err != nil {
+		u.logger.Error(fmt.Sprintf("[ConsistencyUseCase.audit] record %s on %s %d failed", record.Action, record.TargetType, record.TargetID), err)
+	}
+}
@@ -0,0 +1,155 @@
+package consistency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/consistency/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ConsistencyUseCaseTestSuite struct {
+	suite.Suite
+	mockRepository *mocks.IRepository
+	mockAuditor    *mocks.IAuditor
+	mockLogger     *mocks.ILogger
+	useCaseImpl    *useCaseImpl
+}
+
+func (s *ConsistencyUseCaseTestSuite) SetupTest() {
+	s.mockRepository = &mocks.IRepository{}
+	s.mockAuditor = &mocks.IAuditor{}
+	s.mockLogger = &mocks.ILogger{}
+	s.mockLogger.On("Info", mock.Anything).Return()
+	s.mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+	s.useCaseImpl = NewUseCase(s.mockLogger, s.mockRepository, s.mockAuditor)
+}
+
+func TestConsistencyUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsistencyUseCaseTestSuite))
+}
+
+var (
+	renterBob     = sql.NullInt64{Valid: true, Int64: 2}
+	renterDeleted = sql.NullInt64{Valid: true, Int64: 3}
+	mockFleet     = []domain.Bike{
+		{ID: 1, Status: domain.BikeStatusAvailable},
+		{ID: 2, Status: domain.BikeStatusRented},
+		{ID: 3, Status: domain.BikeStatusRented, UserID: renterBob},
+		{ID: 4, Status: domain.BikeStatusRented, UserID: renterDeleted},
+		{ID: 5, Status: domain.BikeStatusAvailable, UserID: renterBob},
+		{ID: 6, Status: domain.BikeStatus("lost")},
+	}
+	mockRenters = []domain.User{
+		{ID: 2, Name: "Bob"},
+		{ID: 3, Name: "Gone", DeletedAt: gorm.DeletedAt{Valid: true}},
+	}
+)
+
+func (s *ConsistencyUseCaseTestSuite) expectFleet() {
+	fleet := append([]domain.Bike{}, mockFleet...)
+	s.mockRepository.On("GetBikesAfter", context.TODO(), int64(0), checkBatchSize).Return(&fleet, nil)
+	s.mockRepository.On("GetUsersByIDs", context.TODO(), []int64{2, 3, 2}).Return(&mockRenters, nil)
+}
+
+func (s *ConsistencyUseCaseTestSuite) TestCheck_DryRun() {
+	s.expectFleet()
+	actual, err := s.useCaseImpl.Check(context.TODO(), true)
+	s.Nil(err)
+	s.True(actual.DryRun)
+	s.Equal(int64(6), actual.Scanned)
+	s.Equal(0, actual.Repaired)
+	s.Equal(map[domain.AnomalyCategory]int{
+		domain.AnomalyUnknownStatus:       1,
+		domain.AnomalyRentedWithoutRenter: 1,
+		domain.AnomalyRenterDeleted:       1,
+		domain.AnomalyIdleWithRenter:      1,
+	}, actual.Counts)
+	s.Equal([]domain.BikeAnomaly{
+		{Category: domain.AnomalyRentedWithoutRenter, BikeID: 2, Status: domain.BikeStatusRented, RepairStatus: domain.BikeStatusAvailable},
+		{Category: domain.AnomalyRenterDeleted, BikeID: 4, Status: domain.BikeStatusRented, UserID: 3, RepairStatus: domain.BikeStatusMaintenance},
+		{Category: domain.AnomalyIdleWithRenter, BikeID: 5, Status: domain.BikeStatusAvailable, UserID: 2, RepairStatus: domain.BikeStatusAvailable},
+		{Category: domain.AnomalyUnknownStatus, BikeID: 6, Status: domain.BikeStatus("lost")},
+	}, actual.Anomalies)
+	s.mockRepository.AssertNotCalled(s.T(), "RepairBike", mock.Anything, mock.Anything, mock.Anything)
+	s.mockAuditor.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything)
+}
+
+func (s *ConsistencyUseCaseTestSuite) TestCheck_Repair() {
+	s.expectFleet()
+	s.mockRepository.On("RepairBike", context.TODO(), &domain.Bike{ID: 2, Status: domain.BikeStatusRented}, domain.BikeStatusAvailable).Return(true, nil)
+	s.mockRepository.On("RepairBike", context.TODO(), &domain.Bike{ID: 4, Status: domain.BikeStatusRented, UserID: renterDeleted}, domain.BikeStatusMaintenance).Return(true, nil)
+	// bike 5 was returned by its renter while the check ran
+	s.mockRepository.On("RepairBike", context.TODO(), &domain.Bike{ID: 5, Status: domain.BikeStatusAvailable, UserID: renterBob}, domain.BikeStatusAvailable).Return(false, nil)
+	s.mockAuditor.On("Record", context.TODO(), domain.AuditRecord{
+		Action:     domain.AuditActionBikeRepair,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   2,
+		Before:     domain.BikeDTO{ID: 2, Status: domain.BikeStatusRented},
+		After:      domain.BikeDTO{ID: 2, Status: domain.BikeStatusAvailable},
+	}).Return(nil)
+	s.mockAuditor.On("Record", context.TODO(), domain.AuditRecord{
+		Action:     domain.AuditActionBikeRepair,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   4,
+		Before:     domain.BikeDTO{ID: 4, Status: domain.BikeStatusRented, UserID: 3},
+		After:      domain.BikeDTO{ID: 4, Status: domain.BikeStatusMaintenance},
+	}).Return(errors.New("audit down"))
+	actual, err := s.useCaseImpl.Check(context.TODO(), false)
+	s.Nil(err)
+	s.False(actual.DryRun)
+	s.Equal(2, actual.Repaired)
+	s.True(actual.Anomalies[0].Repaired)
+	s.True(actual.Anomalies[1].Repaired)
+	s.False(actual.Anomalies[2].Repaired)
+	s.False(actual.Anomalies[3].Repaired)
+	s.mockRepository.AssertExpectations(s.T())
+	s.mockAuditor.AssertExpectations(s.T())
+}
+
+func (s *ConsistencyUseCaseTestSuite) TestCheck_PagesThroughTheFleet() {
+	firstPage := make([]domain.Bike, checkBatchSize)
+	for i := range firstPage {
+		firstPage[i] = domain.Bike{ID: int64(i + 1), Status: domain.BikeStatusAvailable}
+	}
+	secondPage := []domain.Bike{{ID: checkBatchSize + 1, Status: domain.BikeStatusRetired}}
+	s.mockRepository.On("GetBikesAfter", context.TODO(), int64(0), checkBatchSize).Return(&firstPage, nil)
+	s.mockRepository.On("GetBikesAfter", context.TODO(), int64(checkBatchSize), checkBatchSize).Return(&secondPage, nil)
+	actual, err := s.useCaseImpl.Check(context.TODO(), true)
+	s.Nil(err)
+	s.Equal(int64(checkBatchSize+1), actual.Scanned)
+	s.Empty(actual.Anomalies)
+	s.mockRepository.AssertNotCalled(s.T(), "GetUsersByIDs", mock.Anything, mock.Anything)
+}
+
+func (s *ConsistencyUseCaseTestSuite) TestCheck_FetchBikesFailed() {
+	s.mockRepository.On("GetBikesAfter", context.TODO(), int64(0), checkBatchSize).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.Check(context.TODO(), true)
+	s.Equal(domain.ConsistencyReport{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *ConsistencyUseCaseTestSuite) TestCheck_FetchRentersFailed() {
+	fleet := []domain.Bike{{ID: 3, Status: domain.BikeStatusRented, UserID: renterBob}}
+	s.mockRepository.On("GetBikesAfter", context.TODO(), int64(0), checkBatchSize).Return(&fleet, nil)
+	s.mockRepository.On("GetUsersByIDs", context.TODO(), []int64{2}).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.Check(context.TODO(), true)
+	s.Equal(domain.ConsistencyReport{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *ConsistencyUseCaseTestSuite) TestCheck_RepairFailed() {
+	fleet := []domain.Bike{{ID: 2, Status: domain.BikeStatusRented}}
+	s.mockRepository.On("GetBikesAfter", context.TODO(), int64(0), checkBatchSize).Return(&fleet, nil)
+	s.mockRepository.On("RepairBike", context.TODO(), &fleet[0], domain.BikeStatusAvailable).Return(false, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.Check(context.TODO(), false)
+	s.Equal(domain.ConsistencyReport{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
@@ -0,0 +1,32 @@
+package consistency
+
+import (
+	"context"
+
+	"shared-bike/domain"
+)
+
+type IRepository interface {
+	GetBikesAfter(ctx context.Context, afterID int64, limit int) (*[]domain.Bike, error)
+	GetUsersByIDs(ctx context.Context, IDs []int64) (*[]domain.User, error)
+	RepairBike(ctx context.Context, before *domain.Bike, status domain.BikeStatus) (bool, error)
+}
+
+type IAuditor interface {
+	Record(ctx context.Context, body domain.AuditRecord) error
+}
+
+type ILogger interface {
+	Info(i ...interface{})
+	Warn(i ...interface{})
+	Error(i ...interface{})
+}
+
+type IUseCase interface {
+	Check(ctx context.Context, dryRun bool) (domain.ConsistencyReport, error)
+}
+
+//go:generate mockery --name IRepository --output mocks --case underscore
+//go:generate mockery --name IAuditor --output mocks --case underscore
+//go:generate mockery --name ILogger --output mocks --case underscore
+//go:generate mockery --name IUseCase --output mocks --case underscore
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRepository is an autogenerated mock type for the IRepository type
+type IRepository struct {
+	mock.Mock
+}
+
+// GetBikesAfter provides a mock function with given fields: ctx, afterID, limit
+func (_m *IRepository) GetBikesAfter(ctx context.Context, afterID int64, limit int) (*[]domain.Bike, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	var r0 *[]domain.Bike
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) *[]domain.Bike); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Bike)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsersByIDs provides a mock function with given fields: ctx, IDs
+func (_m *IRepository) GetUsersByIDs(ctx context.Context, IDs []int64) (*[]domain.User, error) {
+	ret := _m.Called(ctx, IDs)
+
+	var r0 *[]domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) *[]domain.User); ok {
+		r0 = rf(ctx, IDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, IDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RepairBike provides a mock function with given fields: ctx, before, status
+func (_m *IRepository) RepairBike(ctx context.Context, before *domain.Bike, status domain.BikeStatus) (bool, error) {
+	ret := _m.Called(ctx, before, status)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bike, domain.BikeStatus) bool); ok {
+		r0 = rf(ctx, before, status)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Bike, domain.BikeStatus) error); ok {
+		r1 = rf(ctx, before, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIRepository creates a new instance of IRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIRepository(t mockConstructorTestingTNewIRepository) *IRepository {
+	mock := &IRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,51 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IUseCase is an autogenerated mock type for the IUseCase type
+type IUseCase struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, dryRun
+func (_m *IUseCase) Check(ctx context.Context, dryRun bool) (domain.ConsistencyReport, error) {
+	ret := _m.Called(ctx, dryRun)
+
+	var r0 domain.ConsistencyReport
+	if rf, ok := ret.Get(0).(func(context.Context, bool) domain.ConsistencyReport); ok {
+		r0 = rf(ctx, dryRun)
+	} else {
+		r0 = ret.Get(0).(domain.ConsistencyReport)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIUseCase creates a new instance of IUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIUseCase(t mockConstructorTestingTNewIUseCase) *IUseCase {
+	mock := &IUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repositoryImpl {
+	return &repositoryImpl{
+		db: db,
+	}
+}
+
+// Reserve claims the key of record for its user and reports false when the key is already taken.
+// Expired keys of the user are dropped first, which frees the key for reuse and keeps the table small. A key held
+// past its lease by a request that never completed, e.g. on an instance that crashed, is taken over by the same
+// request, a different one still finds the key taken.
+func (r *repositoryImpl) Reserve(ctx context.Context, record *domain.IdempotentRequest, now time.Time) (bool, error) {
+	ctx, span := tracing.Start(ctx, "IdempotencyRepository.Reserve")
+	defer span.End()
+	err := r.db.WithContext(ctx).Where("user_id = ? AND expires_at < ?", record.UserID, now).Delete(&domain.IdempotentRequest{}).Error
+	if err != nil {
+		return false, err
+	}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+	result = r.db.WithContext(ctx).Model(&domain.IdempotentRequest{}).
+		Where("user_id = ? AND idempotency_key = ? AND fingerprint = ? AND status_code = 0 AND locked_until < ?", record.UserID, record.IdempotencyKey, record.Fingerprint, now).
+		Updates(map[string]interface{}{
+			"locked_until": record.LockedUntil,
+			"expires_at":   record.ExpiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	taken, err := r.Get(ctx, record.UserID, record.IdempotencyKey)
+	if err != nil {
+		return false, err
+	}
+	record.ID = taken.ID
+	return true, nil
+}
+
+func (r *repositoryImpl) Get(ctx context.Context, userID int64, key string) (*domain.IdempotentRequest, error) {
+	ctx, span := tracing.Start(ctx, "IdempotencyRepository.Get")
+	defer span.End()
+	record := domain.IdempotentRequest{}
+	err := r.db.WithContext(ctx).Where("user_id = ? AND idempotency_key = ?", userID, key).First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *repositoryImpl) Complete(ctx context.Context, record *domain.IdempotentRequest) error {
+	ctx, span := tracing.Start(ctx, "IdempotencyRepository.Complete")
+	defer span.End()
+	err := r.db.WithContext(ctx).Model(&domain.IdempotentRequest{}).Where("id = ? AND locked_until = ?", record.ID, record.LockedUntil).Updates(map[string]interface{}{
+		"status_code":  record.StatusCode,
+		"content_type": record.ContentType,
+		"etag":         record.ETag,
+		"body":         record.Body,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Release gives the key up so a retry runs the request again, used when the first attempt failed on the server.
+// Complete and Release leave a key taken over by a retry to the retry.
+func (r *repositoryImpl) Release(ctx context.Context, record *domain.IdempotentRequest) error {
+	ctx, span := tracing.Start(ctx, "IdempotencyRepository.Release")
+	defer span.End()
+	err := r.db.WithContext(ctx).Where("id = ? AND locked_until = ?", record.ID, record.LockedUntil).Delete(&domain.IdempotentRequest{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package idempotency
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type IdempotencyRepositoryTestSuite struct {
+	suite.Suite
+	mockDB         sqlmock.Sqlmock
+	repositoryImpl *repositoryImpl
+}
+
+func (s *IdempotencyRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.Error(err, "Failed to open mock sql db, got error")
+	}
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		s.Error(err, "gormDB init err")
+	}
+	s.mockDB = mock
+	s.repositoryImpl = NewRepository(gormDB)
+}
+
+func TestIdempotencyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyRepositoryTestSuite))
+}
+
+var mockNow = time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+
+func (s *IdempotencyRepositoryTestSuite) mockRecord() *domain.IdempotentRequest {
+	return &domain.IdempotentRequest{
+		UserID:         1,
+		IdempotencyKey: "key-1",
+		Fingerprint:    "abc",
+		ExpiresAt:      mockNow.Add(time.Hour),
+		LockedUntil:    mockNow.Add(time.Minute),
+	}
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestReserve_Reserved() {
+	deleteQuery := regexp.QuoteMeta("DELETE FROM `idempotent_request` WHERE user_id = ? AND expires_at < ?")
+	insertQuery := regexp.QuoteMeta("INSERT INTO `idempotent_request` (`user_id`,`idempotency_key`,`fingerprint`,`status_code`,`content_type`,`etag`,`body`,`expires_at`,`locked_until`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `id`=`id`")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(deleteQuery).WithArgs(int64(1), mockNow).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(7, 1))
+	s.mockDB.ExpectCommit()
+	record := s.mockRecord()
+	actual, err := s.repositoryImpl.Reserve(context.TODO(), record, mockNow)
+	s.Nil(err)
+	s.True(actual)
+	s.Equal(int64(7), record.ID)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestReserve_Taken() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("DELETE FROM `idempotent_request`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("INSERT INTO `idempotent_request`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `idempotent_request`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	actual, err := s.repositoryImpl.Reserve(context.TODO(), s.mockRecord(), mockNow)
+	s.Nil(err)
+	s.False(actual)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestReserve_LeaseTakenOver() {
+	updateQuery := regexp.QuoteMeta("UPDATE `idempotent_request` SET `expires_at`=?,`locked_until`=?,`updated_at`=? WHERE user_id = ? AND idempotency_key = ? AND fingerprint = ? AND status_code = 0 AND locked_until < ?")
+	selectQuery := regexp.QuoteMeta("SELECT * FROM `idempotent_request` WHERE user_id = ? AND idempotency_key = ?")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("DELETE FROM `idempotent_request`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("INSERT INTO `idempotent_request`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(updateQuery).
+		WithArgs(mockNow.Add(time.Hour), mockNow.Add(time.Minute), sqlmock.AnyArg(), int64(1), "key-1", "abc", mockNow).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectQuery(selectQuery).WithArgs(int64(1), "key-1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	record := s.mockRecord()
+	actual, err := s.repositoryImpl.Reserve(context.TODO(), record, mockNow)
+	s.Nil(err)
+	s.True(actual)
+	s.Equal(int64(7), record.ID)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestReserve_FailedTakeover() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("DELETE FROM `idempotent_request`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("INSERT INTO `idempotent_request`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `idempotent_request`")).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	actual, err := s.repositoryImpl.Reserve(context.TODO(), s.mockRecord(), mockNow)
+	s.Equal(gorm.ErrInvalidDB, err)
+	s.False(actual)
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestReserve_FailedDelete() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("DELETE FROM `idempotent_request`")).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	actual, err := s.repositoryImpl.Reserve(context.TODO(), s.mockRecord(), mockNow)
+	s.Equal(gorm.ErrInvalidDB, err)
+	s.False(actual)
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestReserve_FailedInsert() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("DELETE FROM `idempotent_request`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("INSERT INTO `idempotent_request`")).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	actual, err := s.repositoryImpl.Reserve(context.TODO(), s.mockRecord(), mockNow)
+	s.Equal(gorm.ErrInvalidDB, err)
+	s.False(actual)
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestGet_Success() {
+	query := regexp.QuoteMeta("SELECT * FROM `idempotent_request` WHERE user_id = ? AND idempotency_key = ? ORDER BY `idempotent_request`.`id` LIMIT 1")
+	rows := sqlmock.NewRows([]string{"id", "user_id", "idempotency_key", "fingerprint", "status_code", "content_type", "body"}).
+		AddRow(7, 1, "key-1", "abc", 200, "application/json", []byte(`{"id":1}`))
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1), "key-1").WillReturnRows(rows)
+	actual, err := s.repositoryImpl.Get(context.TODO(), 1, "key-1")
+	s.Nil(err)
+	s.Equal(&domain.IdempotentRequest{
+		ID:             7,
+		UserID:         1,
+		IdempotencyKey: "key-1",
+		Fingerprint:    "abc",
+		StatusCode:     200,
+		ContentType:    "application/json",
+		Body:           []byte(`{"id":1}`),
+	}, actual)
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestGet_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `idempotent_request` WHERE user_id = ? AND idempotency_key = ?")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1), "key-1").WillReturnError(gorm.ErrRecordNotFound)
+	actual, err := s.repositoryImpl.Get(context.TODO(), 1, "key-1")
+	s.Nil(actual)
+	s.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestComplete_Success() {
+	query := regexp.QuoteMeta("UPDATE `idempotent_request` SET `body`=?,`content_type`=?,`etag`=?,`status_code`=?,`updated_at`=? WHERE id = ? AND locked_until = ?")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WithArgs([]byte("{}"), "application/json", `"4"`, 200, sqlmock.AnyArg(), int64(7), mockNow).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectCommit()
+	record := &domain.IdempotentRequest{ID: 7, StatusCode: 200, ContentType: "application/json", ETag: `"4"`, Body: []byte("{}"), LockedUntil: mockNow}
+	s.Nil(s.repositoryImpl.Complete(context.TODO(), record))
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestComplete_Failed() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `idempotent_request`")).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	s.Equal(gorm.ErrInvalidDB, s.repositoryImpl.Complete(context.TODO(), &domain.IdempotentRequest{ID: 7}))
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestRelease_Success() {
+	query := regexp.QuoteMeta("DELETE FROM `idempotent_request` WHERE id = ? AND locked_until = ?")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WithArgs(int64(7), mockNow).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectCommit()
+	s.Nil(s.repositoryImpl.Release(context.TODO(), &domain.IdempotentRequest{ID: 7, LockedUntil: mockNow}))
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestRelease_Failed() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("DELETE FROM `idempotent_request`")).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	s.Equal(gorm.ErrInvalidDB, s.repositoryImpl.Release(context.TODO(), &domain.IdempotentRequest{ID: 7}))
+}
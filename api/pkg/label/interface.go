@@ -0,0 +1,27 @@
+package label
+
+import (
+	"context"
+
+	"shared-bike/domain"
+)
+
+type IBikeRepository interface {
+	GetByID(ctx context.Context, id int64) (*domain.Bike, error)
+	GetListByIDs(ctx context.Context, IDs []int64) (*[]domain.Bike, error)
+}
+
+type ILogger interface {
+	Info(i ...interface{})
+	Warn(i ...interface{})
+	Error(i ...interface{})
+}
+
+type IUseCase interface {
+	GetLabel(ctx context.Context, id int64, format domain.LabelFormat) (domain.LabelFile, error)
+	GetSheet(ctx context.Context, ids []int64, format domain.LabelFormat) (domain.LabelFile, error)
+}
+
+//go:generate mockery --name IBikeRepository --output mocks --case underscore
+//go:generate mockery --name ILogger --output mocks --case underscore
+//go:generate mockery --name IUseCase --output mocks --case underscore
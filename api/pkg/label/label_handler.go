@@ -0,0 +1,129 @@
+package label
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"github.com/labstack/echo/v4"
+)
+
+type handlerImpl struct {
+	useCase IUseCase
+}
+
+func NewHandler(useCase IUseCase) *handlerImpl {
+	return &handlerImpl{
+		useCase: useCase,
+	}
+}
+
+// GetLabel godoc
+// @Summary      Get the label of a bike
+// @Description  API for rendering the printable QR code label of a bike, the QR code encodes a deep-link with the bike code
+// @Tags         labels
+// @Produce      image/svg+xml
+// @Produce      image/png
+// @Param 			 id 	path  		string 		true 								"bike id"
+// @Param 			 format 	query  		string 		false 								"svg (default) or png"
+// @Success      200  {file}    file 												"Label"
+// @Failure      400  {string}  string 												"invalid bike id | invalid label query, expected format svg or png and 1 to 24 bike ids"
+// @Failure      403  {string}  string 												"permission denied"
+// @Failure      404  {string}  string 												"bike not found"
+// @Failure      409  {string}  string 												"the bike has no short code yet"
+// @Failure      500  {string}  string 												"internal server error"
+// @Security     BearerAuth
+// @Router       /admin/bikes/{id}/label [get]
+func (h *handlerImpl) GetLabel(c echo.Context) error {
+	span := tracing.StartHandler(c, "LabelHandler.GetLabel")
+	defer span.End()
+	ctx := c.Request().Context()
+	bikeIDStr := c.Param("id")
+	bikeID, err := strconv.ParseInt(bikeIDStr, 10, 64)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[LabelHandler.GetLabel] invalid bike %s", bikeIDStr), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBikeID), apperrors.ErrInvalidBikeID.Error())
+	}
+	format := parseFormat(c.QueryParam("format"))
+	c.Logger().Info(fmt.Sprintf("[LabelHandler.GetLabel] rendering %s label of bike %d", format, bikeID))
+	file, err := h.useCase.GetLabel(ctx, bikeID, format)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[LabelHandler.GetLabel] render label of bike %d failed", bikeID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	return blob(c, fmt.Sprintf("bike-%d-label.%s", bikeID, format), file)
+}
+
+// GetSheet godoc
+// @Summary      Get a printable sheet of bike labels
+// @Description  API for rendering the labels of up to 24 bikes on one A4 page of 4 by 6 stickers
+// @Tags         labels
+// @Produce      image/svg+xml
+// @Produce      image/png
+// @Param 			 ids 	query  		string 		true 								"comma separated bike ids" example(1,2,3)
+// @Param 			 format 	query  		string 		false 								"svg (default) or png"
+// @Success      200  {file}    file 												"Sheet"
+// @Failure      400  {string}  string 												"invalid label query, expected format svg or png and 1 to 24 bike ids"
+// @Failure      403  {string}  string 												"permission denied"
+// @Failure      404  {string}  string 												"bike not found"
+// @Failure      409  {string}  string 												"the bike has no short code yet"
+// @Failure      500  {string}  string 												"internal server error"
+// @Security     BearerAuth
+// @Router       /admin/bikes/labels [get]
+func (h *handlerImpl) GetSheet(c echo.Context) error {
+	span := tracing.StartHandler(c, "LabelHandler.GetSheet")
+	defer span.End()
+	ctx := c.Request().Context()
+	query := domain.LabelQuery{}
+	if err := c.Bind(&query); err != nil {
+		c.Logger().Error("[LabelHandler.GetSheet] invalid query", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidLabelQuery), apperrors.ErrInvalidLabelQuery.Error())
+	}
+	ids, err := parseIDs(query.IDs)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[LabelHandler.GetSheet] invalid ids %q", query.IDs), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	format := parseFormat(query.Format)
+	c.Logger().Info(fmt.Sprintf("[LabelHandler.GetSheet] rendering %s sheet of bikes %v", format, ids))
+	file, err := h.useCase.GetSheet(ctx, ids, format)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[LabelHandler.GetSheet] render sheet of bikes %v failed", ids), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	return blob(c, fmt.Sprintf("bike-labels.%s", format), file)
+}
+
+func parseFormat(value string) domain.LabelFormat {
+	if value == "" {
+		return domain.LabelFormatSVG
+	}
+	return domain.LabelFormat(strings.ToLower(value))
+}
+
+// parseIDs reads a comma separated list of bike ids, a bike listed twice gets a single sticker.
+func parseIDs(value string) ([]int64, error) {
+	ids := []int64{}
+	seen := map[int64]bool{}
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return nil, apperrors.ErrInvalidLabelQuery
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func blob(c echo.Context, filename string, file domain.LabelFile) error {
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", filename))
+	return c.Blob(http.StatusOK, file.ContentType, file.Body)
+}
@@ -0,0 +1,121 @@
+package label
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/label/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LabelHandlerTestSuite struct {
+	suite.Suite
+	mockUseCase *mocks.IUseCase
+	echo        *echo.Echo
+	handlerImpl *handlerImpl
+}
+
+func (s *LabelHandlerTestSuite) SetupTest() {
+	mockUseCase := &mocks.IUseCase{}
+	s.mockUseCase = mockUseCase
+	s.echo = echo.New()
+	s.handlerImpl = NewHandler(mockUseCase)
+}
+
+func TestLabelHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(LabelHandlerTestSuite))
+}
+
+func (s *LabelHandlerTestSuite) TestGetLabel_Success() {
+	file := domain.LabelFile{ContentType: "image/png", Body: []byte("png")}
+	s.mockUseCase.On("GetLabel", context.Background(), int64(1), domain.LabelFormatPNG).Return(file, nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/bikes/1/label?format=PNG", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/admin/bikes/:id/label")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.GetLabel(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("image/png", rec.Header().Get(echo.HeaderContentType))
+	s.Equal(`inline; filename="bike-1-label.png"`, rec.Header().Get(echo.HeaderContentDisposition))
+	s.Equal("png", rec.Body.String())
+}
+
+func (s *LabelHandlerTestSuite) TestGetLabel_DefaultsToSVG() {
+	file := domain.LabelFile{ContentType: "image/svg+xml", Body: []byte("<svg/>")}
+	s.mockUseCase.On("GetLabel", context.Background(), int64(1), domain.LabelFormatSVG).Return(file, nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/bikes/1/label", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.GetLabel(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("image/svg+xml", rec.Header().Get(echo.HeaderContentType))
+}
+
+func (s *LabelHandlerTestSuite) TestGetLabel_FailedParams() {
+	req := httptest.NewRequest(http.MethodGet, "/admin/bikes/abc/label", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	s.NoError(s.handlerImpl.GetLabel(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\""+apperrors.ErrInvalidBikeID.Error()+"\"\n", rec.Body.String())
+	s.mockUseCase.AssertNotCalled(s.T(), "GetLabel", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LabelHandlerTestSuite) TestGetLabel_FailedUseCase() {
+	s.mockUseCase.On("GetLabel", context.Background(), int64(1), domain.LabelFormatSVG).Return(domain.LabelFile{}, apperrors.ErrBikeHasNoCode)
+	req := httptest.NewRequest(http.MethodGet, "/admin/bikes/1/label", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.GetLabel(c))
+	s.Equal(http.StatusConflict, rec.Code)
+	s.Equal("\"e4094 the bike has no short code yet\"\n", rec.Body.String())
+}
+
+func (s *LabelHandlerTestSuite) TestGetSheet_Success() {
+	file := domain.LabelFile{ContentType: "image/svg+xml", Body: []byte("<svg/>")}
+	s.mockUseCase.On("GetSheet", context.Background(), []int64{3, 1}, domain.LabelFormatSVG).Return(file, nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/bikes/labels?ids=3,%201,3&format=svg", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetSheet(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(`inline; filename="bike-labels.svg"`, rec.Header().Get(echo.HeaderContentDisposition))
+	s.Equal("<svg/>", rec.Body.String())
+}
+
+func (s *LabelHandlerTestSuite) TestGetSheet_FailedParams() {
+	for _, target := range []string{"/admin/bikes/labels", "/admin/bikes/labels?ids=1,x"} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+		c := s.echo.NewContext(req, rec)
+		s.NoError(s.handlerImpl.GetSheet(c))
+		s.Equal(http.StatusBadRequest, rec.Code)
+		s.Equal("\""+apperrors.ErrInvalidLabelQuery.Error()+"\"\n", rec.Body.String())
+	}
+	s.mockUseCase.AssertNotCalled(s.T(), "GetSheet", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LabelHandlerTestSuite) TestGetSheet_FailedUseCase() {
+	s.mockUseCase.On("GetSheet", context.Background(), []int64{1}, domain.LabelFormatPNG).Return(domain.LabelFile{}, apperrors.ErrBikeNotFound)
+	req := httptest.NewRequest(http.MethodGet, "/admin/bikes/labels?ids=1&format=png", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetSheet(c))
+	s.Equal(http.StatusNotFound, rec.Code)
+	s.Equal("\"e4040 bike not found\"\n", rec.Body.String())
+}
@@ -0,0 +1,96 @@
+package label
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+)
+
+type useCaseImpl struct {
+	logger         ILogger
+	bikeRepository IBikeRepository
+	linkBaseURL    string
+}
+
+// NewUseCase renders labels whose QR code encodes linkBaseURL followed by the bike code.
+func NewUseCase(logger ILogger, bikeRepository IBikeRepository, linkBaseURL string) *useCaseImpl {
+	return &useCaseImpl{
+		logger:         logger,
+		bikeRepository: bikeRepository,
+		linkBaseURL:    linkBaseURL,
+	}
+}
+
+func (u *useCaseImpl) GetLabel(ctx context.Context, id int64, format domain.LabelFormat) (domain.LabelFile, error) {
+	ctx, span := tracing.Start(ctx, "LabelUseCase.GetLabel")
+	defer span.End()
+	if !format.IsValid() {
+		return domain.LabelFile{}, apperrors.ErrInvalidLabelQuery
+	}
+	bike, err := u.bikeRepository.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info(fmt.Sprintf("[LabelUseCase.GetLabel] cannot find bike %d", id))
+		return domain.LabelFile{}, apperrors.ErrBikeNotFound
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[LabelUseCase.GetLabel] fetch bike %d failed", id), err)
+		return domain.LabelFile{}, apperrors.ErrInternalServerError
+	}
+	if bike.Code == "" {
+		u.logger.Info(fmt.Sprintf("[LabelUseCase.GetLabel] bike %d has no code", id))
+		return domain.LabelFile{}, apperrors.ErrBikeHasNoCode
+	}
+	file, err := render([]domain.Label{u.toLabel(bike)}, 1, 1, format)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[LabelUseCase.GetLabel] render label of bike %d failed", id), err)
+		return domain.LabelFile{}, apperrors.ErrInternalServerError
+	}
+	return file, nil
+}
+
+// GetSheet lays the labels of up to one A4 page of bikes out in id order, unused stickers stay blank.
+func (u *useCaseImpl) GetSheet(ctx context.Context, ids []int64, format domain.LabelFormat) (domain.LabelFile, error) {
+	ctx, span := tracing.Start(ctx, "LabelUseCase.GetSheet")
+	defer span.End()
+	if !format.IsValid() || len(ids) == 0 || len(ids) > maxSheetLabels {
+		return domain.LabelFile{}, apperrors.ErrInvalidLabelQuery
+	}
+	bikes, err := u.bikeRepository.GetListByIDs(ctx, ids)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[LabelUseCase.GetSheet] fetch bikes %v failed", ids), err)
+		return domain.LabelFile{}, apperrors.ErrInternalServerError
+	}
+	if len(*bikes) != len(ids) {
+		u.logger.Info(fmt.Sprintf("[LabelUseCase.GetSheet] found %d of bikes %v", len(*bikes), ids))
+		return domain.LabelFile{}, apperrors.ErrBikeNotFound
+	}
+	labels := make([]domain.Label, 0, len(*bikes))
+	for i := range *bikes {
+		bike := &(*bikes)[i]
+		if bike.Code == "" {
+			u.logger.Info(fmt.Sprintf("[LabelUseCase.GetSheet] bike %d has no code", bike.ID))
+			return domain.LabelFile{}, apperrors.ErrBikeHasNoCode
+		}
+		labels = append(labels, u.toLabel(bike))
+	}
+	file, err := render(labels, sheetColumns, sheetRows, format)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[LabelUseCase.GetSheet] render sheet of bikes %v failed", ids), err)
+		return domain.LabelFile{}, apperrors.ErrInternalServerError
+	}
+	return file, nil
+}
+
+func (u *useCaseImpl) toLabel(bike *domain.Bike) domain.Label {
+	return domain.Label{
+		Code: bike.Code,
+		Name: bike.Name,
+		Link: u.linkBaseURL + bike.Code,
+	}
+}
@@ -0,0 +1,123 @@
+package label
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/label/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type LabelUseCaseTestSuite struct {
+	suite.Suite
+	mockBikeRepository *mocks.IBikeRepository
+	mockLogger         *mocks.ILogger
+	useCaseImpl        *useCaseImpl
+}
+
+func (s *LabelUseCaseTestSuite) SetupTest() {
+	s.mockBikeRepository = &mocks.IBikeRepository{}
+	s.mockLogger = &mocks.ILogger{}
+	s.mockLogger.On("Info", mock.Anything).Return()
+	s.mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+	s.useCaseImpl = NewUseCase(s.mockLogger, s.mockBikeRepository, "https://bike.example.com/r/")
+}
+
+func TestLabelUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(LabelUseCaseTestSuite))
+}
+
+func (s *LabelUseCaseTestSuite) TestGetLabel_Success() {
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(&domain.Bike{ID: 1, Name: "henry", Code: "7F3K9Q2M"}, nil)
+	actual, err := s.useCaseImpl.GetLabel(context.TODO(), 1, domain.LabelFormatSVG)
+	s.Nil(err)
+	s.Equal("image/svg+xml", actual.ContentType)
+	s.Contains(string(actual.Body), ">7F3K9Q2M</text>")
+}
+
+func (s *LabelUseCaseTestSuite) TestGetLabel_InvalidFormat() {
+	actual, err := s.useCaseImpl.GetLabel(context.TODO(), 1, domain.LabelFormat("pdf"))
+	s.Equal(domain.LabelFile{}, actual)
+	s.Equal(apperrors.ErrInvalidLabelQuery, err)
+	s.mockBikeRepository.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+}
+
+func (s *LabelUseCaseTestSuite) TestGetLabel_NotFound() {
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.GetLabel(context.TODO(), 1, domain.LabelFormatPNG)
+	s.Equal(domain.LabelFile{}, actual)
+	s.Equal(apperrors.ErrBikeNotFound, err)
+}
+
+func (s *LabelUseCaseTestSuite) TestGetLabel_InternalServerError() {
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.GetLabel(context.TODO(), 1, domain.LabelFormatPNG)
+	s.Equal(domain.LabelFile{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *LabelUseCaseTestSuite) TestGetLabel_BikeHasNoCode() {
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(&domain.Bike{ID: 1}, nil)
+	actual, err := s.useCaseImpl.GetLabel(context.TODO(), 1, domain.LabelFormatPNG)
+	s.Equal(domain.LabelFile{}, actual)
+	s.Equal(apperrors.ErrBikeHasNoCode, err)
+}
+
+func (s *LabelUseCaseTestSuite) TestGetLabel_LinkTooLong() {
+	s.useCaseImpl.linkBaseURL = strings.Repeat("x", 3000)
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(&domain.Bike{ID: 1, Code: "7F3K9Q2M"}, nil)
+	actual, err := s.useCaseImpl.GetLabel(context.TODO(), 1, domain.LabelFormatSVG)
+	s.Equal(domain.LabelFile{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *LabelUseCaseTestSuite) TestGetSheet_Success() {
+	bikes := []domain.Bike{{ID: 1, Code: "7F3K9Q2M"}, {ID: 2, Code: "A1B2C3D4"}}
+	s.mockBikeRepository.On("GetListByIDs", context.TODO(), []int64{1, 2}).Return(&bikes, nil)
+	actual, err := s.useCaseImpl.GetSheet(context.TODO(), []int64{1, 2}, domain.LabelFormatPNG)
+	s.Nil(err)
+	s.Equal("image/png", actual.ContentType)
+	s.NotEmpty(actual.Body)
+}
+
+func (s *LabelUseCaseTestSuite) TestGetSheet_InvalidQuery() {
+	tooMany := make([]int64, maxSheetLabels+1)
+	for _, ids := range [][]int64{{}, tooMany} {
+		actual, err := s.useCaseImpl.GetSheet(context.TODO(), ids, domain.LabelFormatSVG)
+		s.Equal(domain.LabelFile{}, actual)
+		s.Equal(apperrors.ErrInvalidLabelQuery, err)
+	}
+	actual, err := s.useCaseImpl.GetSheet(context.TODO(), []int64{1}, domain.LabelFormat("gif"))
+	s.Equal(domain.LabelFile{}, actual)
+	s.Equal(apperrors.ErrInvalidLabelQuery, err)
+	s.mockBikeRepository.AssertNotCalled(s.T(), "GetListByIDs", mock.Anything, mock.Anything)
+}
+
+func (s *LabelUseCaseTestSuite) TestGetSheet_InternalServerError() {
+	s.mockBikeRepository.On("GetListByIDs", context.TODO(), []int64{1, 2}).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.GetSheet(context.TODO(), []int64{1, 2}, domain.LabelFormatSVG)
+	s.Equal(domain.LabelFile{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *LabelUseCaseTestSuite) TestGetSheet_MissingBike() {
+	bikes := []domain.Bike{{ID: 1, Code: "7F3K9Q2M"}}
+	s.mockBikeRepository.On("GetListByIDs", context.TODO(), []int64{1, 2}).Return(&bikes, nil)
+	actual, err := s.useCaseImpl.GetSheet(context.TODO(), []int64{1, 2}, domain.LabelFormatSVG)
+	s.Equal(domain.LabelFile{}, actual)
+	s.Equal(apperrors.ErrBikeNotFound, err)
+}
+
+func (s *LabelUseCaseTestSuite) TestGetSheet_BikeHasNoCode() {
+	bikes := []domain.Bike{{ID: 1, Code: "7F3K9Q2M"}, {ID: 2}}
+	s.mockBikeRepository.On("GetListByIDs", context.TODO(), []int64{1, 2}).Return(&bikes, nil)
+	actual, err := s.useCaseImpl.GetSheet(context.TODO(), []int64{1, 2}, domain.LabelFormatSVG)
+	s.Equal(domain.LabelFile{}, actual)
+	s.Equal(apperrors.ErrBikeHasNoCode, err)
+}
@@ -0,0 +1,72 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IUseCase is an autogenerated mock type for the IUseCase type
+type IUseCase struct {
+	mock.Mock
+}
+
+// GetLabel provides a mock function with given fields: ctx, id, format
+func (_m *IUseCase) GetLabel(ctx context.Context, id int64, format domain.LabelFormat) (domain.LabelFile, error) {
+	ret := _m.Called(ctx, id, format)
+
+	var r0 domain.LabelFile
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.LabelFormat) domain.LabelFile); ok {
+		r0 = rf(ctx, id, format)
+	} else {
+		r0 = ret.Get(0).(domain.LabelFile)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.LabelFormat) error); ok {
+		r1 = rf(ctx, id, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSheet provides a mock function with given fields: ctx, ids, format
+func (_m *IUseCase) GetSheet(ctx context.Context, ids []int64, format domain.LabelFormat) (domain.LabelFile, error) {
+	ret := _m.Called(ctx, ids, format)
+
+	var r0 domain.LabelFile
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, domain.LabelFormat) domain.LabelFile); ok {
+		r0 = rf(ctx, ids, format)
+	} else {
+		r0 = ret.Get(0).(domain.LabelFile)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int64, domain.LabelFormat) error); ok {
+		r1 = rf(ctx, ids, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIUseCase creates a new instance of IUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIUseCase(t mockConstructorTestingTNewIUseCase) *IUseCase {
+	mock := &IUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,172 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/draw"
+	"image/png"
+	"strconv"
+
+	"shared-bike/domain"
+
+	qrcode "github.com/skip2/go-qrcode"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// A sheet is an A4 page of sheetColumns x sheetRows stickers, a single label has the size of one sticker.
+// Geometry is kept in millimetres so the SVG prints at its real size.
+const (
+	sheetColumns   = 4
+	sheetRows      = 6
+	maxSheetLabels = sheetColumns * sheetRows
+	pageWidthMM    = 210.0
+	pageHeightMM   = 297.0
+	cellWidthMM    = pageWidthMM / sheetColumns
+	cellHeightMM   = pageHeightMM / sheetRows
+	qrMarginMM     = 3.0
+	qrSizeMM       = 36.0
+	textBaselineMM = 45.5
+	textSizeMM     = 6.0
+	pngPixelsPerMM = 8
+	pngTextScale   = 3
+)
+
+func render(labels []domain.Label, columns, rows int, format domain.LabelFormat) (domain.LabelFile, error) {
+	var (
+		body []byte
+		err  error
+	)
+	if format == domain.LabelFormatPNG {
+		body, err = renderPNG(labels, columns, rows)
+	} else {
+		body, err = renderSVG(labels, columns, rows)
+	}
+	if err != nil {
+		return domain.LabelFile{}, err
+	}
+	return domain.LabelFile{
+		ContentType: format.ContentType(),
+		Body:        body,
+	}, nil
+}
+
+// qrBitmap returns the dark modules of the QR code for link, including the quiet zone scanners need around it.
+func qrBitmap(link string) ([][]bool, error) {
+	code, err := qrcode.New(link, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	return code.Bitmap(), nil
+}
+
+// renderSVG draws every row of dark modules as merged rectangles in a single path per label, which keeps sheets small.
+func renderSVG(labels []domain.Label, columns, rows int) ([]byte, error) {
+	width, height := float64(columns)*cellWidthMM, float64(rows)*cellHeightMM
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%smm" height="%smm" viewBox="0 0 %s %s" shape-rendering="crispEdges">`,
+		formatMM(width), formatMM(height), formatMM(width), formatMM(height))
+	buf.WriteString(`<rect width="100%" height="100%" fill="#fff"/>`)
+	for i, label := range labels {
+		bitmap, err := qrBitmap(label.Link)
+		if err != nil {
+			return nil, err
+		}
+		cellX := float64(i%columns) * cellWidthMM
+		cellY := float64(i/columns) * cellHeightMM
+		module := qrSizeMM / float64(len(bitmap))
+		qrX := cellX + (cellWidthMM-qrSizeMM)/2
+		qrY := cellY + qrMarginMM
+		fmt.Fprintf(&buf, `<g><title>%s</title><path fill="#000" d="`, html.EscapeString(label.Name))
+		for row, line := range bitmap {
+			for col := 0; col < len(line); {
+				if !line[col] {
+					col++
+					continue
+				}
+				start := col
+				for col < len(line) && line[col] {
+					col++
+				}
+				runWidth := float64(col-start) * module
+				fmt.Fprintf(&buf, "M%s %sh%sv%sh-%sz",
+					formatMM(qrX+float64(start)*module), formatMM(qrY+float64(row)*module),
+					formatMM(runWidth), formatMM(module), formatMM(runWidth))
+			}
+		}
+		fmt.Fprintf(&buf, `"/><text x="%s" y="%s" text-anchor="middle" font-family="monospace" font-weight="bold" font-size="%s">%s</text></g>`,
+			formatMM(cellX+cellWidthMM/2), formatMM(cellY+textBaselineMM), formatMM(textSizeMM), html.EscapeString(label.Code))
+	}
+	buf.WriteString("</svg>")
+	return buf.Bytes(), nil
+}
+
+func formatMM(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// renderPNG rasterises at pngPixelsPerMM (about 200 dpi, what label printers expect) with whole-pixel modules.
+func renderPNG(labels []domain.Label, columns, rows int) ([]byte, error) {
+	cellWidth := int(cellWidthMM * pngPixelsPerMM)
+	cellHeight := int(cellHeightMM * pngPixelsPerMM)
+	img := image.NewGray(image.Rect(0, 0, columns*cellWidth, rows*cellHeight))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	for i, label := range labels {
+		bitmap, err := qrBitmap(label.Link)
+		if err != nil {
+			return nil, err
+		}
+		cellX := (i % columns) * cellWidth
+		cellY := (i / columns) * cellHeight
+		module := int(qrSizeMM*pngPixelsPerMM) / len(bitmap)
+		if module < 1 {
+			module = 1
+		}
+		qrX := cellX + (cellWidth-module*len(bitmap))/2
+		qrY := cellY + int(qrMarginMM*pngPixelsPerMM)
+		for row, line := range bitmap {
+			for col, dark := range line {
+				if dark {
+					fillSquare(img, qrX+col*module, qrY+row*module, module)
+				}
+			}
+		}
+		drawText(img, label.Code, cellX+cellWidth/2, cellY+int(textBaselineMM*pngPixelsPerMM))
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// drawText centres text on centerX, basicfont is tiny so it is drawn once and scaled up by pngTextScale.
+func drawText(dst *image.Gray, text string, centerX, baselineY int) {
+	face := basicfont.Face7x13
+	width := font.MeasureString(face, text).Ceil()
+	glyphs := image.NewGray(image.Rect(0, 0, width, face.Height))
+	draw.Draw(glyphs, glyphs.Bounds(), image.White, image.Point{}, draw.Src)
+	drawer := font.Drawer{
+		Dst:  glyphs,
+		Src:  image.Black,
+		Face: face,
+		Dot:  fixed.P(0, face.Ascent),
+	}
+	drawer.DrawString(text)
+	left := centerX - width*pngTextScale/2
+	top := baselineY - face.Ascent*pngTextScale
+	for y := 0; y < face.Height; y++ {
+		for x := 0; x < width; x++ {
+			if glyphs.GrayAt(x, y).Y < 128 {
+				fillSquare(dst, left+x*pngTextScale, top+y*pngTextScale, pngTextScale)
+			}
+		}
+	}
+}
+
+func fillSquare(dst *image.Gray, x, y, size int) {
+	draw.Draw(dst, image.Rect(x, y, x+size, y+size), image.Black, image.Point{}, draw.Src)
+}
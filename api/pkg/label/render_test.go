@@ -0,0 +1,86 @@
+package label
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RenderTestSuite struct {
+	suite.Suite
+}
+
+func TestRenderTestSuite(t *testing.T) {
+	suite.Run(t, new(RenderTestSuite))
+}
+
+var mockLabel = domain.Label{
+	Code: "7F3K9Q2M",
+	Name: "henry",
+	Link: "sharedbike://bikes/7F3K9Q2M",
+}
+
+func (s *RenderTestSuite) TestRender_SVGLabel() {
+	file, err := render([]domain.Label{mockLabel}, 1, 1, domain.LabelFormatSVG)
+	s.Nil(err)
+	s.Equal("image/svg+xml", file.ContentType)
+	body := string(file.Body)
+	s.True(strings.HasPrefix(body, `<svg xmlns="http://www.w3.org/2000/svg" width="52.5mm" height="49.5mm"`))
+	s.Contains(body, ">7F3K9Q2M</text>")
+	s.Contains(body, "<title>henry</title>")
+	s.NoError(xml.Unmarshal(file.Body, new(interface{})))
+}
+
+func (s *RenderTestSuite) TestRender_SVGSheet() {
+	other := domain.Label{Code: "A1B2C3D4", Name: "<b&o>", Link: "sharedbike://bikes/A1B2C3D4"}
+	file, err := render([]domain.Label{mockLabel, other}, sheetColumns, sheetRows, domain.LabelFormatSVG)
+	s.Nil(err)
+	body := string(file.Body)
+	s.Contains(body, `width="210mm" height="297mm"`)
+	s.Equal(2, strings.Count(body, "<g>"))
+	s.Contains(body, `<text x="78.75" y="45.5"`)
+	s.Contains(body, "<title>&lt;b&amp;o&gt;</title>")
+}
+
+func (s *RenderTestSuite) TestRender_PNGLabel() {
+	file, err := render([]domain.Label{mockLabel}, 1, 1, domain.LabelFormatPNG)
+	s.Nil(err)
+	s.Equal("image/png", file.ContentType)
+	img, err := png.Decode(bytes.NewReader(file.Body))
+	s.Nil(err)
+	s.Equal(image.Rect(0, 0, 420, 396), img.Bounds())
+	bitmap, err := qrBitmap(mockLabel.Link)
+	s.Nil(err)
+	module := 288 / len(bitmap)
+	qrX := (420 - module*len(bitmap)) / 2
+	// the quiet zone stays white and the finder pattern right after it is dark
+	s.False(isDark(img, qrX+module/2, 24+module/2))
+	s.True(isDark(img, qrX+4*module+module/2, 24+4*module+module/2))
+}
+
+func (s *RenderTestSuite) TestRender_PNGSheet() {
+	file, err := render([]domain.Label{mockLabel}, sheetColumns, sheetRows, domain.LabelFormatPNG)
+	s.Nil(err)
+	img, err := png.Decode(bytes.NewReader(file.Body))
+	s.Nil(err)
+	s.Equal(image.Rect(0, 0, 1680, 2376), img.Bounds())
+	// the second sticker is left blank
+	s.False(isDark(img, 420+210, 198))
+}
+
+func (s *RenderTestSuite) TestQRBitmap_TooLong() {
+	_, err := qrBitmap(strings.Repeat("x", 3000))
+	s.Error(err)
+}
+
+func isDark(img image.Image, x, y int) bool {
+	r, _, _, _ := img.At(x, y).RGBA()
+	return r < 0x8000
+}
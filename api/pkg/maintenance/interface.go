@@ -0,0 +1,42 @@
+package maintenance
+
+import (
+	"context"
+
+	"shared-bike/domain"
+)
+
+type IRepository interface {
+	Create(ctx context.Context, body *domain.MaintenanceTicket) error
+	GetByID(ctx context.Context, id int64) (*domain.MaintenanceTicket, error)
+	GetList(ctx context.Context, filter domain.TicketFilter) (*[]domain.MaintenanceTicket, error)
+	UpdateStatus(ctx context.Context, body *domain.MaintenanceTicket) error
+	CountConfirmedByBikeID(ctx context.Context, bikeID int64) (int64, error)
+}
+
+type IBikeRepository interface {
+	GetByID(ctx context.Context, id int64) (*domain.Bike, error)
+	UpdateStatusAndUserID(ctx context.Context, body *domain.Bike) (bool, error)
+}
+
+type IAuditor interface {
+	Record(ctx context.Context, body domain.AuditRecord) error
+}
+
+type ILogger interface {
+	Info(i ...interface{})
+	Warn(i ...interface{})
+	Error(i ...interface{})
+}
+
+type IUseCase interface {
+	Report(ctx context.Context, body domain.ReportBikeRequestPayload) (domain.MaintenanceTicketDTO, error)
+	GetList(ctx context.Context, filter domain.TicketFilter) ([]domain.MaintenanceTicketDTO, error)
+	UpdateStatus(ctx context.Context, body domain.UpdateTicketRequestPayload) (domain.MaintenanceTicketDTO, error)
+}
+
+//go:generate mockery --name IRepository --output mocks --case underscore
+//go:generate mockery --name IBikeRepository --output mocks --case underscore
+//go:generate mockery --name IAuditor --output mocks --case underscore
+//go:generate mockery --name ILogger --output mocks --case underscore
+//go:generate mockery --name IUseCase --output mocks --case underscore
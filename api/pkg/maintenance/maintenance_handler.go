@@ -0,0 +1,166 @@
+package maintenance
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/middleware"
+	"shared-bike/tracing"
+
+	"github.com/labstack/echo/v4"
+)
+
+type handlerImpl struct {
+	useCase IUseCase
+}
+
+func NewHandler(useCase IUseCase) *handlerImpl {
+	return &handlerImpl{
+		useCase: useCase,
+	}
+}
+
+// Report godoc
+// @Summary      Report a damaged bike
+// @Description  API for riders to report a problem with a bike, the bike stays in service until staff confirm the ticket
+// @Tags         bikes
+// @Accept       json
+// @Produce      json
+// @Param 			 id 	     path  		string 		             true 	"bike id"
+// @Param    		 request  body      domain.ReportBikeBody  true  "Report body"
+// @Success      201  {object}  domain.MaintenanceTicketDTO 			"Success"
+// @Failure      400  {string}  string 												"invalid bike id | invalid body | invalid report category | bike not found"
+// @Failure      409  {string}  string 												"the bike was changed by someone else, reload it and try again"
+// @Failure      500  {string}  string 												"internal server error"
+// @Security     BearerAuth
+// @Router       /bikes/{id}/report [post]
+func (h *handlerImpl) Report(c echo.Context) error {
+	span := tracing.StartHandler(c, "MaintenanceHandler.Report")
+	defer span.End()
+	var (
+		ctx    = c.Request().Context()
+		bikeID int64
+		err    error
+	)
+	bikeIDStr := c.Param("id")
+	if bikeID, err = strconv.ParseInt(bikeIDStr, 10, 64); err != nil {
+		c.Logger().Error(fmt.Sprintf("[MaintenanceHandler.Report] invalid bike id %s", bikeIDStr), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBikeID), apperrors.ErrInvalidBikeID.Error())
+	}
+	body := domain.ReportBikeBody{}
+	if err := c.Bind(&body); err != nil {
+		c.Logger().Error("[MaintenanceHandler.Report] invalid body", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBody), apperrors.ErrInvalidBody.Error())
+	}
+	claims, err := middleware.GetClaims(c)
+	if err != nil {
+		c.Logger().Error("[MaintenanceHandler.Report] missing claims", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	userID := claims.ID
+	request := domain.ReportBikeRequestPayload{
+		BikeID:     bikeID,
+		ReporterID: userID,
+		Category:   body.Category,
+		Note:       body.Note,
+	}
+	c.Logger().Info(fmt.Sprintf("[MaintenanceHandler.Report] user %d is reporting bike %d", userID, bikeID))
+	ticket, err := h.useCase.Report(ctx, request)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[MaintenanceHandler.Report] user %d report bike %d failed", userID, bikeID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[MaintenanceHandler.Report] user %d report bike %d success", userID, bikeID))
+	return c.JSON(http.StatusCreated, ticket)
+}
+
+// GetList godoc
+// @Summary      Get maintenance tickets
+// @Description  API for the maintenance crew to list tickets, newest first
+// @Tags         maintenance
+// @Accept       json
+// @Produce      json
+// @Param        status  query     string  false  "open | in_progress | resolved"
+// @Param        bikeId  query     int     false  "bike id"
+// @Success      200  {array}   []domain.MaintenanceTicketDTO "Success"
+// @Failure      400  {string}  string 	"invalid body"
+// @Failure      403  {string}  string 	"you do not have permission to perform this action"
+// @Failure      500  {string}  string 	"internal server error"
+// @Security     BearerAuth
+// @Router       /maintenance/tickets [get]
+func (h *handlerImpl) GetList(c echo.Context) error {
+	span := tracing.StartHandler(c, "MaintenanceHandler.GetList")
+	defer span.End()
+	c.Logger().Info("[MaintenanceHandler.GetList] starting")
+	ctx := c.Request().Context()
+	filter := domain.TicketFilter{}
+	if err := c.Bind(&filter); err != nil {
+		c.Logger().Error("[MaintenanceHandler.GetList] invalid query", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBody), apperrors.ErrInvalidBody.Error())
+	}
+	tickets, err := h.useCase.GetList(ctx, filter)
+	if err != nil {
+		c.Logger().Error("[MaintenanceHandler.GetList] cannot get maintenance tickets", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info("[MaintenanceHandler.GetList] success")
+	return c.JSON(http.StatusOK, tickets)
+}
+
+// UpdateStatus godoc
+// @Summary      Update a maintenance ticket
+// @Description  API for moving a ticket between open, in_progress and resolved. in_progress confirms the problem and takes the bike out of service, resolving with retireBike retires it
+// @Tags         maintenance
+// @Accept       json
+// @Produce      json
+// @Param 			 id 	     path  		string 		               true 	"ticket id"
+// @Param    		 request  body      domain.UpdateTicketBody  true  "Update ticket body"
+// @Success      200  {object}  domain.MaintenanceTicketDTO 			"Success"
+// @Failure      400  {string}  string 												"invalid maintenance ticket id | invalid body | bike not found"
+// @Failure      403  {string}  string 												"you do not have permission to perform this action"
+// @Failure      404  {string}  string 												"maintenance ticket not found"
+// @Failure      409  {string}  string 												"cannot move the maintenance ticket to this status | the bike was changed by someone else, reload it and try again"
+// @Failure      500  {string}  string 												"internal server error"
+// @Security     BearerAuth
+// @Router       /maintenance/tickets/{id} [patch]
+func (h *handlerImpl) UpdateStatus(c echo.Context) error {
+	span := tracing.StartHandler(c, "MaintenanceHandler.UpdateStatus")
+	defer span.End()
+	var (
+		ctx      = c.Request().Context()
+		ticketID int64
+		err      error
+	)
+	ticketIDStr := c.Param("id")
+	if ticketID, err = strconv.ParseInt(ticketIDStr, 10, 64); err != nil {
+		c.Logger().Error(fmt.Sprintf("[MaintenanceHandler.UpdateStatus] invalid ticket id %s", ticketIDStr), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidTicketID), apperrors.ErrInvalidTicketID.Error())
+	}
+	body := domain.UpdateTicketBody{}
+	if err := c.Bind(&body); err != nil {
+		c.Logger().Error("[MaintenanceHandler.UpdateStatus] invalid body", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBody), apperrors.ErrInvalidBody.Error())
+	}
+	claims, err := middleware.GetClaims(c)
+	if err != nil {
+		c.Logger().Error("[MaintenanceHandler.UpdateStatus] missing claims", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	request := domain.UpdateTicketRequestPayload{
+		ID:         ticketID,
+		Status:     body.Status,
+		RetireBike: body.RetireBike,
+		ActorID:    claims.ID,
+	}
+	c.Logger().Info(fmt.Sprintf("[MaintenanceHandler.UpdateStatus] moving ticket %d to %s", ticketID, body.Status))
+	ticket, err := h.useCase.UpdateStatus(ctx, request)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[MaintenanceHandler.UpdateStatus] move ticket %d failed", ticketID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[MaintenanceHandler.UpdateStatus] move ticket %d success", ticketID))
+	return c.JSON(http.StatusOK, ticket)
+}
@@ -0,0 +1,189 @@
+package maintenance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/middleware"
+	"shared-bike/pkg/maintenance/mocks"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MaintenanceHandlerTestSuite struct {
+	suite.Suite
+	mockUseCase *mocks.IUseCase
+	echo        *echo.Echo
+	handlerImpl *handlerImpl
+}
+
+func (s *MaintenanceHandlerTestSuite) SetupTest() {
+	mockUseCase := &mocks.IUseCase{}
+	s.mockUseCase = mockUseCase
+	s.echo = echo.New()
+	s.handlerImpl = NewHandler(mockUseCase)
+}
+
+func TestMaintenanceHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceHandlerTestSuite))
+}
+
+func (s *MaintenanceHandlerTestSuite) newReportContext(bikeID string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPost, "/bikes/"+bikeID+"/report", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes/:id/report")
+	c.SetParamNames("id")
+	c.SetParamValues(bikeID)
+	c.Set(middleware.UserKey, &jwt.Token{
+		Claims: &domain.Claims{ID: 2},
+	})
+	return c, rec
+}
+
+func (s *MaintenanceHandlerTestSuite) TestReport_Success() {
+	request := domain.ReportBikeRequestPayload{
+		BikeID:     1,
+		ReporterID: 2,
+		Category:   domain.ReportCategoryBrakes,
+		Note:       "front brake",
+	}
+	mockResult := domain.MaintenanceTicketDTO{
+		ID:         3,
+		BikeID:     1,
+		ReporterID: 2,
+		Category:   domain.ReportCategoryBrakes,
+		Note:       "front brake",
+		Status:     domain.TicketStatusOpen,
+	}
+	s.mockUseCase.On("Report", context.Background(), request).Return(mockResult, nil)
+	c, rec := s.newReportContext("1", `{"category":"brakes","note":"front brake"}`)
+	respBody := `{"id":3,"bikeId":1,"reporterId":2,"category":"brakes","note":"front brake","status":"open","createdAt":"0001-01-01T00:00:00Z"}
+`
+	s.NoError(s.handlerImpl.Report(c))
+	s.Equal(http.StatusCreated, rec.Code)
+	s.Equal(respBody, rec.Body.String())
+}
+
+func (s *MaintenanceHandlerTestSuite) TestReport_FailedParams() {
+	c, rec := s.newReportContext("abc", `{"category":"brakes"}`)
+	s.NoError(s.handlerImpl.Report(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(`"e4006 invalid bike id"`+"\n", rec.Body.String())
+}
+
+func (s *MaintenanceHandlerTestSuite) TestReport_FailedBody() {
+	c, rec := s.newReportContext("1", `{"category":`)
+	s.NoError(s.handlerImpl.Report(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(`"`+apperrors.ErrInvalidBody.Error()+`"`+"\n", rec.Body.String())
+}
+
+func (s *MaintenanceHandlerTestSuite) TestReport_FailedUseCase() {
+	request := domain.ReportBikeRequestPayload{
+		BikeID:     1,
+		ReporterID: 2,
+		Category:   "wobbly",
+	}
+	s.mockUseCase.On("Report", context.Background(), request).Return(domain.MaintenanceTicketDTO{}, apperrors.ErrInvalidCategory)
+	c, rec := s.newReportContext("1", `{"category":"wobbly"}`)
+	s.NoError(s.handlerImpl.Report(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(`"e40010 invalid report category"`+"\n", rec.Body.String())
+}
+
+func (s *MaintenanceHandlerTestSuite) TestGetList_Success() {
+	filter := domain.TicketFilter{Status: domain.TicketStatusOpen, BikeID: 1}
+	s.mockUseCase.On("GetList", context.Background(), filter).Return([]domain.MaintenanceTicketDTO{}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/maintenance/tickets?status=open&bikeId=1", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("[]\n", rec.Body.String())
+}
+
+func (s *MaintenanceHandlerTestSuite) TestGetList_FailedQuery() {
+	req := httptest.NewRequest(http.MethodGet, "/maintenance/tickets?bikeId=abc", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *MaintenanceHandlerTestSuite) TestGetList_FailedUseCase() {
+	s.mockUseCase.On("GetList", context.Background(), domain.TicketFilter{}).Return(nil, apperrors.ErrForbidden)
+	req := httptest.NewRequest(http.MethodGet, "/maintenance/tickets", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *MaintenanceHandlerTestSuite) newUpdateContext(ticketID string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPatch, "/maintenance/tickets/"+ticketID, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/maintenance/tickets/:id")
+	c.SetParamNames("id")
+	c.SetParamValues(ticketID)
+	c.Set(middleware.UserKey, &jwt.Token{
+		Claims: &domain.Claims{ID: 9},
+	})
+	return c, rec
+}
+
+func (s *MaintenanceHandlerTestSuite) TestUpdateStatus_Success() {
+	request := domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusResolved, RetireBike: true, ActorID: 9}
+	s.mockUseCase.On("UpdateStatus", context.Background(), request).Return(domain.MaintenanceTicketDTO{ID: 3, Status: domain.TicketStatusResolved}, nil)
+	c, rec := s.newUpdateContext("3", `{"status":"resolved","retireBike":true}`)
+	s.NoError(s.handlerImpl.UpdateStatus(c))
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *MaintenanceHandlerTestSuite) TestUpdateStatus_FailedParams() {
+	c, rec := s.newUpdateContext("abc", `{"status":"resolved"}`)
+	s.NoError(s.handlerImpl.UpdateStatus(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(`"e40011 invalid maintenance ticket id"`+"\n", rec.Body.String())
+}
+
+func (s *MaintenanceHandlerTestSuite) TestUpdateStatus_FailedBody() {
+	c, rec := s.newUpdateContext("3", `{"status":`)
+	s.NoError(s.handlerImpl.UpdateStatus(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *MaintenanceHandlerTestSuite) TestUpdateStatus_FailedUseCase() {
+	request := domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusOpen, ActorID: 9}
+	s.mockUseCase.On("UpdateStatus", context.Background(), request).Return(domain.MaintenanceTicketDTO{}, apperrors.ErrInvalidTicketTransition)
+	c, rec := s.newUpdateContext("3", `{"status":"open"}`)
+	s.NoError(s.handlerImpl.UpdateStatus(c))
+	s.Equal(http.StatusConflict, rec.Code)
+}
+
+func (s *MaintenanceHandlerTestSuite) TestUpdateStatus_Unauthorized() {
+	c, rec := s.newUpdateContext("3", `{"status":"resolved"}`)
+	c.Set(middleware.UserKey, nil)
+	s.NoError(s.handlerImpl.UpdateStatus(c))
+	s.Equal(http.StatusUnauthorized, rec.Code)
+	s.mockUseCase.AssertNotCalled(s.T(), "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func (s *MaintenanceHandlerTestSuite) TestReport_Unauthorized() {
+	c, rec := s.newReportContext("1", `{"category":"brakes","note":"front brake"}`)
+	c.Set(middleware.UserKey, nil)
+	s.NoError(s.handlerImpl.Report(c))
+	s.Equal(http.StatusUnauthorized, rec.Code)
+	s.Equal("\"e4010 unauthorized\"\n", rec.Body.String())
+}
@@ -0,0 +1,84 @@
+package maintenance
+
+import (
+	"context"
+
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repositoryImpl {
+	return &repositoryImpl{
+		db: db,
+	}
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, body *domain.MaintenanceTicket) error {
+	ctx, span := tracing.Start(ctx, "MaintenanceRepository.Create")
+	defer span.End()
+	err := r.db.WithContext(ctx).Create(body).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repositoryImpl) GetByID(ctx context.Context, id int64) (*domain.MaintenanceTicket, error) {
+	ctx, span := tracing.Start(ctx, "MaintenanceRepository.GetByID")
+	defer span.End()
+	ticket := domain.MaintenanceTicket{}
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&ticket).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+func (r *repositoryImpl) GetList(ctx context.Context, filter domain.TicketFilter) (*[]domain.MaintenanceTicket, error) {
+	ctx, span := tracing.Start(ctx, "MaintenanceRepository.GetList")
+	defer span.End()
+	tickets := []domain.MaintenanceTicket{}
+	query := r.db.WithContext(ctx).Model(&domain.MaintenanceTicket{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.BikeID != 0 {
+		query = query.Where("bike_id = ?", filter.BikeID)
+	}
+	err := query.Order("id DESC").Find(&tickets).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tickets, nil
+}
+
+func (r *repositoryImpl) UpdateStatus(ctx context.Context, body *domain.MaintenanceTicket) error {
+	ctx, span := tracing.Start(ctx, "MaintenanceRepository.UpdateStatus")
+	defer span.End()
+	err := r.db.WithContext(ctx).Select("status", "resolved_at").Where("id = ?", body.ID).Updates(body).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CountConfirmedByBikeID counts the tickets of the bike staff confirmed and have not resolved yet, the ones keeping
+// the bike out of service.
+func (r *repositoryImpl) CountConfirmedByBikeID(ctx context.Context, bikeID int64) (int64, error) {
+	ctx, span := tracing.Start(ctx, "MaintenanceRepository.CountConfirmedByBikeID")
+	defer span.End()
+	var total int64
+	err := r.db.WithContext(ctx).Model(domain.MaintenanceTicket{}).
+		Where("bike_id = ? AND status = ?", bikeID, domain.TicketStatusInProgress).
+		Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,179 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type MaintenanceRepositoryTestSuite struct {
+	suite.Suite
+	mockDB         sqlmock.Sqlmock
+	repositoryImpl *repositoryImpl
+}
+
+func (s *MaintenanceRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.Error(err, "Failed to open mock sql db, got error")
+	}
+
+	if db == nil {
+		s.Error(nil, "mock db is null")
+	}
+
+	if mock == nil {
+		s.Error(nil, "sqlmock is null")
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		s.Error(err, "gormDB init err")
+	}
+	s.mockDB = mock
+	repositoryImpl := NewRepository(gormDB)
+	s.repositoryImpl = repositoryImpl
+}
+
+func TestMaintenanceRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceRepositoryTestSuite))
+}
+
+func (s *MaintenanceRepositoryTestSuite) ticketRows(tickets ...domain.MaintenanceTicket) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "bike_id", "reporter_id", "category", "note", "status", "resolved_at", "created_at", "updated_at"})
+	for _, ticket := range tickets {
+		rows.AddRow(ticket.ID, ticket.BikeID, ticket.ReporterID, ticket.Category, ticket.Note, ticket.Status, nil, ticket.CreatedAt, ticket.UpdatedAt)
+	}
+	return rows
+}
+
+func (s *MaintenanceRepositoryTestSuite) TestCreate_Success() {
+	query := regexp.QuoteMeta("INSERT INTO `maintenance_ticket` (`bike_id`,`reporter_id`,`category`,`note`,`status`,`resolved_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).
+		WithArgs(int64(1), int64(2), domain.ReportCategoryBrakes, "broken", domain.TicketStatusOpen, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	s.mockDB.ExpectCommit()
+	ticket := &domain.MaintenanceTicket{
+		BikeID:     1,
+		ReporterID: 2,
+		Category:   domain.ReportCategoryBrakes,
+		Note:       "broken",
+		Status:     domain.TicketStatusOpen,
+	}
+	err := s.repositoryImpl.Create(context.TODO(), ticket)
+	s.Nil(err)
+	s.Equal(int64(7), ticket.ID)
+}
+
+func (s *MaintenanceRepositoryTestSuite) TestCreate_Failed() {
+	query := regexp.QuoteMeta("INSERT INTO `maintenance_ticket`")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	err := s.repositoryImpl.Create(context.TODO(), &domain.MaintenanceTicket{})
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *MaintenanceRepositoryTestSuite) TestGetByID_Success() {
+	mockTicket := domain.MaintenanceTicket{
+		ID:         1,
+		BikeID:     2,
+		ReporterID: 3,
+		Category:   domain.ReportCategoryChain,
+		Note:       "chain fell off",
+		Status:     domain.TicketStatusOpen,
+	}
+	query := regexp.QuoteMeta("SELECT * FROM `maintenance_ticket` WHERE id = ? ORDER BY `maintenance_ticket`.`id` LIMIT 1")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(s.ticketRows(mockTicket))
+	actual, err := s.repositoryImpl.GetByID(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal(mockTicket, *actual)
+}
+
+func (s *MaintenanceRepositoryTestSuite) TestGetByID_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `maintenance_ticket` WHERE id = ? ORDER BY `maintenance_ticket`.`id` LIMIT 1")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(gorm.ErrRecordNotFound)
+	actual, err := s.repositoryImpl.GetByID(context.TODO(), 1)
+	s.Nil(actual)
+	s.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (s *MaintenanceRepositoryTestSuite) TestGetList_Success() {
+	mockTickets := []domain.MaintenanceTicket{
+		{ID: 2, BikeID: 1, ReporterID: 3, Category: domain.ReportCategoryLights, Status: domain.TicketStatusOpen},
+		{ID: 1, BikeID: 1, ReporterID: 4, Category: domain.ReportCategoryOther, Status: domain.TicketStatusOpen},
+	}
+	query := regexp.QuoteMeta("SELECT * FROM `maintenance_ticket` WHERE status = ? AND bike_id = ? ORDER BY id DESC")
+	s.mockDB.ExpectQuery(query).WithArgs(domain.TicketStatusOpen, int64(1)).WillReturnRows(s.ticketRows(mockTickets...))
+	actual, err := s.repositoryImpl.GetList(context.TODO(), domain.TicketFilter{Status: domain.TicketStatusOpen, BikeID: 1})
+	s.Nil(err)
+	s.Equal(mockTickets, *actual)
+}
+
+func (s *MaintenanceRepositoryTestSuite) TestGetList_SuccessWithoutFilter() {
+	query := regexp.QuoteMeta("SELECT * FROM `maintenance_ticket` ORDER BY id DESC")
+	s.mockDB.ExpectQuery(query).WillReturnRows(s.ticketRows())
+	actual, err := s.repositoryImpl.GetList(context.TODO(), domain.TicketFilter{})
+	s.Nil(err)
+	s.Equal([]domain.MaintenanceTicket{}, *actual)
+}
+
+func (s *MaintenanceRepositoryTestSuite) TestGetList_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `maintenance_ticket` ORDER BY id DESC")
+	s.mockDB.ExpectQuery(query).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetList(context.TODO(), domain.TicketFilter{})
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *MaintenanceRepositoryTestSuite) TestUpdateStatus_Success() {
+	resolvedAt := sql.NullTime{Valid: true, Time: time.Date(2022, 7, 6, 18, 51, 44, 0, time.UTC)}
+	query := regexp.QuoteMeta("UPDATE `maintenance_ticket` SET `status`=?,`resolved_at`=?,`updated_at`=? WHERE id = ? AND `id` = ?")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).
+		WithArgs(domain.TicketStatusResolved, resolvedAt, sqlmock.AnyArg(), int64(1), int64(1)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mockDB.ExpectCommit()
+	err := s.repositoryImpl.UpdateStatus(context.TODO(), &domain.MaintenanceTicket{ID: 1, Status: domain.TicketStatusResolved, ResolvedAt: resolvedAt})
+	s.Nil(err)
+}
+
+func (s *MaintenanceRepositoryTestSuite) TestUpdateStatus_Failed() {
+	query := regexp.QuoteMeta("UPDATE `maintenance_ticket`")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	err := s.repositoryImpl.UpdateStatus(context.TODO(), &domain.MaintenanceTicket{ID: 1, Status: domain.TicketStatusInProgress})
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *MaintenanceRepositoryTestSuite) TestCountConfirmedByBikeID_Success() {
+	query := regexp.QuoteMeta("SELECT count(*) FROM `maintenance_ticket` WHERE bike_id = ? AND status = ?")
+	s.mockDB.ExpectQuery(query).
+		WithArgs(int64(1), domain.TicketStatusInProgress).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(2))
+	actual, err := s.repositoryImpl.CountConfirmedByBikeID(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal(int64(2), actual)
+}
+
+func (s *MaintenanceRepositoryTestSuite) TestCountConfirmedByBikeID_Failed() {
+	query := regexp.QuoteMeta("SELECT count(*) FROM `maintenance_ticket`")
+	s.mockDB.ExpectQuery(query).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.CountConfirmedByBikeID(context.TODO(), 1)
+	s.Equal(int64(0), actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
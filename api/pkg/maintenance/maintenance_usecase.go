@@ -0,0 +1,255 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+)
+
+// maxBikeUpdateAttempts bounds how often a status change is retried against a bike that keeps changing under it.
+const maxBikeUpdateAttempts = 3
+
+type useCaseImpl struct {
+	repository     IRepository
+	logger         ILogger
+	bikeRepository IBikeRepository
+	auditor        IAuditor
+	now            func() time.Time
+}
+
+func NewUseCase(logger ILogger, repository IRepository, bikeRepository IBikeRepository, auditor IAuditor) *useCaseImpl {
+	return &useCaseImpl{
+		repository:     repository,
+		logger:         logger,
+		bikeRepository: bikeRepository,
+		auditor:        auditor,
+		now:            time.Now,
+	}
+}
+
+// Report opens a ticket for the bike. The bike stays in service until staff confirm the ticket, so a rider alone
+// cannot take bikes off the street.
+func (u *useCaseImpl) Report(ctx context.Context, body domain.ReportBikeRequestPayload) (domain.MaintenanceTicketDTO, error) {
+	ctx, span := tracing.Start(ctx, "MaintenanceUseCase.Report")
+	defer span.End()
+	u.logger.Info(fmt.Sprintf("[MaintenanceUseCase.Report] user %d is reporting bike %d", body.ReporterID, body.BikeID))
+	if !body.Category.IsValid() {
+		u.logger.Info(fmt.Sprintf("[MaintenanceUseCase.Report] invalid category %s", body.Category))
+		return domain.MaintenanceTicketDTO{}, apperrors.ErrInvalidCategory
+	}
+	if _, err := u.fetchBike(ctx, body.BikeID); err != nil {
+		return domain.MaintenanceTicketDTO{}, err
+	}
+	ticket := &domain.MaintenanceTicket{
+		BikeID:     body.BikeID,
+		ReporterID: body.ReporterID,
+		Category:   body.Category,
+		Note:       body.Note,
+		Status:     domain.TicketStatusOpen,
+	}
+	err := u.repository.Create(ctx, ticket)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[MaintenanceUseCase.Report] create ticket for bike %d failed", body.BikeID), err)
+		return domain.MaintenanceTicketDTO{}, apperrors.ErrInternalServerError
+	}
+	u.logger.Info(fmt.Sprintf("[MaintenanceUseCase.Report] user %d report bike %d success", body.ReporterID, body.BikeID))
+	result := ticket.ToDTO()
+	u.audit(ctx, domain.AuditRecord{
+		ActorID:    body.ReporterID,
+		Action:     domain.AuditActionBikeReport,
+		TargetType: domain.AuditTargetTicket,
+		TargetID:   ticket.ID,
+		After:      result,
+	})
+	return result, nil
+}
+
+func (u *useCaseImpl) GetList(ctx context.Context, filter domain.TicketFilter) ([]domain.MaintenanceTicketDTO, error) {
+	ctx, span := tracing.Start(ctx, "MaintenanceUseCase.GetList")
+	defer span.End()
+	u.logger.Info("[MaintenanceUseCase.GetList] fetching maintenance tickets")
+	if err := domain.Authorize(ctx, domain.PermissionBikesMaintain); err != nil {
+		u.logger.Error("[MaintenanceUseCase.GetList] permission denied", err)
+		return []domain.MaintenanceTicketDTO{}, err
+	}
+	tickets, err := u.repository.GetList(ctx, filter)
+	if err != nil {
+		u.logger.Error("[MaintenanceUseCase.GetList] fetch maintenance tickets failed", err)
+		return []domain.MaintenanceTicketDTO{}, apperrors.ErrInternalServerError
+	}
+	results := []domain.MaintenanceTicketDTO{}
+	for _, ticket := range *tickets {
+		results = append(results, ticket.ToDTO())
+	}
+	u.logger.Info("[MaintenanceUseCase.GetList] fetch maintenance tickets success")
+	return results, nil
+}
+
+// UpdateStatus moves a ticket through its lifecycle. Moving it to in_progress confirms the problem and takes an idle
+// bike out of service, a rented one goes to maintenance when it is returned. Resolving or reopening the last confirmed
+// ticket puts the bike back into service, unless RetireBike is set on resolving, in which case the bike is retired.
+func (u *useCaseImpl) UpdateStatus(ctx context.Context, body domain.UpdateTicketRequestPayload) (domain.MaintenanceTicketDTO, error) {
+	ctx, span := tracing.Start(ctx, "MaintenanceUseCase.UpdateStatus")
+	defer span.End()
+	u.logger.Info(fmt.Sprintf("[MaintenanceUseCase.UpdateStatus] moving ticket %d to %s", body.ID, body.Status))
+	if err := domain.Authorize(ctx, domain.PermissionBikesMaintain); err != nil {
+		u.logger.Error("[MaintenanceUseCase.UpdateStatus] permission denied", err)
+		return domain.MaintenanceTicketDTO{}, err
+	}
+	currentTicket, err := u.repository.GetByID(ctx, body.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info(fmt.Sprintf("[MaintenanceUseCase.UpdateStatus] cannot find ticket %d", body.ID))
+		return domain.MaintenanceTicketDTO{}, apperrors.ErrTicketNotFound
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[MaintenanceUseCase.UpdateStatus] fetch ticket %d failed", body.ID), err)
+		return domain.MaintenanceTicketDTO{}, apperrors.ErrInternalServerError
+	}
+	if !currentTicket.CanTransitionTo(body.Status) {
+		u.logger.Info(fmt.Sprintf("[MaintenanceUseCase.UpdateStatus] cannot move ticket %d from %s to %s", body.ID, currentTicket.Status, body.Status))
+		return domain.MaintenanceTicketDTO{}, apperrors.ErrInvalidTicketTransition
+	}
+	updatedTicket := *currentTicket
+	updatedTicket.Status = body.Status
+	if body.Status == domain.TicketStatusResolved {
+		updatedTicket.ResolvedAt = sql.NullTime{Valid: true, Time: u.now()}
+	}
+	err = u.repository.UpdateStatus(ctx, &updatedTicket)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[MaintenanceUseCase.UpdateStatus] update ticket %d failed", body.ID), err)
+		return domain.MaintenanceTicketDTO{}, apperrors.ErrInternalServerError
+	}
+	switch {
+	case body.Status == domain.TicketStatusInProgress:
+		err = u.withdrawBike(ctx, currentTicket.BikeID, body.ActorID)
+	case body.Status == domain.TicketStatusResolved || currentTicket.Status == domain.TicketStatusInProgress:
+		err = u.releaseBike(ctx, currentTicket.BikeID, body.Status == domain.TicketStatusResolved && body.RetireBike, body.ActorID)
+	}
+	if err != nil {
+		return domain.MaintenanceTicketDTO{}, err
+	}
+	u.logger.Info(fmt.Sprintf("[MaintenanceUseCase.UpdateStatus] move ticket %d to %s success", body.ID, body.Status))
+	result := updatedTicket.ToDTO()
+	u.audit(ctx, domain.AuditRecord{
+		ActorID:    body.ActorID,
+		Action:     domain.AuditActionTicketUpdate,
+		TargetType: domain.AuditTargetTicket,
+		TargetID:   body.ID,
+		Before:     currentTicket.ToDTO(),
+		After:      result,
+	})
+	return result, nil
+}
+
+// withdrawBike takes the bike of a confirmed ticket out of service, unless it is rented or already withdrawn.
+func (u *useCaseImpl) withdrawBike(ctx context.Context, bikeID int64, actorID int64) error {
+	currentBike, err := u.fetchBike(ctx, bikeID)
+	if err != nil {
+		return err
+	}
+	return u.updateBikeStatus(ctx, currentBike, domain.BikeEventReport, actorID)
+}
+
+// releaseBike decides what happens to a bike in maintenance once one of its tickets is no longer confirmed.
+// A rented bike is left alone, Return sends it to maintenance again if other tickets are still confirmed.
+func (u *useCaseImpl) releaseBike(ctx context.Context, bikeID int64, retire bool, actorID int64) error {
+	currentBike, err := u.fetchBike(ctx, bikeID)
+	if err != nil {
+		return err
+	}
+	event := domain.BikeEventRepair
+	if retire {
+		event = domain.BikeEventRetire
+	}
+	if _, err := currentBike.Next(event, actorID); err != nil {
+		u.logger.Info(fmt.Sprintf("[MaintenanceUseCase.releaseBike] %s", err.Error()))
+		return nil
+	}
+	if retire {
+		return u.updateBikeStatus(ctx, currentBike, event, actorID)
+	}
+	confirmedTickets, err := u.repository.CountConfirmedByBikeID(ctx, bikeID)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[MaintenanceUseCase.releaseBike] count confirmed tickets of bike %d failed", bikeID), err)
+		return apperrors.ErrInternalServerError
+	}
+	if confirmedTickets > 0 {
+		u.logger.Info(fmt.Sprintf("[MaintenanceUseCase.releaseBike] bike %d still has %d confirmed tickets", bikeID, confirmedTickets))
+		return nil
+	}
+	return u.updateBikeStatus(ctx, currentBike, event, actorID)
+}
+
+func (u *useCaseImpl) fetchBike(ctx context.Context, bikeID int64) (*domain.Bike, error) {
+	currentBike, err := u.bikeRepository.GetByID(ctx, bikeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info(fmt.Sprintf("[MaintenanceUseCase.fetchBike] cannot find bike %d", bikeID))
+		return nil, apperrors.ErrBikeNotFound
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[MaintenanceUseCase.fetchBike] fetch bike %d failed", bikeID), err)
+		return nil, apperrors.ErrInternalServerError
+	}
+	return currentBike, nil
+}
+
+// updateBikeStatus applies event to the bike unless the bike cannot take it. When a rider or a lock changed the bike
+// since it was read, the bike is read again and the event applied to the newer row, a report or repair still holds.
+func (u *useCaseImpl) updateBikeStatus(ctx context.Context, currentBike *domain.Bike, event domain.BikeEvent, actorID int64) error {
+	for attempt := 1; ; attempt++ {
+		status, err := currentBike.Next(event, actorID)
+		if err != nil {
+			u.logger.Info(fmt.Sprintf("[MaintenanceUseCase.updateBikeStatus] %s", err.Error()))
+			return nil
+		}
+		updatedBike := &domain.Bike{
+			ID:      currentBike.ID,
+			Name:    currentBike.Name,
+			Lat:     currentBike.Lat,
+			Long:    currentBike.Long,
+			Status:  status,
+			UserID:  currentBike.UserID,
+			Version: currentBike.Version,
+		}
+		updated, err := u.bikeRepository.UpdateStatusAndUserID(ctx, updatedBike)
+		if err != nil {
+			u.logger.Error(fmt.Sprintf("[MaintenanceUseCase.updateBikeStatus] move bike %d to %s failed", currentBike.ID, status), err)
+			return apperrors.ErrInternalServerError
+		}
+		if updated {
+			u.audit(ctx, domain.AuditRecord{
+				ActorID:    actorID,
+				Action:     domain.AuditActionBikeStatus,
+				TargetType: domain.AuditTargetBike,
+				TargetID:   currentBike.ID,
+				Before:     currentBike.ToDTO(),
+				After:      updatedBike.ToDTO(),
+			})
+			return nil
+		}
+		if attempt == maxBikeUpdateAttempts {
+			u.logger.Error(fmt.Sprintf("[MaintenanceUseCase.updateBikeStatus] bike %d kept changing, gave up moving it to %s", currentBike.ID, status), apperrors.ErrBikeVersionConflict)
+			return apperrors.ErrBikeVersionConflict
+		}
+		u.logger.Info(fmt.Sprintf("[MaintenanceUseCase.updateBikeStatus] bike %d changed since version %d was read, retrying", currentBike.ID, currentBike.Version))
+		currentBike, err = u.fetchBike(ctx, currentBike.ID)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// audit never fails the caller because the ticket has already been written.
+func (u *useCaseImpl) audit(ctx context.Context, record domain.AuditRecord) {
+	if err := u.auditor.Record(ctx, record); err != nil {
+		u.logger.Error(fmt.Sprintf("[MaintenanceUseCase.audit] record %s on %s %d failed", record.Action, record.TargetType, record.TargetID), err)
+	}
+}
@@ -0,0 +1,411 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/maintenance/mocks"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type MaintenanceUseCaseTestSuite struct {
+	suite.Suite
+	mockRepository     *mocks.IRepository
+	mockBikeRepository *mocks.IBikeRepository
+	mockLogger         *mocks.ILogger
+	mockAuditor        *mocks.IAuditor
+	useCaseImpl        *useCaseImpl
+	mechanicContext    context.Context
+	mockTime           time.Time
+}
+
+func (s *MaintenanceUseCaseTestSuite) SetupTest() {
+	mockRepository := &mocks.IRepository{}
+	s.mockRepository = mockRepository
+	mockBikeRepository := &mocks.IBikeRepository{}
+	s.mockBikeRepository = mockBikeRepository
+	mockLogger := &mocks.ILogger{}
+	s.mockLogger = mockLogger
+	s.mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+	s.mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+	mockAuditor := &mocks.IAuditor{}
+	s.mockAuditor = mockAuditor
+	s.useCaseImpl = NewUseCase(mockLogger, mockRepository, mockBikeRepository, mockAuditor)
+	s.mockTime = time.Date(2022, 7, 6, 18, 51, 44, 0, time.UTC)
+	s.useCaseImpl.now = func() time.Time { return s.mockTime }
+	s.mechanicContext = domain.NewContextWithClaims(context.TODO(), &domain.Claims{
+		ID:          9,
+		Permissions: []domain.Permission{domain.PermissionBikesMaintain},
+	})
+}
+
+func TestMaintenanceUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceUseCaseTestSuite))
+}
+
+func (s *MaintenanceUseCaseTestSuite) mockBike(status domain.BikeStatus, userID sql.NullInt64) *domain.Bike {
+	lat := decimal.NewFromFloat(50.119504)
+	long := decimal.NewFromFloat(8.638137)
+	return &domain.Bike{
+		ID:     1,
+		Name:   "Bike 1",
+		Lat:    &lat,
+		Long:   &long,
+		Status: status,
+		UserID: userID,
+	}
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestReport_SuccessBikeStaysInService() {
+	currentBike := s.mockBike(domain.BikeStatusAvailable, sql.NullInt64{})
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(currentBike, nil)
+	s.mockRepository.On("Create", context.TODO(), mock.AnythingOfType("*domain.MaintenanceTicket")).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.MaintenanceTicket).ID = 3
+	}).Return(nil)
+	s.mockAuditor.On("Record", context.TODO(), mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.Report(context.TODO(), domain.ReportBikeRequestPayload{
+		BikeID:     1,
+		ReporterID: 2,
+		Category:   domain.ReportCategoryBrakes,
+		Note:       "front brake",
+	})
+	s.Nil(err)
+	s.Equal(domain.MaintenanceTicketDTO{
+		ID:         3,
+		BikeID:     1,
+		ReporterID: 2,
+		Category:   domain.ReportCategoryBrakes,
+		Note:       "front brake",
+		Status:     domain.TicketStatusOpen,
+	}, actual)
+	s.mockBikeRepository.AssertNotCalled(s.T(), "UpdateStatusAndUserID", mock.Anything, mock.Anything)
+	s.mockAuditor.AssertCalled(s.T(), "Record", context.TODO(), domain.AuditRecord{
+		ActorID:    2,
+		Action:     domain.AuditActionBikeReport,
+		TargetType: domain.AuditTargetTicket,
+		TargetID:   3,
+		After:      actual,
+	})
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestReport_SuccessRentedBikeStaysRented() {
+	currentBike := s.mockBike(domain.BikeStatusRented, sql.NullInt64{Valid: true, Int64: 2})
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(currentBike, nil)
+	s.mockRepository.On("Create", context.TODO(), mock.AnythingOfType("*domain.MaintenanceTicket")).Return(nil)
+	s.mockAuditor.On("Record", context.TODO(), mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.Report(context.TODO(), domain.ReportBikeRequestPayload{
+		BikeID:     1,
+		ReporterID: 2,
+		Category:   domain.ReportCategoryFlatTire,
+	})
+	s.Nil(err)
+	s.Equal(domain.TicketStatusOpen, actual.Status)
+	s.mockBikeRepository.AssertNotCalled(s.T(), "UpdateStatusAndUserID", mock.Anything, mock.Anything)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestReport_FailedInvalidCategory() {
+	actual, err := s.useCaseImpl.Report(context.TODO(), domain.ReportBikeRequestPayload{
+		BikeID:   1,
+		Category: "wobbly",
+	})
+	s.Equal(apperrors.ErrInvalidCategory, err)
+	s.Equal(domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestReport_FailedBikeNotFound() {
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.Report(context.TODO(), domain.ReportBikeRequestPayload{
+		BikeID:   1,
+		Category: domain.ReportCategoryLock,
+	})
+	s.Equal(apperrors.ErrBikeNotFound, err)
+	s.Equal(domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestReport_FailedFetchBike() {
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.Report(context.TODO(), domain.ReportBikeRequestPayload{
+		BikeID:   1,
+		Category: domain.ReportCategoryLock,
+	})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal(domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestReport_FailedCreate() {
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(s.mockBike(domain.BikeStatusAvailable, sql.NullInt64{}), nil)
+	s.mockRepository.On("Create", context.TODO(), mock.Anything).Return(gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.Report(context.TODO(), domain.ReportBikeRequestPayload{
+		BikeID:   1,
+		Category: domain.ReportCategoryLock,
+	})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal(domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestReport_SuccessAuditFailed() {
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(s.mockBike(domain.BikeStatusRented, sql.NullInt64{Valid: true, Int64: 2}), nil)
+	s.mockRepository.On("Create", context.TODO(), mock.Anything).Return(nil)
+	s.mockAuditor.On("Record", context.TODO(), mock.Anything).Return(apperrors.ErrInternalServerError)
+	_, err := s.useCaseImpl.Report(context.TODO(), domain.ReportBikeRequestPayload{
+		BikeID:   1,
+		Category: domain.ReportCategoryLock,
+	})
+	s.Nil(err)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestGetList_Success() {
+	filter := domain.TicketFilter{Status: domain.TicketStatusOpen}
+	mockTickets := []domain.MaintenanceTicket{
+		{ID: 1, BikeID: 1, Category: domain.ReportCategoryChain, Status: domain.TicketStatusOpen},
+	}
+	s.mockRepository.On("GetList", s.mechanicContext, filter).Return(&mockTickets, nil)
+	actual, err := s.useCaseImpl.GetList(s.mechanicContext, filter)
+	s.Nil(err)
+	s.Equal([]domain.MaintenanceTicketDTO{
+		{ID: 1, BikeID: 1, Category: domain.ReportCategoryChain, Status: domain.TicketStatusOpen},
+	}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestGetList_FailedForbidden() {
+	ctx := domain.NewContextWithClaims(context.TODO(), &domain.Claims{ID: 2})
+	actual, err := s.useCaseImpl.GetList(ctx, domain.TicketFilter{})
+	s.Equal(apperrors.ErrForbidden, err)
+	s.Equal([]domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestGetList_FailedRepository() {
+	s.mockRepository.On("GetList", s.mechanicContext, domain.TicketFilter{}).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.GetList(s.mechanicContext, domain.TicketFilter{})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal([]domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_SuccessInProgressWithdrawsBike() {
+	currentTicket := &domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusOpen}
+	currentBike := s.mockBike(domain.BikeStatusAvailable, sql.NullInt64{})
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(currentTicket, nil)
+	s.mockRepository.On("UpdateStatus", s.mechanicContext, &domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusInProgress}).Return(nil)
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(currentBike, nil)
+	updatedBike := *currentBike
+	updatedBike.Status = domain.BikeStatusMaintenance
+	s.mockBikeRepository.On("UpdateStatusAndUserID", s.mechanicContext, &updatedBike).Return(true, nil)
+	s.mockAuditor.On("Record", s.mechanicContext, mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusInProgress, ActorID: 9})
+	s.Nil(err)
+	s.Equal(domain.MaintenanceTicketDTO{ID: 3, BikeID: 1, Status: domain.TicketStatusInProgress}, actual)
+	s.mockBikeRepository.AssertNumberOfCalls(s.T(), "UpdateStatusAndUserID", 1)
+	s.mockAuditor.AssertCalled(s.T(), "Record", s.mechanicContext, domain.AuditRecord{
+		ActorID:    9,
+		Action:     domain.AuditActionBikeStatus,
+		TargetType: domain.AuditTargetBike,
+		TargetID:   1,
+		Before:     currentBike.ToDTO(),
+		After:      updatedBike.ToDTO(),
+	})
+	s.mockAuditor.AssertCalled(s.T(), "Record", s.mechanicContext, domain.AuditRecord{
+		ActorID:    9,
+		Action:     domain.AuditActionTicketUpdate,
+		TargetType: domain.AuditTargetTicket,
+		TargetID:   3,
+		Before:     currentTicket.ToDTO(),
+		After:      actual,
+	})
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_SuccessInProgressRentedBikeStaysRented() {
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(&domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusOpen}, nil)
+	s.mockRepository.On("UpdateStatus", s.mechanicContext, mock.Anything).Return(nil)
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(s.mockBike(domain.BikeStatusRented, sql.NullInt64{Valid: true, Int64: 2}), nil)
+	s.mockAuditor.On("Record", s.mechanicContext, mock.Anything).Return(nil)
+	_, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusInProgress})
+	s.Nil(err)
+	s.mockBikeRepository.AssertNotCalled(s.T(), "UpdateStatusAndUserID", mock.Anything, mock.Anything)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_SuccessReopenedReleasesBike() {
+	currentBike := s.mockBike(domain.BikeStatusMaintenance, sql.NullInt64{})
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(&domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusInProgress}, nil)
+	s.mockRepository.On("UpdateStatus", s.mechanicContext, mock.Anything).Return(nil)
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(currentBike, nil)
+	s.mockRepository.On("CountConfirmedByBikeID", s.mechanicContext, int64(1)).Return(int64(0), nil)
+	updatedBike := *currentBike
+	updatedBike.Status = domain.BikeStatusAvailable
+	s.mockBikeRepository.On("UpdateStatusAndUserID", s.mechanicContext, &updatedBike).Return(true, nil)
+	s.mockAuditor.On("Record", s.mechanicContext, mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusOpen, RetireBike: true})
+	s.Nil(err)
+	s.Equal(domain.TicketStatusOpen, actual.Status)
+	s.mockBikeRepository.AssertNumberOfCalls(s.T(), "UpdateStatusAndUserID", 1)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_SuccessResolvedUnconfirmed() {
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(&domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusOpen}, nil)
+	s.mockRepository.On("UpdateStatus", s.mechanicContext, mock.Anything).Return(nil)
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(s.mockBike(domain.BikeStatusAvailable, sql.NullInt64{}), nil)
+	s.mockAuditor.On("Record", s.mechanicContext, mock.Anything).Return(nil)
+	_, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusResolved})
+	s.Nil(err)
+	s.mockBikeRepository.AssertNotCalled(s.T(), "UpdateStatusAndUserID", mock.Anything, mock.Anything)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_SuccessResolvedBikeAvailable() {
+	currentBike := s.mockBike(domain.BikeStatusMaintenance, sql.NullInt64{})
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(&domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusInProgress}, nil)
+	s.mockRepository.On("UpdateStatus", s.mechanicContext, mock.Anything).Return(nil)
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(currentBike, nil)
+	s.mockRepository.On("CountConfirmedByBikeID", s.mechanicContext, int64(1)).Return(int64(0), nil)
+	updatedBike := *currentBike
+	updatedBike.Status = domain.BikeStatusAvailable
+	s.mockBikeRepository.On("UpdateStatusAndUserID", s.mechanicContext, &updatedBike).Return(true, nil)
+	s.mockAuditor.On("Record", s.mechanicContext, mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusResolved})
+	s.Nil(err)
+	s.Equal(domain.TicketStatusResolved, actual.Status)
+	s.Equal(&s.mockTime, actual.ResolvedAt)
+	s.mockBikeRepository.AssertNumberOfCalls(s.T(), "UpdateStatusAndUserID", 1)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_SuccessResolvedOtherTicketsConfirmed() {
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(&domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusOpen}, nil)
+	s.mockRepository.On("UpdateStatus", s.mechanicContext, mock.Anything).Return(nil)
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(s.mockBike(domain.BikeStatusMaintenance, sql.NullInt64{}), nil)
+	s.mockRepository.On("CountConfirmedByBikeID", s.mechanicContext, int64(1)).Return(int64(1), nil)
+	s.mockAuditor.On("Record", s.mechanicContext, mock.Anything).Return(nil)
+	_, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusResolved})
+	s.Nil(err)
+	s.mockBikeRepository.AssertNotCalled(s.T(), "UpdateStatusAndUserID", mock.Anything, mock.Anything)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_SuccessResolvedRetireBike() {
+	currentBike := s.mockBike(domain.BikeStatusMaintenance, sql.NullInt64{})
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(&domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusOpen}, nil)
+	s.mockRepository.On("UpdateStatus", s.mechanicContext, mock.Anything).Return(nil)
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(currentBike, nil)
+	updatedBike := *currentBike
+	updatedBike.Status = domain.BikeStatusRetired
+	s.mockBikeRepository.On("UpdateStatusAndUserID", s.mechanicContext, &updatedBike).Return(true, nil)
+	s.mockAuditor.On("Record", s.mechanicContext, mock.Anything).Return(nil)
+	_, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusResolved, RetireBike: true})
+	s.Nil(err)
+	s.mockRepository.AssertNotCalled(s.T(), "CountConfirmedByBikeID", mock.Anything, mock.Anything)
+	s.mockBikeRepository.AssertNumberOfCalls(s.T(), "UpdateStatusAndUserID", 1)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_SuccessResolvedRentedBike() {
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(&domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusOpen}, nil)
+	s.mockRepository.On("UpdateStatus", s.mechanicContext, mock.Anything).Return(nil)
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(s.mockBike(domain.BikeStatusRented, sql.NullInt64{Valid: true, Int64: 2}), nil)
+	s.mockAuditor.On("Record", s.mechanicContext, mock.Anything).Return(nil)
+	_, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusResolved, RetireBike: true})
+	s.Nil(err)
+	s.mockBikeRepository.AssertNotCalled(s.T(), "UpdateStatusAndUserID", mock.Anything, mock.Anything)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_FailedForbidden() {
+	ctx := domain.NewContextWithClaims(context.TODO(), &domain.Claims{ID: 2})
+	actual, err := s.useCaseImpl.UpdateStatus(ctx, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusResolved})
+	s.Equal(apperrors.ErrForbidden, err)
+	s.Equal(domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_FailedNotFound() {
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusResolved})
+	s.Equal(apperrors.ErrTicketNotFound, err)
+	s.Equal(domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_FailedFetchTicket() {
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusResolved})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal(domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_FailedInvalidTransition() {
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(&domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusResolved}, nil)
+	actual, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusOpen})
+	s.Equal(apperrors.ErrInvalidTicketTransition, err)
+	s.Equal(domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_FailedUpdate() {
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(&domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusOpen}, nil)
+	s.mockRepository.On("UpdateStatus", s.mechanicContext, mock.Anything).Return(gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusResolved})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal(domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_FailedCountConfirmedTickets() {
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(&domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusOpen}, nil)
+	s.mockRepository.On("UpdateStatus", s.mechanicContext, mock.Anything).Return(nil)
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(s.mockBike(domain.BikeStatusMaintenance, sql.NullInt64{}), nil)
+	s.mockRepository.On("CountConfirmedByBikeID", s.mechanicContext, int64(1)).Return(int64(0), gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusResolved})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal(domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) confirmTicket() {
+	s.mockRepository.On("GetByID", s.mechanicContext, int64(3)).Return(&domain.MaintenanceTicket{ID: 3, BikeID: 1, Status: domain.TicketStatusOpen}, nil)
+	s.mockRepository.On("UpdateStatus", s.mechanicContext, mock.Anything).Return(nil)
+	s.mockAuditor.On("Record", s.mechanicContext, mock.Anything).Return(nil)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_FailedWithdrawBike() {
+	s.confirmTicket()
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(s.mockBike(domain.BikeStatusAvailable, sql.NullInt64{}), nil)
+	s.mockBikeRepository.On("UpdateStatusAndUserID", s.mechanicContext, mock.Anything).Return(false, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusInProgress})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal(domain.MaintenanceTicketDTO{}, actual)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_RetriesWhenBikeChanged() {
+	staleBike := s.mockBike(domain.BikeStatusAvailable, sql.NullInt64{})
+	staleBike.Version = 3
+	freshBike := s.mockBike(domain.BikeStatusAvailable, sql.NullInt64{})
+	freshBike.Version = 4
+	s.confirmTicket()
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(staleBike, nil).Once()
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(freshBike, nil).Once()
+	s.mockBikeRepository.On("UpdateStatusAndUserID", s.mechanicContext, mock.MatchedBy(func(bike *domain.Bike) bool {
+		return bike.Version == 3
+	})).Return(false, nil).Once()
+	s.mockBikeRepository.On("UpdateStatusAndUserID", s.mechanicContext, mock.MatchedBy(func(bike *domain.Bike) bool {
+		return bike.Version == 4 && bike.Status == domain.BikeStatusMaintenance
+	})).Return(true, nil).Once()
+	_, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusInProgress})
+	s.Nil(err)
+	s.mockBikeRepository.AssertExpectations(s.T())
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_SkipsBikeRentedMeanwhile() {
+	s.confirmTicket()
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(s.mockBike(domain.BikeStatusAvailable, sql.NullInt64{}), nil).Once()
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(s.mockBike(domain.BikeStatusRented, sql.NullInt64{Valid: true, Int64: 5}), nil).Once()
+	s.mockBikeRepository.On("UpdateStatusAndUserID", s.mechanicContext, mock.Anything).Return(false, nil).Once()
+	_, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusInProgress})
+	s.Nil(err)
+	s.mockBikeRepository.AssertNumberOfCalls(s.T(), "UpdateStatusAndUserID", 1)
+}
+
+func (s *MaintenanceUseCaseTestSuite) TestUpdateStatus_ConflictWhenBikeKeepsChanging() {
+	s.confirmTicket()
+	s.mockBikeRepository.On("GetByID", s.mechanicContext, int64(1)).Return(s.mockBike(domain.BikeStatusAvailable, sql.NullInt64{}), nil)
+	s.mockBikeRepository.On("UpdateStatusAndUserID", s.mechanicContext, mock.Anything).Return(false, nil)
+	actual, err := s.useCaseImpl.UpdateStatus(s.mechanicContext, domain.UpdateTicketRequestPayload{ID: 3, Status: domain.TicketStatusInProgress})
+	s.Equal(apperrors.ErrBikeVersionConflict, err)
+	s.Equal(domain.MaintenanceTicketDTO{}, actual)
+	s.mockBikeRepository.AssertNumberOfCalls(s.T(), "UpdateStatusAndUserID", maxBikeUpdateAttempts)
+}
@@ -0,0 +1,125 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRepository is an autogenerated mock type for the IRepository type
+type IRepository struct {
+	mock.Mock
+}
+
+// CountConfirmedByBikeID provides a mock function with given fields: ctx, bikeID
+func (_m *IRepository) CountConfirmedByBikeID(ctx context.Context, bikeID int64) (int64, error) {
+	ret := _m.Called(ctx, bikeID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, bikeID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, bikeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, body
+func (_m *IRepository) Create(ctx context.Context, body *domain.MaintenanceTicket) error {
+	ret := _m.Called(ctx, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.MaintenanceTicket) error); ok {
+		r0 = rf(ctx, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetByID(ctx context.Context, id int64) (*domain.MaintenanceTicket, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.MaintenanceTicket
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.MaintenanceTicket); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.MaintenanceTicket)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetList provides a mock function with given fields: ctx, filter
+func (_m *IRepository) GetList(ctx context.Context, filter domain.TicketFilter) (*[]domain.MaintenanceTicket, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 *[]domain.MaintenanceTicket
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TicketFilter) *[]domain.MaintenanceTicket); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.MaintenanceTicket)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TicketFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, body
+func (_m *IRepository) UpdateStatus(ctx context.Context, body *domain.MaintenanceTicket) error {
+	ret := _m.Called(ctx, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.MaintenanceTicket) error); ok {
+		r0 = rf(ctx, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIRepository creates a new instance of IRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIRepository(t mockConstructorTestingTNewIRepository) *IRepository {
+	mock := &IRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,75 @@
+package photo
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+
+	"shared-bike/apperrors"
+)
+
+const (
+	maxPhotoSize    = 5 << 20
+	maxPhotoPixels  = 40_000_000
+	thumbnailSize   = 256
+	thumbnailFormat = "image/jpeg"
+)
+
+var photoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+// validateImage sniffs the content instead of trusting the client's content type and checks the dimensions
+// before decoding so a tiny file cannot expand into a huge bitmap.
+func validateImage(data []byte) (string, image.Image, error) {
+	if len(data) > maxPhotoSize {
+		return "", nil, apperrors.ErrPhotoTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	if _, ok := photoExtensions[contentType]; !ok {
+		return "", nil, apperrors.ErrInvalidPhoto
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width*config.Height > maxPhotoPixels {
+		return "", nil, apperrors.ErrInvalidPhoto
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", nil, apperrors.ErrInvalidPhoto
+	}
+	return contentType, img, nil
+}
+
+// makeThumbnail scales the image down to fit thumbnailSize with nearest neighbour sampling and encodes it as JPEG.
+func makeThumbnail(img image.Image) ([]byte, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	thumbWidth, thumbHeight := width, height
+	if width > thumbnailSize || height > thumbnailSize {
+		if width >= height {
+			thumbWidth, thumbHeight = thumbnailSize, height*thumbnailSize/width
+		} else {
+			thumbWidth, thumbHeight = width*thumbnailSize/height, thumbnailSize
+		}
+	}
+	if thumbWidth < 1 {
+		thumbWidth = 1
+	}
+	if thumbHeight < 1 {
+		thumbHeight = 1
+	}
+	thumbnail := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	for y := 0; y < thumbHeight; y++ {
+		for x := 0; x < thumbWidth; x++ {
+			thumbnail.Set(x, y, img.At(bounds.Min.X+x*width/thumbWidth, bounds.Min.Y+y*height/thumbHeight))
+		}
+	}
+	buffer := bytes.Buffer{}
+	if err := jpeg.Encode(&buffer, thumbnail, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
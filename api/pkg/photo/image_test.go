@@ -0,0 +1,100 @@
+package photo
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"shared-bike/apperrors"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ImageTestSuite struct {
+	suite.Suite
+}
+
+func TestImageTestSuite(t *testing.T) {
+	suite.Run(t, new(ImageTestSuite))
+}
+
+func newTestImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func encodePNG(img image.Image) []byte {
+	buffer := bytes.Buffer{}
+	png.Encode(&buffer, img)
+	return buffer.Bytes()
+}
+
+func encodeJPEG(img image.Image) []byte {
+	buffer := bytes.Buffer{}
+	jpeg.Encode(&buffer, img, nil)
+	return buffer.Bytes()
+}
+
+func (s *ImageTestSuite) TestValidateImage_SuccessPNG() {
+	contentType, img, err := validateImage(encodePNG(newTestImage(10, 20)))
+	s.Nil(err)
+	s.Equal("image/png", contentType)
+	s.Equal(image.Rect(0, 0, 10, 20), img.Bounds())
+}
+
+func (s *ImageTestSuite) TestValidateImage_SuccessJPEG() {
+	contentType, _, err := validateImage(encodeJPEG(newTestImage(10, 20)))
+	s.Nil(err)
+	s.Equal("image/jpeg", contentType)
+}
+
+func (s *ImageTestSuite) TestValidateImage_FailedNotAnImage() {
+	_, _, err := validateImage([]byte("%PDF-1.4 not a photo"))
+	s.Equal(apperrors.ErrInvalidPhoto, err)
+}
+
+func (s *ImageTestSuite) TestValidateImage_FailedCorrupted() {
+	data := encodePNG(newTestImage(10, 20))
+	_, _, err := validateImage(data[:len(data)/2])
+	s.Equal(apperrors.ErrInvalidPhoto, err)
+}
+
+func (s *ImageTestSuite) TestValidateImage_FailedTooLarge() {
+	_, _, err := validateImage(make([]byte, maxPhotoSize+1))
+	s.Equal(apperrors.ErrPhotoTooLarge, err)
+}
+
+func (s *ImageTestSuite) TestMakeThumbnail_SuccessLandscape() {
+	data, err := makeThumbnail(newTestImage(1024, 512))
+	s.Nil(err)
+	thumbnail, format, err := image.Decode(bytes.NewReader(data))
+	s.Nil(err)
+	s.Equal("jpeg", format)
+	s.Equal(image.Rect(0, 0, thumbnailSize, thumbnailSize/2), thumbnail.Bounds())
+}
+
+func (s *ImageTestSuite) TestMakeThumbnail_SuccessPortrait() {
+	data, err := makeThumbnail(newTestImage(300, 600))
+	s.Nil(err)
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	s.Nil(err)
+	s.Equal(thumbnailSize/2, config.Width)
+	s.Equal(thumbnailSize, config.Height)
+}
+
+func (s *ImageTestSuite) TestMakeThumbnail_SuccessSmallImageKeepsSize() {
+	data, err := makeThumbnail(newTestImage(40, 30))
+	s.Nil(err)
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	s.Nil(err)
+	s.Equal(40, config.Width)
+	s.Equal(30, config.Height)
+}
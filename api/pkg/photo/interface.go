@@ -0,0 +1,58 @@
+package photo
+
+import (
+	"context"
+	"io"
+
+	"shared-bike/domain"
+)
+
+type IRepository interface {
+	Create(ctx context.Context, body *domain.BikePhoto) error
+	GetByID(ctx context.Context, id int64) (*domain.BikePhoto, error)
+	GetListByBikeID(ctx context.Context, bikeID int64) (*[]domain.BikePhoto, error)
+}
+
+type IBikeRepository interface {
+	GetByID(ctx context.Context, id int64) (*domain.Bike, error)
+}
+
+type ITicketRepository interface {
+	GetByID(ctx context.Context, id int64) (*domain.MaintenanceTicket, error)
+}
+
+type IBlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type ISigner interface {
+	Sign(path string) string
+	Verify(path string, expires int64, signature string) error
+}
+
+type IAuditor interface {
+	Record(ctx context.Context, body domain.AuditRecord) error
+}
+
+type ILogger interface {
+	Info(i ...interface{})
+	Warn(i ...interface{})
+	Error(i ...interface{})
+}
+
+type IUseCase interface {
+	Upload(ctx context.Context, body domain.UploadPhotoRequestPayload) (domain.PhotoDTO, error)
+	GetListByBikeID(ctx context.Context, bikeID int64) ([]domain.PhotoDTO, error)
+	Download(ctx context.Context, body domain.DownloadPhotoRequestPayload) (domain.PhotoContent, error)
+}
+
+//go:generate mockery --name IRepository --output mocks --case underscore
+//go:generate mockery --name IBikeRepository --output mocks --case underscore
+//go:generate mockery --name ITicketRepository --output mocks --case underscore
+//go:generate mockery --name IBlobStore --output mocks --case underscore
+//go:generate mockery --name ISigner --output mocks --case underscore
+//go:generate mockery --name IAuditor --output mocks --case underscore
+//go:generate mockery --name ILogger --output mocks --case underscore
+//go:generate mockery --name IUseCase --output mocks --case underscore
@@ -0,0 +1,44 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAuditor is an autogenerated mock type for the IAuditor type
+type IAuditor struct {
+	mock.Mock
+}
+
+// Record provides a mock function with given fields: ctx, body
+func (_m *IAuditor) Record(ctx context.Context, body domain.AuditRecord) error {
+	ret := _m.Called(ctx, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditRecord) error); ok {
+		r0 = rf(ctx, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIAuditor interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIAuditor creates a new instance of IAuditor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIAuditor(t mockConstructorTestingTNewIAuditor) *IAuditor {
+	mock := &IAuditor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
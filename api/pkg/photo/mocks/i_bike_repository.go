@@ -0,0 +1,53 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IBikeRepository is an autogenerated mock type for the IBikeRepository type
+type IBikeRepository struct {
+	mock.Mock
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *IBikeRepository) GetByID(ctx context.Context, id int64) (*domain.Bike, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Bike
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Bike); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Bike)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIBikeRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIBikeRepository creates a new instance of IBikeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIBikeRepository(t mockConstructorTestingTNewIBikeRepository) *IBikeRepository {
+	mock := &IBikeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
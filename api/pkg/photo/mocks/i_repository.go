@@ -0,0 +1,90 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRepository is an autogenerated mock type for the IRepository type
+type IRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, body
+func (_m *IRepository) Create(ctx context.Context, body *domain.BikePhoto) error {
+	ret := _m.Called(ctx, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.BikePhoto) error); ok {
+		r0 = rf(ctx, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetByID(ctx context.Context, id int64) (*domain.BikePhoto, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.BikePhoto
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.BikePhoto); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BikePhoto)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetListByBikeID provides a mock function with given fields: ctx, bikeID
+func (_m *IRepository) GetListByBikeID(ctx context.Context, bikeID int64) (*[]domain.BikePhoto, error) {
+	ret := _m.Called(ctx, bikeID)
+
+	var r0 *[]domain.BikePhoto
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *[]domain.BikePhoto); ok {
+		r0 = rf(ctx, bikeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.BikePhoto)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, bikeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIRepository creates a new instance of IRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIRepository(t mockConstructorTestingTNewIRepository) *IRepository {
+	mock := &IRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,53 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// ISigner is an autogenerated mock type for the ISigner type
+type ISigner struct {
+	mock.Mock
+}
+
+// Sign provides a mock function with given fields: path
+func (_m *ISigner) Sign(path string) string {
+	ret := _m.Called(path)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Verify provides a mock function with given fields: path, expires, signature
+func (_m *ISigner) Verify(path string, expires int64, signature string) error {
+	ret := _m.Called(path, expires, signature)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64, string) error); ok {
+		r0 = rf(path, expires, signature)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewISigner interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewISigner creates a new instance of ISigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewISigner(t mockConstructorTestingTNewISigner) *ISigner {
+	mock := &ISigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
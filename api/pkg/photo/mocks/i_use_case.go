@@ -0,0 +1,95 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IUseCase is an autogenerated mock type for the IUseCase type
+type IUseCase struct {
+	mock.Mock
+}
+
+// Download provides a mock function with given fields: ctx, body
+func (_m *IUseCase) Download(ctx context.Context, body domain.DownloadPhotoRequestPayload) (domain.PhotoContent, error) {
+	ret := _m.Called(ctx, body)
+
+	var r0 domain.PhotoContent
+	if rf, ok := ret.Get(0).(func(context.Context, domain.DownloadPhotoRequestPayload) domain.PhotoContent); ok {
+		r0 = rf(ctx, body)
+	} else {
+		r0 = ret.Get(0).(domain.PhotoContent)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.DownloadPhotoRequestPayload) error); ok {
+		r1 = rf(ctx, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetListByBikeID provides a mock function with given fields: ctx, bikeID
+func (_m *IUseCase) GetListByBikeID(ctx context.Context, bikeID int64) ([]domain.PhotoDTO, error) {
+	ret := _m.Called(ctx, bikeID)
+
+	var r0 []domain.PhotoDTO
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PhotoDTO); ok {
+		r0 = rf(ctx, bikeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PhotoDTO)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, bikeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upload provides a mock function with given fields: ctx, body
+func (_m *IUseCase) Upload(ctx context.Context, body domain.UploadPhotoRequestPayload) (domain.PhotoDTO, error) {
+	ret := _m.Called(ctx, body)
+
+	var r0 domain.PhotoDTO
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UploadPhotoRequestPayload) domain.PhotoDTO); ok {
+		r0 = rf(ctx, body)
+	} else {
+		r0 = ret.Get(0).(domain.PhotoDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UploadPhotoRequestPayload) error); ok {
+		r1 = rf(ctx, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIUseCase creates a new instance of IUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIUseCase(t mockConstructorTestingTNewIUseCase) *IUseCase {
+	mock := &IUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,226 @@
+package photo
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/middleware"
+	"shared-bike/tracing"
+
+	"github.com/labstack/echo/v4"
+)
+
+const photoFormField = "photo"
+
+type handlerImpl struct {
+	useCase IUseCase
+}
+
+func NewHandler(useCase IUseCase) *handlerImpl {
+	return &handlerImpl{
+		useCase: useCase,
+	}
+}
+
+// UploadReportPhoto godoc
+// @Summary      Upload a photo for a damage report
+// @Description  API for attaching photo evidence to a bike report, jpeg or png up to 5MB
+// @Tags         photos
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param 			 id 	      path  		string 		true 	"bike id"
+// @Param 			 ticketId 	path  		string 		true 	"maintenance ticket id returned by the report"
+// @Param 			 photo 	    formData  file 		  true 	"photo"
+// @Success      201  {object}  domain.PhotoDTO 							"Success"
+// @Failure      400  {string}  string 												"invalid bike id | invalid maintenance ticket id | invalid photo, only jpeg and png images are accepted"
+// @Failure      403  {string}  string 												"you do not have permission to perform this action"
+// @Failure      404  {string}  string 												"maintenance ticket not found"
+// @Failure      413  {string}  string 												"photo is too large"
+// @Failure      500  {string}  string 												"internal server error"
+// @Security     BearerAuth
+// @Router       /bikes/{id}/report/{ticketId}/photos [post]
+func (h *handlerImpl) UploadReportPhoto(c echo.Context) error {
+	span := tracing.StartHandler(c, "PhotoHandler.UploadReportPhoto")
+	defer span.End()
+	var (
+		ticketID int64
+		err      error
+	)
+	ticketIDStr := c.Param("ticketId")
+	if ticketID, err = strconv.ParseInt(ticketIDStr, 10, 64); err != nil {
+		c.Logger().Error(fmt.Sprintf("[PhotoHandler.UploadReportPhoto] invalid ticket id %s", ticketIDStr), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidTicketID), apperrors.ErrInvalidTicketID.Error())
+	}
+	return h.upload(c, domain.PhotoKindReport, ticketID)
+}
+
+// UploadReturnPhoto godoc
+// @Summary      Upload a photo when returning a bike
+// @Description  API for the current renter to attach photos of the bike before ending the ride, jpeg or png up to 5MB
+// @Tags         photos
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param 			 id 	      path  		string 		true 	"bike id"
+// @Param 			 photo 	    formData  file 		  true 	"photo"
+// @Success      201  {object}  domain.PhotoDTO 							"Success"
+// @Failure      400  {string}  string 												"invalid bike id | invalid photo, only jpeg and png images are accepted"
+// @Failure      403  {string}  string 												"you do not have permission to perform this action"
+// @Failure      404  {string}  string 												"bike not found"
+// @Failure      413  {string}  string 												"photo is too large"
+// @Failure      500  {string}  string 												"internal server error"
+// @Security     BearerAuth
+// @Router       /bikes/{id}/return/photos [post]
+func (h *handlerImpl) UploadReturnPhoto(c echo.Context) error {
+	span := tracing.StartHandler(c, "PhotoHandler.UploadReturnPhoto")
+	defer span.End()
+	return h.upload(c, domain.PhotoKindReturn, 0)
+}
+
+func (h *handlerImpl) upload(c echo.Context, kind domain.PhotoKind, ticketID int64) error {
+	var (
+		ctx    = c.Request().Context()
+		bikeID int64
+		err    error
+	)
+	bikeIDStr := c.Param("id")
+	if bikeID, err = strconv.ParseInt(bikeIDStr, 10, 64); err != nil {
+		c.Logger().Error(fmt.Sprintf("[PhotoHandler.upload] invalid bike id %s", bikeIDStr), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBikeID), apperrors.ErrInvalidBikeID.Error())
+	}
+	data, err := h.readPhoto(c)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[PhotoHandler.upload] read photo of bike %d failed", bikeID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	claims, err := middleware.GetClaims(c)
+	if err != nil {
+		c.Logger().Error("[PhotoHandler.upload] missing claims", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	userID := claims.ID
+	request := domain.UploadPhotoRequestPayload{
+		BikeID:     bikeID,
+		TicketID:   ticketID,
+		UploaderID: userID,
+		Kind:       kind,
+		Data:       data,
+	}
+	c.Logger().Info(fmt.Sprintf("[PhotoHandler.upload] user %d is uploading a %s photo of bike %d", userID, kind, bikeID))
+	photo, err := h.useCase.Upload(ctx, request)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[PhotoHandler.upload] user %d upload photo of bike %d failed", userID, bikeID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[PhotoHandler.upload] user %d upload photo of bike %d success", userID, bikeID))
+	return c.JSON(http.StatusCreated, photo)
+}
+
+// readPhoto reads at most one byte over the limit so an oversized upload is refused without buffering all of it.
+func (h *handlerImpl) readPhoto(c echo.Context) ([]byte, error) {
+	header, err := c.FormFile(photoFormField)
+	if err != nil {
+		return nil, apperrors.ErrInvalidPhoto
+	}
+	if header.Size > maxPhotoSize {
+		return nil, apperrors.ErrPhotoTooLarge
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, apperrors.ErrInvalidPhoto
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxPhotoSize+1))
+	if err != nil {
+		return nil, apperrors.ErrInvalidPhoto
+	}
+	if len(data) > maxPhotoSize {
+		return nil, apperrors.ErrPhotoTooLarge
+	}
+	return data, nil
+}
+
+// GetListByBikeID godoc
+// @Summary      Get photos of a bike
+// @Description  API for the maintenance crew to list report and return photos of a bike with signed download links
+// @Tags         photos
+// @Accept       json
+// @Produce      json
+// @Param 			 id 	path  		string 		true 								"bike id"
+// @Success      200  {array}   []domain.PhotoDTO 						"Success"
+// @Failure      400  {string}  string 												"invalid bike id"
+// @Failure      403  {string}  string 												"you do not have permission to perform this action"
+// @Failure      500  {string}  string 												"internal server error"
+// @Security     BearerAuth
+// @Router       /bikes/{id}/photos [get]
+func (h *handlerImpl) GetListByBikeID(c echo.Context) error {
+	span := tracing.StartHandler(c, "PhotoHandler.GetListByBikeID")
+	defer span.End()
+	var (
+		ctx    = c.Request().Context()
+		bikeID int64
+		err    error
+	)
+	bikeIDStr := c.Param("id")
+	if bikeID, err = strconv.ParseInt(bikeIDStr, 10, 64); err != nil {
+		c.Logger().Error(fmt.Sprintf("[PhotoHandler.GetListByBikeID] invalid bike id %s", bikeIDStr), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBikeID), apperrors.ErrInvalidBikeID.Error())
+	}
+	photos, err := h.useCase.GetListByBikeID(ctx, bikeID)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[PhotoHandler.GetListByBikeID] fetch photos of bike %d failed", bikeID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[PhotoHandler.GetListByBikeID] fetch photos of bike %d success", bikeID))
+	return c.JSON(http.StatusOK, photos)
+}
+
+// Download godoc
+// @Summary      Download a photo
+// @Description  API for downloading a photo or its thumbnail with a signed link, no bearer token is needed
+// @Tags         photos
+// @Produce      image/jpeg,image/png
+// @Param 			 id 	      path  		string 		true 	"photo id"
+// @Param 			 variant 	  path  		string 		true 	"original | thumbnail"
+// @Param 			 expires 	  query  		int 		  true 	"unix time the link expires at"
+// @Param 			 signature 	query  		string 		true 	"link signature"
+// @Success      200  {file}    file 												  "Success"
+// @Failure      400  {string}  string 												"invalid photo id"
+// @Failure      403  {string}  string 												"download link is invalid or expired"
+// @Failure      404  {string}  string 												"photo not found"
+// @Failure      500  {string}  string 												"internal server error"
+// @Router       /photos/{id}/{variant} [get]
+func (h *handlerImpl) Download(c echo.Context) error {
+	span := tracing.StartHandler(c, "PhotoHandler.Download")
+	defer span.End()
+	var (
+		ctx     = c.Request().Context()
+		photoID int64
+		err     error
+	)
+	photoIDStr := c.Param("id")
+	variant := domain.PhotoVariant(c.Param("variant"))
+	if photoID, err = strconv.ParseInt(photoIDStr, 10, 64); err != nil || !variant.IsValid() {
+		c.Logger().Error(fmt.Sprintf("[PhotoHandler.Download] invalid photo %s/%s", photoIDStr, variant), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidPhotoID), apperrors.ErrInvalidPhotoID.Error())
+	}
+	request := domain.DownloadPhotoRequestPayload{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &request); err != nil {
+		c.Logger().Error(fmt.Sprintf("[PhotoHandler.Download] invalid link of photo %d", photoID), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidSignature), apperrors.ErrInvalidSignature.Error())
+	}
+	request.ID = photoID
+	request.Variant = variant
+	request.Path = c.Request().URL.Path
+	content, err := h.useCase.Download(ctx, request)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[PhotoHandler.Download] download photo %d failed", photoID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	defer content.Body.Close()
+	c.Response().Header().Set(echo.HeaderCacheControl, "private, max-age=300")
+	return c.Stream(http.StatusOK, content.ContentType, content.Body)
+}
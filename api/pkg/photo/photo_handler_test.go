@@ -0,0 +1,246 @@
+package photo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/middleware"
+	"shared-bike/pkg/photo/mocks"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type PhotoHandlerTestSuite struct {
+	suite.Suite
+	mockUseCase *mocks.IUseCase
+	echo        *echo.Echo
+	handlerImpl *handlerImpl
+}
+
+func (s *PhotoHandlerTestSuite) SetupTest() {
+	mockUseCase := &mocks.IUseCase{}
+	s.mockUseCase = mockUseCase
+	s.echo = echo.New()
+	s.handlerImpl = NewHandler(mockUseCase)
+}
+
+func TestPhotoHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(PhotoHandlerTestSuite))
+}
+
+func (s *PhotoHandlerTestSuite) newUploadContext(target string, field string, data []byte) (echo.Context, *httptest.ResponseRecorder) {
+	body := bytes.Buffer{}
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, "photo.png")
+	s.Nil(err)
+	part.Write(data)
+	writer.Close()
+	req := httptest.NewRequest(http.MethodPost, target, &body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(middleware.UserKey, &jwt.Token{
+		Claims: &domain.Claims{ID: 3},
+	})
+	return c, rec
+}
+
+func (s *PhotoHandlerTestSuite) TestUploadReportPhoto_Success() {
+	request := domain.UploadPhotoRequestPayload{
+		BikeID:     1,
+		TicketID:   2,
+		UploaderID: 3,
+		Kind:       domain.PhotoKindReport,
+		Data:       []byte("photo"),
+	}
+	s.mockUseCase.On("Upload", context.Background(), request).Return(domain.PhotoDTO{ID: 4, BikeID: 1, TicketID: 2}, nil)
+	c, rec := s.newUploadContext("/bikes/1/report/2/photos", photoFormField, []byte("photo"))
+	c.SetPath("/bikes/:id/report/:ticketId/photos")
+	c.SetParamNames("id", "ticketId")
+	c.SetParamValues("1", "2")
+	s.NoError(s.handlerImpl.UploadReportPhoto(c))
+	s.Equal(http.StatusCreated, rec.Code)
+}
+
+func (s *PhotoHandlerTestSuite) TestUploadReportPhoto_FailedTicketID() {
+	c, rec := s.newUploadContext("/bikes/1/report/abc/photos", photoFormField, []byte("photo"))
+	c.SetPath("/bikes/:id/report/:ticketId/photos")
+	c.SetParamNames("id", "ticketId")
+	c.SetParamValues("1", "abc")
+	s.NoError(s.handlerImpl.UploadReportPhoto(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(`"e40011 invalid maintenance ticket id"`+"\n", rec.Body.String())
+}
+
+func (s *PhotoHandlerTestSuite) TestUploadReturnPhoto_Success() {
+	request := domain.UploadPhotoRequestPayload{
+		BikeID:     1,
+		UploaderID: 3,
+		Kind:       domain.PhotoKindReturn,
+		Data:       []byte("photo"),
+	}
+	s.mockUseCase.On("Upload", context.Background(), request).Return(domain.PhotoDTO{ID: 4, BikeID: 1}, nil)
+	c, rec := s.newUploadContext("/bikes/1/return/photos", photoFormField, []byte("photo"))
+	c.SetPath("/bikes/:id/return/photos")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.UploadReturnPhoto(c))
+	s.Equal(http.StatusCreated, rec.Code)
+}
+
+func (s *PhotoHandlerTestSuite) TestUploadReturnPhoto_FailedBikeID() {
+	c, rec := s.newUploadContext("/bikes/abc/return/photos", photoFormField, []byte("photo"))
+	c.SetPath("/bikes/:id/return/photos")
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	s.NoError(s.handlerImpl.UploadReturnPhoto(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(`"e4006 invalid bike id"`+"\n", rec.Body.String())
+}
+
+func (s *PhotoHandlerTestSuite) TestUploadReturnPhoto_FailedMissingFile() {
+	c, rec := s.newUploadContext("/bikes/1/return/photos", "other", []byte("photo"))
+	c.SetPath("/bikes/:id/return/photos")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.UploadReturnPhoto(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(`"`+apperrors.ErrInvalidPhoto.Error()+`"`+"\n", rec.Body.String())
+}
+
+func (s *PhotoHandlerTestSuite) TestUploadReturnPhoto_FailedTooLarge() {
+	c, rec := s.newUploadContext("/bikes/1/return/photos", photoFormField, make([]byte, maxPhotoSize+1))
+	c.SetPath("/bikes/:id/return/photos")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.UploadReturnPhoto(c))
+	s.Equal(http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func (s *PhotoHandlerTestSuite) TestUploadReturnPhoto_FailedUseCase() {
+	s.mockUseCase.On("Upload", context.Background(), domain.UploadPhotoRequestPayload{
+		BikeID:     1,
+		UploaderID: 3,
+		Kind:       domain.PhotoKindReturn,
+		Data:       []byte("photo"),
+	}).Return(domain.PhotoDTO{}, apperrors.ErrForbidden)
+	c, rec := s.newUploadContext("/bikes/1/return/photos", photoFormField, []byte("photo"))
+	c.SetPath("/bikes/:id/return/photos")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.UploadReturnPhoto(c))
+	s.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *PhotoHandlerTestSuite) TestGetListByBikeID_Success() {
+	s.mockUseCase.On("GetListByBikeID", context.Background(), int64(1)).Return([]domain.PhotoDTO{}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/bikes/1/photos", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes/:id/photos")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.GetListByBikeID(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("[]\n", rec.Body.String())
+}
+
+func (s *PhotoHandlerTestSuite) TestGetListByBikeID_FailedParams() {
+	req := httptest.NewRequest(http.MethodGet, "/bikes/abc/photos", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes/:id/photos")
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	s.NoError(s.handlerImpl.GetListByBikeID(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *PhotoHandlerTestSuite) TestGetListByBikeID_FailedUseCase() {
+	s.mockUseCase.On("GetListByBikeID", context.Background(), int64(1)).Return(nil, apperrors.ErrForbidden)
+	req := httptest.NewRequest(http.MethodGet, "/bikes/1/photos", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/bikes/:id/photos")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	s.NoError(s.handlerImpl.GetListByBikeID(c))
+	s.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *PhotoHandlerTestSuite) newDownloadContext(photoID string, variant string, query string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/photos/"+photoID+"/"+variant+"?"+query, nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/api/v1/photos/:id/:variant")
+	c.SetParamNames("id", "variant")
+	c.SetParamValues(photoID, variant)
+	return c, rec
+}
+
+func (s *PhotoHandlerTestSuite) TestDownload_Success() {
+	request := domain.DownloadPhotoRequestPayload{
+		ID:        4,
+		Variant:   domain.PhotoVariantThumbnail,
+		Path:      "/api/v1/photos/4/thumbnail",
+		Expires:   1657133504,
+		Signature: "abc",
+	}
+	s.mockUseCase.On("Download", context.Background(), request).Return(domain.PhotoContent{
+		ContentType: "image/jpeg",
+		Body:        io.NopCloser(strings.NewReader("content")),
+	}, nil)
+	c, rec := s.newDownloadContext("4", "thumbnail", "expires=1657133504&signature=abc")
+	s.NoError(s.handlerImpl.Download(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("image/jpeg", rec.Header().Get(echo.HeaderContentType))
+	s.Equal("content", rec.Body.String())
+}
+
+func (s *PhotoHandlerTestSuite) TestDownload_FailedVariant() {
+	c, rec := s.newDownloadContext("4", "huge", "expires=1657133504&signature=abc")
+	s.NoError(s.handlerImpl.Download(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(`"e40013 invalid photo id"`+"\n", rec.Body.String())
+}
+
+func (s *PhotoHandlerTestSuite) TestDownload_FailedQuery() {
+	c, rec := s.newDownloadContext("4", "original", "expires=tomorrow&signature=abc")
+	s.NoError(s.handlerImpl.Download(c))
+	s.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *PhotoHandlerTestSuite) TestDownload_FailedUseCase() {
+	s.mockUseCase.On("Download", context.Background(), domain.DownloadPhotoRequestPayload{
+		ID:        4,
+		Variant:   domain.PhotoVariantOriginal,
+		Path:      "/api/v1/photos/4/original",
+		Expires:   1657133504,
+		Signature: "abc",
+	}).Return(domain.PhotoContent{}, apperrors.ErrInvalidSignature)
+	c, rec := s.newDownloadContext("4", "original", "expires=1657133504&signature=abc")
+	s.NoError(s.handlerImpl.Download(c))
+	s.Equal(http.StatusForbidden, rec.Code)
+	s.Equal(`"e4031 download link is invalid or expired"`+"\n", rec.Body.String())
+}
+
+func (s *PhotoHandlerTestSuite) TestUploadReturnPhoto_Unauthorized() {
+	c, rec := s.newUploadContext("/bikes/1/return/photos", photoFormField, []byte("photo"))
+	c.SetPath("/bikes/:id/return/photos")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.Set(middleware.UserKey, nil)
+	s.NoError(s.handlerImpl.UploadReturnPhoto(c))
+	s.Equal(http.StatusUnauthorized, rec.Code)
+	s.Equal("\"e4010 unauthorized\"\n", rec.Body.String())
+}
@@ -0,0 +1,52 @@
+package photo
+
+import (
+	"context"
+
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repositoryImpl {
+	return &repositoryImpl{
+		db: db,
+	}
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, body *domain.BikePhoto) error {
+	ctx, span := tracing.Start(ctx, "PhotoRepository.Create")
+	defer span.End()
+	err := r.db.WithContext(ctx).Create(body).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repositoryImpl) GetByID(ctx context.Context, id int64) (*domain.BikePhoto, error) {
+	ctx, span := tracing.Start(ctx, "PhotoRepository.GetByID")
+	defer span.End()
+	photo := domain.BikePhoto{}
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&photo).Error
+	if err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
+
+func (r *repositoryImpl) GetListByBikeID(ctx context.Context, bikeID int64) (*[]domain.BikePhoto, error) {
+	ctx, span := tracing.Start(ctx, "PhotoRepository.GetListByBikeID")
+	defer span.End()
+	photos := []domain.BikePhoto{}
+	err := r.db.WithContext(ctx).Where("bike_id = ?", bikeID).Order("id DESC").Find(&photos).Error
+	if err != nil {
+		return nil, err
+	}
+	return &photos, nil
+}
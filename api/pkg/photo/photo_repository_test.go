@@ -0,0 +1,142 @@
+package photo
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type PhotoRepositoryTestSuite struct {
+	suite.Suite
+	mockDB         sqlmock.Sqlmock
+	repositoryImpl *repositoryImpl
+}
+
+func (s *PhotoRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.Error(err, "Failed to open mock sql db, got error")
+	}
+
+	if db == nil {
+		s.Error(nil, "mock db is null")
+	}
+
+	if mock == nil {
+		s.Error(nil, "sqlmock is null")
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		s.Error(err, "gormDB init err")
+	}
+	s.mockDB = mock
+	repositoryImpl := NewRepository(gormDB)
+	s.repositoryImpl = repositoryImpl
+}
+
+func TestPhotoRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PhotoRepositoryTestSuite))
+}
+
+func (s *PhotoRepositoryTestSuite) photoRows(photos ...domain.BikePhoto) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "bike_id", "ticket_id", "uploader_id", "kind", "content_type", "size", "storage_key", "thumbnail_key", "created_at"})
+	for _, photo := range photos {
+		var ticketID interface{}
+		if photo.TicketID.Valid {
+			ticketID = photo.TicketID.Int64
+		}
+		rows.AddRow(photo.ID, photo.BikeID, ticketID, photo.UploaderID, photo.Kind, photo.ContentType, photo.Size, photo.StorageKey, photo.ThumbnailKey, photo.CreatedAt)
+	}
+	return rows
+}
+
+func (s *PhotoRepositoryTestSuite) TestCreate_Success() {
+	query := regexp.QuoteMeta("INSERT INTO `bike_photo` (`bike_id`,`ticket_id`,`uploader_id`,`kind`,`content_type`,`size`,`storage_key`,`thumbnail_key`,`created_at`) VALUES (?,?,?,?,?,?,?,?,?)")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).
+		WithArgs(int64(1), int64(2), int64(3), domain.PhotoKindReport, "image/png", int64(100), "bikes/1/a.png", "bikes/1/a_thumb.jpg", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	s.mockDB.ExpectCommit()
+	photo := &domain.BikePhoto{
+		BikeID:       1,
+		TicketID:     sql.NullInt64{Valid: true, Int64: 2},
+		UploaderID:   3,
+		Kind:         domain.PhotoKindReport,
+		ContentType:  "image/png",
+		Size:         100,
+		StorageKey:   "bikes/1/a.png",
+		ThumbnailKey: "bikes/1/a_thumb.jpg",
+	}
+	err := s.repositoryImpl.Create(context.TODO(), photo)
+	s.Nil(err)
+	s.Equal(int64(4), photo.ID)
+}
+
+func (s *PhotoRepositoryTestSuite) TestCreate_Failed() {
+	query := regexp.QuoteMeta("INSERT INTO `bike_photo`")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	err := s.repositoryImpl.Create(context.TODO(), &domain.BikePhoto{})
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *PhotoRepositoryTestSuite) TestGetByID_Success() {
+	mockPhoto := domain.BikePhoto{
+		ID:           4,
+		BikeID:       1,
+		UploaderID:   3,
+		Kind:         domain.PhotoKindReturn,
+		ContentType:  "image/jpeg",
+		Size:         100,
+		StorageKey:   "bikes/1/a.jpg",
+		ThumbnailKey: "bikes/1/a_thumb.jpg",
+		CreatedAt:    time.Time{},
+	}
+	query := regexp.QuoteMeta("SELECT * FROM `bike_photo` WHERE id = ? ORDER BY `bike_photo`.`id` LIMIT 1")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(4)).WillReturnRows(s.photoRows(mockPhoto))
+	actual, err := s.repositoryImpl.GetByID(context.TODO(), 4)
+	s.Nil(err)
+	s.Equal(mockPhoto, *actual)
+}
+
+func (s *PhotoRepositoryTestSuite) TestGetByID_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `bike_photo` WHERE id = ? ORDER BY `bike_photo`.`id` LIMIT 1")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(4)).WillReturnError(gorm.ErrRecordNotFound)
+	actual, err := s.repositoryImpl.GetByID(context.TODO(), 4)
+	s.Nil(actual)
+	s.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (s *PhotoRepositoryTestSuite) TestGetListByBikeID_Success() {
+	mockPhotos := []domain.BikePhoto{
+		{ID: 5, BikeID: 1, TicketID: sql.NullInt64{Valid: true, Int64: 2}, Kind: domain.PhotoKindReport},
+		{ID: 4, BikeID: 1, Kind: domain.PhotoKindReturn},
+	}
+	query := regexp.QuoteMeta("SELECT * FROM `bike_photo` WHERE bike_id = ? ORDER BY id DESC")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(s.photoRows(mockPhotos...))
+	actual, err := s.repositoryImpl.GetListByBikeID(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal(mockPhotos, *actual)
+}
+
+func (s *PhotoRepositoryTestSuite) TestGetListByBikeID_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `bike_photo` WHERE bike_id = ? ORDER BY id DESC")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetListByBikeID(context.TODO(), 1)
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
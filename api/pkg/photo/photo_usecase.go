@@ -0,0 +1,237 @@
+package photo
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"shared-bike/apperrors"
+	"shared-bike/blobstore"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+)
+
+type useCaseImpl struct {
+	repository       IRepository
+	logger           ILogger
+	bikeRepository   IBikeRepository
+	ticketRepository ITicketRepository
+	blobStore        IBlobStore
+	signer           ISigner
+	auditor          IAuditor
+	newKey           func() string
+}
+
+func NewUseCase(logger ILogger, repository IRepository, bikeRepository IBikeRepository, ticketRepository ITicketRepository, blobStore IBlobStore, signer ISigner, auditor IAuditor) *useCaseImpl {
+	return &useCaseImpl{
+		repository:       repository,
+		logger:           logger,
+		bikeRepository:   bikeRepository,
+		ticketRepository: ticketRepository,
+		blobStore:        blobStore,
+		signer:           signer,
+		auditor:          auditor,
+		newKey:           randomKey,
+	}
+}
+
+// Upload stores a photo with its thumbnail. Report photos may be added by the reporter or the maintenance crew,
+// return photos only by the rider who currently has the bike, before the ride is ended.
+func (u *useCaseImpl) Upload(ctx context.Context, body domain.UploadPhotoRequestPayload) (domain.PhotoDTO, error) {
+	ctx, span := tracing.Start(ctx, "PhotoUseCase.Upload")
+	defer span.End()
+	u.logger.Info(fmt.Sprintf("[PhotoUseCase.Upload] user %d is uploading a %s photo of bike %d", body.UploaderID, body.Kind, body.BikeID))
+	var err error
+	switch body.Kind {
+	case domain.PhotoKindReport:
+		err = u.checkReportUploader(ctx, body)
+	case domain.PhotoKindReturn:
+		err = u.checkReturnUploader(ctx, body)
+	default:
+		err = apperrors.ErrInvalidBody
+	}
+	if err != nil {
+		return domain.PhotoDTO{}, err
+	}
+	contentType, img, err := validateImage(body.Data)
+	if err != nil {
+		u.logger.Info(fmt.Sprintf("[PhotoUseCase.Upload] rejected photo of bike %d: %s", body.BikeID, err))
+		return domain.PhotoDTO{}, err
+	}
+	thumbnail, err := makeThumbnail(img)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PhotoUseCase.Upload] make thumbnail of bike %d photo failed", body.BikeID), err)
+		return domain.PhotoDTO{}, apperrors.ErrInternalServerError
+	}
+	name := u.newKey()
+	photo := &domain.BikePhoto{
+		BikeID:       body.BikeID,
+		UploaderID:   body.UploaderID,
+		Kind:         body.Kind,
+		ContentType:  contentType,
+		Size:         int64(len(body.Data)),
+		StorageKey:   fmt.Sprintf("bikes/%d/%s%s", body.BikeID, name, photoExtensions[contentType]),
+		ThumbnailKey: fmt.Sprintf("bikes/%d/%s_thumb%s", body.BikeID, name, photoExtensions[thumbnailFormat]),
+	}
+	if body.Kind == domain.PhotoKindReport {
+		photo.TicketID = sql.NullInt64{Valid: true, Int64: body.TicketID}
+	}
+	if err := u.store(ctx, photo, body.Data, thumbnail); err != nil {
+		return domain.PhotoDTO{}, err
+	}
+	u.logger.Info(fmt.Sprintf("[PhotoUseCase.Upload] user %d upload photo %d of bike %d success", body.UploaderID, photo.ID, body.BikeID))
+	result := u.toDTO(photo)
+	err = u.auditor.Record(ctx, domain.AuditRecord{
+		ActorID:    body.UploaderID,
+		Action:     domain.AuditActionPhotoUpload,
+		TargetType: domain.AuditTargetPhoto,
+		TargetID:   photo.ID,
+		After:      photo.ToDTO(),
+	})
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PhotoUseCase.Upload] record upload of photo %d failed", photo.ID), err)
+	}
+	return result, nil
+}
+
+func (u *useCaseImpl) GetListByBikeID(ctx context.Context, bikeID int64) ([]domain.PhotoDTO, error) {
+	ctx, span := tracing.Start(ctx, "PhotoUseCase.GetListByBikeID")
+	defer span.End()
+	u.logger.Info(fmt.Sprintf("[PhotoUseCase.GetListByBikeID] fetching photos of bike %d", bikeID))
+	if err := domain.Authorize(ctx, domain.PermissionBikesMaintain); err != nil {
+		u.logger.Error("[PhotoUseCase.GetListByBikeID] permission denied", err)
+		return []domain.PhotoDTO{}, err
+	}
+	photos, err := u.repository.GetListByBikeID(ctx, bikeID)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PhotoUseCase.GetListByBikeID] fetch photos of bike %d failed", bikeID), err)
+		return []domain.PhotoDTO{}, apperrors.ErrInternalServerError
+	}
+	results := []domain.PhotoDTO{}
+	for _, photo := range *photos {
+		results = append(results, u.toDTO(&photo))
+	}
+	u.logger.Info(fmt.Sprintf("[PhotoUseCase.GetListByBikeID] fetch photos of bike %d success", bikeID))
+	return results, nil
+}
+
+// Download is reached without a JWT, the signature on the link is the only credential.
+func (u *useCaseImpl) Download(ctx context.Context, body domain.DownloadPhotoRequestPayload) (domain.PhotoContent, error) {
+	ctx, span := tracing.Start(ctx, "PhotoUseCase.Download")
+	defer span.End()
+	u.logger.Info(fmt.Sprintf("[PhotoUseCase.Download] downloading %s of photo %d", body.Variant, body.ID))
+	if err := u.signer.Verify(body.Path, body.Expires, body.Signature); err != nil {
+		u.logger.Info(fmt.Sprintf("[PhotoUseCase.Download] reject link of photo %d: %s", body.ID, err))
+		return domain.PhotoContent{}, apperrors.ErrInvalidSignature
+	}
+	photo, err := u.repository.GetByID(ctx, body.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info(fmt.Sprintf("[PhotoUseCase.Download] cannot find photo %d", body.ID))
+		return domain.PhotoContent{}, apperrors.ErrPhotoNotFound
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PhotoUseCase.Download] fetch photo %d failed", body.ID), err)
+		return domain.PhotoContent{}, apperrors.ErrInternalServerError
+	}
+	content, err := u.blobStore.Get(ctx, photo.Key(body.Variant))
+	if errors.Is(err, blobstore.ErrNotFound) {
+		u.logger.Error(fmt.Sprintf("[PhotoUseCase.Download] blob of photo %d is missing", body.ID), err)
+		return domain.PhotoContent{}, apperrors.ErrPhotoNotFound
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PhotoUseCase.Download] read blob of photo %d failed", body.ID), err)
+		return domain.PhotoContent{}, apperrors.ErrInternalServerError
+	}
+	contentType := photo.ContentType
+	if body.Variant == domain.PhotoVariantThumbnail {
+		contentType = thumbnailFormat
+	}
+	return domain.PhotoContent{ContentType: contentType, Body: content}, nil
+}
+
+func (u *useCaseImpl) checkReportUploader(ctx context.Context, body domain.UploadPhotoRequestPayload) error {
+	ticket, err := u.ticketRepository.GetByID(ctx, body.TicketID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info(fmt.Sprintf("[PhotoUseCase.checkReportUploader] cannot find ticket %d", body.TicketID))
+		return apperrors.ErrTicketNotFound
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PhotoUseCase.checkReportUploader] fetch ticket %d failed", body.TicketID), err)
+		return apperrors.ErrInternalServerError
+	}
+	if ticket.BikeID != body.BikeID {
+		u.logger.Info(fmt.Sprintf("[PhotoUseCase.checkReportUploader] ticket %d does not belong to bike %d", body.TicketID, body.BikeID))
+		return apperrors.ErrTicketNotFound
+	}
+	if ticket.ReporterID == body.UploaderID {
+		return nil
+	}
+	if err := domain.Authorize(ctx, domain.PermissionBikesMaintain); err != nil {
+		u.logger.Info(fmt.Sprintf("[PhotoUseCase.checkReportUploader] user %d is not the reporter of ticket %d", body.UploaderID, body.TicketID))
+		return err
+	}
+	return nil
+}
+
+func (u *useCaseImpl) checkReturnUploader(ctx context.Context, body domain.UploadPhotoRequestPayload) error {
+	currentBike, err := u.bikeRepository.GetByID(ctx, body.BikeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info(fmt.Sprintf("[PhotoUseCase.checkReturnUploader] cannot find bike %d", body.BikeID))
+		return apperrors.ErrBikeNotFound
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PhotoUseCase.checkReturnUploader] fetch bike %d failed", body.BikeID), err)
+		return apperrors.ErrInternalServerError
+	}
+	if _, err := currentBike.Next(domain.BikeEventReturn, body.UploaderID); err != nil {
+		u.logger.Info(fmt.Sprintf("[PhotoUseCase.checkReturnUploader] user %d is not renting bike %d", body.UploaderID, body.BikeID))
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
+
+// store writes both blobs before the row so a photo row never points at a missing file, and cleans up on failure.
+func (u *useCaseImpl) store(ctx context.Context, photo *domain.BikePhoto, data []byte, thumbnail []byte) error {
+	if err := u.blobStore.Put(ctx, photo.StorageKey, bytes.NewReader(data)); err != nil {
+		u.logger.Error(fmt.Sprintf("[PhotoUseCase.store] put %s failed", photo.StorageKey), err)
+		return apperrors.ErrInternalServerError
+	}
+	if err := u.blobStore.Put(ctx, photo.ThumbnailKey, bytes.NewReader(thumbnail)); err != nil {
+		u.logger.Error(fmt.Sprintf("[PhotoUseCase.store] put %s failed", photo.ThumbnailKey), err)
+		u.cleanUp(ctx, photo.StorageKey)
+		return apperrors.ErrInternalServerError
+	}
+	if err := u.repository.Create(ctx, photo); err != nil {
+		u.logger.Error(fmt.Sprintf("[PhotoUseCase.store] create photo of bike %d failed", photo.BikeID), err)
+		u.cleanUp(ctx, photo.StorageKey, photo.ThumbnailKey)
+		return apperrors.ErrInternalServerError
+	}
+	return nil
+}
+
+func (u *useCaseImpl) cleanUp(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := u.blobStore.Delete(ctx, key); err != nil {
+			u.logger.Error(fmt.Sprintf("[PhotoUseCase.cleanUp] delete %s failed", key), err)
+		}
+	}
+}
+
+func (u *useCaseImpl) toDTO(photo *domain.BikePhoto) domain.PhotoDTO {
+	result := photo.ToDTO()
+	result.URL = u.signer.Sign(domain.PhotoDownloadPath(photo.ID, domain.PhotoVariantOriginal))
+	result.ThumbnailURL = u.signer.Sign(domain.PhotoDownloadPath(photo.ID, domain.PhotoVariantThumbnail))
+	return result
+}
+
+func randomKey() string {
+	buffer := make([]byte, 16)
+	rand.Read(buffer)
+	return hex.EncodeToString(buffer)
+}
@@ -0,0 +1,322 @@
+package photo
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"strings"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/blobstore"
+	"shared-bike/domain"
+	"shared-bike/pkg/photo/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PhotoUseCaseTestSuite struct {
+	suite.Suite
+	mockRepository       *mocks.IRepository
+	mockBikeRepository   *mocks.IBikeRepository
+	mockTicketRepository *mocks.ITicketRepository
+	mockBlobStore        *mocks.IBlobStore
+	mockSigner           *mocks.ISigner
+	mockAuditor          *mocks.IAuditor
+	mockLogger           *mocks.ILogger
+	useCaseImpl          *useCaseImpl
+	mechanicContext      context.Context
+	photoData            []byte
+}
+
+func (s *PhotoUseCaseTestSuite) SetupTest() {
+	s.mockRepository = &mocks.IRepository{}
+	s.mockBikeRepository = &mocks.IBikeRepository{}
+	s.mockTicketRepository = &mocks.ITicketRepository{}
+	s.mockBlobStore = &mocks.IBlobStore{}
+	s.mockSigner = &mocks.ISigner{}
+	s.mockSigner.On("Sign", mock.Anything).Return(func(path string) string { return path + "?signed" })
+	s.mockAuditor = &mocks.IAuditor{}
+	s.mockLogger = &mocks.ILogger{}
+	s.mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+	s.mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+	s.useCaseImpl = NewUseCase(s.mockLogger, s.mockRepository, s.mockBikeRepository, s.mockTicketRepository, s.mockBlobStore, s.mockSigner, s.mockAuditor)
+	s.useCaseImpl.newKey = func() string { return "abc" }
+	s.mechanicContext = domain.NewContextWithClaims(context.TODO(), &domain.Claims{
+		ID:          9,
+		Permissions: []domain.Permission{domain.PermissionBikesMaintain},
+	})
+	s.photoData = encodePNG(newTestImage(10, 20))
+}
+
+func TestPhotoUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(PhotoUseCaseTestSuite))
+}
+
+func (s *PhotoUseCaseTestSuite) reportPayload(uploaderID int64) domain.UploadPhotoRequestPayload {
+	return domain.UploadPhotoRequestPayload{
+		BikeID:     1,
+		TicketID:   2,
+		UploaderID: uploaderID,
+		Kind:       domain.PhotoKindReport,
+		Data:       s.photoData,
+	}
+}
+
+func (s *PhotoUseCaseTestSuite) mockStored(ctx context.Context) {
+	s.mockBlobStore.On("Put", ctx, "bikes/1/abc.png", mock.Anything).Return(nil)
+	s.mockBlobStore.On("Put", ctx, "bikes/1/abc_thumb.jpg", mock.Anything).Return(nil)
+	s.mockRepository.On("Create", ctx, mock.AnythingOfType("*domain.BikePhoto")).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.BikePhoto).ID = 4
+	}).Return(nil)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_SuccessReportByReporter() {
+	s.mockTicketRepository.On("GetByID", context.TODO(), int64(2)).Return(&domain.MaintenanceTicket{ID: 2, BikeID: 1, ReporterID: 3}, nil)
+	s.mockStored(context.TODO())
+	s.mockAuditor.On("Record", context.TODO(), mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.Upload(context.TODO(), s.reportPayload(3))
+	s.Nil(err)
+	s.Equal(domain.PhotoDTO{
+		ID:           4,
+		BikeID:       1,
+		TicketID:     2,
+		UploaderID:   3,
+		Kind:         domain.PhotoKindReport,
+		ContentType:  "image/png",
+		Size:         int64(len(s.photoData)),
+		URL:          "/api/v1/photos/4/original?signed",
+		ThumbnailURL: "/api/v1/photos/4/thumbnail?signed",
+	}, actual)
+	s.mockRepository.AssertCalled(s.T(), "Create", context.TODO(), &domain.BikePhoto{
+		ID:           4,
+		BikeID:       1,
+		TicketID:     sql.NullInt64{Valid: true, Int64: 2},
+		UploaderID:   3,
+		Kind:         domain.PhotoKindReport,
+		ContentType:  "image/png",
+		Size:         int64(len(s.photoData)),
+		StorageKey:   "bikes/1/abc.png",
+		ThumbnailKey: "bikes/1/abc_thumb.jpg",
+	})
+	s.mockAuditor.AssertNumberOfCalls(s.T(), "Record", 1)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_SuccessReportByMechanic() {
+	s.mockTicketRepository.On("GetByID", s.mechanicContext, int64(2)).Return(&domain.MaintenanceTicket{ID: 2, BikeID: 1, ReporterID: 3}, nil)
+	s.mockStored(s.mechanicContext)
+	s.mockAuditor.On("Record", s.mechanicContext, mock.Anything).Return(apperrors.ErrInternalServerError)
+	actual, err := s.useCaseImpl.Upload(s.mechanicContext, s.reportPayload(9))
+	s.Nil(err)
+	s.Equal(int64(4), actual.ID)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_FailedReportByStranger() {
+	ctx := domain.NewContextWithClaims(context.TODO(), &domain.Claims{ID: 5})
+	s.mockTicketRepository.On("GetByID", ctx, int64(2)).Return(&domain.MaintenanceTicket{ID: 2, BikeID: 1, ReporterID: 3}, nil)
+	actual, err := s.useCaseImpl.Upload(ctx, s.reportPayload(5))
+	s.Equal(apperrors.ErrForbidden, err)
+	s.Equal(domain.PhotoDTO{}, actual)
+	s.mockBlobStore.AssertNotCalled(s.T(), "Put", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_FailedTicketOfOtherBike() {
+	s.mockTicketRepository.On("GetByID", context.TODO(), int64(2)).Return(&domain.MaintenanceTicket{ID: 2, BikeID: 7, ReporterID: 3}, nil)
+	_, err := s.useCaseImpl.Upload(context.TODO(), s.reportPayload(3))
+	s.Equal(apperrors.ErrTicketNotFound, err)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_FailedTicketNotFound() {
+	s.mockTicketRepository.On("GetByID", context.TODO(), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+	_, err := s.useCaseImpl.Upload(context.TODO(), s.reportPayload(3))
+	s.Equal(apperrors.ErrTicketNotFound, err)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_FailedFetchTicket() {
+	s.mockTicketRepository.On("GetByID", context.TODO(), int64(2)).Return(nil, gorm.ErrInvalidDB)
+	_, err := s.useCaseImpl.Upload(context.TODO(), s.reportPayload(3))
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_SuccessReturnByRenter() {
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(&domain.Bike{ID: 1, Status: domain.BikeStatusRented, UserID: sql.NullInt64{Valid: true, Int64: 3}}, nil)
+	s.mockStored(context.TODO())
+	s.mockAuditor.On("Record", context.TODO(), mock.Anything).Return(nil)
+	actual, err := s.useCaseImpl.Upload(context.TODO(), domain.UploadPhotoRequestPayload{
+		BikeID:     1,
+		UploaderID: 3,
+		Kind:       domain.PhotoKindReturn,
+		Data:       s.photoData,
+	})
+	s.Nil(err)
+	s.Equal(domain.PhotoKindReturn, actual.Kind)
+	s.Equal(int64(0), actual.TicketID)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_FailedReturnNotRenter() {
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(&domain.Bike{ID: 1, Status: domain.BikeStatusRented, UserID: sql.NullInt64{Valid: true, Int64: 8}}, nil)
+	_, err := s.useCaseImpl.Upload(context.TODO(), domain.UploadPhotoRequestPayload{
+		BikeID:     1,
+		UploaderID: 3,
+		Kind:       domain.PhotoKindReturn,
+		Data:       s.photoData,
+	})
+	s.Equal(apperrors.ErrForbidden, err)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_FailedReturnBikeNotFound() {
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+	_, err := s.useCaseImpl.Upload(context.TODO(), domain.UploadPhotoRequestPayload{
+		BikeID: 1,
+		Kind:   domain.PhotoKindReturn,
+	})
+	s.Equal(apperrors.ErrBikeNotFound, err)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_FailedReturnFetchBike() {
+	s.mockBikeRepository.On("GetByID", context.TODO(), int64(1)).Return(nil, gorm.ErrInvalidDB)
+	_, err := s.useCaseImpl.Upload(context.TODO(), domain.UploadPhotoRequestPayload{
+		BikeID: 1,
+		Kind:   domain.PhotoKindReturn,
+	})
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_FailedUnknownKind() {
+	_, err := s.useCaseImpl.Upload(context.TODO(), domain.UploadPhotoRequestPayload{BikeID: 1, Kind: "selfie"})
+	s.Equal(apperrors.ErrInvalidBody, err)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_FailedInvalidImage() {
+	s.mockTicketRepository.On("GetByID", context.TODO(), int64(2)).Return(&domain.MaintenanceTicket{ID: 2, BikeID: 1, ReporterID: 3}, nil)
+	payload := s.reportPayload(3)
+	payload.Data = []byte("GIF89a")
+	_, err := s.useCaseImpl.Upload(context.TODO(), payload)
+	s.Equal(apperrors.ErrInvalidPhoto, err)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_FailedPutOriginal() {
+	s.mockTicketRepository.On("GetByID", context.TODO(), int64(2)).Return(&domain.MaintenanceTicket{ID: 2, BikeID: 1, ReporterID: 3}, nil)
+	s.mockBlobStore.On("Put", context.TODO(), "bikes/1/abc.png", mock.Anything).Return(io.ErrShortWrite)
+	_, err := s.useCaseImpl.Upload(context.TODO(), s.reportPayload(3))
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.mockBlobStore.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_FailedPutThumbnail() {
+	s.mockTicketRepository.On("GetByID", context.TODO(), int64(2)).Return(&domain.MaintenanceTicket{ID: 2, BikeID: 1, ReporterID: 3}, nil)
+	s.mockBlobStore.On("Put", context.TODO(), "bikes/1/abc.png", mock.Anything).Return(nil)
+	s.mockBlobStore.On("Put", context.TODO(), "bikes/1/abc_thumb.jpg", mock.Anything).Return(io.ErrShortWrite)
+	s.mockBlobStore.On("Delete", context.TODO(), "bikes/1/abc.png").Return(nil)
+	_, err := s.useCaseImpl.Upload(context.TODO(), s.reportPayload(3))
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.mockBlobStore.AssertCalled(s.T(), "Delete", context.TODO(), "bikes/1/abc.png")
+}
+
+func (s *PhotoUseCaseTestSuite) TestUpload_FailedCreate() {
+	s.mockTicketRepository.On("GetByID", context.TODO(), int64(2)).Return(&domain.MaintenanceTicket{ID: 2, BikeID: 1, ReporterID: 3}, nil)
+	s.mockBlobStore.On("Put", context.TODO(), mock.Anything, mock.Anything).Return(nil)
+	s.mockRepository.On("Create", context.TODO(), mock.Anything).Return(gorm.ErrInvalidDB)
+	s.mockBlobStore.On("Delete", context.TODO(), mock.Anything).Return(io.ErrClosedPipe)
+	_, err := s.useCaseImpl.Upload(context.TODO(), s.reportPayload(3))
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.mockBlobStore.AssertNumberOfCalls(s.T(), "Delete", 2)
+}
+
+func (s *PhotoUseCaseTestSuite) TestGetListByBikeID_Success() {
+	mockPhotos := []domain.BikePhoto{{ID: 4, BikeID: 1, Kind: domain.PhotoKindReturn}}
+	s.mockRepository.On("GetListByBikeID", s.mechanicContext, int64(1)).Return(&mockPhotos, nil)
+	actual, err := s.useCaseImpl.GetListByBikeID(s.mechanicContext, 1)
+	s.Nil(err)
+	s.Equal([]domain.PhotoDTO{{
+		ID:           4,
+		BikeID:       1,
+		Kind:         domain.PhotoKindReturn,
+		URL:          "/api/v1/photos/4/original?signed",
+		ThumbnailURL: "/api/v1/photos/4/thumbnail?signed",
+	}}, actual)
+}
+
+func (s *PhotoUseCaseTestSuite) TestGetListByBikeID_FailedForbidden() {
+	ctx := domain.NewContextWithClaims(context.TODO(), &domain.Claims{ID: 3})
+	actual, err := s.useCaseImpl.GetListByBikeID(ctx, 1)
+	s.Equal(apperrors.ErrForbidden, err)
+	s.Equal([]domain.PhotoDTO{}, actual)
+}
+
+func (s *PhotoUseCaseTestSuite) TestGetListByBikeID_FailedRepository() {
+	s.mockRepository.On("GetListByBikeID", s.mechanicContext, int64(1)).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.GetListByBikeID(s.mechanicContext, 1)
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal([]domain.PhotoDTO{}, actual)
+}
+
+func (s *PhotoUseCaseTestSuite) downloadPayload(variant domain.PhotoVariant) domain.DownloadPhotoRequestPayload {
+	return domain.DownloadPhotoRequestPayload{
+		ID:        4,
+		Variant:   variant,
+		Path:      "/api/v1/photos/4/" + string(variant),
+		Expires:   1657133504,
+		Signature: "signature",
+	}
+}
+
+func (s *PhotoUseCaseTestSuite) TestDownload_SuccessOriginal() {
+	body := io.NopCloser(strings.NewReader("content"))
+	s.mockSigner.On("Verify", "/api/v1/photos/4/original", int64(1657133504), "signature").Return(nil)
+	s.mockRepository.On("GetByID", context.TODO(), int64(4)).Return(&domain.BikePhoto{ID: 4, ContentType: "image/png", StorageKey: "bikes/1/abc.png", ThumbnailKey: "bikes/1/abc_thumb.jpg"}, nil)
+	s.mockBlobStore.On("Get", context.TODO(), "bikes/1/abc.png").Return(body, nil)
+	actual, err := s.useCaseImpl.Download(context.TODO(), s.downloadPayload(domain.PhotoVariantOriginal))
+	s.Nil(err)
+	s.Equal(domain.PhotoContent{ContentType: "image/png", Body: body}, actual)
+}
+
+func (s *PhotoUseCaseTestSuite) TestDownload_SuccessThumbnail() {
+	body := io.NopCloser(strings.NewReader("content"))
+	s.mockSigner.On("Verify", "/api/v1/photos/4/thumbnail", int64(1657133504), "signature").Return(nil)
+	s.mockRepository.On("GetByID", context.TODO(), int64(4)).Return(&domain.BikePhoto{ID: 4, ContentType: "image/png", StorageKey: "bikes/1/abc.png", ThumbnailKey: "bikes/1/abc_thumb.jpg"}, nil)
+	s.mockBlobStore.On("Get", context.TODO(), "bikes/1/abc_thumb.jpg").Return(body, nil)
+	actual, err := s.useCaseImpl.Download(context.TODO(), s.downloadPayload(domain.PhotoVariantThumbnail))
+	s.Nil(err)
+	s.Equal("image/jpeg", actual.ContentType)
+}
+
+func (s *PhotoUseCaseTestSuite) TestDownload_FailedSignature() {
+	s.mockSigner.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(blobstore.ErrSignatureExpired)
+	actual, err := s.useCaseImpl.Download(context.TODO(), s.downloadPayload(domain.PhotoVariantOriginal))
+	s.Equal(apperrors.ErrInvalidSignature, err)
+	s.Equal(domain.PhotoContent{}, actual)
+	s.mockRepository.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+}
+
+func (s *PhotoUseCaseTestSuite) TestDownload_FailedPhotoNotFound() {
+	s.mockSigner.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.mockRepository.On("GetByID", context.TODO(), int64(4)).Return(nil, gorm.ErrRecordNotFound)
+	_, err := s.useCaseImpl.Download(context.TODO(), s.downloadPayload(domain.PhotoVariantOriginal))
+	s.Equal(apperrors.ErrPhotoNotFound, err)
+}
+
+func (s *PhotoUseCaseTestSuite) TestDownload_FailedFetchPhoto() {
+	s.mockSigner.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.mockRepository.On("GetByID", context.TODO(), int64(4)).Return(nil, gorm.ErrInvalidDB)
+	_, err := s.useCaseImpl.Download(context.TODO(), s.downloadPayload(domain.PhotoVariantOriginal))
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *PhotoUseCaseTestSuite) TestDownload_FailedBlobMissing() {
+	s.mockSigner.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.mockRepository.On("GetByID", context.TODO(), int64(4)).Return(&domain.BikePhoto{ID: 4, StorageKey: "bikes/1/abc.png"}, nil)
+	s.mockBlobStore.On("Get", context.TODO(), "bikes/1/abc.png").Return(nil, blobstore.ErrNotFound)
+	_, err := s.useCaseImpl.Download(context.TODO(), s.downloadPayload(domain.PhotoVariantOriginal))
+	s.Equal(apperrors.ErrPhotoNotFound, err)
+}
+
+func (s *PhotoUseCaseTestSuite) TestDownload_FailedBlobRead() {
+	s.mockSigner.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.mockRepository.On("GetByID", context.TODO(), int64(4)).Return(&domain.BikePhoto{ID: 4, StorageKey: "bikes/1/abc.png"}, nil)
+	s.mockBlobStore.On("Get", context.TODO(), "bikes/1/abc.png").Return(nil, io.ErrUnexpectedEOF)
+	_, err := s.useCaseImpl.Download(context.TODO(), s.downloadPayload(domain.PhotoVariantOriginal))
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
@@ -0,0 +1,54 @@
+package privacy
+
+import (
+	"context"
+
+	"shared-bike/domain"
+)
+
+type IRepository interface {
+	GetUserByID(ctx context.Context, id int64) (*domain.User, error)
+	GetRoleNamesByUserID(ctx context.Context, userID int64) ([]string, error)
+	GetAuditEventsByUserID(ctx context.Context, userID int64) (*[]domain.AuditEvent, error)
+	GetTicketsByReporterID(ctx context.Context, reporterID int64) (*[]domain.MaintenanceTicket, error)
+	GetPhotosByUploaderID(ctx context.Context, uploaderID int64) (*[]domain.BikePhoto, error)
+	Erase(ctx context.Context, user *domain.User) (bool, *[]domain.BikePhoto, error)
+}
+
+// IUserCache is the cache of user names in front of the bike list, see user.CachedRepository.
+type IUserCache interface {
+	Invalidate(id int64)
+}
+
+// IBlobStore holds the photo files, see photo.IBlobStore.
+type IBlobStore interface {
+	Delete(ctx context.Context, key string) error
+}
+
+// ISigner signs photo download links, see photo.ISigner.
+type ISigner interface {
+	Sign(path string) string
+}
+
+type IAuditor interface {
+	Record(ctx context.Context, body domain.AuditRecord) error
+}
+
+type ILogger interface {
+	Info(i ...interface{})
+	Warn(i ...interface{})
+	Error(i ...interface{})
+}
+
+type IUseCase interface {
+	Export(ctx context.Context, userID int64) (domain.UserExport, error)
+	Erase(ctx context.Context, body domain.ErasureRequestPayload) error
+}
+
+//go:generate mockery --name IRepository --output mocks --case underscore
+//go:generate mockery --name IUserCache --output mocks --case underscore
+//go:generate mockery --name IBlobStore --output mocks --case underscore
+//go:generate mockery --name ISigner --output mocks --case underscore
+//go:generate mockery --name IAuditor --output mocks --case underscore
+//go:generate mockery --name ILogger --output mocks --case underscore
+//go:generate mockery --name IUseCase --output mocks --case underscore
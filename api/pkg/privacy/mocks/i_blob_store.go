@@ -0,0 +1,43 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IBlobStore is an autogenerated mock type for the IBlobStore type
+type IBlobStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, key
+func (_m *IBlobStore) Delete(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIBlobStore interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIBlobStore creates a new instance of IBlobStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIBlobStore(t mockConstructorTestingTNewIBlobStore) *IBlobStore {
+	mock := &IBlobStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,175 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRepository is an autogenerated mock type for the IRepository type
+type IRepository struct {
+	mock.Mock
+}
+
+// Erase provides a mock function with given fields: ctx, user
+func (_m *IRepository) Erase(ctx context.Context, user *domain.User) (bool, *[]domain.BikePhoto, error) {
+	ret := _m.Called(ctx, user)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) bool); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 *[]domain.BikePhoto
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User) *[]domain.BikePhoto); ok {
+		r1 = rf(ctx, user)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*[]domain.BikePhoto)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *domain.User) error); ok {
+		r2 = rf(ctx, user)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAuditEventsByUserID provides a mock function with given fields: ctx, userID
+func (_m *IRepository) GetAuditEventsByUserID(ctx context.Context, userID int64) (*[]domain.AuditEvent, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *[]domain.AuditEvent
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *[]domain.AuditEvent); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.AuditEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPhotosByUploaderID provides a mock function with given fields: ctx, uploaderID
+func (_m *IRepository) GetPhotosByUploaderID(ctx context.Context, uploaderID int64) (*[]domain.BikePhoto, error) {
+	ret := _m.Called(ctx, uploaderID)
+
+	var r0 *[]domain.BikePhoto
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *[]domain.BikePhoto); ok {
+		r0 = rf(ctx, uploaderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.BikePhoto)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, uploaderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleNamesByUserID provides a mock function with given fields: ctx, userID
+func (_m *IRepository) GetRoleNamesByUserID(ctx context.Context, userID int64) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTicketsByReporterID provides a mock function with given fields: ctx, reporterID
+func (_m *IRepository) GetTicketsByReporterID(ctx context.Context, reporterID int64) (*[]domain.MaintenanceTicket, error) {
+	ret := _m.Called(ctx, reporterID)
+
+	var r0 *[]domain.MaintenanceTicket
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *[]domain.MaintenanceTicket); ok {
+		r0 = rf(ctx, reporterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.MaintenanceTicket)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, reporterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByID provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetUserByID(ctx context.Context, id int64) (*domain.User, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIRepository creates a new instance of IRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIRepository(t mockConstructorTestingTNewIRepository) *IRepository {
+	mock := &IRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
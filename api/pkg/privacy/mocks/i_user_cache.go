@@ -0,0 +1,30 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// IUserCache is an autogenerated mock type for the IUserCache type
+type IUserCache struct {
+	mock.Mock
+}
+
+// Invalidate provides a mock function with given fields: id
+func (_m *IUserCache) Invalidate(id int64) {
+	_m.Called(id)
+}
+
+type mockConstructorTestingTNewIUserCache interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIUserCache creates a new instance of IUserCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIUserCache(t mockConstructorTestingTNewIUserCache) *IUserCache {
+	mock := &IUserCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
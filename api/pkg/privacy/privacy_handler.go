@@ -0,0 +1,101 @@
+package privacy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/middleware"
+	"shared-bike/tracing"
+
+	"github.com/labstack/echo/v4"
+)
+
+type handlerImpl struct {
+	useCase IUseCase
+}
+
+func NewHandler(useCase IUseCase) *handlerImpl {
+	return &handlerImpl{
+		useCase: useCase,
+	}
+}
+
+// Export godoc
+// @Summary      Export my data
+// @Description  API for downloading everything stored about the logged in user as a JSON file: profile, rides, payments, reports and audit entries. Rides and payments are rebuilt from the audit trail.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  domain.UserExport "Success"
+// @Header       200  {string}  Content-Disposition "attachment; filename=\"shared-bike-export-1.json\""
+// @Failure      400  {string}  string 	"user does not exist or inactive"
+// @Failure      500  {string}  string 	"internal server error"
+// @Security     BearerAuth
+// @Router       /users/me/export [get]
+func (h *handlerImpl) Export(c echo.Context) error {
+	span := tracing.StartHandler(c, "PrivacyHandler.Export")
+	defer span.End()
+	ctx := c.Request().Context()
+	claims, err := middleware.GetClaims(c)
+	if err != nil {
+		c.Logger().Error("[PrivacyHandler.Export] missing claims", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[PrivacyHandler.Export] user %d is exporting its data", claims.ID))
+	export, err := h.useCase.Export(ctx, claims.ID)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[PrivacyHandler.Export] export user %d failed", claims.ID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	body, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[PrivacyHandler.Export] marshal export of user %d failed", claims.ID), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInternalServerError), apperrors.ErrInternalServerError.Error())
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("shared-bike-export-%d.json", claims.ID)))
+	c.Logger().Info(fmt.Sprintf("[PrivacyHandler.Export] export user %d success", claims.ID))
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, body)
+}
+
+// Erase godoc
+// @Summary      Erase my account
+// @Description  API for erasing the logged in user. Username, name and password are anonymised and the account cannot log in again, rides, payments and the audit trail are kept without personal fields. Refused while a bike is rented.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param    		 request  body      domain.ErasureBody  true  "Current password to confirm"
+// @Success      204  "Erased"
+// @Failure      400  {string}  string 	"invalid body | password is wrong | user does not exist or inactive"
+// @Failure      409  {string}  string 	"cannot erase the account while a bike is rented"
+// @Failure      500  {string}  string 	"internal server error"
+// @Security     BearerAuth
+// @Router       /users/me/erasure [post]
+func (h *handlerImpl) Erase(c echo.Context) error {
+	span := tracing.StartHandler(c, "PrivacyHandler.Erase")
+	defer span.End()
+	ctx := c.Request().Context()
+	body := domain.ErasureBody{}
+	if err := c.Bind(&body); err != nil {
+		c.Logger().Error("[PrivacyHandler.Erase] invalid body", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBody), apperrors.ErrInvalidBody.Error())
+	}
+	claims, err := middleware.GetClaims(c)
+	if err != nil {
+		c.Logger().Error("[PrivacyHandler.Erase] missing claims", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[PrivacyHandler.Erase] user %d is erasing its account", claims.ID))
+	err = h.useCase.Erase(ctx, domain.ErasureRequestPayload{
+		UserID:   claims.ID,
+		Password: body.Password,
+	})
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[PrivacyHandler.Erase] erase user %d failed", claims.ID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[PrivacyHandler.Erase] erase user %d success", claims.ID))
+	return c.NoContent(http.StatusNoContent)
+}
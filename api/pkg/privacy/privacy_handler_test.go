@@ -0,0 +1,139 @@
+package privacy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/middleware"
+	"shared-bike/pkg/privacy/mocks"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type PrivacyHandlerTestSuite struct {
+	suite.Suite
+	mockUseCase *mocks.IUseCase
+	echo        *echo.Echo
+	handlerImpl *handlerImpl
+}
+
+func (s *PrivacyHandlerTestSuite) SetupTest() {
+	mockUseCase := &mocks.IUseCase{}
+	s.mockUseCase = mockUseCase
+	s.echo = echo.New()
+	s.handlerImpl = NewHandler(mockUseCase)
+}
+
+func TestPrivacyHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(PrivacyHandlerTestSuite))
+}
+
+func (s *PrivacyHandlerTestSuite) newContext(method, target, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.Set(middleware.UserKey, &jwt.Token{
+		Valid:  true,
+		Claims: &domain.Claims{ID: 2, Name: "Bob", Username: "bob"},
+	})
+	return c, rec
+}
+
+func (s *PrivacyHandlerTestSuite) TestExport_Success() {
+	export := domain.UserExport{
+		ExportedAt:   time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC),
+		Profile:      domain.UserExportProfile{ID: 2, Username: "bob", Name: "Bob", Roles: []string{}},
+		Rides:        []domain.RideExport{},
+		Payments:     []domain.PaymentExport{},
+		Reports:      []domain.MaintenanceTicketDTO{},
+		Photos:       []domain.PhotoDTO{},
+		AuditEntries: []domain.AuditEventDTO{},
+	}
+	s.mockUseCase.On("Export", context.Background(), int64(2)).Return(export, nil)
+	c, rec := s.newContext(http.MethodGet, "/users/me/export", "")
+	s.NoError(s.handlerImpl.Export(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(`attachment; filename="shared-bike-export-2.json"`, rec.Header().Get(echo.HeaderContentDisposition))
+	s.Equal(echo.MIMEApplicationJSONCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+	s.Equal(`{
+  "exportedAt": "2026-10-19T14:00:00Z",
+  "profile": {
+    "id": 2,
+    "username": "bob",
+    "name": "Bob",
+    "roles": [],
+    "createdAt": "0001-01-01T00:00:00Z",
+    "updatedAt": "0001-01-01T00:00:00Z"
+  },
+  "rides": [],
+  "payments": [],
+  "reports": [],
+  "photos": [],
+  "auditEntries": []
+}`, rec.Body.String())
+}
+
+func (s *PrivacyHandlerTestSuite) TestExport_Failed() {
+	s.mockUseCase.On("Export", context.Background(), int64(2)).Return(domain.UserExport{}, apperrors.ErrUserNotExisted)
+	c, rec := s.newContext(http.MethodGet, "/users/me/export", "")
+	s.NoError(s.handlerImpl.Export(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4042 user does not exist or inactive\"\n", rec.Body.String())
+	s.Empty(rec.Header().Get(echo.HeaderContentDisposition))
+}
+
+func (s *PrivacyHandlerTestSuite) TestErase_Success() {
+	s.mockUseCase.On("Erase", context.Background(), domain.ErasureRequestPayload{UserID: 2, Password: "secret"}).Return(nil)
+	c, rec := s.newContext(http.MethodPost, "/users/me/erasure", `{"password":"secret"}`)
+	s.NoError(s.handlerImpl.Erase(c))
+	s.Equal(http.StatusNoContent, rec.Code)
+	s.Empty(rec.Body.String())
+}
+
+func (s *PrivacyHandlerTestSuite) TestErase_InvalidBody() {
+	c, rec := s.newContext(http.MethodPost, "/users/me/erasure", `{"password":`)
+	s.NoError(s.handlerImpl.Erase(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4005 invalid body\"\n", rec.Body.String())
+}
+
+func (s *PrivacyHandlerTestSuite) TestErase_WhileRenting() {
+	s.mockUseCase.On("Erase", context.Background(), domain.ErasureRequestPayload{UserID: 2, Password: "secret"}).Return(apperrors.ErrErasureWhileRenting)
+	c, rec := s.newContext(http.MethodPost, "/users/me/erasure", `{"password":"secret"}`)
+	s.NoError(s.handlerImpl.Erase(c))
+	s.Equal(http.StatusConflict, rec.Code)
+	s.Equal("\"e4098 cannot erase the account while a bike is rented\"\n", rec.Body.String())
+}
+
+func (s *PrivacyHandlerTestSuite) TestErase_WrongPassword() {
+	s.mockUseCase.On("Erase", context.Background(), domain.ErasureRequestPayload{UserID: 2, Password: "guess"}).Return(apperrors.ErrWrongPassword)
+	c, rec := s.newContext(http.MethodPost, "/users/me/erasure", `{"password":"guess"}`)
+	s.NoError(s.handlerImpl.Erase(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e40026 password is wrong\"\n", rec.Body.String())
+}
+
+func (s *PrivacyHandlerTestSuite) TestExport_Unauthorized() {
+	c, rec := s.newContext(http.MethodGet, "/users/me/export", "")
+	c.Set(middleware.UserKey, nil)
+	s.NoError(s.handlerImpl.Export(c))
+	s.Equal(http.StatusUnauthorized, rec.Code)
+	s.Equal("\"e4010 unauthorized\"\n", rec.Body.String())
+}
+
+func (s *PrivacyHandlerTestSuite) TestErase_Unauthorized() {
+	c, rec := s.newContext(http.MethodPost, "/users/me/erasure", `{"password":"secret"}`)
+	c.Set(middleware.UserKey, &jwt.Token{Valid: true, Claims: jwt.MapClaims{"id": 2}})
+	s.NoError(s.handlerImpl.Erase(c))
+	s.Equal(http.StatusUnauthorized, rec.Code)
+	s.Equal("\"e4010 unauthorized\"\n", rec.Body.String())
+}
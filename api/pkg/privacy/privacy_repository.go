@@ -0,0 +1,180 @@
+package privacy
+
+import (
+	"context"
+
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repositoryImpl {
+	return &repositoryImpl{
+		db: db,
+	}
+}
+
+func (r *repositoryImpl) GetUserByID(ctx context.Context, id int64) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyRepository.GetUserByID")
+	defer span.End()
+	user := domain.User{}
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *repositoryImpl) GetRoleNamesByUserID(ctx context.Context, userID int64) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyRepository.GetRoleNamesByUserID")
+	defer span.End()
+	names := []string{}
+	err := r.db.WithContext(ctx).Model(&domain.Role{}).
+		Joins("JOIN user_role ON user_role.role_id = role.id").
+		Where("user_role.user_id = ?", userID).
+		Order("role.name").
+		Pluck("role.name", &names).Error
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// GetAuditEventsByUserID returns what the user did and what was done to the user, oldest first.
+func (r *repositoryImpl) GetAuditEventsByUserID(ctx context.Context, userID int64) (*[]domain.AuditEvent, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyRepository.GetAuditEventsByUserID")
+	defer span.End()
+	events := []domain.AuditEvent{}
+	err := r.db.WithContext(ctx).Where("actor_id = ? OR (target_type = ? AND target_id = ?)", userID, domain.AuditTargetUser, userID).
+		Order("id").
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return &events, nil
+}
+
+func (r *repositoryImpl) GetTicketsByReporterID(ctx context.Context, reporterID int64) (*[]domain.MaintenanceTicket, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyRepository.GetTicketsByReporterID")
+	defer span.End()
+	tickets := []domain.MaintenanceTicket{}
+	err := r.db.WithContext(ctx).Where("reporter_id = ?", reporterID).Order("id").Find(&tickets).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tickets, nil
+}
+
+// GetPhotosByUploaderID returns the photos the user took, oldest first.
+func (r *repositoryImpl) GetPhotosByUploaderID(ctx context.Context, uploaderID int64) (*[]domain.BikePhoto, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyRepository.GetPhotosByUploaderID")
+	defer span.End()
+	photos := []domain.BikePhoto{}
+	err := r.db.WithContext(ctx).Where("uploader_id = ?", uploaderID).Order("id").Find(&photos).Error
+	if err != nil {
+		return nil, err
+	}
+	return &photos, nil
+}
+
+// Erase writes the anonymised user, clears the notes of the tickets the user reported and scrubs the audit events
+// about the user and those tickets, and drops the user's roles, photos and stored idempotent responses, all in one
+// transaction. The user row is locked first, so two erasures or another write to the user take turns, and the audit
+// events and photos are read and locked inside it, so one recorded while erasing is not left behind. The deleted photos
+// are returned because their files live outside the database. It erases nothing and reports false while a bike is
+// rented by the user.
+func (r *repositoryImpl) Erase(ctx context.Context, user *domain.User) (bool, *[]domain.BikePhoto, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyRepository.Erase")
+	defer span.End()
+	erased := false
+	photos := []domain.BikePhoto{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", user.ID).First(&domain.User{}).Error; err != nil {
+			return err
+		}
+		var rented int64
+		if err := tx.Model(&domain.Bike{}).Where("user_id = ?", user.ID).Count(&rented).Error; err != nil {
+			return err
+		}
+		if rented > 0 {
+			return nil
+		}
+		err := tx.Model(&domain.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+			"username":   user.Username,
+			"name":       user.Name,
+			"password":   user.Password,
+			"deleted_at": user.DeletedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", user.ID).Delete(&domain.UserRole{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", user.ID).Delete(&domain.IdempotentRequest{}).Error; err != nil {
+			return err
+		}
+		ticketIDs := []int64{}
+		if err := tx.Model(&domain.MaintenanceTicket{}).Where("reporter_id = ?", user.ID).Pluck("id", &ticketIDs).Error; err != nil {
+			return err
+		}
+		if len(ticketIDs) > 0 {
+			if err := tx.Model(&domain.MaintenanceTicket{}).Where("id IN (?)", ticketIDs).Update("note", "").Error; err != nil {
+				return err
+			}
+		}
+		if err := scrubAuditEvents(tx, user.ID, ticketIDs); err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uploader_id = ?", user.ID).Order("id").Find(&photos).Error; err != nil {
+			return err
+		}
+		if len(photos) > 0 {
+			photoIDs := make([]int64, 0, len(photos))
+			for _, photo := range photos {
+				photoIDs = append(photoIDs, photo.ID)
+			}
+			if err := tx.Where("id IN (?)", photoIDs).Delete(&domain.BikePhoto{}).Error; err != nil {
+				return err
+			}
+		}
+		erased = true
+		return nil
+	})
+	if err != nil {
+		return false, nil, err
+	}
+	return erased, &photos, nil
+}
+
+func scrubAuditEvents(tx *gorm.DB, userID int64, ticketIDs []int64) error {
+	query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("actor_id = ? OR (target_type = ? AND target_id = ?)", userID, domain.AuditTargetUser, userID)
+	if len(ticketIDs) > 0 {
+		query = query.Or("target_type = ? AND target_id IN (?)", domain.AuditTargetTicket, ticketIDs)
+	}
+	events := []domain.AuditEvent{}
+	if err := query.Order("id").Find(&events).Error; err != nil {
+		return err
+	}
+	for _, event := range events {
+		if !domain.ScrubAuditEvent(&event, userID, ticketIDs) {
+			continue
+		}
+		err := tx.Model(&domain.AuditEvent{}).Where("id = ?", event.ID).Updates(map[string]interface{}{
+			"before": event.Before,
+			"after":  event.After,
+			"ip":     event.IP,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package privacy
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type PrivacyRepositoryTestSuite struct {
+	suite.Suite
+	mockDB         sqlmock.Sqlmock
+	repositoryImpl *repositoryImpl
+}
+
+func (s *PrivacyRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.Error(err, "Failed to open mock sql db, got error")
+	}
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		s.Error(err, "gormDB init err")
+	}
+	s.mockDB = mock
+	s.repositoryImpl = NewRepository(gormDB)
+}
+
+func TestPrivacyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PrivacyRepositoryTestSuite))
+}
+
+func (s *PrivacyRepositoryTestSuite) TestGetUserByID_Success() {
+	query := regexp.QuoteMeta("SELECT * FROM `user` WHERE id = ? AND `user`.`deleted_at` IS NULL ORDER BY `user`.`id` LIMIT 1")
+	rows := sqlmock.NewRows([]string{"id", "username", "name"}).AddRow(2, "bob", "Bob")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetUserByID(context.TODO(), 2)
+	s.Nil(err)
+	s.Equal(&domain.User{ID: 2, Username: "bob", Name: "Bob"}, actual)
+}
+
+func (s *PrivacyRepositoryTestSuite) TestGetUserByID_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `user` WHERE id = ?")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(2)).WillReturnError(gorm.ErrRecordNotFound)
+	actual, err := s.repositoryImpl.GetUserByID(context.TODO(), 2)
+	s.Nil(actual)
+	s.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (s *PrivacyRepositoryTestSuite) TestGetRoleNamesByUserID_Success() {
+	query := regexp.QuoteMeta("SELECT `role`.`name` FROM `role` JOIN user_role ON user_role.role_id = role.id WHERE user_role.user_id = ? ORDER BY role.name")
+	rows := sqlmock.NewRows([]string{"name"}).AddRow("mechanic").AddRow("support")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetRoleNamesByUserID(context.TODO(), 2)
+	s.Nil(err)
+	s.Equal([]string{"mechanic", "support"}, actual)
+}
+
+func (s *PrivacyRepositoryTestSuite) TestGetRoleNamesByUserID_Failed() {
+	query := regexp.QuoteMeta("SELECT `role`.`name` FROM `role`")
+	s.mockDB.ExpectQuery(query).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetRoleNamesByUserID(context.TODO(), 2)
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *PrivacyRepositoryTestSuite) TestGetAuditEventsByUserID_Success() {
+	query := regexp.QuoteMeta("SELECT * FROM `audit_event` WHERE actor_id = ? OR (target_type = ? AND target_id = ?) ORDER BY id")
+	rows := sqlmock.NewRows([]string{"id", "actor_id", "action", "target_type", "target_id"}).
+		AddRow(1, 2, domain.AuditActionUserRegister, domain.AuditTargetUser, 2).
+		AddRow(5, 1, domain.AuditActionRoleAssign, domain.AuditTargetUser, 2)
+	s.mockDB.ExpectQuery(query).WithArgs(int64(2), domain.AuditTargetUser, int64(2)).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetAuditEventsByUserID(context.TODO(), 2)
+	s.Nil(err)
+	s.Equal(&[]domain.AuditEvent{
+		{ID: 1, ActorID: sql.NullInt64{Valid: true, Int64: 2}, Action: domain.AuditActionUserRegister, TargetType: domain.AuditTargetUser, TargetID: 2},
+		{ID: 5, ActorID: sql.NullInt64{Valid: true, Int64: 1}, Action: domain.AuditActionRoleAssign, TargetType: domain.AuditTargetUser, TargetID: 2},
+	}, actual)
+}
+
+func (s *PrivacyRepositoryTestSuite) TestGetAuditEventsByUserID_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `audit_event`")
+	s.mockDB.ExpectQuery(query).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetAuditEventsByUserID(context.TODO(), 2)
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *PrivacyRepositoryTestSuite) TestGetTicketsByReporterID_Success() {
+	query := regexp.QuoteMeta("SELECT * FROM `maintenance_ticket` WHERE reporter_id = ? ORDER BY id")
+	rows := sqlmock.NewRows([]string{"id", "bike_id", "reporter_id", "note"}).AddRow(3, 1, 2, "flat tyre")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetTicketsByReporterID(context.TODO(), 2)
+	s.Nil(err)
+	s.Equal(&[]domain.MaintenanceTicket{{ID: 3, BikeID: 1, ReporterID: 2, Note: "flat tyre"}}, actual)
+}
+
+func (s *PrivacyRepositoryTestSuite) TestGetTicketsByReporterID_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `maintenance_ticket`")
+	s.mockDB.ExpectQuery(query).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetTicketsByReporterID(context.TODO(), 2)
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *PrivacyRepositoryTestSuite) TestGetPhotosByUploaderID_Success() {
+	query := regexp.QuoteMeta("SELECT * FROM `bike_photo` WHERE uploader_id = ? ORDER BY id")
+	rows := sqlmock.NewRows([]string{"id", "bike_id", "uploader_id", "kind", "storage_key"}).AddRow(5, 1, 2, domain.PhotoKindReport, "a1")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetPhotosByUploaderID(context.TODO(), 2)
+	s.Nil(err)
+	s.Equal(&[]domain.BikePhoto{{ID: 5, BikeID: 1, UploaderID: 2, Kind: domain.PhotoKindReport, StorageKey: "a1"}}, actual)
+}
+
+func (s *PrivacyRepositoryTestSuite) TestGetPhotosByUploaderID_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `bike_photo`")
+	s.mockDB.ExpectQuery(query).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetPhotosByUploaderID(context.TODO(), 2)
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *PrivacyRepositoryTestSuite) erasedUser() *domain.User {
+	user := &domain.User{ID: 2}
+	user.Anonymize(time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC))
+	return user
+}
+
+func (s *PrivacyRepositoryTestSuite) TestErase_Success() {
+	user := s.erasedUser()
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `user` WHERE id = ? AND `user`.`deleted_at` IS NULL ORDER BY `user`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `bike` WHERE user_id = ? AND `bike`.`deleted_at` IS NULL")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	s.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `user` SET `deleted_at`=?,`name`=?,`password`=?,`username`=?,`updated_at`=? WHERE id = ? AND `user`.`deleted_at` IS NULL")).
+		WithArgs(user.DeletedAt, "", "", "erased-2", sqlmock.AnyArg(), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectExec(regexp.QuoteMeta("DELETE FROM `user_role` WHERE user_id = ?")).
+		WithArgs(int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mockDB.ExpectExec(regexp.QuoteMeta("DELETE FROM `idempotent_request` WHERE user_id = ?")).
+		WithArgs(int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `maintenance_ticket` WHERE reporter_id = ?")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	s.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `maintenance_ticket` SET `note`=?,`updated_at`=? WHERE id IN (?)")).
+		WithArgs("", sqlmock.AnyArg(), int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `audit_event` WHERE (actor_id = ? OR (target_type = ? AND target_id = ?)) OR (target_type = ? AND target_id IN (?)) ORDER BY id FOR UPDATE")).
+		WithArgs(int64(2), domain.AuditTargetUser, int64(2), domain.AuditTargetTicket, int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor_id", "target_type", "target_id", "after", "ip"}).
+			AddRow(4, 2, domain.AuditTargetBike, 1, `{"id":1,"nameOfRenter":"Bob"}`, "10.0.0.1").
+			AddRow(5, 1, domain.AuditTargetTicket, 7, `{"id":7,"note":"call me on 0170 1234567"}`, "10.0.0.9").
+			AddRow(6, 1, domain.AuditTargetBike, 3, `{"id":3}`, "10.0.0.9"))
+	s.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `audit_event` SET `after`=?,`before`=?,`ip`=? WHERE id = ?")).
+		WithArgs(sql.NullString{Valid: true, String: `{"id":1}`}, sql.NullString{}, "", int64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `audit_event` SET `after`=?,`before`=?,`ip`=? WHERE id = ?")).
+		WithArgs(sql.NullString{Valid: true, String: `{"id":7}`}, sql.NullString{}, "10.0.0.9", int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `bike_photo` WHERE uploader_id = ? ORDER BY id FOR UPDATE")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uploader_id", "storage_key", "thumbnail_key"}).
+			AddRow(5, 2, "a1", "a1-thumb").
+			AddRow(6, 2, "b2", "b2-thumb"))
+	s.mockDB.ExpectExec(regexp.QuoteMeta("DELETE FROM `bike_photo` WHERE id IN (?,?)")).
+		WithArgs(int64(5), int64(6)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mockDB.ExpectCommit()
+	erased, photos, err := s.repositoryImpl.Erase(context.TODO(), user)
+	s.Nil(err)
+	s.True(erased)
+	s.Equal(&[]domain.BikePhoto{
+		{ID: 5, UploaderID: 2, StorageKey: "a1", ThumbnailKey: "a1-thumb"},
+		{ID: 6, UploaderID: 2, StorageKey: "b2", ThumbnailKey: "b2-thumb"},
+	}, photos)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *PrivacyRepositoryTestSuite) TestErase_Renting() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `user` WHERE id = ? AND `user`.`deleted_at` IS NULL ORDER BY `user`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `bike` WHERE user_id = ?")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	s.mockDB.ExpectCommit()
+	erased, photos, err := s.repositoryImpl.Erase(context.TODO(), s.erasedUser())
+	s.Nil(err)
+	s.False(erased)
+	s.Equal(&[]domain.BikePhoto{}, photos)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *PrivacyRepositoryTestSuite) TestErase_UserGone() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `user`")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	s.mockDB.ExpectRollback()
+	erased, photos, err := s.repositoryImpl.Erase(context.TODO(), s.erasedUser())
+	s.Equal(gorm.ErrRecordNotFound, err)
+	s.False(erased)
+	s.Nil(photos)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
+
+func (s *PrivacyRepositoryTestSuite) TestErase_Failed() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `user` WHERE id = ? AND `user`.`deleted_at` IS NULL ORDER BY `user`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `bike` WHERE user_id = ?")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	s.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `user` SET")).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	erased, photos, err := s.repositoryImpl.Erase(context.TODO(), s.erasedUser())
+	s.Equal(gorm.ErrInvalidDB, err)
+	s.False(erased)
+	s.Nil(photos)
+	s.Nil(s.mockDB.ExpectationsWereMet())
+}
@@ -0,0 +1,179 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+)
+
+// photoCleanUpTimeout bounds deleting the files of an erased user's photos, which runs even when the client is gone.
+const photoCleanUpTimeout = 30 * time.Second
+
+type useCaseImpl struct {
+	logger     ILogger
+	repository IRepository
+	userCache  IUserCache
+	photoStore IBlobStore
+	signer     ISigner
+	auditor    IAuditor
+	now        func() time.Time
+}
+
+func NewUseCase(logger ILogger, repository IRepository, userCache IUserCache, photoStore IBlobStore, signer ISigner, auditor IAuditor) *useCaseImpl {
+	return &useCaseImpl{
+		logger:     logger,
+		repository: repository,
+		userCache:  userCache,
+		photoStore: photoStore,
+		signer:     signer,
+		auditor:    auditor,
+		now:        time.Now,
+	}
+}
+
+// Export collects everything stored about a user. Rides and payments are rebuilt from the audit trail, which is
+// the only place rentals and return surcharges are recorded.
+func (u *useCaseImpl) Export(ctx context.Context, userID int64) (domain.UserExport, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyUseCase.Export")
+	defer span.End()
+	u.logger.Info(fmt.Sprintf("[PrivacyUseCase.Export] exporting user %d", userID))
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return domain.UserExport{}, err
+	}
+	roles, err := u.repository.GetRoleNamesByUserID(ctx, userID)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PrivacyUseCase.Export] fetch roles of user %d failed", userID), err)
+		return domain.UserExport{}, apperrors.ErrInternalServerError
+	}
+	events, err := u.repository.GetAuditEventsByUserID(ctx, userID)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PrivacyUseCase.Export] fetch audit events of user %d failed", userID), err)
+		return domain.UserExport{}, apperrors.ErrInternalServerError
+	}
+	tickets, err := u.repository.GetTicketsByReporterID(ctx, userID)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PrivacyUseCase.Export] fetch reports of user %d failed", userID), err)
+		return domain.UserExport{}, apperrors.ErrInternalServerError
+	}
+	photos, err := u.repository.GetPhotosByUploaderID(ctx, userID)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PrivacyUseCase.Export] fetch photos of user %d failed", userID), err)
+		return domain.UserExport{}, apperrors.ErrInternalServerError
+	}
+	rides, payments := domain.BuildRideHistory(userID, *events)
+	result := domain.UserExport{
+		ExportedAt: u.now().UTC(),
+		Profile: domain.UserExportProfile{
+			ID:        user.ID,
+			Username:  user.Username,
+			Name:      user.Name,
+			Roles:     roles,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		},
+		Rides:        rides,
+		Payments:     payments,
+		Reports:      []domain.MaintenanceTicketDTO{},
+		Photos:       []domain.PhotoDTO{},
+		AuditEntries: []domain.AuditEventDTO{},
+	}
+	for _, ticket := range *tickets {
+		result.Reports = append(result.Reports, ticket.ToDTO())
+	}
+	for _, photo := range *photos {
+		photoDTO := photo.ToDTO()
+		photoDTO.URL = u.signer.Sign(domain.PhotoDownloadPath(photo.ID, domain.PhotoVariantOriginal))
+		photoDTO.ThumbnailURL = u.signer.Sign(domain.PhotoDownloadPath(photo.ID, domain.PhotoVariantThumbnail))
+		result.Photos = append(result.Photos, photoDTO)
+	}
+	for _, event := range *events {
+		result.AuditEntries = append(result.AuditEntries, event.ToDTO())
+	}
+	u.audit(ctx, domain.AuditRecord{
+		ActorID:    userID,
+		Action:     domain.AuditActionUserExport,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userID,
+	})
+	u.logger.Info(fmt.Sprintf("[PrivacyUseCase.Export] export user %d success", userID))
+	return result, nil
+}
+
+// Erase anonymises the user after checking the password again. Rides, payments and the audit trail stay, with the
+// personal fields scrubbed, because they are financial records. Reports stay too, without their note. The user's
+// photos are deleted, files included.
+func (u *useCaseImpl) Erase(ctx context.Context, body domain.ErasureRequestPayload) error {
+	ctx, span := tracing.Start(ctx, "PrivacyUseCase.Erase")
+	defer span.End()
+	u.logger.Info(fmt.Sprintf("[PrivacyUseCase.Erase] erasing user %d", body.UserID))
+	user, err := u.getUser(ctx, body.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.ValidatePassword(body.Password) {
+		u.logger.Info(fmt.Sprintf("[PrivacyUseCase.Erase] wrong password for user %d", body.UserID))
+		return apperrors.ErrWrongPassword
+	}
+	user.Anonymize(u.now())
+	erased, photos, err := u.repository.Erase(ctx, user)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PrivacyUseCase.Erase] erase user %d failed", body.UserID), err)
+		return apperrors.ErrInternalServerError
+	}
+	if !erased {
+		u.logger.Info(fmt.Sprintf("[PrivacyUseCase.Erase] user %d is renting a bike", body.UserID))
+		return apperrors.ErrErasureWhileRenting
+	}
+	u.userCache.Invalidate(body.UserID)
+	u.deletePhotoFiles(ctx, *photos)
+	u.audit(ctx, domain.AuditRecord{
+		ActorID:    body.UserID,
+		Action:     domain.AuditActionUserErase,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   body.UserID,
+	})
+	u.logger.Info(fmt.Sprintf("[PrivacyUseCase.Erase] erase user %d success", body.UserID))
+	return nil
+}
+
+func (u *useCaseImpl) getUser(ctx context.Context, userID int64) (*domain.User, error) {
+	user, err := u.repository.GetUserByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info(fmt.Sprintf("[PrivacyUseCase.getUser] user %d not exists", userID))
+		return nil, apperrors.ErrUserNotExisted
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[PrivacyUseCase.getUser] fetch user %d failed", userID), err)
+		return nil, apperrors.ErrInternalServerError
+	}
+	return user, nil
+}
+
+// deletePhotoFiles removes the files of photos whose rows the erasure deleted. The user is erased by then, so a file
+// that cannot be deleted is only logged, nothing links to it any more.
+func (u *useCaseImpl) deletePhotoFiles(ctx context.Context, photos []domain.BikePhoto) {
+	ctx, cancel := domain.Detach(ctx, photoCleanUpTimeout)
+	defer cancel()
+	for _, photo := range photos {
+		for _, key := range []string{photo.StorageKey, photo.ThumbnailKey} {
+			if err := u.photoStore.Delete(ctx, key); err != nil {
+				u.logger.Error(fmt.Sprintf("[PrivacyUseCase.deletePhotoFiles] delete %s of photo %d failed", key, photo.ID), err)
+			}
+		}
+	}
+}
+
+// audit never fails the caller because the export is already built or the user already erased.
+func (u *useCaseImpl) audit(ctx context.Context, record domain.AuditRecord) {
+	if err := u.auditor.Record(ctx, record); err != nil {
+		u.logger.Error(fmt.Sprintf("[PrivacyUseCase.audit] record %s on user %d failed", record.Action, record.TargetID), err)
+	}
+}
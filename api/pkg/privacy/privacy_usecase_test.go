@@ -0,0 +1,232 @@
+package privacy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/privacy/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type PrivacyUseCaseTestSuite struct {
+	suite.Suite
+	mockRepository *mocks.IRepository
+	mockUserCache  *mocks.IUserCache
+	mockPhotoStore *mocks.IBlobStore
+	mockSigner     *mocks.ISigner
+	mockAuditor    *mocks.IAuditor
+	mockLogger     *mocks.ILogger
+	mockNow        time.Time
+	useCaseImpl    *useCaseImpl
+}
+
+func (s *PrivacyUseCaseTestSuite) SetupTest() {
+	s.mockRepository = &mocks.IRepository{}
+	s.mockUserCache = &mocks.IUserCache{}
+	s.mockPhotoStore = &mocks.IBlobStore{}
+	s.mockSigner = &mocks.ISigner{}
+	s.mockSigner.On("Sign", mock.Anything).Return(func(path string) string { return path + "?signature=x" })
+	s.mockAuditor = &mocks.IAuditor{}
+	s.mockLogger = &mocks.ILogger{}
+	s.mockLogger.On("Info", mock.Anything).Return()
+	s.mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+	s.mockNow = time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	s.useCaseImpl = NewUseCase(s.mockLogger, s.mockRepository, s.mockUserCache, s.mockPhotoStore, s.mockSigner, s.mockAuditor)
+	s.useCaseImpl.now = func() time.Time { return s.mockNow }
+}
+
+func TestPrivacyUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(PrivacyUseCaseTestSuite))
+}
+
+func (s *PrivacyUseCaseTestSuite) mockUser() *domain.User {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	s.Nil(err)
+	return &domain.User{ID: 2, Username: "bob", Name: "Bob", Password: string(hash)}
+}
+
+func (s *PrivacyUseCaseTestSuite) mockEvents() *[]domain.AuditEvent {
+	rentedAt := s.mockNow.Add(-2 * time.Hour)
+	returnedAt := s.mockNow.Add(-time.Hour)
+	return &[]domain.AuditEvent{
+		{
+			ID:         1,
+			ActorID:    sql.NullInt64{Valid: true, Int64: 2},
+			Action:     domain.AuditActionBikeRent,
+			TargetType: domain.AuditTargetBike,
+			TargetID:   1,
+			After:      sql.NullString{Valid: true, String: `{"id":1,"nameOfRenter":"Bob"}`},
+			IP:         "10.0.0.1",
+			CreatedAt:  rentedAt,
+		},
+		{
+			ID:         2,
+			ActorID:    sql.NullInt64{Valid: true, Int64: 2},
+			Action:     domain.AuditActionBikeReturn,
+			TargetType: domain.AuditTargetBike,
+			TargetID:   1,
+			After:      sql.NullString{Valid: true, String: `{"id":1,"surcharge":"5.00"}`},
+			CreatedAt:  returnedAt,
+		},
+	}
+}
+
+func (s *PrivacyUseCaseTestSuite) TestExport_Success() {
+	user := s.mockUser()
+	events := s.mockEvents()
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(user, nil)
+	s.mockRepository.On("GetRoleNamesByUserID", context.TODO(), int64(2)).Return([]string{"support"}, nil)
+	s.mockRepository.On("GetAuditEventsByUserID", context.TODO(), int64(2)).Return(events, nil)
+	s.mockRepository.On("GetTicketsByReporterID", context.TODO(), int64(2)).Return(&[]domain.MaintenanceTicket{{ID: 3, BikeID: 1, ReporterID: 2, Note: "flat tyre"}}, nil)
+	s.mockRepository.On("GetPhotosByUploaderID", context.TODO(), int64(2)).Return(&[]domain.BikePhoto{{ID: 5, BikeID: 1, UploaderID: 2, Kind: domain.PhotoKindReport, StorageKey: "a1"}}, nil)
+	s.mockAuditor.On("Record", context.TODO(), domain.AuditRecord{
+		ActorID:    2,
+		Action:     domain.AuditActionUserExport,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   2,
+	}).Return(nil)
+	actual, err := s.useCaseImpl.Export(context.TODO(), 2)
+	s.Nil(err)
+	rentedAt := (*events)[0].CreatedAt
+	returnedAt := (*events)[1].CreatedAt
+	s.Equal(s.mockNow, actual.ExportedAt)
+	s.Equal(domain.UserExportProfile{ID: 2, Username: "bob", Name: "Bob", Roles: []string{"support"}}, actual.Profile)
+	s.Equal([]domain.RideExport{{BikeID: 1, RentedAt: &rentedAt, ReturnedAt: &returnedAt, Surcharge: "5.00"}}, actual.Rides)
+	s.Equal([]domain.PaymentExport{{BikeID: 1, Reason: domain.PaymentReasonReturnSurcharge, Amount: "5.00", ChargedAt: returnedAt}}, actual.Payments)
+	s.Equal([]domain.MaintenanceTicketDTO{(&domain.MaintenanceTicket{ID: 3, BikeID: 1, ReporterID: 2, Note: "flat tyre"}).ToDTO()}, actual.Reports)
+	s.Equal([]domain.PhotoDTO{{
+		ID:           5,
+		BikeID:       1,
+		UploaderID:   2,
+		Kind:         domain.PhotoKindReport,
+		URL:          "/api/v1/photos/5/original?signature=x",
+		ThumbnailURL: "/api/v1/photos/5/thumbnail?signature=x",
+	}}, actual.Photos)
+	s.Len(actual.AuditEntries, 2)
+	s.Equal("10.0.0.1", actual.AuditEntries[0].IP)
+	s.mockAuditor.AssertExpectations(s.T())
+}
+
+func (s *PrivacyUseCaseTestSuite) TestExport_UserNotExisted() {
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.Export(context.TODO(), 2)
+	s.Equal(domain.UserExport{}, actual)
+	s.Equal(apperrors.ErrUserNotExisted, err)
+}
+
+func (s *PrivacyUseCaseTestSuite) TestExport_FailedFetchUser() {
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(nil, gorm.ErrInvalidDB)
+	_, err := s.useCaseImpl.Export(context.TODO(), 2)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *PrivacyUseCaseTestSuite) TestExport_FailedFetchRoles() {
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(s.mockUser(), nil)
+	s.mockRepository.On("GetRoleNamesByUserID", context.TODO(), int64(2)).Return(nil, gorm.ErrInvalidDB)
+	_, err := s.useCaseImpl.Export(context.TODO(), 2)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *PrivacyUseCaseTestSuite) TestExport_FailedFetchAuditEvents() {
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(s.mockUser(), nil)
+	s.mockRepository.On("GetRoleNamesByUserID", context.TODO(), int64(2)).Return([]string{}, nil)
+	s.mockRepository.On("GetAuditEventsByUserID", context.TODO(), int64(2)).Return(nil, gorm.ErrInvalidDB)
+	_, err := s.useCaseImpl.Export(context.TODO(), 2)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *PrivacyUseCaseTestSuite) TestExport_FailedFetchReports() {
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(s.mockUser(), nil)
+	s.mockRepository.On("GetRoleNamesByUserID", context.TODO(), int64(2)).Return([]string{}, nil)
+	s.mockRepository.On("GetAuditEventsByUserID", context.TODO(), int64(2)).Return(&[]domain.AuditEvent{}, nil)
+	s.mockRepository.On("GetTicketsByReporterID", context.TODO(), int64(2)).Return(nil, gorm.ErrInvalidDB)
+	_, err := s.useCaseImpl.Export(context.TODO(), 2)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *PrivacyUseCaseTestSuite) TestExport_FailedFetchPhotos() {
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(s.mockUser(), nil)
+	s.mockRepository.On("GetRoleNamesByUserID", context.TODO(), int64(2)).Return([]string{}, nil)
+	s.mockRepository.On("GetAuditEventsByUserID", context.TODO(), int64(2)).Return(&[]domain.AuditEvent{}, nil)
+	s.mockRepository.On("GetTicketsByReporterID", context.TODO(), int64(2)).Return(&[]domain.MaintenanceTicket{}, nil)
+	s.mockRepository.On("GetPhotosByUploaderID", context.TODO(), int64(2)).Return(nil, gorm.ErrInvalidDB)
+	_, err := s.useCaseImpl.Export(context.TODO(), 2)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *PrivacyUseCaseTestSuite) TestExport_SuccessWhenAuditFails() {
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(s.mockUser(), nil)
+	s.mockRepository.On("GetRoleNamesByUserID", context.TODO(), int64(2)).Return([]string{}, nil)
+	s.mockRepository.On("GetAuditEventsByUserID", context.TODO(), int64(2)).Return(&[]domain.AuditEvent{}, nil)
+	s.mockRepository.On("GetTicketsByReporterID", context.TODO(), int64(2)).Return(&[]domain.MaintenanceTicket{}, nil)
+	s.mockRepository.On("GetPhotosByUploaderID", context.TODO(), int64(2)).Return(&[]domain.BikePhoto{}, nil)
+	s.mockAuditor.On("Record", context.TODO(), mock.Anything).Return(errors.New("audit down"))
+	actual, err := s.useCaseImpl.Export(context.TODO(), 2)
+	s.Nil(err)
+	s.Equal([]domain.RideExport{}, actual.Rides)
+	s.Equal([]domain.MaintenanceTicketDTO{}, actual.Reports)
+	s.Equal([]domain.PhotoDTO{}, actual.Photos)
+	s.Equal([]domain.AuditEventDTO{}, actual.AuditEntries)
+}
+
+func (s *PrivacyUseCaseTestSuite) TestErase_Success() {
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(s.mockUser(), nil)
+	s.mockRepository.On("Erase", context.TODO(), mock.MatchedBy(func(user *domain.User) bool {
+		return user.ID == 2 && user.Username == "erased-2" && user.Name == "" && user.Password == "" && user.DeletedAt.Time.Equal(s.mockNow)
+	})).Return(true, &[]domain.BikePhoto{{ID: 5, StorageKey: "a1", ThumbnailKey: "a1-thumb"}}, nil)
+	s.mockUserCache.On("Invalidate", int64(2)).Return()
+	s.mockPhotoStore.On("Delete", mock.Anything, "a1").Return(nil)
+	s.mockPhotoStore.On("Delete", mock.Anything, "a1-thumb").Return(errors.New("mock error"))
+	s.mockAuditor.On("Record", context.TODO(), domain.AuditRecord{
+		ActorID:    2,
+		Action:     domain.AuditActionUserErase,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   2,
+	}).Return(nil)
+	err := s.useCaseImpl.Erase(context.TODO(), domain.ErasureRequestPayload{UserID: 2, Password: "secret"})
+	s.Nil(err)
+	s.mockRepository.AssertExpectations(s.T())
+	s.mockUserCache.AssertExpectations(s.T())
+	s.mockPhotoStore.AssertExpectations(s.T())
+	s.mockAuditor.AssertExpectations(s.T())
+}
+
+func (s *PrivacyUseCaseTestSuite) TestErase_WrongPassword() {
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(s.mockUser(), nil)
+	err := s.useCaseImpl.Erase(context.TODO(), domain.ErasureRequestPayload{UserID: 2, Password: "guess"})
+	s.Equal(apperrors.ErrWrongPassword, err)
+	s.mockRepository.AssertNotCalled(s.T(), "Erase", mock.Anything, mock.Anything)
+}
+
+func (s *PrivacyUseCaseTestSuite) TestErase_UserNotExisted() {
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+	err := s.useCaseImpl.Erase(context.TODO(), domain.ErasureRequestPayload{UserID: 2, Password: "secret"})
+	s.Equal(apperrors.ErrUserNotExisted, err)
+}
+
+func (s *PrivacyUseCaseTestSuite) TestErase_WhileRenting() {
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(s.mockUser(), nil)
+	s.mockRepository.On("Erase", context.TODO(), mock.Anything).Return(false, &[]domain.BikePhoto{}, nil)
+	err := s.useCaseImpl.Erase(context.TODO(), domain.ErasureRequestPayload{UserID: 2, Password: "secret"})
+	s.Equal(apperrors.ErrErasureWhileRenting, err)
+	s.mockUserCache.AssertNotCalled(s.T(), "Invalidate", mock.Anything)
+	s.mockPhotoStore.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+	s.mockAuditor.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything)
+}
+
+func (s *PrivacyUseCaseTestSuite) TestErase_Failed() {
+	s.mockRepository.On("GetUserByID", context.TODO(), int64(2)).Return(s.mockUser(), nil)
+	s.mockRepository.On("Erase", context.TODO(), mock.Anything).Return(false, nil, gorm.ErrInvalidDB)
+	err := s.useCaseImpl.Erase(context.TODO(), domain.ErasureRequestPayload{UserID: 2, Password: "secret"})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.mockUserCache.AssertNotCalled(s.T(), "Invalidate", mock.Anything)
+}
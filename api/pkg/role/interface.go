@@ -0,0 +1,43 @@
+package role
+
+import (
+	"context"
+
+	"shared-bike/domain"
+)
+
+type IRepository interface {
+	GetList(ctx context.Context) (*[]domain.Role, error)
+	GetByID(ctx context.Context, id int64) (*domain.Role, error)
+	GetListByUserID(ctx context.Context, userID int64) (*[]domain.Role, error)
+	GetPermissionsByUserID(ctx context.Context, userID int64) ([]domain.Permission, error)
+	AssignToUser(ctx context.Context, body *domain.UserRole) error
+	RemoveFromUser(ctx context.Context, body *domain.UserRole) error
+}
+
+type IUserRepository interface {
+	GetByID(ctx context.Context, id int64) (*domain.User, error)
+}
+
+type IAuditor interface {
+	Record(ctx context.Context, body domain.AuditRecord) error
+}
+
+type ILogger interface {
+	Info(i ...interface{})
+	Warn(i ...interface{})
+	Error(i ...interface{})
+}
+
+type IUseCase interface {
+	GetAllRoles(ctx context.Context) ([]domain.RoleDTO, error)
+	GetUserRoles(ctx context.Context, userID int64) ([]domain.RoleDTO, error)
+	AssignRole(ctx context.Context, body domain.AssignRoleRequestPayload) ([]domain.RoleDTO, error)
+	UnassignRole(ctx context.Context, body domain.AssignRoleRequestPayload) ([]domain.RoleDTO, error)
+}
+
+//go:generate mockery --name IRepository --output mocks --case underscore
+//go:generate mockery --name IUserRepository --output mocks --case underscore
+//go:generate mockery --name IAuditor --output mocks --case underscore
+//go:generate mockery --name ILogger --output mocks --case underscore
+//go:generate mockery --name IUseCase --output mocks --case underscore
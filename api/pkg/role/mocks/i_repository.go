@@ -0,0 +1,150 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRepository is an autogenerated mock type for the IRepository type
+type IRepository struct {
+	mock.Mock
+}
+
+// AssignToUser provides a mock function with given fields: ctx, body
+func (_m *IRepository) AssignToUser(ctx context.Context, body *domain.UserRole) error {
+	ret := _m.Called(ctx, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserRole) error); ok {
+		r0 = rf(ctx, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetByID(ctx context.Context, id int64) (*domain.Role, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Role
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Role); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetList provides a mock function with given fields: ctx
+func (_m *IRepository) GetList(ctx context.Context) (*[]domain.Role, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *[]domain.Role
+	if rf, ok := ret.Get(0).(func(context.Context) *[]domain.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetListByUserID provides a mock function with given fields: ctx, userID
+func (_m *IRepository) GetListByUserID(ctx context.Context, userID int64) (*[]domain.Role, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *[]domain.Role
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *[]domain.Role); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPermissionsByUserID provides a mock function with given fields: ctx, userID
+func (_m *IRepository) GetPermissionsByUserID(ctx context.Context, userID int64) ([]domain.Permission, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.Permission
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Permission); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Permission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveFromUser provides a mock function with given fields: ctx, body
+func (_m *IRepository) RemoveFromUser(ctx context.Context, body *domain.UserRole) error {
+	ret := _m.Called(ctx, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserRole) error); ok {
+		r0 = rf(ctx, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIRepository creates a new instance of IRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIRepository(t mockConstructorTestingTNewIRepository) *IRepository {
+	mock := &IRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
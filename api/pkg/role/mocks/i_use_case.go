@@ -0,0 +1,122 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IUseCase is an autogenerated mock type for the IUseCase type
+type IUseCase struct {
+	mock.Mock
+}
+
+// AssignRole provides a mock function with given fields: ctx, body
+func (_m *IUseCase) AssignRole(ctx context.Context, body domain.AssignRoleRequestPayload) ([]domain.RoleDTO, error) {
+	ret := _m.Called(ctx, body)
+
+	var r0 []domain.RoleDTO
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AssignRoleRequestPayload) []domain.RoleDTO); ok {
+		r0 = rf(ctx, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RoleDTO)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AssignRoleRequestPayload) error); ok {
+		r1 = rf(ctx, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllRoles provides a mock function with given fields: ctx
+func (_m *IUseCase) GetAllRoles(ctx context.Context) ([]domain.RoleDTO, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.RoleDTO
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.RoleDTO); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RoleDTO)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserRoles provides a mock function with given fields: ctx, userID
+func (_m *IUseCase) GetUserRoles(ctx context.Context, userID int64) ([]domain.RoleDTO, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.RoleDTO
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.RoleDTO); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RoleDTO)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnassignRole provides a mock function with given fields: ctx, body
+func (_m *IUseCase) UnassignRole(ctx context.Context, body domain.AssignRoleRequestPayload) ([]domain.RoleDTO, error) {
+	ret := _m.Called(ctx, body)
+
+	var r0 []domain.RoleDTO
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AssignRoleRequestPayload) []domain.RoleDTO); ok {
+		r0 = rf(ctx, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RoleDTO)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AssignRoleRequestPayload) error); ok {
+		r1 = rf(ctx, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIUseCase creates a new instance of IUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIUseCase(t mockConstructorTestingTNewIUseCase) *IUseCase {
+	mock := &IUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
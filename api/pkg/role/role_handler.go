@@ -0,0 +1,183 @@
+package role
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"github.com/labstack/echo/v4"
+)
+
+type handlerImpl struct {
+	useCase IUseCase
+}
+
+func NewHandler(useCase IUseCase) *handlerImpl {
+	return &handlerImpl{
+		useCase: useCase,
+	}
+}
+
+// GetAllRoles godoc
+// @Summary      Get all roles
+// @Description  API for getting all staff roles and their permissions
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   []domain.RoleDTO "Success"
+// @Failure      403  {string}  string 	"you do not have permission to perform this action"
+// @Failure      500  {string}  string 	"internal server error"
+// @Security     BearerAuth
+// @Router       /admin/roles [get]
+func (h *handlerImpl) GetAllRoles(c echo.Context) error {
+	span := tracing.StartHandler(c, "RoleHandler.GetAllRoles")
+	defer span.End()
+	c.Logger().Info("[RoleHandler.GetAllRoles] starting")
+	ctx := c.Request().Context()
+	roles, err := h.useCase.GetAllRoles(ctx)
+	if err != nil {
+		c.Logger().Error("[RoleHandler.GetAllRoles] cannot get all roles", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info("[RoleHandler.GetAllRoles] success")
+	return c.JSON(http.StatusOK, roles)
+}
+
+// GetUserRoles godoc
+// @Summary      Get roles of a user
+// @Description  API for getting the roles assigned to a user
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param 			 id 	path  		string 		true 								"user id"
+// @Success      200  {array}   []domain.RoleDTO 							"Success"
+// @Failure      400  {string}  string 												"invalid user id | user does not exist or inactive"
+// @Failure      403  {string}  string 												"you do not have permission to perform this action"
+// @Failure      500  {string}  string 												"internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/roles [get]
+func (h *handlerImpl) GetUserRoles(c echo.Context) error {
+	span := tracing.StartHandler(c, "RoleHandler.GetUserRoles")
+	defer span.End()
+	var (
+		ctx    = c.Request().Context()
+		userID int64
+		err    error
+	)
+	userIDStr := c.Param("id")
+	if userID, err = strconv.ParseInt(userIDStr, 10, 64); err != nil {
+		c.Logger().Error(fmt.Sprintf("[RoleHandler.GetUserRoles] invalid user id %s", userIDStr), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidUserID), apperrors.ErrInvalidUserID.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[RoleHandler.GetUserRoles] fetching roles of user %d", userID))
+	roles, err := h.useCase.GetUserRoles(ctx, userID)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[RoleHandler.GetUserRoles] fetch roles of user %d failed", userID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[RoleHandler.GetUserRoles] fetch roles of user %d success", userID))
+	return c.JSON(http.StatusOK, roles)
+}
+
+// AssignRole godoc
+// @Summary      Assign a role to a user
+// @Description  API for granting a staff role to a user, the user has to login again to receive the new permissions
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param 			 id 	     path  		string 		             true 	"user id"
+// @Param    		 request  body      domain.AssignRoleBody  true  "Assign role body"
+// @Success      200  {array}   []domain.RoleDTO 							"Success"
+// @Failure      400  {string}  string 												"invalid user id | invalid role id | invalid body | user does not exist or inactive"
+// @Failure      403  {string}  string 												"you do not have permission to perform this action"
+// @Failure      404  {string}  string 												"role not found"
+// @Failure      500  {string}  string 												"internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/roles [post]
+func (h *handlerImpl) AssignRole(c echo.Context) error {
+	span := tracing.StartHandler(c, "RoleHandler.AssignRole")
+	defer span.End()
+	var (
+		ctx    = c.Request().Context()
+		userID int64
+		err    error
+	)
+	userIDStr := c.Param("id")
+	if userID, err = strconv.ParseInt(userIDStr, 10, 64); err != nil {
+		c.Logger().Error(fmt.Sprintf("[RoleHandler.AssignRole] invalid user id %s", userIDStr), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidUserID), apperrors.ErrInvalidUserID.Error())
+	}
+	body := domain.AssignRoleBody{}
+	if err := c.Bind(&body); err != nil {
+		c.Logger().Error("[RoleHandler.AssignRole] invalid body", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBody), apperrors.ErrInvalidBody.Error())
+	}
+	if body.RoleID <= 0 {
+		c.Logger().Error(fmt.Sprintf("[RoleHandler.AssignRole] invalid role id %d", body.RoleID), apperrors.ErrInvalidRoleID)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidRoleID), apperrors.ErrInvalidRoleID.Error())
+	}
+	request := domain.AssignRoleRequestPayload{
+		UserID: userID,
+		RoleID: body.RoleID,
+	}
+	c.Logger().Info(fmt.Sprintf("[RoleHandler.AssignRole] assigning role %d to user %d", body.RoleID, userID))
+	roles, err := h.useCase.AssignRole(ctx, request)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[RoleHandler.AssignRole] assign role %d to user %d failed", body.RoleID, userID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[RoleHandler.AssignRole] assign role %d to user %d success", body.RoleID, userID))
+	return c.JSON(http.StatusOK, roles)
+}
+
+// UnassignRole godoc
+// @Summary      Remove a role from a user
+// @Description  API for revoking a staff role from a user
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param 			 id 	    path  		string 		true 								"user id"
+// @Param 			 roleId 	path  		string 		true 								"role id"
+// @Success      200  {array}   []domain.RoleDTO 							"Success"
+// @Failure      400  {string}  string 												"invalid user id | invalid role id | user does not exist or inactive"
+// @Failure      403  {string}  string 												"you do not have permission to perform this action"
+// @Failure      404  {string}  string 												"role not found"
+// @Failure      500  {string}  string 												"internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/roles/{roleId} [delete]
+func (h *handlerImpl) UnassignRole(c echo.Context) error {
+	span := tracing.StartHandler(c, "RoleHandler.UnassignRole")
+	defer span.End()
+	var (
+		ctx    = c.Request().Context()
+		userID int64
+		roleID int64
+		err    error
+	)
+	userIDStr := c.Param("id")
+	if userID, err = strconv.ParseInt(userIDStr, 10, 64); err != nil {
+		c.Logger().Error(fmt.Sprintf("[RoleHandler.UnassignRole] invalid user id %s", userIDStr), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidUserID), apperrors.ErrInvalidUserID.Error())
+	}
+	roleIDStr := c.Param("roleId")
+	if roleID, err = strconv.ParseInt(roleIDStr, 10, 64); err != nil {
+		c.Logger().Error(fmt.Sprintf("[RoleHandler.UnassignRole] invalid role id %s", roleIDStr), err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidRoleID), apperrors.ErrInvalidRoleID.Error())
+	}
+	request := domain.AssignRoleRequestPayload{
+		UserID: userID,
+		RoleID: roleID,
+	}
+	c.Logger().Info(fmt.Sprintf("[RoleHandler.UnassignRole] removing role %d from user %d", roleID, userID))
+	roles, err := h.useCase.UnassignRole(ctx, request)
+	if err != nil {
+		c.Logger().Error(fmt.Sprintf("[RoleHandler.UnassignRole] remove role %d from user %d failed", roleID, userID), err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[RoleHandler.UnassignRole] remove role %d from user %d success", roleID, userID))
+	return c.JSON(http.StatusOK, roles)
+}
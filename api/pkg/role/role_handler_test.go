@@ -0,0 +1,205 @@
+package role
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/role/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleHandlerTestSuite struct {
+	suite.Suite
+	mockUseCase *mocks.IUseCase
+	echo        *echo.Echo
+	handlerImpl *handlerImpl
+}
+
+func (s *RoleHandlerTestSuite) SetupTest() {
+	mockUseCase := &mocks.IUseCase{}
+	s.mockUseCase = mockUseCase
+	s.echo = echo.New()
+	s.handlerImpl = NewHandler(mockUseCase)
+}
+
+func TestRoleHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(RoleHandlerTestSuite))
+}
+
+func (s *RoleHandlerTestSuite) TestGetAllRoles_Success() {
+	mockResult := []domain.RoleDTO{
+		{ID: 2, Name: "mechanic", Description: "Maintains bikes", Permissions: []domain.Permission{domain.PermissionBikesMaintain}},
+	}
+	s.mockUseCase.On("GetAllRoles", context.Background()).Return(mockResult, nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/roles", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	respBody := `[{"id":2,"name":"mechanic","description":"Maintains bikes","permissions":["bikes:maintain"]}]
+`
+	s.NoError(s.handlerImpl.GetAllRoles(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(respBody, rec.Body.String())
+}
+
+func (s *RoleHandlerTestSuite) TestGetAllRoles_Failed() {
+	s.mockUseCase.On("GetAllRoles", context.Background()).Return(nil, apperrors.ErrForbidden)
+	req := httptest.NewRequest(http.MethodGet, "/admin/roles", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	respBody := `"e4030 you do not have permission to perform this action"
+`
+	s.NoError(s.handlerImpl.GetAllRoles(c))
+	s.Equal(http.StatusForbidden, rec.Code)
+	s.Equal(respBody, rec.Body.String())
+}
+
+func (s *RoleHandlerTestSuite) TestGetUserRoles_Success() {
+	s.mockUseCase.On("GetUserRoles", context.Background(), int64(5)).Return([]domain.RoleDTO{}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/users/5/roles", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/admin/users/:id/roles")
+	c.SetParamNames("id")
+	c.SetParamValues("5")
+	s.NoError(s.handlerImpl.GetUserRoles(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("[]\n", rec.Body.String())
+}
+
+func (s *RoleHandlerTestSuite) TestGetUserRoles_FailedParams() {
+	req := httptest.NewRequest(http.MethodGet, "/admin/users/abc/roles", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/admin/users/:id/roles")
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	s.NoError(s.handlerImpl.GetUserRoles(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4007 invalid user id\"\n", rec.Body.String())
+}
+
+func (s *RoleHandlerTestSuite) TestGetUserRoles_FailedUseCase() {
+	s.mockUseCase.On("GetUserRoles", context.Background(), int64(5)).Return(nil, apperrors.ErrUserNotExisted)
+	req := httptest.NewRequest(http.MethodGet, "/admin/users/5/roles", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/admin/users/:id/roles")
+	c.SetParamNames("id")
+	c.SetParamValues("5")
+	s.NoError(s.handlerImpl.GetUserRoles(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *RoleHandlerTestSuite) TestAssignRole_Success() {
+	mockResult := []domain.RoleDTO{{ID: 2, Name: "mechanic", Permissions: []domain.Permission{}}}
+	s.mockUseCase.On("AssignRole", context.Background(), domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2}).Return(mockResult, nil)
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/5/roles", strings.NewReader(`{"roleId":2}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/admin/users/:id/roles")
+	c.SetParamNames("id")
+	c.SetParamValues("5")
+	respBody := `[{"id":2,"name":"mechanic","description":"","permissions":[]}]
+`
+	s.NoError(s.handlerImpl.AssignRole(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(respBody, rec.Body.String())
+}
+
+func (s *RoleHandlerTestSuite) TestAssignRole_FailedParams() {
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/abc/roles", strings.NewReader(`{"roleId":2}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/admin/users/:id/roles")
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	s.NoError(s.handlerImpl.AssignRole(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4007 invalid user id\"\n", rec.Body.String())
+}
+
+func (s *RoleHandlerTestSuite) TestAssignRole_FailedBody() {
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/5/roles", strings.NewReader(`{"roleId":"abc"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/admin/users/:id/roles")
+	c.SetParamNames("id")
+	c.SetParamValues("5")
+	s.NoError(s.handlerImpl.AssignRole(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4005 invalid body\"\n", rec.Body.String())
+}
+
+func (s *RoleHandlerTestSuite) TestAssignRole_FailedRoleID() {
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/5/roles", strings.NewReader(`{}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/admin/users/:id/roles")
+	c.SetParamNames("id")
+	c.SetParamValues("5")
+	s.NoError(s.handlerImpl.AssignRole(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4008 invalid role id\"\n", rec.Body.String())
+}
+
+func (s *RoleHandlerTestSuite) TestAssignRole_FailedUseCase() {
+	s.mockUseCase.On("AssignRole", context.Background(), domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2}).Return(nil, apperrors.ErrRoleNotFound)
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/5/roles", strings.NewReader(`{"roleId":2}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/admin/users/:id/roles")
+	c.SetParamNames("id")
+	c.SetParamValues("5")
+	s.NoError(s.handlerImpl.AssignRole(c))
+	s.Equal(http.StatusNotFound, rec.Code)
+	s.Equal("\"e4043 role not found\"\n", rec.Body.String())
+}
+
+func (s *RoleHandlerTestSuite) TestUnassignRole_Success() {
+	s.mockUseCase.On("UnassignRole", context.Background(), domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2}).Return([]domain.RoleDTO{}, nil)
+	req := httptest.NewRequest(http.MethodDelete, "/admin/users/5/roles/2", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/admin/users/:id/roles/:roleId")
+	c.SetParamNames("id", "roleId")
+	c.SetParamValues("5", "2")
+	s.NoError(s.handlerImpl.UnassignRole(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("[]\n", rec.Body.String())
+}
+
+func (s *RoleHandlerTestSuite) TestUnassignRole_FailedRoleParams() {
+	req := httptest.NewRequest(http.MethodDelete, "/admin/users/5/roles/abc", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/admin/users/:id/roles/:roleId")
+	c.SetParamNames("id", "roleId")
+	c.SetParamValues("5", "abc")
+	s.NoError(s.handlerImpl.UnassignRole(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4008 invalid role id\"\n", rec.Body.String())
+}
+
+func (s *RoleHandlerTestSuite) TestUnassignRole_FailedUseCase() {
+	s.mockUseCase.On("UnassignRole", context.Background(), domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2}).Return(nil, apperrors.ErrForbidden)
+	req := httptest.NewRequest(http.MethodDelete, "/admin/users/5/roles/2", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	c.SetPath("/admin/users/:id/roles/:roleId")
+	c.SetParamNames("id", "roleId")
+	c.SetParamValues("5", "2")
+	s.NoError(s.handlerImpl.UnassignRole(c))
+	s.Equal(http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,137 @@
+package role
+
+import (
+	"context"
+
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+type rolePermission struct {
+	RoleID int64
+	Code   domain.Permission
+}
+
+func NewRepository(db *gorm.DB) *repositoryImpl {
+	return &repositoryImpl{
+		db: db,
+	}
+}
+
+func (r *repositoryImpl) GetList(ctx context.Context) (*[]domain.Role, error) {
+	ctx, span := tracing.Start(ctx, "RoleRepository.GetList")
+	defer span.End()
+	roles := []domain.Role{}
+	err := r.db.WithContext(ctx).Order("id").Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.fillPermissions(ctx, roles); err != nil {
+		return nil, err
+	}
+	return &roles, nil
+}
+
+func (r *repositoryImpl) GetByID(ctx context.Context, id int64) (*domain.Role, error) {
+	ctx, span := tracing.Start(ctx, "RoleRepository.GetByID")
+	defer span.End()
+	role := domain.Role{}
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	roles := []domain.Role{role}
+	if err := r.fillPermissions(ctx, roles); err != nil {
+		return nil, err
+	}
+	return &roles[0], nil
+}
+
+func (r *repositoryImpl) GetListByUserID(ctx context.Context, userID int64) (*[]domain.Role, error) {
+	ctx, span := tracing.Start(ctx, "RoleRepository.GetListByUserID")
+	defer span.End()
+	roles := []domain.Role{}
+	err := r.db.WithContext(ctx).Joins("JOIN user_role ON user_role.role_id = role.id").
+		Where("user_role.user_id = ?", userID).
+		Order("role.id").
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.fillPermissions(ctx, roles); err != nil {
+		return nil, err
+	}
+	return &roles, nil
+}
+
+func (r *repositoryImpl) GetPermissionsByUserID(ctx context.Context, userID int64) ([]domain.Permission, error) {
+	ctx, span := tracing.Start(ctx, "RoleRepository.GetPermissionsByUserID")
+	defer span.End()
+	permissions := []domain.Permission{}
+	err := r.db.WithContext(ctx).Table("permission").
+		Distinct("permission.code").
+		Joins("JOIN role_permission ON role_permission.permission_id = permission.id").
+		Joins("JOIN user_role ON user_role.role_id = role_permission.role_id").
+		Where("user_role.user_id = ?", userID).
+		Order("permission.code").
+		Pluck("permission.code", &permissions).Error
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (r *repositoryImpl) AssignToUser(ctx context.Context, body *domain.UserRole) error {
+	ctx, span := tracing.Start(ctx, "RoleRepository.AssignToUser")
+	defer span.End()
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(body).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repositoryImpl) RemoveFromUser(ctx context.Context, body *domain.UserRole) error {
+	ctx, span := tracing.Start(ctx, "RoleRepository.RemoveFromUser")
+	defer span.End()
+	err := r.db.WithContext(ctx).Where("user_id = ? AND role_id = ?", body.UserID, body.RoleID).Delete(&domain.UserRole{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repositoryImpl) fillPermissions(ctx context.Context, roles []domain.Role) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	roleIDs := make([]int64, 0, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, role.ID)
+	}
+	rows := []rolePermission{}
+	err := r.db.WithContext(ctx).Table("role_permission").
+		Select("role_permission.role_id, permission.code").
+		Joins("JOIN permission ON permission.id = role_permission.permission_id").
+		Where("role_permission.role_id IN (?)", roleIDs).
+		Order("permission.code").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+	permissionsByRole := map[int64][]domain.Permission{}
+	for _, row := range rows {
+		permissionsByRole[row.RoleID] = append(permissionsByRole[row.RoleID], row.Code)
+	}
+	for i := range roles {
+		roles[i].Permissions = permissionsByRole[roles[i].ID]
+	}
+	return nil
+}
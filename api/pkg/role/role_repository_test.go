@@ -0,0 +1,207 @@
+package role
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type RoleRepositoryTestSuite struct {
+	suite.Suite
+	mockDB         sqlmock.Sqlmock
+	repositoryImpl *repositoryImpl
+}
+
+func (s *RoleRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.Error(err, "Failed to open mock sql db, got error")
+	}
+
+	if db == nil {
+		s.Error(nil, "mock db is null")
+	}
+
+	if mock == nil {
+		s.Error(nil, "sqlmock is null")
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		s.Error(err, "gormDB init err")
+	}
+	s.mockDB = mock
+	repositoryImpl := NewRepository(gormDB)
+	s.repositoryImpl = repositoryImpl
+}
+
+func TestRoleRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RoleRepositoryTestSuite))
+}
+
+func (s *RoleRepositoryTestSuite) expectPermissions(roleIDs int) *sqlmock.ExpectedQuery {
+	query := regexp.QuoteMeta("SELECT role_permission.role_id, permission.code FROM `role_permission` JOIN permission ON permission.id = role_permission.permission_id WHERE role_permission.role_id IN (" + strings.TrimSuffix(strings.Repeat("?,", roleIDs), ",") + ") ORDER BY permission.code")
+	return s.mockDB.ExpectQuery(query)
+}
+
+func (s *RoleRepositoryTestSuite) TestGetList_Success() {
+	mockTime := time.Time{}
+	mockRoles := []domain.Role{
+		{
+			ID:          1,
+			Name:        "admin",
+			Description: "Full access",
+			Permissions: []domain.Permission{domain.PermissionBikesRead, domain.PermissionRolesManage},
+			CreatedAt:   mockTime,
+			UpdatedAt:   mockTime,
+		},
+		{
+			ID:          2,
+			Name:        "mechanic",
+			Description: "Maintains bikes",
+			Permissions: []domain.Permission{domain.PermissionBikesMaintain},
+			CreatedAt:   mockTime,
+			UpdatedAt:   mockTime,
+		},
+	}
+	rows := sqlmock.NewRows([]string{"id", "name", "description", "created_at", "updated_at"}).
+		AddRow(mockRoles[0].ID, mockRoles[0].Name, mockRoles[0].Description, mockTime, mockTime).
+		AddRow(mockRoles[1].ID, mockRoles[1].Name, mockRoles[1].Description, mockTime, mockTime)
+	permissionRows := sqlmock.NewRows([]string{"role_id", "code"}).
+		AddRow(1, domain.PermissionBikesRead).
+		AddRow(2, domain.PermissionBikesMaintain).
+		AddRow(1, domain.PermissionRolesManage)
+	query := regexp.QuoteMeta("SELECT * FROM `role` ORDER BY id")
+	s.mockDB.ExpectQuery(query).WillReturnRows(rows)
+	s.expectPermissions(2).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(permissionRows)
+	actual, err := s.repositoryImpl.GetList(context.TODO())
+	s.Nil(err)
+	s.Equal(mockRoles, *actual)
+}
+
+func (s *RoleRepositoryTestSuite) TestGetList_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `role` ORDER BY id")
+	s.mockDB.ExpectQuery(query).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetList(context.TODO())
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *RoleRepositoryTestSuite) TestGetList_FailedPermissions() {
+	rows := sqlmock.NewRows([]string{"id", "name", "description"}).AddRow(1, "admin", "Full access")
+	query := regexp.QuoteMeta("SELECT * FROM `role` ORDER BY id")
+	s.mockDB.ExpectQuery(query).WillReturnRows(rows)
+	s.expectPermissions(1).WithArgs(sqlmock.AnyArg()).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetList(context.TODO())
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *RoleRepositoryTestSuite) TestGetByID_Success() {
+	mockRole := domain.Role{
+		ID:          2,
+		Name:        "mechanic",
+		Description: "Maintains bikes",
+		Permissions: []domain.Permission{domain.PermissionBikesMaintain},
+	}
+	rows := sqlmock.NewRows([]string{"id", "name", "description"}).AddRow(mockRole.ID, mockRole.Name, mockRole.Description)
+	permissionRows := sqlmock.NewRows([]string{"role_id", "code"}).AddRow(2, domain.PermissionBikesMaintain)
+	query := regexp.QuoteMeta("SELECT * FROM `role` WHERE id = ? ORDER BY `role`.`id` LIMIT 1")
+	s.mockDB.ExpectQuery(query).WithArgs(sqlmock.AnyArg()).WillReturnRows(rows)
+	s.expectPermissions(1).WithArgs(sqlmock.AnyArg()).WillReturnRows(permissionRows)
+	actual, err := s.repositoryImpl.GetByID(context.TODO(), 2)
+	s.Nil(err)
+	s.Equal(mockRole, *actual)
+}
+
+func (s *RoleRepositoryTestSuite) TestGetByID_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `role` WHERE id = ? ORDER BY `role`.`id` LIMIT 1")
+	s.mockDB.ExpectQuery(query).WithArgs(sqlmock.AnyArg()).WillReturnError(gorm.ErrRecordNotFound)
+	actual, err := s.repositoryImpl.GetByID(context.TODO(), 2)
+	s.Nil(actual)
+	s.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (s *RoleRepositoryTestSuite) TestGetListByUserID_Success() {
+	rows := sqlmock.NewRows([]string{"id", "name", "description"})
+	query := regexp.QuoteMeta("SELECT `role`.`id`,`role`.`name`,`role`.`description`,`role`.`created_at`,`role`.`updated_at` FROM `role` JOIN user_role ON user_role.role_id = role.id WHERE user_role.user_id = ? ORDER BY role.id")
+	s.mockDB.ExpectQuery(query).WithArgs(sqlmock.AnyArg()).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetListByUserID(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal([]domain.Role{}, *actual)
+}
+
+func (s *RoleRepositoryTestSuite) TestGetListByUserID_Failed() {
+	query := regexp.QuoteMeta("SELECT `role`.`id`,`role`.`name`,`role`.`description`,`role`.`created_at`,`role`.`updated_at` FROM `role` JOIN user_role ON user_role.role_id = role.id WHERE user_role.user_id = ? ORDER BY role.id")
+	s.mockDB.ExpectQuery(query).WithArgs(sqlmock.AnyArg()).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetListByUserID(context.TODO(), 1)
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *RoleRepositoryTestSuite) TestGetPermissionsByUserID_Success() {
+	rows := sqlmock.NewRows([]string{"code"}).
+		AddRow(domain.PermissionBikesMaintain).
+		AddRow(domain.PermissionBikesRead)
+	query := regexp.QuoteMeta("SELECT DISTINCT permission.code FROM `permission` JOIN role_permission ON role_permission.permission_id = permission.id JOIN user_role ON user_role.role_id = role_permission.role_id WHERE user_role.user_id = ? ORDER BY permission.code")
+	s.mockDB.ExpectQuery(query).WithArgs(sqlmock.AnyArg()).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetPermissionsByUserID(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal([]domain.Permission{domain.PermissionBikesMaintain, domain.PermissionBikesRead}, actual)
+}
+
+func (s *RoleRepositoryTestSuite) TestGetPermissionsByUserID_Failed() {
+	query := regexp.QuoteMeta("SELECT DISTINCT permission.code FROM `permission` JOIN role_permission ON role_permission.permission_id = permission.id JOIN user_role ON user_role.role_id = role_permission.role_id WHERE user_role.user_id = ? ORDER BY permission.code")
+	s.mockDB.ExpectQuery(query).WithArgs(sqlmock.AnyArg()).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetPermissionsByUserID(context.TODO(), 1)
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *RoleRepositoryTestSuite) TestAssignToUser_Success() {
+	query := regexp.QuoteMeta("INSERT INTO `user_role` (`user_id`,`role_id`,`created_at`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `user_id`=`user_id`")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mockDB.ExpectCommit()
+	err := s.repositoryImpl.AssignToUser(context.TODO(), &domain.UserRole{UserID: 1, RoleID: 2})
+	s.Nil(err)
+}
+
+func (s *RoleRepositoryTestSuite) TestAssignToUser_Failed() {
+	query := regexp.QuoteMeta("INSERT INTO `user_role` (`user_id`,`role_id`,`created_at`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `user_id`=`user_id`")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	err := s.repositoryImpl.AssignToUser(context.TODO(), &domain.UserRole{UserID: 1, RoleID: 2})
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *RoleRepositoryTestSuite) TestRemoveFromUser_Success() {
+	query := regexp.QuoteMeta("DELETE FROM `user_role` WHERE user_id = ? AND role_id = ?")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectCommit()
+	err := s.repositoryImpl.RemoveFromUser(context.TODO(), &domain.UserRole{UserID: 1, RoleID: 2})
+	s.Nil(err)
+}
+
+func (s *RoleRepositoryTestSuite) TestRemoveFromUser_Failed() {
+	query := regexp.QuoteMeta("DELETE FROM `user_role` WHERE user_id = ? AND role_id = ?")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	err := s.repositoryImpl.RemoveFromUser(context.TODO(), &domain.UserRole{UserID: 1, RoleID: 2})
+	s.Equal(gorm.ErrInvalidDB, err)
+}
@@ -0,0 +1,170 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+)
+
+type useCaseImpl struct {
+	repository     IRepository
+	logger         ILogger
+	userRepository IUserRepository
+	auditor        IAuditor
+}
+
+func NewUseCase(logger ILogger, repository IRepository, userRepository IUserRepository, auditor IAuditor) *useCaseImpl {
+	return &useCaseImpl{
+		repository:     repository,
+		logger:         logger,
+		userRepository: userRepository,
+		auditor:        auditor,
+	}
+}
+
+func (u *useCaseImpl) GetAllRoles(ctx context.Context) ([]domain.RoleDTO, error) {
+	ctx, span := tracing.Start(ctx, "RoleUseCase.GetAllRoles")
+	defer span.End()
+	u.logger.Info("[RoleUseCase.GetAllRoles] fetching all roles")
+	if err := domain.Authorize(ctx, domain.PermissionRolesManage); err != nil {
+		u.logger.Error("[RoleUseCase.GetAllRoles] permission denied", err)
+		return []domain.RoleDTO{}, err
+	}
+	roles, err := u.repository.GetList(ctx)
+	if err != nil {
+		u.logger.Error("[RoleUseCase.GetAllRoles] fetch all roles failed", err)
+		return []domain.RoleDTO{}, apperrors.ErrInternalServerError
+	}
+	u.logger.Info("[RoleUseCase.GetAllRoles] fetch all roles success")
+	return u.transformRoleDTOList(roles), nil
+}
+
+func (u *useCaseImpl) GetUserRoles(ctx context.Context, userID int64) ([]domain.RoleDTO, error) {
+	ctx, span := tracing.Start(ctx, "RoleUseCase.GetUserRoles")
+	defer span.End()
+	u.logger.Info(fmt.Sprintf("[RoleUseCase.GetUserRoles] fetching roles of user %d", userID))
+	if err := domain.Authorize(ctx, domain.PermissionRolesManage); err != nil {
+		u.logger.Error("[RoleUseCase.GetUserRoles] permission denied", err)
+		return []domain.RoleDTO{}, err
+	}
+	if err := u.checkUserExisted(ctx, userID); err != nil {
+		return []domain.RoleDTO{}, err
+	}
+	return u.fetchUserRoles(ctx, userID)
+}
+
+func (u *useCaseImpl) AssignRole(ctx context.Context, body domain.AssignRoleRequestPayload) ([]domain.RoleDTO, error) {
+	ctx, span := tracing.Start(ctx, "RoleUseCase.AssignRole")
+	defer span.End()
+	u.logger.Info(fmt.Sprintf("[RoleUseCase.AssignRole] assigning role %d to user %d", body.RoleID, body.UserID))
+	if err := u.validateAssignment(ctx, body); err != nil {
+		return []domain.RoleDTO{}, err
+	}
+	before, err := u.fetchUserRoles(ctx, body.UserID)
+	if err != nil {
+		return []domain.RoleDTO{}, err
+	}
+	err = u.repository.AssignToUser(ctx, &domain.UserRole{UserID: body.UserID, RoleID: body.RoleID})
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[RoleUseCase.AssignRole] assign role %d to user %d failed", body.RoleID, body.UserID), err)
+		return []domain.RoleDTO{}, apperrors.ErrInternalServerError
+	}
+	u.logger.Info(fmt.Sprintf("[RoleUseCase.AssignRole] assign role %d to user %d success", body.RoleID, body.UserID))
+	return u.auditUserRoles(ctx, domain.AuditActionRoleAssign, body.UserID, before)
+}
+
+func (u *useCaseImpl) UnassignRole(ctx context.Context, body domain.AssignRoleRequestPayload) ([]domain.RoleDTO, error) {
+	ctx, span := tracing.Start(ctx, "RoleUseCase.UnassignRole")
+	defer span.End()
+	u.logger.Info(fmt.Sprintf("[RoleUseCase.UnassignRole] removing role %d from user %d", body.RoleID, body.UserID))
+	if err := u.validateAssignment(ctx, body); err != nil {
+		return []domain.RoleDTO{}, err
+	}
+	before, err := u.fetchUserRoles(ctx, body.UserID)
+	if err != nil {
+		return []domain.RoleDTO{}, err
+	}
+	err = u.repository.RemoveFromUser(ctx, &domain.UserRole{UserID: body.UserID, RoleID: body.RoleID})
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[RoleUseCase.UnassignRole] remove role %d from user %d failed", body.RoleID, body.UserID), err)
+		return []domain.RoleDTO{}, apperrors.ErrInternalServerError
+	}
+	u.logger.Info(fmt.Sprintf("[RoleUseCase.UnassignRole] remove role %d from user %d success", body.RoleID, body.UserID))
+	return u.auditUserRoles(ctx, domain.AuditActionRoleUnassign, body.UserID, before)
+}
+
+func (u *useCaseImpl) validateAssignment(ctx context.Context, body domain.AssignRoleRequestPayload) error {
+	if err := domain.Authorize(ctx, domain.PermissionRolesManage); err != nil {
+		u.logger.Error("[RoleUseCase.validateAssignment] permission denied", err)
+		return err
+	}
+	if err := u.checkUserExisted(ctx, body.UserID); err != nil {
+		return err
+	}
+	_, err := u.repository.GetByID(ctx, body.RoleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info(fmt.Sprintf("[RoleUseCase.validateAssignment] cannot find role %d", body.RoleID))
+		return apperrors.ErrRoleNotFound
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[RoleUseCase.validateAssignment] fetch role %d failed", body.RoleID), err)
+		return apperrors.ErrInternalServerError
+	}
+	return nil
+}
+
+func (u *useCaseImpl) checkUserExisted(ctx context.Context, userID int64) error {
+	_, err := u.userRepository.GetByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info(fmt.Sprintf("[RoleUseCase.checkUserExisted] user %d not exists", userID))
+		return apperrors.ErrUserNotExisted
+	}
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[RoleUseCase.checkUserExisted] fetch user %d failed", userID), err)
+		return apperrors.ErrInternalServerError
+	}
+	return nil
+}
+
+func (u *useCaseImpl) fetchUserRoles(ctx context.Context, userID int64) ([]domain.RoleDTO, error) {
+	roles, err := u.repository.GetListByUserID(ctx, userID)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[RoleUseCase.fetchUserRoles] fetch roles of user %d failed", userID), err)
+		return []domain.RoleDTO{}, apperrors.ErrInternalServerError
+	}
+	u.logger.Info(fmt.Sprintf("[RoleUseCase.fetchUserRoles] fetch roles of user %d success", userID))
+	return u.transformRoleDTOList(roles), nil
+}
+
+// auditUserRoles records the change with the roles before and after it and returns the current roles.
+func (u *useCaseImpl) auditUserRoles(ctx context.Context, action domain.AuditAction, userID int64, before []domain.RoleDTO) ([]domain.RoleDTO, error) {
+	after, err := u.fetchUserRoles(ctx, userID)
+	if err != nil {
+		return []domain.RoleDTO{}, err
+	}
+	err = u.auditor.Record(ctx, domain.AuditRecord{
+		Action:     action,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userID,
+		Before:     before,
+		After:      after,
+	})
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[RoleUseCase.auditUserRoles] record %s on user %d failed", action, userID), err)
+	}
+	return after, nil
+}
+
+func (u *useCaseImpl) transformRoleDTOList(roles *[]domain.Role) []domain.RoleDTO {
+	results := []domain.RoleDTO{}
+	for _, role := range *roles {
+		results = append(results, role.ToDTO())
+	}
+	return results
+}
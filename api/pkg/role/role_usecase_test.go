@@ -0,0 +1,204 @@
+package role
+
+import (
+	"context"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/role/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type RoleUseCaseTestSuite struct {
+	suite.Suite
+	mockRepository     *mocks.IRepository
+	mockUserRepository *mocks.IUserRepository
+	mockLogger         *mocks.ILogger
+	mockAuditor        *mocks.IAuditor
+	useCaseImpl        *useCaseImpl
+	adminContext       context.Context
+}
+
+func (s *RoleUseCaseTestSuite) SetupTest() {
+	mockRepository := &mocks.IRepository{}
+	s.mockRepository = mockRepository
+	mockUserRepository := &mocks.IUserRepository{}
+	s.mockUserRepository = mockUserRepository
+	mockLogger := &mocks.ILogger{}
+	s.mockLogger = mockLogger
+	s.mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+	s.mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+	mockAuditor := &mocks.IAuditor{}
+	s.mockAuditor = mockAuditor
+	s.useCaseImpl = NewUseCase(mockLogger, mockRepository, mockUserRepository, mockAuditor)
+	s.adminContext = domain.NewContextWithClaims(context.TODO(), &domain.Claims{
+		ID:          1,
+		Permissions: []domain.Permission{domain.PermissionRolesManage},
+	})
+}
+
+func TestRoleUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(RoleUseCaseTestSuite))
+}
+
+func (s *RoleUseCaseTestSuite) TestGetAllRoles_Success() {
+	mockRoles := []domain.Role{
+		{ID: 1, Name: "admin", Permissions: []domain.Permission{domain.PermissionRolesManage}},
+		{ID: 2, Name: "mechanic"},
+	}
+	s.mockRepository.On("GetList", s.adminContext).Return(&mockRoles, nil)
+	actual, err := s.useCaseImpl.GetAllRoles(s.adminContext)
+	s.Nil(err)
+	s.Equal([]domain.RoleDTO{
+		{ID: 1, Name: "admin", Permissions: []domain.Permission{domain.PermissionRolesManage}},
+		{ID: 2, Name: "mechanic", Permissions: []domain.Permission{}},
+	}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestGetAllRoles_FailedUnauthorized() {
+	actual, err := s.useCaseImpl.GetAllRoles(context.TODO())
+	s.Equal(apperrors.ErrUnauthorizeError, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestGetAllRoles_FailedForbidden() {
+	ctx := domain.NewContextWithClaims(context.TODO(), &domain.Claims{ID: 2})
+	actual, err := s.useCaseImpl.GetAllRoles(ctx)
+	s.Equal(apperrors.ErrForbidden, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestGetAllRoles_FailedRepository() {
+	s.mockRepository.On("GetList", s.adminContext).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.GetAllRoles(s.adminContext)
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestGetUserRoles_Success() {
+	mockRoles := []domain.Role{{ID: 2, Name: "mechanic", Permissions: []domain.Permission{domain.PermissionBikesMaintain}}}
+	s.mockUserRepository.On("GetByID", s.adminContext, int64(5)).Return(&domain.User{ID: 5}, nil)
+	s.mockRepository.On("GetListByUserID", s.adminContext, int64(5)).Return(&mockRoles, nil)
+	actual, err := s.useCaseImpl.GetUserRoles(s.adminContext, 5)
+	s.Nil(err)
+	s.Equal([]domain.RoleDTO{mockRoles[0].ToDTO()}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestGetUserRoles_FailedUserNotExisted() {
+	s.mockUserRepository.On("GetByID", s.adminContext, int64(5)).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.GetUserRoles(s.adminContext, 5)
+	s.Equal(apperrors.ErrUserNotExisted, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestGetUserRoles_FailedFetchUser() {
+	s.mockUserRepository.On("GetByID", s.adminContext, int64(5)).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.GetUserRoles(s.adminContext, 5)
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestGetUserRoles_FailedForbidden() {
+	ctx := domain.NewContextWithClaims(context.TODO(), &domain.Claims{ID: 2})
+	actual, err := s.useCaseImpl.GetUserRoles(ctx, 5)
+	s.Equal(apperrors.ErrForbidden, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestAssignRole_Success() {
+	mockRoles := []domain.Role{{ID: 2, Name: "mechanic", Permissions: []domain.Permission{domain.PermissionBikesMaintain}}}
+	s.mockUserRepository.On("GetByID", s.adminContext, int64(5)).Return(&domain.User{ID: 5}, nil)
+	s.mockRepository.On("GetByID", s.adminContext, int64(2)).Return(&mockRoles[0], nil)
+	s.mockRepository.On("AssignToUser", s.adminContext, &domain.UserRole{UserID: 5, RoleID: 2}).Return(nil)
+	s.mockRepository.On("GetListByUserID", s.adminContext, int64(5)).Return(&[]domain.Role{}, nil).Once()
+	s.mockRepository.On("GetListByUserID", s.adminContext, int64(5)).Return(&mockRoles, nil).Once()
+	s.mockAuditor.On("Record", s.adminContext, domain.AuditRecord{
+		Action:     domain.AuditActionRoleAssign,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   5,
+		Before:     []domain.RoleDTO{},
+		After:      []domain.RoleDTO{mockRoles[0].ToDTO()},
+	}).Return(nil)
+	actual, err := s.useCaseImpl.AssignRole(s.adminContext, domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2})
+	s.Nil(err)
+	s.Equal([]domain.RoleDTO{mockRoles[0].ToDTO()}, actual)
+	s.mockAuditor.AssertExpectations(s.T())
+}
+
+func (s *RoleUseCaseTestSuite) TestAssignRole_FailedForbidden() {
+	ctx := domain.NewContextWithClaims(context.TODO(), &domain.Claims{ID: 2, Permissions: []domain.Permission{domain.PermissionUsersRead}})
+	actual, err := s.useCaseImpl.AssignRole(ctx, domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2})
+	s.Equal(apperrors.ErrForbidden, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestAssignRole_FailedRoleNotFound() {
+	s.mockUserRepository.On("GetByID", s.adminContext, int64(5)).Return(&domain.User{ID: 5}, nil)
+	s.mockRepository.On("GetByID", s.adminContext, int64(2)).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.AssignRole(s.adminContext, domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2})
+	s.Equal(apperrors.ErrRoleNotFound, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestAssignRole_FailedFetchRole() {
+	s.mockUserRepository.On("GetByID", s.adminContext, int64(5)).Return(&domain.User{ID: 5}, nil)
+	s.mockRepository.On("GetByID", s.adminContext, int64(2)).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.AssignRole(s.adminContext, domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestAssignRole_FailedAssign() {
+	s.mockUserRepository.On("GetByID", s.adminContext, int64(5)).Return(&domain.User{ID: 5}, nil)
+	s.mockRepository.On("GetByID", s.adminContext, int64(2)).Return(&domain.Role{ID: 2}, nil)
+	s.mockRepository.On("GetListByUserID", s.adminContext, int64(5)).Return(&[]domain.Role{}, nil)
+	s.mockRepository.On("AssignToUser", s.adminContext, &domain.UserRole{UserID: 5, RoleID: 2}).Return(gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.AssignRole(s.adminContext, domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestAssignRole_FailedFetchUserRoles() {
+	s.mockUserRepository.On("GetByID", s.adminContext, int64(5)).Return(&domain.User{ID: 5}, nil)
+	s.mockRepository.On("GetByID", s.adminContext, int64(2)).Return(&domain.Role{ID: 2}, nil)
+	s.mockRepository.On("GetListByUserID", s.adminContext, int64(5)).Return(&[]domain.Role{}, nil).Once()
+	s.mockRepository.On("AssignToUser", s.adminContext, &domain.UserRole{UserID: 5, RoleID: 2}).Return(nil)
+	s.mockRepository.On("GetListByUserID", s.adminContext, int64(5)).Return(nil, gorm.ErrInvalidDB).Once()
+	actual, err := s.useCaseImpl.AssignRole(s.adminContext, domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestUnassignRole_Success() {
+	mockRoles := []domain.Role{}
+	s.mockUserRepository.On("GetByID", s.adminContext, int64(5)).Return(&domain.User{ID: 5}, nil)
+	s.mockRepository.On("GetByID", s.adminContext, int64(2)).Return(&domain.Role{ID: 2}, nil)
+	s.mockRepository.On("GetListByUserID", s.adminContext, int64(5)).Return(&[]domain.Role{{ID: 2, Name: "mechanic"}}, nil).Once()
+	s.mockRepository.On("RemoveFromUser", s.adminContext, &domain.UserRole{UserID: 5, RoleID: 2}).Return(nil)
+	s.mockRepository.On("GetListByUserID", s.adminContext, int64(5)).Return(&mockRoles, nil).Once()
+	s.mockAuditor.On("Record", s.adminContext, mock.Anything).Return(apperrors.ErrInternalServerError)
+	actual, err := s.useCaseImpl.UnassignRole(s.adminContext, domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2})
+	s.Nil(err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestUnassignRole_FailedUserNotExisted() {
+	s.mockUserRepository.On("GetByID", s.adminContext, int64(5)).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.UnassignRole(s.adminContext, domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2})
+	s.Equal(apperrors.ErrUserNotExisted, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
+
+func (s *RoleUseCaseTestSuite) TestUnassignRole_FailedRemove() {
+	s.mockUserRepository.On("GetByID", s.adminContext, int64(5)).Return(&domain.User{ID: 5}, nil)
+	s.mockRepository.On("GetByID", s.adminContext, int64(2)).Return(&domain.Role{ID: 2}, nil)
+	s.mockRepository.On("GetListByUserID", s.adminContext, int64(5)).Return(&[]domain.Role{}, nil)
+	s.mockRepository.On("RemoveFromUser", s.adminContext, &domain.UserRole{UserID: 5, RoleID: 2}).Return(gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.UnassignRole(s.adminContext, domain.AssignRoleRequestPayload{UserID: 5, RoleID: 2})
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal([]domain.RoleDTO{}, actual)
+}
@@ -0,0 +1,27 @@
+package station
+
+import (
+	"context"
+
+	"shared-bike/domain"
+)
+
+type IRepository interface {
+	GetList(ctx context.Context) (*[]domain.StationOccupancy, error)
+	GetByID(ctx context.Context, id int64) (*domain.Station, error)
+	CountBikesByStationID(ctx context.Context, id int64) (int64, error)
+}
+
+type ILogger interface {
+	Info(i ...interface{})
+	Warn(i ...interface{})
+	Error(i ...interface{})
+}
+
+type IUseCase interface {
+	GetList(ctx context.Context) ([]domain.StationDTO, error)
+}
+
+//go:generate mockery --name IRepository --output mocks --case underscore
+//go:generate mockery --name ILogger --output mocks --case underscore
+//go:generate mockery --name IUseCase --output mocks --case underscore
@@ -0,0 +1,44 @@
+package station
+
+import (
+	"net/http"
+
+	"shared-bike/apperrors"
+	"shared-bike/tracing"
+
+	"github.com/labstack/echo/v4"
+)
+
+type handlerImpl struct {
+	useCase IUseCase
+}
+
+func NewHandler(useCase IUseCase) *handlerImpl {
+	return &handlerImpl{
+		useCase: useCase,
+	}
+}
+
+// GetList godoc
+// @Summary      Get all stations
+// @Description  API for getting all docking stations with their available bikes and free docks
+// @Tags         stations
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   []domain.StationDTO "Success"
+// @Failure      500  {string}  string 	"internal server error"
+// @Security     BearerAuth
+// @Router       /stations [get]
+func (h *handlerImpl) GetList(c echo.Context) error {
+	span := tracing.StartHandler(c, "StationHandler.GetList")
+	defer span.End()
+	c.Logger().Info("[StationHandler.GetList] starting")
+	ctx := c.Request().Context()
+	stations, err := h.useCase.GetList(ctx)
+	if err != nil {
+		c.Logger().Error("[StationHandler.GetList] cannot get stations", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info("[StationHandler.GetList] success")
+	return c.JSON(http.StatusOK, stations)
+}
@@ -0,0 +1,56 @@
+package station
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/station/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type StationHandlerTestSuite struct {
+	suite.Suite
+	mockUseCase *mocks.IUseCase
+	echo        *echo.Echo
+	handlerImpl *handlerImpl
+}
+
+func (s *StationHandlerTestSuite) SetupTest() {
+	mockUseCase := &mocks.IUseCase{}
+	s.mockUseCase = mockUseCase
+	s.echo = echo.New()
+	s.handlerImpl = NewHandler(mockUseCase)
+}
+
+func TestStationHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(StationHandlerTestSuite))
+}
+
+func (s *StationHandlerTestSuite) TestGetList_Success() {
+	stations := []domain.StationDTO{
+		{ID: 1, Name: "Hauptbahnhof", Lat: "50.107145", Long: "8.663789", Capacity: 20, AvailableBikes: 7, FreeDocks: 11},
+	}
+	s.mockUseCase.On("GetList", context.Background()).Return(stations, nil)
+	req := httptest.NewRequest(http.MethodGet, "/stations", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(`[{"id":1,"name":"Hauptbahnhof","lat":"50.107145","long":"8.663789","capacity":20,"availableBikes":7,"freeDocks":11}]`+"\n", rec.Body.String())
+}
+
+func (s *StationHandlerTestSuite) TestGetList_Failed() {
+	s.mockUseCase.On("GetList", context.Background()).Return([]domain.StationDTO{}, apperrors.ErrInternalServerError)
+	req := httptest.NewRequest(http.MethodGet, "/stations", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Equal(`"e5000 internal server error"`+"\n", rec.Body.String())
+}
@@ -0,0 +1,59 @@
+package station
+
+import (
+	"context"
+
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repositoryImpl {
+	return &repositoryImpl{
+		db: db,
+	}
+}
+
+// GetList counts the docked bikes of every station in the same query, so the numbers are consistent with each other.
+func (r *repositoryImpl) GetList(ctx context.Context) (*[]domain.StationOccupancy, error) {
+	ctx, span := tracing.Start(ctx, "StationRepository.GetList")
+	defer span.End()
+	stations := []domain.StationOccupancy{}
+	err := r.db.WithContext(ctx).Model(&domain.Station{}).
+		Select("`station`.*, COUNT(`bike`.`id`) AS docked_bikes, COALESCE(SUM(`bike`.`status` = ?), 0) AS available_bikes", domain.BikeStatusAvailable).
+		Joins("LEFT JOIN `bike` ON `bike`.`station_id` = `station`.`id` AND `bike`.`deleted_at` IS NULL").
+		Group("`station`.`id`").
+		Order("`station`.`id`").
+		Scan(&stations).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stations, nil
+}
+
+func (r *repositoryImpl) GetByID(ctx context.Context, id int64) (*domain.Station, error) {
+	ctx, span := tracing.Start(ctx, "StationRepository.GetByID")
+	defer span.End()
+	station := domain.Station{}
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&station).Error
+	if err != nil {
+		return nil, err
+	}
+	return &station, nil
+}
+
+func (r *repositoryImpl) CountBikesByStationID(ctx context.Context, id int64) (int64, error) {
+	ctx, span := tracing.Start(ctx, "StationRepository.CountBikesByStationID")
+	defer span.End()
+	var total int64
+	err := r.db.WithContext(ctx).Model(domain.Bike{}).Where("station_id = ?", id).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,118 @@
+package station
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"shared-bike/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type StationRepositoryTestSuite struct {
+	suite.Suite
+	mockDB         sqlmock.Sqlmock
+	repositoryImpl *repositoryImpl
+}
+
+func (s *StationRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.Error(err, "Failed to open mock sql db, got error")
+	}
+
+	if db == nil {
+		s.Error(nil, "mock db is null")
+	}
+
+	if mock == nil {
+		s.Error(nil, "sqlmock is null")
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		s.Error(err, "gormDB init err")
+	}
+	s.mockDB = mock
+	repositoryImpl := NewRepository(gormDB)
+	s.repositoryImpl = repositoryImpl
+}
+
+func TestStationRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(StationRepositoryTestSuite))
+}
+
+func (s *StationRepositoryTestSuite) TestGetList_Success() {
+	lat := decimal.RequireFromString("50.107145")
+	long := decimal.RequireFromString("8.663789")
+	query := regexp.QuoteMeta("SELECT `station`.*, COUNT(`bike`.`id`) AS docked_bikes, COALESCE(SUM(`bike`.`status` = ?), 0) AS available_bikes FROM `station` LEFT JOIN `bike` ON `bike`.`station_id` = `station`.`id` AND `bike`.`deleted_at` IS NULL WHERE `station`.`deleted_at` IS NULL GROUP BY `station`.`id` ORDER BY `station`.`id`")
+	rows := sqlmock.NewRows([]string{"id", "name", "lat", "long", "capacity", "docked_bikes", "available_bikes"}).
+		AddRow(1, "Hauptbahnhof", "50.107145", "8.663789", 20, 9, 7)
+	s.mockDB.ExpectQuery(query).WithArgs(domain.BikeStatusAvailable).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetList(context.TODO())
+	expected := &[]domain.StationOccupancy{
+		{
+			Station: domain.Station{
+				ID:       1,
+				Name:     "Hauptbahnhof",
+				Lat:      &lat,
+				Long:     &long,
+				Capacity: 20,
+			},
+			DockedBikes:    9,
+			AvailableBikes: 7,
+		},
+	}
+	s.Equal(expected, actual)
+	s.Nil(err)
+}
+
+func (s *StationRepositoryTestSuite) TestGetList_Failed() {
+	query := regexp.QuoteMeta("SELECT `station`.*")
+	s.mockDB.ExpectQuery(query).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetList(context.TODO())
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *StationRepositoryTestSuite) TestGetByID_Success() {
+	query := regexp.QuoteMeta("SELECT * FROM `station` WHERE id = ? AND `station`.`deleted_at` IS NULL ORDER BY `station`.`id` LIMIT 1")
+	rows := sqlmock.NewRows([]string{"id", "name", "capacity"}).AddRow(1, "Hauptbahnhof", 20)
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetByID(context.TODO(), int64(1))
+	s.Equal(&domain.Station{ID: 1, Name: "Hauptbahnhof", Capacity: 20}, actual)
+	s.Nil(err)
+}
+
+func (s *StationRepositoryTestSuite) TestGetByID_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `station` WHERE id = ?")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(gorm.ErrRecordNotFound)
+	actual, err := s.repositoryImpl.GetByID(context.TODO(), int64(1))
+	s.Nil(actual)
+	s.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (s *StationRepositoryTestSuite) TestCountBikesByStationID_Success() {
+	query := regexp.QuoteMeta("SELECT count(*) FROM `bike` WHERE station_id = ? AND `bike`.`deleted_at` IS NULL")
+	rows := sqlmock.NewRows([]string{"count"}).AddRow(int64(4))
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.CountBikesByStationID(context.TODO(), int64(1))
+	s.Equal(int64(4), actual)
+	s.Nil(err)
+}
+
+func (s *StationRepositoryTestSuite) TestCountBikesByStationID_Failed() {
+	query := regexp.QuoteMeta("SELECT count(*) FROM `bike` WHERE station_id = ?")
+	s.mockDB.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.CountBikesByStationID(context.TODO(), int64(1))
+	s.Equal(int64(0), actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
@@ -0,0 +1,38 @@
+package station
+
+import (
+	"context"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+)
+
+type useCaseImpl struct {
+	logger     ILogger
+	repository IRepository
+}
+
+func NewUseCase(logger ILogger, repository IRepository) *useCaseImpl {
+	return &useCaseImpl{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+func (u *useCaseImpl) GetList(ctx context.Context) ([]domain.StationDTO, error) {
+	ctx, span := tracing.Start(ctx, "StationUseCase.GetList")
+	defer span.End()
+	u.logger.Info("[StationUseCase.GetList] fetching all stations")
+	stations, err := u.repository.GetList(ctx)
+	if err != nil {
+		u.logger.Error("[StationUseCase.GetList] fetch all stations failed", err)
+		return []domain.StationDTO{}, apperrors.ErrInternalServerError
+	}
+	result := []domain.StationDTO{}
+	for _, station := range *stations {
+		result = append(result, station.ToDTO())
+	}
+	u.logger.Info("[StationUseCase.GetList] fetch all stations success")
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package station
+
+import (
+	"context"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/station/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type StationUseCaseTestSuite struct {
+	suite.Suite
+	mockRepository *mocks.IRepository
+	mockLogger     *mocks.ILogger
+	useCaseImpl    *useCaseImpl
+}
+
+func (s *StationUseCaseTestSuite) SetupTest() {
+	s.mockRepository = &mocks.IRepository{}
+	s.mockLogger = &mocks.ILogger{}
+	s.mockLogger.On("Info", mock.Anything).Return()
+	s.mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+	s.useCaseImpl = NewUseCase(s.mockLogger, s.mockRepository)
+}
+
+func TestStationUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(StationUseCaseTestSuite))
+}
+
+func (s *StationUseCaseTestSuite) TestGetList_Success() {
+	mockContext := context.TODO()
+	stations := &[]domain.StationOccupancy{
+		{Station: domain.Station{ID: 1, Name: "Hauptbahnhof", Capacity: 20}, DockedBikes: 9, AvailableBikes: 7},
+		{Station: domain.Station{ID: 2, Name: "Römer", Capacity: 10}, DockedBikes: 10, AvailableBikes: 10},
+	}
+	s.mockRepository.On("GetList", mockContext).Return(stations, nil)
+	actual, err := s.useCaseImpl.GetList(mockContext)
+	s.Equal([]domain.StationDTO{
+		{ID: 1, Name: "Hauptbahnhof", Capacity: 20, AvailableBikes: 7, FreeDocks: 11},
+		{ID: 2, Name: "Römer", Capacity: 10, AvailableBikes: 10, FreeDocks: 0},
+	}, actual)
+	s.Nil(err)
+}
+
+func (s *StationUseCaseTestSuite) TestGetList_Empty() {
+	mockContext := context.TODO()
+	s.mockRepository.On("GetList", mockContext).Return(&[]domain.StationOccupancy{}, nil)
+	actual, err := s.useCaseImpl.GetList(mockContext)
+	s.Equal([]domain.StationDTO{}, actual)
+	s.Nil(err)
+}
+
+func (s *StationUseCaseTestSuite) TestGetList_InternalServerError() {
+	mockContext := context.TODO()
+	s.mockRepository.On("GetList", mockContext).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.GetList(mockContext)
+	s.Equal([]domain.StationDTO{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
@@ -0,0 +1,32 @@
+package telemetry
+
+import (
+	"context"
+
+	"shared-bike/domain"
+)
+
+type IRepository interface {
+	GetDeviceByID(ctx context.Context, id string) (*domain.Device, error)
+	Create(ctx context.Context, reading *domain.TelemetryReading) (bool, error)
+}
+
+type IBikeRepository interface {
+	UpdateTelemetry(ctx context.Context, reading *domain.TelemetryReading) (bool, error)
+}
+
+type ILogger interface {
+	Info(i ...interface{})
+	Warn(i ...interface{})
+	Error(i ...interface{})
+}
+
+type IUseCase interface {
+	Authenticate(ctx context.Context, signature domain.DeviceSignature, body []byte) (*domain.Device, error)
+	Ingest(ctx context.Context, device *domain.Device, body domain.TelemetryBody) (domain.TelemetryResultDTO, error)
+}
+
+//go:generate mockery --name IRepository --output mocks --case underscore
+//go:generate mockery --name IBikeRepository --output mocks --case underscore
+//go:generate mockery --name ILogger --output mocks --case underscore
+//go:generate mockery --name IUseCase --output mocks --case underscore
@@ -0,0 +1,51 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IBikeRepository is an autogenerated mock type for the IBikeRepository type
+type IBikeRepository struct {
+	mock.Mock
+}
+
+// UpdateTelemetry provides a mock function with given fields: ctx, reading
+func (_m *IBikeRepository) UpdateTelemetry(ctx context.Context, reading *domain.TelemetryReading) (bool, error) {
+	ret := _m.Called(ctx, reading)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TelemetryReading) bool); ok {
+		r0 = rf(ctx, reading)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TelemetryReading) error); ok {
+		r1 = rf(ctx, reading)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIBikeRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIBikeRepository creates a new instance of IBikeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIBikeRepository(t mockConstructorTestingTNewIBikeRepository) *IBikeRepository {
+	mock := &IBikeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,74 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRepository is an autogenerated mock type for the IRepository type
+type IRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, reading
+func (_m *IRepository) Create(ctx context.Context, reading *domain.TelemetryReading) (bool, error) {
+	ret := _m.Called(ctx, reading)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TelemetryReading) bool); ok {
+		r0 = rf(ctx, reading)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TelemetryReading) error); ok {
+		r1 = rf(ctx, reading)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeviceByID provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetDeviceByID(ctx context.Context, id string) (*domain.Device, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Device
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Device); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Device)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIRepository creates a new instance of IRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIRepository(t mockConstructorTestingTNewIRepository) *IRepository {
+	mock := &IRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,74 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IUseCase is an autogenerated mock type for the IUseCase type
+type IUseCase struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, signature, body
+func (_m *IUseCase) Authenticate(ctx context.Context, signature domain.DeviceSignature, body []byte) (*domain.Device, error) {
+	ret := _m.Called(ctx, signature, body)
+
+	var r0 *domain.Device
+	if rf, ok := ret.Get(0).(func(context.Context, domain.DeviceSignature, []byte) *domain.Device); ok {
+		r0 = rf(ctx, signature, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Device)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.DeviceSignature, []byte) error); ok {
+		r1 = rf(ctx, signature, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ingest provides a mock function with given fields: ctx, device, body
+func (_m *IUseCase) Ingest(ctx context.Context, device *domain.Device, body domain.TelemetryBody) (domain.TelemetryResultDTO, error) {
+	ret := _m.Called(ctx, device, body)
+
+	var r0 domain.TelemetryResultDTO
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Device, domain.TelemetryBody) domain.TelemetryResultDTO); ok {
+		r0 = rf(ctx, device, body)
+	} else {
+		r0 = ret.Get(0).(domain.TelemetryResultDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Device, domain.TelemetryBody) error); ok {
+		r1 = rf(ctx, device, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIUseCase creates a new instance of IUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIUseCase(t mockConstructorTestingTNewIUseCase) *IUseCase {
+	mock := &IUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
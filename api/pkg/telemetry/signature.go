@@ -0,0 +1,23 @@
+package telemetry
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	HeaderDeviceID  = "X-Device-ID"
+	HeaderTimestamp = "X-Device-Timestamp"
+	HeaderSignature = "X-Device-Signature"
+)
+
+// Sign returns the hex encoded HMAC-SHA256 of "<timestamp>.<body>" keyed with the device secret.
+// Devices and the simulator sign with it, the API recomputes it to authenticate a message.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
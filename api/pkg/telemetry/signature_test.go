@@ -0,0 +1,26 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SignatureTestSuite struct {
+	suite.Suite
+}
+
+func TestSignatureTestSuite(t *testing.T) {
+	suite.Run(t, new(SignatureTestSuite))
+}
+
+func (s *SignatureTestSuite) TestSign() {
+	// echo -n '1792418400.{}' | openssl dgst -sha256 -hmac secret
+	s.Equal("ba41116303939d3d5be0a003f5971ee2d76f5073e367677350bdba1bb4dcff2d", Sign("secret", "1792418400", []byte("{}")))
+}
+
+func (s *SignatureTestSuite) TestSign_CoversTimestampAndSecret() {
+	signature := Sign("secret", "1792418400", []byte("{}"))
+	s.NotEqual(signature, Sign("secret", "1792418401", []byte("{}")))
+	s.NotEqual(signature, Sign("other", "1792418400", []byte("{}")))
+}
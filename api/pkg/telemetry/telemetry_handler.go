@@ -0,0 +1,73 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxTelemetryBodySize is far above a single reading, it only stops a misbehaving device from streaming.
+const maxTelemetryBodySize = 4 << 10
+
+type handlerImpl struct {
+	useCase IUseCase
+}
+
+func NewHandler(useCase IUseCase) *handlerImpl {
+	return &handlerImpl{
+		useCase: useCase,
+	}
+}
+
+// Ingest godoc
+// @Summary      Ingest lock telemetry
+// @Description  Device-facing API for a smart lock to report its position, battery and lock state. The body is signed with the device secret: X-Device-Signature is the hex HMAC-SHA256 of "<X-Device-Timestamp>.<raw body>", the timestamp is unix seconds and must be within 5 minutes of the server clock. Readings are deduplicated by device and recordedAt, a reading older than the newest one is stored but does not move the bike
+// @Tags         devices
+// @Accept       json
+// @Produce      json
+// @Param        X-Device-ID         header    string               true  "Device ID"
+// @Param        X-Device-Timestamp  header    string               true  "Unix timestamp in seconds"
+// @Param        X-Device-Signature  header    string               true  "Hex HMAC-SHA256 signature"
+// @Param    		 request  body      domain.TelemetryBody  true  "Telemetry reading"
+// @Success      202  {object}  domain.TelemetryResultDTO "Accepted"
+// @Failure      400  {string}  string 	"invalid body | invalid telemetry reading"
+// @Failure      401  {string}  string 	"invalid device signature"
+// @Failure      500  {string}  string 	"internal server error"
+// @Router       /devices/telemetry [post]
+func (h *handlerImpl) Ingest(c echo.Context) error {
+	span := tracing.StartHandler(c, "TelemetryHandler.Ingest")
+	defer span.End()
+	ctx := c.Request().Context()
+	raw, err := io.ReadAll(io.LimitReader(c.Request().Body, maxTelemetryBodySize+1))
+	if err != nil || len(raw) > maxTelemetryBodySize {
+		c.Logger().Error("[TelemetryHandler.Ingest] cannot read body", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBody), apperrors.ErrInvalidBody.Error())
+	}
+	signature := domain.DeviceSignature{
+		DeviceID:  c.Request().Header.Get(HeaderDeviceID),
+		Timestamp: c.Request().Header.Get(HeaderTimestamp),
+		Signature: c.Request().Header.Get(HeaderSignature),
+	}
+	device, err := h.useCase.Authenticate(ctx, signature, raw)
+	if err != nil {
+		c.Logger().Error("[TelemetryHandler.Ingest] cannot authenticate device", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	body := domain.TelemetryBody{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		c.Logger().Error("[TelemetryHandler.Ingest] invalid body", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBody), apperrors.ErrInvalidBody.Error())
+	}
+	result, err := h.useCase.Ingest(ctx, device, body)
+	if err != nil {
+		c.Logger().Error("[TelemetryHandler.Ingest] cannot ingest reading", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	return c.JSON(http.StatusAccepted, result)
+}
@@ -0,0 +1,99 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/telemetry/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TelemetryHandlerTestSuite struct {
+	suite.Suite
+	mockUseCase *mocks.IUseCase
+	echo        *echo.Echo
+	handlerImpl *handlerImpl
+	mockDevice  *domain.Device
+	mockBody    string
+}
+
+func (s *TelemetryHandlerTestSuite) SetupTest() {
+	mockUseCase := &mocks.IUseCase{}
+	s.mockUseCase = mockUseCase
+	s.echo = echo.New()
+	s.handlerImpl = NewHandler(mockUseCase)
+	s.mockDevice = &domain.Device{ID: "lock-0001", BikeID: 1}
+	s.mockBody = `{"recordedAt":"2026-10-19T14:00:00Z","lockState":"locked"}`
+}
+
+func TestTelemetryHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(TelemetryHandlerTestSuite))
+}
+
+func (s *TelemetryHandlerTestSuite) newIngestContext(body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPost, "/devices/telemetry", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(HeaderDeviceID, "lock-0001")
+	req.Header.Set(HeaderTimestamp, "1792418400")
+	req.Header.Set(HeaderSignature, "abc")
+	rec := httptest.NewRecorder()
+	return s.echo.NewContext(req, rec), rec
+}
+
+func (s *TelemetryHandlerTestSuite) mockSignature() domain.DeviceSignature {
+	return domain.DeviceSignature{DeviceID: "lock-0001", Timestamp: "1792418400", Signature: "abc"}
+}
+
+func (s *TelemetryHandlerTestSuite) TestIngest_Success() {
+	s.mockUseCase.On("Authenticate", context.Background(), s.mockSignature(), []byte(s.mockBody)).Return(s.mockDevice, nil)
+	s.mockUseCase.On("Ingest", context.Background(), s.mockDevice, domain.TelemetryBody{
+		RecordedAt: time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC),
+		LockState:  domain.LockStateLocked,
+	}).Return(domain.TelemetryResultDTO{Status: domain.TelemetryStatusApplied}, nil)
+	c, rec := s.newIngestContext(s.mockBody)
+	s.NoError(s.handlerImpl.Ingest(c))
+	s.Equal(http.StatusAccepted, rec.Code)
+	s.Equal("{\"status\":\"applied\"}\n", rec.Body.String())
+}
+
+func (s *TelemetryHandlerTestSuite) TestIngest_FailedAuthenticate() {
+	s.mockUseCase.On("Authenticate", context.Background(), s.mockSignature(), []byte(s.mockBody)).Return(nil, apperrors.ErrInvalidDeviceSignature)
+	c, rec := s.newIngestContext(s.mockBody)
+	s.NoError(s.handlerImpl.Ingest(c))
+	s.Equal(http.StatusUnauthorized, rec.Code)
+	s.Equal("\"e4011 invalid device signature\"\n", rec.Body.String())
+	s.mockUseCase.AssertNotCalled(s.T(), "Ingest", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TelemetryHandlerTestSuite) TestIngest_FailedBody() {
+	s.mockUseCase.On("Authenticate", context.Background(), s.mockSignature(), []byte("not json")).Return(s.mockDevice, nil)
+	c, rec := s.newIngestContext("not json")
+	s.NoError(s.handlerImpl.Ingest(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e4005 invalid body\"\n", rec.Body.String())
+}
+
+func (s *TelemetryHandlerTestSuite) TestIngest_BodyTooLarge() {
+	c, rec := s.newIngestContext(strings.Repeat(" ", maxTelemetryBodySize+1))
+	s.NoError(s.handlerImpl.Ingest(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.mockUseCase.AssertNotCalled(s.T(), "Authenticate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TelemetryHandlerTestSuite) TestIngest_FailedIngest() {
+	s.mockUseCase.On("Authenticate", context.Background(), s.mockSignature(), []byte(s.mockBody)).Return(s.mockDevice, nil)
+	s.mockUseCase.On("Ingest", context.Background(), s.mockDevice, mock.Anything).Return(domain.TelemetryResultDTO{}, apperrors.ErrInvalidTelemetry)
+	c, rec := s.newIngestContext(s.mockBody)
+	s.NoError(s.handlerImpl.Ingest(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("\"e40020 invalid telemetry reading\"\n", rec.Body.String())
+}
@@ -0,0 +1,43 @@
+package telemetry
+
+import (
+	"context"
+
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repositoryImpl {
+	return &repositoryImpl{
+		db: db,
+	}
+}
+
+func (r *repositoryImpl) GetDeviceByID(ctx context.Context, id string) (*domain.Device, error) {
+	ctx, span := tracing.Start(ctx, "TelemetryRepository.GetDeviceByID")
+	defer span.End()
+	device := domain.Device{}
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&device).Error
+	if err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+// Create stores the reading and reports false when the device already delivered a reading with the same timestamp.
+func (r *repositoryImpl) Create(ctx context.Context, reading *domain.TelemetryReading) (bool, error) {
+	ctx, span := tracing.Start(ctx, "TelemetryRepository.Create")
+	defer span.End()
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(reading)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
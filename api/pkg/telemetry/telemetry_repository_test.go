@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type TelemetryRepositoryTestSuite struct {
+	suite.Suite
+	mockDB         sqlmock.Sqlmock
+	repositoryImpl *repositoryImpl
+}
+
+func (s *TelemetryRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.Error(err, "Failed to open mock sql db, got error")
+	}
+
+	if db == nil {
+		s.Error(nil, "mock db is null")
+	}
+
+	if mock == nil {
+		s.Error(nil, "sqlmock is null")
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		s.Error(err, "gormDB init err")
+	}
+	s.mockDB = mock
+	repositoryImpl := NewRepository(gormDB)
+	s.repositoryImpl = repositoryImpl
+}
+
+func TestTelemetryRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(TelemetryRepositoryTestSuite))
+}
+
+func (s *TelemetryRepositoryTestSuite) TestGetDeviceByID_Success() {
+	query := regexp.QuoteMeta("SELECT * FROM `device` WHERE id = ? AND `device`.`deleted_at` IS NULL ORDER BY `device`.`id` LIMIT 1")
+	rows := sqlmock.NewRows([]string{"id", "bike_id", "secret"}).AddRow("lock-0001", 1, "secret")
+	s.mockDB.ExpectQuery(query).WithArgs("lock-0001").WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetDeviceByID(context.TODO(), "lock-0001")
+	s.Equal(&domain.Device{ID: "lock-0001", BikeID: 1, Secret: "secret"}, actual)
+	s.Nil(err)
+}
+
+func (s *TelemetryRepositoryTestSuite) TestGetDeviceByID_NotFound() {
+	query := regexp.QuoteMeta("SELECT * FROM `device` WHERE id = ? AND `device`.`deleted_at` IS NULL ORDER BY `device`.`id` LIMIT 1")
+	s.mockDB.ExpectQuery(query).WithArgs("lock-0001").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	actual, err := s.repositoryImpl.GetDeviceByID(context.TODO(), "lock-0001")
+	s.Nil(actual)
+	s.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (s *TelemetryRepositoryTestSuite) mockReading() *domain.TelemetryReading {
+	return &domain.TelemetryReading{
+		DeviceID:   "lock-0001",
+		BikeID:     1,
+		RecordedAt: time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC),
+		Battery:    sql.NullInt64{Int64: 87, Valid: true},
+		LockState:  domain.LockStateLocked,
+	}
+}
+
+func (s *TelemetryRepositoryTestSuite) TestCreate_Created() {
+	query := regexp.QuoteMeta("INSERT INTO `telemetry_reading` (`device_id`,`bike_id`,`recorded_at`,`lat`,`long`,`battery`,`lock_state`,`created_at`) VALUES (?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `id`=`id`")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mockDB.ExpectCommit()
+	actual, err := s.repositoryImpl.Create(context.TODO(), s.mockReading())
+	s.True(actual)
+	s.Nil(err)
+}
+
+func (s *TelemetryRepositoryTestSuite) TestCreate_Duplicate() {
+	query := regexp.QuoteMeta("INSERT INTO `telemetry_reading`")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	actual, err := s.repositoryImpl.Create(context.TODO(), s.mockReading())
+	s.False(actual)
+	s.Nil(err)
+}
+
+func (s *TelemetryRepositoryTestSuite) TestCreate_Failed() {
+	query := regexp.QuoteMeta("INSERT INTO `telemetry_reading`")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnError(errors.New("connection refused"))
+	s.mockDB.ExpectRollback()
+	actual, err := s.repositoryImpl.Create(context.TODO(), s.mockReading())
+	s.False(actual)
+	s.Equal(errors.New("connection refused"), err)
+}
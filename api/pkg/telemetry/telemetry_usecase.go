@@ -0,0 +1,140 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/hmac"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// maxClockSkew bounds how far a device clock may drift from ours, it limits how long a captured message can be replayed.
+	maxClockSkew        = 5 * time.Minute
+	coordinatePrecision = 6
+	maxBattery          = 100
+)
+
+type useCaseImpl struct {
+	logger         ILogger
+	repository     IRepository
+	bikeRepository IBikeRepository
+	now            func() time.Time
+}
+
+func NewUseCase(logger ILogger, repository IRepository, bikeRepository IBikeRepository) *useCaseImpl {
+	return &useCaseImpl{
+		logger:         logger,
+		repository:     repository,
+		bikeRepository: bikeRepository,
+		now:            time.Now,
+	}
+}
+
+// Authenticate checks the signature of a raw telemetry body. An unknown device and a wrong signature
+// produce the same error so the endpoint does not reveal which device IDs exist.
+func (u *useCaseImpl) Authenticate(ctx context.Context, signature domain.DeviceSignature, body []byte) (*domain.Device, error) {
+	ctx, span := tracing.Start(ctx, "TelemetryUseCase.Authenticate")
+	defer span.End()
+	timestamp, err := strconv.ParseInt(signature.Timestamp, 10, 64)
+	if err != nil || signature.DeviceID == "" || signature.Signature == "" {
+		u.logger.Info("[TelemetryUseCase.Authenticate] missing signature headers")
+		return nil, apperrors.ErrInvalidDeviceSignature
+	}
+	skew := u.now().Sub(time.Unix(timestamp, 0))
+	if skew > maxClockSkew || skew < -maxClockSkew {
+		u.logger.Info("[TelemetryUseCase.Authenticate] timestamp outside the allowed clock skew", signature.DeviceID)
+		return nil, apperrors.ErrInvalidDeviceSignature
+	}
+	device, err := u.repository.GetDeviceByID(ctx, signature.DeviceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		u.logger.Info("[TelemetryUseCase.Authenticate] unknown device", signature.DeviceID)
+		return nil, apperrors.ErrInvalidDeviceSignature
+	}
+	if err != nil {
+		u.logger.Error("[TelemetryUseCase.Authenticate] get device failed", err)
+		return nil, apperrors.ErrInternalServerError
+	}
+	expected := Sign(device.Secret, signature.Timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(signature.Signature)) {
+		u.logger.Info("[TelemetryUseCase.Authenticate] signature mismatch", signature.DeviceID)
+		return nil, apperrors.ErrInvalidDeviceSignature
+	}
+	return device, nil
+}
+
+// Ingest stores a reading and moves the bike when the reading is the newest one seen for it.
+// The bike is updated before the reading is stored so a device retrying after a failed insert still ends up with the bike moved.
+func (u *useCaseImpl) Ingest(ctx context.Context, device *domain.Device, body domain.TelemetryBody) (domain.TelemetryResultDTO, error) {
+	ctx, span := tracing.Start(ctx, "TelemetryUseCase.Ingest")
+	defer span.End()
+	reading, err := u.toReading(device, body)
+	if err != nil {
+		u.logger.Info("[TelemetryUseCase.Ingest] invalid reading", device.ID, err)
+		return domain.TelemetryResultDTO{}, apperrors.ErrInvalidTelemetry
+	}
+	applied, err := u.bikeRepository.UpdateTelemetry(ctx, &reading)
+	if err != nil {
+		u.logger.Error("[TelemetryUseCase.Ingest] update bike failed", err)
+		return domain.TelemetryResultDTO{}, apperrors.ErrInternalServerError
+	}
+	created, err := u.repository.Create(ctx, &reading)
+	if err != nil {
+		u.logger.Error("[TelemetryUseCase.Ingest] create reading failed", err)
+		return domain.TelemetryResultDTO{}, apperrors.ErrInternalServerError
+	}
+	if !created {
+		u.logger.Info("[TelemetryUseCase.Ingest] duplicate reading", device.ID)
+		return domain.TelemetryResultDTO{Status: domain.TelemetryStatusDuplicate}, nil
+	}
+	if !applied {
+		u.logger.Info("[TelemetryUseCase.Ingest] stale reading", device.ID)
+		return domain.TelemetryResultDTO{Status: domain.TelemetryStatusStale}, nil
+	}
+	u.logger.Info("[TelemetryUseCase.Ingest] reading applied", device.ID)
+	return domain.TelemetryResultDTO{Status: domain.TelemetryStatusApplied}, nil
+}
+
+func (u *useCaseImpl) toReading(device *domain.Device, body domain.TelemetryBody) (domain.TelemetryReading, error) {
+	if body.RecordedAt.IsZero() {
+		return domain.TelemetryReading{}, errors.New("recordedAt is required")
+	}
+	if body.RecordedAt.Sub(u.now()) > maxClockSkew {
+		return domain.TelemetryReading{}, errors.New("recordedAt is in the future")
+	}
+	reading := domain.TelemetryReading{
+		DeviceID:   device.ID,
+		BikeID:     device.BikeID,
+		RecordedAt: body.RecordedAt.UTC(),
+		LockState:  body.LockState,
+	}
+	if (body.Lat == nil) != (body.Long == nil) {
+		return domain.TelemetryReading{}, errors.New("lat and long must be sent together")
+	}
+	if body.Lat != nil {
+		lat := body.Lat.Round(coordinatePrecision)
+		long := body.Long.Round(coordinatePrecision)
+		if err := domain.ValidateCoordinates(&lat, &long); err != nil {
+			return domain.TelemetryReading{}, err
+		}
+		reading.Lat = &lat
+		reading.Long = &long
+	}
+	if body.Battery != nil {
+		if *body.Battery < 0 || *body.Battery > maxBattery {
+			return domain.TelemetryReading{}, errors.New("battery must be between 0 and 100")
+		}
+		reading.Battery = sql.NullInt64{Int64: *body.Battery, Valid: true}
+	}
+	if body.LockState != "" && !body.LockState.IsValid() {
+		return domain.TelemetryReading{}, errors.New("unknown lock state")
+	}
+	return reading, nil
+}
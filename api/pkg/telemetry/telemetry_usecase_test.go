@@ -0,0 +1,206 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/telemetry/mocks"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type TelemetryUseCaseTestSuite struct {
+	suite.Suite
+	mockRepository     *mocks.IRepository
+	mockBikeRepository *mocks.IBikeRepository
+	mockLogger         *mocks.ILogger
+	useCaseImpl        *useCaseImpl
+	mockTime           time.Time
+	mockDevice         *domain.Device
+}
+
+func (s *TelemetryUseCaseTestSuite) SetupTest() {
+	s.mockRepository = &mocks.IRepository{}
+	s.mockBikeRepository = &mocks.IBikeRepository{}
+	s.mockLogger = &mocks.ILogger{}
+	s.mockLogger.On("Info", mock.Anything).Return()
+	s.mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+	s.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return()
+	s.mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+	s.useCaseImpl = NewUseCase(s.mockLogger, s.mockRepository, s.mockBikeRepository)
+	s.mockTime = time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	s.useCaseImpl.now = func() time.Time { return s.mockTime }
+	s.mockDevice = &domain.Device{ID: "lock-0001", BikeID: 1, Secret: "secret"}
+}
+
+func TestTelemetryUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(TelemetryUseCaseTestSuite))
+}
+
+func (s *TelemetryUseCaseTestSuite) signature(timestamp time.Time, body []byte) domain.DeviceSignature {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return domain.DeviceSignature{
+		DeviceID:  s.mockDevice.ID,
+		Timestamp: ts,
+		Signature: Sign(s.mockDevice.Secret, ts, body),
+	}
+}
+
+func (s *TelemetryUseCaseTestSuite) validBody() domain.TelemetryBody {
+	lat := decimal.RequireFromString("50.1195041")
+	long := decimal.RequireFromString("8.638137")
+	battery := int64(87)
+	return domain.TelemetryBody{
+		RecordedAt: s.mockTime.Add(-time.Second),
+		Lat:        &lat,
+		Long:       &long,
+		Battery:    &battery,
+		LockState:  domain.LockStateLocked,
+	}
+}
+
+func (s *TelemetryUseCaseTestSuite) TestAuthenticate_Success() {
+	body := []byte(`{"lockState":"locked"}`)
+	s.mockRepository.On("GetDeviceByID", context.TODO(), "lock-0001").Return(s.mockDevice, nil)
+	actual, err := s.useCaseImpl.Authenticate(context.TODO(), s.signature(s.mockTime, body), body)
+	s.Equal(s.mockDevice, actual)
+	s.Nil(err)
+}
+
+func (s *TelemetryUseCaseTestSuite) TestAuthenticate_WrongSignature() {
+	body := []byte(`{"lockState":"locked"}`)
+	signature := s.signature(s.mockTime, []byte(`{"lockState":"unlocked"}`))
+	s.mockRepository.On("GetDeviceByID", context.TODO(), "lock-0001").Return(s.mockDevice, nil)
+	actual, err := s.useCaseImpl.Authenticate(context.TODO(), signature, body)
+	s.Nil(actual)
+	s.Equal(apperrors.ErrInvalidDeviceSignature, err)
+}
+
+func (s *TelemetryUseCaseTestSuite) TestAuthenticate_ExpiredTimestamp() {
+	body := []byte(`{"lockState":"locked"}`)
+	actual, err := s.useCaseImpl.Authenticate(context.TODO(), s.signature(s.mockTime.Add(-10*time.Minute), body), body)
+	s.Nil(actual)
+	s.Equal(apperrors.ErrInvalidDeviceSignature, err)
+	s.mockRepository.AssertNotCalled(s.T(), "GetDeviceByID", mock.Anything, mock.Anything)
+}
+
+func (s *TelemetryUseCaseTestSuite) TestAuthenticate_MissingHeaders() {
+	actual, err := s.useCaseImpl.Authenticate(context.TODO(), domain.DeviceSignature{}, []byte(`{}`))
+	s.Nil(actual)
+	s.Equal(apperrors.ErrInvalidDeviceSignature, err)
+}
+
+func (s *TelemetryUseCaseTestSuite) TestAuthenticate_UnknownDevice() {
+	body := []byte(`{}`)
+	s.mockRepository.On("GetDeviceByID", context.TODO(), "lock-0001").Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.useCaseImpl.Authenticate(context.TODO(), s.signature(s.mockTime, body), body)
+	s.Nil(actual)
+	s.Equal(apperrors.ErrInvalidDeviceSignature, err)
+}
+
+func (s *TelemetryUseCaseTestSuite) TestAuthenticate_GetDeviceFailed() {
+	body := []byte(`{}`)
+	s.mockRepository.On("GetDeviceByID", context.TODO(), "lock-0001").Return(nil, errors.New("connection refused"))
+	actual, err := s.useCaseImpl.Authenticate(context.TODO(), s.signature(s.mockTime, body), body)
+	s.Nil(actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *TelemetryUseCaseTestSuite) expectedReading() *domain.TelemetryReading {
+	lat := decimal.RequireFromString("50.119504")
+	long := decimal.RequireFromString("8.638137")
+	return &domain.TelemetryReading{
+		DeviceID:   "lock-0001",
+		BikeID:     1,
+		RecordedAt: s.mockTime.Add(-time.Second),
+		Lat:        &lat,
+		Long:       &long,
+		Battery:    sql.NullInt64{Int64: 87, Valid: true},
+		LockState:  domain.LockStateLocked,
+	}
+}
+
+func (s *TelemetryUseCaseTestSuite) TestIngest_Applied() {
+	reading := s.expectedReading()
+	s.mockBikeRepository.On("UpdateTelemetry", context.TODO(), reading).Return(true, nil)
+	s.mockRepository.On("Create", context.TODO(), reading).Return(true, nil)
+	actual, err := s.useCaseImpl.Ingest(context.TODO(), s.mockDevice, s.validBody())
+	s.Equal(domain.TelemetryResultDTO{Status: domain.TelemetryStatusApplied}, actual)
+	s.Nil(err)
+}
+
+func (s *TelemetryUseCaseTestSuite) TestIngest_Stale() {
+	reading := s.expectedReading()
+	s.mockBikeRepository.On("UpdateTelemetry", context.TODO(), reading).Return(false, nil)
+	s.mockRepository.On("Create", context.TODO(), reading).Return(true, nil)
+	actual, err := s.useCaseImpl.Ingest(context.TODO(), s.mockDevice, s.validBody())
+	s.Equal(domain.TelemetryResultDTO{Status: domain.TelemetryStatusStale}, actual)
+	s.Nil(err)
+}
+
+func (s *TelemetryUseCaseTestSuite) TestIngest_Duplicate() {
+	reading := s.expectedReading()
+	s.mockBikeRepository.On("UpdateTelemetry", context.TODO(), reading).Return(false, nil)
+	s.mockRepository.On("Create", context.TODO(), reading).Return(false, nil)
+	actual, err := s.useCaseImpl.Ingest(context.TODO(), s.mockDevice, s.validBody())
+	s.Equal(domain.TelemetryResultDTO{Status: domain.TelemetryStatusDuplicate}, actual)
+	s.Nil(err)
+}
+
+func (s *TelemetryUseCaseTestSuite) TestIngest_WithoutPosition() {
+	body := domain.TelemetryBody{RecordedAt: s.mockTime, LockState: domain.LockStateUnlocked}
+	reading := &domain.TelemetryReading{DeviceID: "lock-0001", BikeID: 1, RecordedAt: s.mockTime, LockState: domain.LockStateUnlocked}
+	s.mockBikeRepository.On("UpdateTelemetry", context.TODO(), reading).Return(true, nil)
+	s.mockRepository.On("Create", context.TODO(), reading).Return(true, nil)
+	actual, err := s.useCaseImpl.Ingest(context.TODO(), s.mockDevice, body)
+	s.Equal(domain.TelemetryResultDTO{Status: domain.TelemetryStatusApplied}, actual)
+	s.Nil(err)
+}
+
+func (s *TelemetryUseCaseTestSuite) TestIngest_InvalidReading() {
+	outOfRange := decimal.RequireFromString("91")
+	battery := int64(101)
+	cases := map[string]func(body *domain.TelemetryBody){
+		"missing recordedAt": func(body *domain.TelemetryBody) { body.RecordedAt = time.Time{} },
+		"future recordedAt":  func(body *domain.TelemetryBody) { body.RecordedAt = s.mockTime.Add(time.Hour) },
+		"lat without long":   func(body *domain.TelemetryBody) { body.Long = nil },
+		"lat out of range":   func(body *domain.TelemetryBody) { body.Lat = &outOfRange },
+		"battery too high":   func(body *domain.TelemetryBody) { body.Battery = &battery },
+		"unknown lock state": func(body *domain.TelemetryBody) { body.LockState = "jammed" },
+	}
+	for name, modify := range cases {
+		body := s.validBody()
+		modify(&body)
+		actual, err := s.useCaseImpl.Ingest(context.TODO(), s.mockDevice, body)
+		s.Equal(domain.TelemetryResultDTO{}, actual, name)
+		s.Equal(apperrors.ErrInvalidTelemetry, err, name)
+	}
+	s.mockBikeRepository.AssertNotCalled(s.T(), "UpdateTelemetry", mock.Anything, mock.Anything)
+}
+
+func (s *TelemetryUseCaseTestSuite) TestIngest_UpdateBikeFailed() {
+	reading := s.expectedReading()
+	s.mockBikeRepository.On("UpdateTelemetry", context.TODO(), reading).Return(false, errors.New("connection refused"))
+	actual, err := s.useCaseImpl.Ingest(context.TODO(), s.mockDevice, s.validBody())
+	s.Equal(domain.TelemetryResultDTO{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.mockRepository.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *TelemetryUseCaseTestSuite) TestIngest_CreateReadingFailed() {
+	reading := s.expectedReading()
+	s.mockBikeRepository.On("UpdateTelemetry", context.TODO(), reading).Return(true, nil)
+	s.mockRepository.On("Create", context.TODO(), reading).Return(false, errors.New("connection refused"))
+	actual, err := s.useCaseImpl.Ingest(context.TODO(), s.mockDevice, s.validBody())
+	s.Equal(domain.TelemetryResultDTO{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
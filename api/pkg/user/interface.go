@@ -11,6 +11,20 @@ type IRepository interface {
 	Create(ctx context.Context, body *domain.User) error
 }
 
+// IReader is the lookup side of the user repository that CachedRepository sits in front of.
+type IReader interface {
+	GetByID(ctx context.Context, id int64) (*domain.User, error)
+	GetListByIDs(ctx context.Context, IDs []int64) (*[]domain.User, error)
+}
+
+type IRoleRepository interface {
+	GetPermissionsByUserID(ctx context.Context, userID int64) ([]domain.Permission, error)
+}
+
+type IAuditor interface {
+	Record(ctx context.Context, body domain.AuditRecord) error
+}
+
 type ILogger interface {
 	Info(i ...interface{})
 	Warn(i ...interface{})
@@ -22,5 +36,8 @@ type IUseCase interface {
 }
 
 //go:generate mockery --name IRepository --output mocks --case underscore
+//go:generate mockery --name IReader --output mocks --case underscore
+//go:generate mockery --name IRoleRepository --output mocks --case underscore
+//go:generate mockery --name IAuditor --output mocks --case underscore
 //go:generate mockery --name ILogger --output mocks --case underscore
 //go:generate mockery --name IUseCase --output mocks --case underscore
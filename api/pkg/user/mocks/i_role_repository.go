@@ -0,0 +1,53 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRoleRepository is an autogenerated mock type for the IRoleRepository type
+type IRoleRepository struct {
+	mock.Mock
+}
+
+// GetPermissionsByUserID provides a mock function with given fields: ctx, userID
+func (_m *IRoleRepository) GetPermissionsByUserID(ctx context.Context, userID int64) ([]domain.Permission, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.Permission
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Permission); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Permission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIRoleRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIRoleRepository creates a new instance of IRoleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIRoleRepository(t mockConstructorTestingTNewIRoleRepository) *IRoleRepository {
+	mock := &IRoleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,243 @@
+package user
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+// loadTimeout bounds a query shared by concurrent misses, it runs detached from the caller that started it.
+const loadTimeout = 5 * time.Second
+
+// CacheStats is a snapshot of the counters of a CachedRepository.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+}
+
+type cacheEntry struct {
+	user      domain.User
+	expiresAt time.Time
+}
+
+// CachedRepository is a read-through cache in front of the user lookups. Entries live for ttl and the least
+// recently used ones are evicted once maxEntries is reached. Concurrent misses on the same users share a
+// single query. Users that do not exist are not cached, so a new user shows up on the next lookup.
+type CachedRepository struct {
+	next       IReader
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[int64]*list.Element
+	order   *list.List
+	// epoch goes up with every invalidation, a load that started before it must not store what it read.
+	epoch uint64
+	group singleflight.Group
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+func NewCachedRepository(next IReader, ttl time.Duration, maxEntries int) *CachedRepository {
+	return &CachedRepository{
+		next:       next,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    map[int64]*list.Element{},
+		order:      list.New(),
+	}
+}
+
+func (r *CachedRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserCache.GetByID")
+	defer span.End()
+	if user, ok := r.get(id); ok {
+		atomic.AddUint64(&r.hits, 1)
+		span.SetAttributes(attribute.Bool("cache.hit", true))
+		return &user, nil
+	}
+	atomic.AddUint64(&r.misses, 1)
+	epoch := r.currentEpoch()
+	result, err := r.do(ctx, "id:"+strconv.FormatInt(id, 10), func(ctx context.Context) (interface{}, error) {
+		// a flight that just finished may have filled the entry between the lookup above and this one.
+		if user, ok := r.get(id); ok {
+			return user, nil
+		}
+		user, err := r.next.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		r.set(epoch, *user)
+		return *user, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	user := result.(domain.User)
+	return &user, nil
+}
+
+// GetListByIDs returns the users found in the order of IDs, duplicates and unknown IDs are dropped. Only the
+// users missing from the cache are queried, and nothing is queried when IDs is empty.
+func (r *CachedRepository) GetListByIDs(ctx context.Context, IDs []int64) (*[]domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserCache.GetListByIDs")
+	defer span.End()
+	found := map[int64]domain.User{}
+	seen := map[int64]bool{}
+	missing := []int64{}
+	for _, id := range IDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if user, ok := r.get(id); ok {
+			atomic.AddUint64(&r.hits, 1)
+			found[id] = user
+			continue
+		}
+		atomic.AddUint64(&r.misses, 1)
+		missing = append(missing, id)
+	}
+	if len(missing) > 0 {
+		sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+		epoch := r.currentEpoch()
+		result, err := r.do(ctx, "ids:"+joinIDs(missing), func(ctx context.Context) (interface{}, error) {
+			users, err := r.next.GetListByIDs(ctx, missing)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return []domain.User{}, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			for _, user := range *users {
+				r.set(epoch, user)
+			}
+			return *users, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range result.([]domain.User) {
+			found[user.ID] = user
+		}
+	}
+	users := []domain.User{}
+	for _, id := range IDs {
+		if user, ok := found[id]; ok {
+			users = append(users, user)
+			delete(found, id)
+		}
+	}
+	return &users, nil
+}
+
+// do runs load once for the concurrent callers of key. The load gets a context detached from the caller that started
+// it, so that caller going away does not fail the others, and every caller stops waiting when its own ctx ends.
+func (r *CachedRepository) do(ctx context.Context, key string, load func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	flight := r.group.DoChan(key, func() (interface{}, error) {
+		loadCtx, cancel := domain.Detach(ctx, loadTimeout)
+		defer cancel()
+		return load(loadCtx)
+	})
+	select {
+	case result := <-flight:
+		return result.Val, result.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Invalidate drops a user from the cache, it has to be called whenever something the cache holds about the
+// user changes, like its name.
+func (r *CachedRepository) Invalidate(id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.epoch++
+	if element, ok := r.entries[id]; ok {
+		r.order.Remove(element)
+		delete(r.entries, id)
+	}
+}
+
+func (r *CachedRepository) Stats() CacheStats {
+	r.mu.Lock()
+	entries := len(r.entries)
+	r.mu.Unlock()
+	return CacheStats{
+		Hits:      atomic.LoadUint64(&r.hits),
+		Misses:    atomic.LoadUint64(&r.misses),
+		Evictions: atomic.LoadUint64(&r.evictions),
+		Entries:   entries,
+	}
+}
+
+func (r *CachedRepository) get(id int64) (domain.User, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	element, ok := r.entries[id]
+	if !ok {
+		return domain.User{}, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if !r.now().Before(entry.expiresAt) {
+		r.order.Remove(element)
+		delete(r.entries, id)
+		return domain.User{}, false
+	}
+	r.order.MoveToFront(element)
+	return entry.user, true
+}
+
+func (r *CachedRepository) set(epoch uint64, user domain.User) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if epoch != r.epoch {
+		return
+	}
+	entry := &cacheEntry{user: user, expiresAt: r.now().Add(r.ttl)}
+	if element, ok := r.entries[user.ID]; ok {
+		element.Value = entry
+		r.order.MoveToFront(element)
+		return
+	}
+	r.entries[user.ID] = r.order.PushFront(entry)
+	for r.maxEntries > 0 && len(r.entries) > r.maxEntries {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.entries, oldest.Value.(*cacheEntry).user.ID)
+		atomic.AddUint64(&r.evictions, 1)
+	}
+}
+
+func (r *CachedRepository) currentEpoch() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.epoch
+}
+
+func joinIDs(IDs []int64) string {
+	parts := make([]string, 0, len(IDs))
+	for _, id := range IDs {
+		parts = append(parts, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(parts, ",")
+}
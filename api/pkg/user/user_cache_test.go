@@ -0,0 +1,195 @@
+package user
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"shared-bike/domain"
+	"shared-bike/pkg/user/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type UserCacheTestSuite struct {
+	suite.Suite
+	mockReader *mocks.IReader
+	clock      time.Time
+	cacheImpl  *CachedRepository
+}
+
+func (s *UserCacheTestSuite) SetupTest() {
+	s.mockReader = &mocks.IReader{}
+	s.clock = time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	s.cacheImpl = NewCachedRepository(s.mockReader, time.Minute, 3)
+	s.cacheImpl.now = func() time.Time { return s.clock }
+}
+
+func TestUserCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(UserCacheTestSuite))
+}
+
+func (s *UserCacheTestSuite) TestGetListByIDs_QueriesOnlyMisses() {
+	s.mockReader.On("GetListByIDs", mock.Anything, []int64{1, 2}).Return(&[]domain.User{{ID: 1, Name: "Bob"}, {ID: 2, Name: "Alice"}}, nil).Once()
+	s.mockReader.On("GetListByIDs", mock.Anything, []int64{3}).Return(&[]domain.User{{ID: 3, Name: "Eve"}}, nil).Once()
+	actual, err := s.cacheImpl.GetListByIDs(context.TODO(), []int64{2, 1, 2})
+	s.Nil(err)
+	s.Equal(&[]domain.User{{ID: 2, Name: "Alice"}, {ID: 1, Name: "Bob"}}, actual)
+	actual, err = s.cacheImpl.GetListByIDs(context.TODO(), []int64{1, 3})
+	s.Nil(err)
+	s.Equal(&[]domain.User{{ID: 1, Name: "Bob"}, {ID: 3, Name: "Eve"}}, actual)
+	s.Equal(CacheStats{Hits: 1, Misses: 3, Entries: 3}, s.cacheImpl.Stats())
+	s.mockReader.AssertExpectations(s.T())
+}
+
+func (s *UserCacheTestSuite) TestGetListByIDs_EmptySkipsQuery() {
+	actual, err := s.cacheImpl.GetListByIDs(context.TODO(), nil)
+	s.Nil(err)
+	s.Equal(&[]domain.User{}, actual)
+	s.mockReader.AssertNotCalled(s.T(), "GetListByIDs", mock.Anything, mock.Anything)
+}
+
+func (s *UserCacheTestSuite) TestGetListByIDs_UnknownUserNotCached() {
+	s.mockReader.On("GetListByIDs", mock.Anything, []int64{9}).Return(&[]domain.User{}, nil).Twice()
+	for i := 0; i < 2; i++ {
+		actual, err := s.cacheImpl.GetListByIDs(context.TODO(), []int64{9})
+		s.Nil(err)
+		s.Equal(&[]domain.User{}, actual)
+	}
+	s.mockReader.AssertExpectations(s.T())
+}
+
+func (s *UserCacheTestSuite) TestGetListByIDs_RecordNotFound() {
+	s.mockReader.On("GetListByIDs", mock.Anything, []int64{9}).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.cacheImpl.GetListByIDs(context.TODO(), []int64{9})
+	s.Nil(err)
+	s.Equal(&[]domain.User{}, actual)
+}
+
+func (s *UserCacheTestSuite) TestGetListByIDs_Failed() {
+	s.mockReader.On("GetListByIDs", mock.Anything, []int64{1}).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.cacheImpl.GetListByIDs(context.TODO(), []int64{1})
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+	s.Equal(0, s.cacheImpl.Stats().Entries)
+}
+
+func (s *UserCacheTestSuite) TestGetByID_ExpiresAfterTTL() {
+	s.mockReader.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Name: "Bob"}, nil).Once()
+	s.mockReader.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Name: "Bobby"}, nil).Once()
+	actual, err := s.cacheImpl.GetByID(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal("Bob", actual.Name)
+	s.clock = s.clock.Add(59 * time.Second)
+	actual, _ = s.cacheImpl.GetByID(context.TODO(), 1)
+	s.Equal("Bob", actual.Name)
+	s.clock = s.clock.Add(time.Second)
+	actual, _ = s.cacheImpl.GetByID(context.TODO(), 1)
+	s.Equal("Bobby", actual.Name)
+	s.mockReader.AssertExpectations(s.T())
+}
+
+func (s *UserCacheTestSuite) TestGetByID_NotFound() {
+	s.mockReader.On("GetByID", mock.Anything, int64(1)).Return(nil, gorm.ErrRecordNotFound)
+	actual, err := s.cacheImpl.GetByID(context.TODO(), 1)
+	s.Nil(actual)
+	s.Equal(gorm.ErrRecordNotFound, err)
+	s.Equal(0, s.cacheImpl.Stats().Entries)
+}
+
+func (s *UserCacheTestSuite) TestGetByID_ReturnsCopy() {
+	s.mockReader.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Name: "Bob"}, nil).Once()
+	actual, _ := s.cacheImpl.GetByID(context.TODO(), 1)
+	actual.Name = "changed"
+	actual, _ = s.cacheImpl.GetByID(context.TODO(), 1)
+	s.Equal("Bob", actual.Name)
+}
+
+func (s *UserCacheTestSuite) TestSet_EvictsLeastRecentlyUsed() {
+	s.mockReader.On("GetListByIDs", mock.Anything, []int64{1, 2, 3}).Return(&[]domain.User{{ID: 1}, {ID: 2}, {ID: 3}}, nil).Once()
+	s.mockReader.On("GetListByIDs", mock.Anything, []int64{4}).Return(&[]domain.User{{ID: 4}}, nil).Once()
+	s.mockReader.On("GetListByIDs", mock.Anything, []int64{2}).Return(&[]domain.User{{ID: 2}}, nil).Once()
+	_, _ = s.cacheImpl.GetListByIDs(context.TODO(), []int64{1, 2, 3})
+	_, _ = s.cacheImpl.GetByID(context.TODO(), 1)
+	_, _ = s.cacheImpl.GetListByIDs(context.TODO(), []int64{4})
+	_, _ = s.cacheImpl.GetListByIDs(context.TODO(), []int64{1, 2})
+	stats := s.cacheImpl.Stats()
+	s.Equal(3, stats.Entries)
+	s.Equal(uint64(2), stats.Evictions)
+	s.mockReader.AssertExpectations(s.T())
+}
+
+func (s *UserCacheTestSuite) TestInvalidate() {
+	s.mockReader.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Name: "Bob"}, nil).Once()
+	s.mockReader.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Name: "Robert"}, nil).Once()
+	_, _ = s.cacheImpl.GetByID(context.TODO(), 1)
+	s.cacheImpl.Invalidate(1)
+	actual, err := s.cacheImpl.GetByID(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal("Robert", actual.Name)
+	s.mockReader.AssertExpectations(s.T())
+}
+
+func (s *UserCacheTestSuite) TestInvalidate_DuringLoadDoesNotStoreStaleUser() {
+	s.mockReader.On("GetByID", mock.Anything, int64(1)).Run(func(args mock.Arguments) {
+		s.cacheImpl.Invalidate(1)
+	}).Return(&domain.User{ID: 1, Name: "Bob"}, nil).Once()
+	actual, err := s.cacheImpl.GetByID(context.TODO(), 1)
+	s.Nil(err)
+	s.Equal("Bob", actual.Name)
+	s.Equal(0, s.cacheImpl.Stats().Entries)
+}
+
+func (s *UserCacheTestSuite) TestGetByID_ConcurrentMissesShareOneQuery() {
+	release := make(chan struct{})
+	s.mockReader.On("GetByID", mock.Anything, int64(1)).Run(func(args mock.Arguments) {
+		<-release
+	}).Return(&domain.User{ID: 1, Name: "Bob"}, nil).Once()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			actual, err := s.cacheImpl.GetByID(context.TODO(), 1)
+			s.Nil(err)
+			s.Equal("Bob", actual.Name)
+		}()
+	}
+	s.Eventually(func() bool { return s.cacheImpl.Stats().Misses == 5 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	s.mockReader.AssertExpectations(s.T())
+}
+
+func (s *UserCacheTestSuite) TestGetByID_FirstCallerGoneDoesNotFailOthers() {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s.mockReader.On("GetByID", mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil }), int64(1)).Run(func(args mock.Arguments) {
+		close(started)
+		<-release
+		s.Nil(args.Get(0).(context.Context).Err())
+	}).Return(&domain.User{ID: 1, Name: "Bob"}, nil).Once()
+	first, cancel := context.WithCancel(context.TODO())
+	firstDone := make(chan error)
+	go func() {
+		_, err := s.cacheImpl.GetByID(first, 1)
+		firstDone <- err
+	}()
+	<-started
+	secondDone := make(chan *domain.User)
+	go func() {
+		actual, err := s.cacheImpl.GetByID(context.TODO(), 1)
+		s.Nil(err)
+		secondDone <- actual
+	}()
+	s.Eventually(func() bool { return s.cacheImpl.Stats().Misses == 2 }, time.Second, time.Millisecond)
+	cancel()
+	s.Equal(context.Canceled, <-firstDone)
+	close(release)
+	s.Equal("Bob", (<-secondDone).Name)
+	s.Equal(1, s.cacheImpl.Stats().Entries)
+	s.mockReader.AssertExpectations(s.T())
+}
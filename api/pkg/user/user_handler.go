@@ -2,12 +2,12 @@ package user
 
 import (
 	"net/http"
-	"os"
 	"time"
 
 	"shared-bike/domain"
 
 	"shared-bike/apperrors"
+	"shared-bike/tracing"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
@@ -15,11 +15,14 @@ import (
 
 type handlerImpl struct {
 	usecase IUseCase
+	secret  string
 }
 
-func NewHandler(usecase IUseCase) *handlerImpl {
+// NewHandler signs the access tokens with secret, the one the JWT middleware verifies them with.
+func NewHandler(usecase IUseCase, secret string) *handlerImpl {
 	return &handlerImpl{
 		usecase: usecase,
+		secret:  secret,
 	}
 }
 
@@ -36,6 +39,8 @@ func NewHandler(usecase IUseCase) *handlerImpl {
 // @Failure      500  {string}  string 							"internal server error"
 // @Router       /users/login [post]
 func (h *handlerImpl) Login(c echo.Context) error {
+	span := tracing.StartHandler(c, "UserHandler.Login")
+	defer span.End()
 	ctx := c.Request().Context()
 	body := domain.LoginBody{}
 	if err := c.Bind(&body); err != nil {
@@ -61,16 +66,16 @@ func (h *handlerImpl) signToken(user domain.UserDTO, duration time.Duration) (st
 	expiresAt := time.Now().Add(time.Minute * time.Duration(duration)).Unix()
 	// Set custom claims
 	claims := &domain.Claims{
-		ID:       user.ID,
-		Username: user.Username,
-		Name:     user.Name,
+		ID:          user.ID,
+		Username:    user.Username,
+		Name:        user.Name,
+		Permissions: user.Permissions,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: expiresAt,
 		},
 	}
-	secret := os.Getenv("SECRET")
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	t, err := token.SignedString([]byte(secret))
+	t, err := token.SignedString([]byte(h.secret))
 	if err != nil {
 		return "", err
 	}
@@ -89,6 +94,8 @@ func (h *handlerImpl) signToken(user domain.UserDTO, duration time.Duration) (st
 // @Failure      500  {string}  string 							"internal server error"
 // @Router       /users/register [post]
 func (h *handlerImpl) Register(c echo.Context) error {
+	span := tracing.StartHandler(c, "UserHandler.Register")
+	defer span.End()
 	c.Logger().Info("[UserHandler.Register] register is starting")
 	ctx := c.Request().Context()
 	body := domain.RegisterBody{}
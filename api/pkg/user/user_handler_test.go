@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"shared-bike/apperrors"
@@ -10,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/suite"
 )
@@ -26,7 +28,7 @@ func (s *UserHandlerTestSuite) SetupTest() {
 	s.mockUseCase = mockUseCase
 	e := echo.New()
 	s.echo = e
-	handler := NewHandler(mockUseCase)
+	handler := NewHandler(mockUseCase, "secret")
 	s.handlerImpl = handler
 }
 func TestUserHandlerTestSuite(t *testing.T) {
@@ -35,7 +37,7 @@ func TestUserHandlerTestSuite(t *testing.T) {
 
 func (s *UserHandlerTestSuite) TestLogin_Success() {
 	var (
-		mockContext = context.TODO()
+		mockContext = context.Background()
 		mockBody    = domain.LoginBody{
 			Username: "testUsername",
 			Password: "testPassword",
@@ -56,11 +58,19 @@ func (s *UserHandlerTestSuite) TestLogin_Success() {
 	s.NoError(s.handlerImpl.Login(c))
 	s.Equal(http.StatusOK, rec.Code)
 	s.Contains(rec.Body.String(), "accessToken")
+	credentials := domain.Credentials{}
+	s.Nil(json.Unmarshal(rec.Body.Bytes(), &credentials))
+	claims := &domain.Claims{}
+	_, err := jwt.ParseWithClaims(credentials.AccessToken, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte("secret"), nil
+	})
+	s.Nil(err)
+	s.Equal(int64(1), claims.ID)
 }
 
 func (s *UserHandlerTestSuite) TestLogin_InvalidBody() {
 	var (
-		mockContext = context.TODO()
+		mockContext = context.Background()
 		mockBody    = domain.LoginBody{
 			Username: "testUsername",
 			Password: "testPassword",
@@ -87,7 +97,7 @@ func (s *UserHandlerTestSuite) TestLogin_InvalidBody() {
 
 func (s *UserHandlerTestSuite) TestLogin_InternalError() {
 	var (
-		mockContext = context.TODO()
+		mockContext = context.Background()
 		mockBody    = domain.LoginBody{
 			Username: "testUsername",
 			Password: "testPassword",
@@ -109,7 +119,7 @@ func (s *UserHandlerTestSuite) TestLogin_InternalError() {
 
 func (s *UserHandlerTestSuite) TestRegister_Success() {
 	var (
-		mockContext = context.TODO()
+		mockContext = context.Background()
 		mockBody    = domain.RegisterBody{
 			Username: "testUsername",
 			Password: "testPassword",
@@ -135,7 +145,7 @@ func (s *UserHandlerTestSuite) TestRegister_Success() {
 
 func (s *UserHandlerTestSuite) TestRegister_InvalidBody() {
 	var (
-		mockContext = context.TODO()
+		mockContext = context.Background()
 		mockBody    = domain.RegisterBody{
 			Username: "testUsername",
 			Password: "testPassword",
@@ -163,7 +173,7 @@ func (s *UserHandlerTestSuite) TestRegister_InvalidBody() {
 
 func (s *UserHandlerTestSuite) TestRegister_InternalError() {
 	var (
-		mockContext = context.TODO()
+		mockContext = context.Background()
 		mockBody    = domain.RegisterBody{
 			Username: "testUsername",
 			Password: "testPassword",
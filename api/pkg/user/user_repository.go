@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"shared-bike/domain"
+	"shared-bike/tracing"
 
 	"gorm.io/gorm"
 )
@@ -19,8 +20,10 @@ func NewRepository(db *gorm.DB) *repositoryImpl {
 }
 
 func (r *repositoryImpl) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetByID")
+	defer span.End()
 	user := domain.User{}
-	err := r.db.Where("id = ?", id).First(&user).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -28,8 +31,10 @@ func (r *repositoryImpl) GetByID(ctx context.Context, id int64) (*domain.User, e
 }
 
 func (r *repositoryImpl) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetByUsername")
+	defer span.End()
 	user := domain.User{}
-	err := r.db.Where("username = ?", username).First(&user).Error
+	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -37,8 +42,10 @@ func (r *repositoryImpl) GetByUsername(ctx context.Context, username string) (*d
 }
 
 func (r *repositoryImpl) GetListByIDs(ctx context.Context, IDs []int64) (*[]domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetListByIDs")
+	defer span.End()
 	user := []domain.User{}
-	err := r.db.Where("id IN (?)", IDs).Find(&user).Error
+	err := r.db.WithContext(ctx).Where("id IN (?)", IDs).Find(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +53,9 @@ func (r *repositoryImpl) GetListByIDs(ctx context.Context, IDs []int64) (*[]doma
 }
 
 func (r *repositoryImpl) Create(ctx context.Context, body *domain.User) error {
-	err := r.db.Create(body).Error
+	ctx, span := tracing.Start(ctx, "UserRepository.Create")
+	defer span.End()
+	err := r.db.WithContext(ctx).Create(body).Error
 	if err != nil {
 		return err
 	}
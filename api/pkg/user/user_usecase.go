@@ -7,24 +7,31 @@ import (
 
 	"shared-bike/apperrors"
 	"shared-bike/domain"
+	"shared-bike/tracing"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 type useCaseImpl struct {
-	repository IRepository
-	logger     ILogger
+	repository     IRepository
+	logger         ILogger
+	roleRepository IRoleRepository
+	auditor        IAuditor
 }
 
-func NewUseCase(logger ILogger, repository IRepository) *useCaseImpl {
+func NewUseCase(logger ILogger, repository IRepository, roleRepository IRoleRepository, auditor IAuditor) *useCaseImpl {
 	return &useCaseImpl{
-		logger:     logger,
-		repository: repository,
+		logger:         logger,
+		repository:     repository,
+		roleRepository: roleRepository,
+		auditor:        auditor,
 	}
 }
 
 func (u *useCaseImpl) Login(ctx context.Context, body domain.LoginBody) (domain.UserDTO, error) {
+	ctx, span := tracing.Start(ctx, "UserUseCase.Login")
+	defer span.End()
 	u.logger.Info("[UserUseCase.Login] starting")
 	user, err := u.repository.GetByUsername(ctx, body.Username)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -39,11 +46,22 @@ func (u *useCaseImpl) Login(ctx context.Context, body domain.LoginBody) (domain.
 		u.logger.Info(fmt.Sprintf("[UserUseCase.Login] user %d login with password does not match", user.ID))
 		return domain.UserDTO{}, apperrors.ErrUserLoginNotFound
 	}
+	permissions, err := u.roleRepository.GetPermissionsByUserID(ctx, user.ID)
+	if err != nil {
+		u.logger.Error(fmt.Sprintf("[UserUseCase.Login] fetch permissions of user %d failed", user.ID), err)
+		return domain.UserDTO{}, apperrors.ErrInternalServerError
+	}
 	u.logger.Info(fmt.Sprintf("[UserUseCase.Login] user %d login success", user.ID))
-	return user.ToDTO(), nil
+	result := user.ToDTO()
+	if len(permissions) > 0 {
+		result.Permissions = permissions
+	}
+	return result, nil
 }
 
 func (u *useCaseImpl) Register(ctx context.Context, body domain.RegisterBody) (domain.UserDTO, error) {
+	ctx, span := tracing.Start(ctx, "UserUseCase.Register")
+	defer span.End()
 	existedUser, err := u.repository.GetByUsername(ctx, body.Username)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		u.logger.Error("[UserUseCase.Register] fetch user by username failed", err)
@@ -66,5 +84,20 @@ func (u *useCaseImpl) Register(ctx context.Context, body domain.RegisterBody) (d
 		return domain.UserDTO{}, apperrors.ErrInternalServerError
 	}
 	u.logger.Info(fmt.Sprintf("[UserUseCase.Register] user %d register success", newUser.ID))
-	return newUser.ToDTO(), nil
+	result := newUser.ToDTO()
+	u.audit(ctx, domain.AuditRecord{
+		ActorID:    newUser.ID,
+		Action:     domain.AuditActionUserRegister,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   newUser.ID,
+		After:      result,
+	})
+	return result, nil
+}
+
+// audit never fails the caller because the user row has already been written.
+func (u *useCaseImpl) audit(ctx context.Context, record domain.AuditRecord) {
+	if err := u.auditor.Record(ctx, record); err != nil {
+		u.logger.Error(fmt.Sprintf("[UserUseCase.audit] record %s on user %d failed", record.Action, record.TargetID), err)
+	}
 }
@@ -16,19 +16,25 @@ import (
 
 type UserUseCaseTestSuite struct {
 	suite.Suite
-	mockRepository *mocks.IRepository
-	mockLogger     *mocks.ILogger
-	useCaseImpl    *useCaseImpl
+	mockRepository     *mocks.IRepository
+	mockRoleRepository *mocks.IRoleRepository
+	mockAuditor        *mocks.IAuditor
+	mockLogger         *mocks.ILogger
+	useCaseImpl        *useCaseImpl
 }
 
 func (s *UserUseCaseTestSuite) SetupTest() {
 	mockRepository := &mocks.IRepository{}
+	mockRoleRepository := &mocks.IRoleRepository{}
 	mockLogger := &mocks.ILogger{}
 	s.mockRepository = mockRepository
+	s.mockRoleRepository = mockRoleRepository
 	s.mockLogger = mockLogger
 	s.mockLogger.On("Info", mock.Anything, mock.Anything).Return()
 	s.mockLogger.On("Error", mock.Anything, mock.Anything).Return()
-	useCase := NewUseCase(mockLogger, mockRepository)
+	mockAuditor := &mocks.IAuditor{}
+	s.mockAuditor = mockAuditor
+	useCase := NewUseCase(mockLogger, mockRepository, mockRoleRepository, mockAuditor)
 	s.useCaseImpl = useCase
 }
 func TestUserUseCaseTestSuite(t *testing.T) {
@@ -52,11 +58,61 @@ func (s *UserUseCaseTestSuite) TestLogin_Success() {
 		DeletedAt: gorm.DeletedAt{Valid: false},
 	}
 	s.mockRepository.On("GetByUsername", mockContext, mockPayload.Username).Return(&mockUserResult, nil)
+	s.mockRoleRepository.On("GetPermissionsByUserID", mockContext, mockUserResult.ID).Return([]domain.Permission{}, nil)
 	actual, err := s.useCaseImpl.Login(context.TODO(), mockPayload)
 	s.Nil(err)
 	s.Equal(mockUserResult.ToDTO(), actual)
 }
 
+func (s *UserUseCaseTestSuite) TestLogin_SuccessWithPermissions() {
+	mockContext := context.TODO()
+	mockPayload := domain.LoginBody{
+		Username: "testUsername",
+		Password: "testPassword",
+	}
+	mockTime := time.Time{}
+	mockUserResult := domain.User{
+		ID:        1,
+		Username:  "testUsername",
+		Password:  "$2a$10$Mjx4fmq9ykGxlqlT/l9yGuojZ0FLV8QmrDhGwxmdE3QdkaXQgCcMG",
+		Name:      "testName",
+		CreatedAt: mockTime,
+		UpdatedAt: mockTime,
+		DeletedAt: gorm.DeletedAt{Valid: false},
+	}
+	mockPermissions := []domain.Permission{domain.PermissionBikesMaintain, domain.PermissionBikesRead}
+	s.mockRepository.On("GetByUsername", mockContext, mockPayload.Username).Return(&mockUserResult, nil)
+	s.mockRoleRepository.On("GetPermissionsByUserID", mockContext, mockUserResult.ID).Return(mockPermissions, nil)
+	actual, err := s.useCaseImpl.Login(context.TODO(), mockPayload)
+	expected := mockUserResult.ToDTO()
+	expected.Permissions = mockPermissions
+	s.Nil(err)
+	s.Equal(expected, actual)
+}
+
+func (s *UserUseCaseTestSuite) TestLogin_FailedByPermissions() {
+	mockContext := context.TODO()
+	mockPayload := domain.LoginBody{
+		Username: "testUsername",
+		Password: "testPassword",
+	}
+	mockTime := time.Time{}
+	mockUserResult := domain.User{
+		ID:        1,
+		Username:  "testUsername",
+		Password:  "$2a$10$Mjx4fmq9ykGxlqlT/l9yGuojZ0FLV8QmrDhGwxmdE3QdkaXQgCcMG",
+		Name:      "testName",
+		CreatedAt: mockTime,
+		UpdatedAt: mockTime,
+		DeletedAt: gorm.DeletedAt{Valid: false},
+	}
+	s.mockRepository.On("GetByUsername", mockContext, mockPayload.Username).Return(&mockUserResult, nil)
+	s.mockRoleRepository.On("GetPermissionsByUserID", mockContext, mockUserResult.ID).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.Login(context.TODO(), mockPayload)
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.Equal(domain.UserDTO{}, actual)
+}
+
 func (s *UserUseCaseTestSuite) TestLogin_FailedByUserNotFound() {
 	mockContext := context.TODO()
 	mockPayload := domain.LoginBody{
@@ -117,9 +173,15 @@ func (s *UserUseCaseTestSuite) TestRegister_Success() {
 	}
 	s.mockRepository.On("GetByUsername", mockContext, mockPayload.Username).Return(nil, gorm.ErrRecordNotFound)
 	s.mockRepository.On("Create", mockContext, mock.Anything).Return(nil)
+	s.mockAuditor.On("Record", mockContext, domain.AuditRecord{
+		Action:     domain.AuditActionUserRegister,
+		TargetType: domain.AuditTargetUser,
+		After:      mockUserResult,
+	}).Return(nil)
 	actual, err := s.useCaseImpl.Register(context.TODO(), mockPayload)
 	s.Nil(err)
 	s.Equal(mockUserResult, actual)
+	s.mockAuditor.AssertExpectations(s.T())
 }
 
 func (s *UserUseCaseTestSuite) TestRegister_Failed() {
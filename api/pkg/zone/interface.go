@@ -0,0 +1,35 @@
+package zone
+
+import (
+	"context"
+
+	"shared-bike/domain"
+
+	"github.com/shopspring/decimal"
+)
+
+type IRepository interface {
+	GetList(ctx context.Context) (*[]domain.Zone, error)
+	CreateList(ctx context.Context, zones *[]domain.Zone) error
+}
+
+type IAuditor interface {
+	Record(ctx context.Context, body domain.AuditRecord) error
+}
+
+type ILogger interface {
+	Info(i ...interface{})
+	Warn(i ...interface{})
+	Error(i ...interface{})
+}
+
+type IUseCase interface {
+	GetList(ctx context.Context) (domain.ZoneFeatureCollection, error)
+	Import(ctx context.Context, body domain.ZoneFeatureCollection) (domain.ZoneFeatureCollection, error)
+	CheckReturn(ctx context.Context, lat, long decimal.Decimal) (decimal.Decimal, error)
+}
+
+//go:generate mockery --name IRepository --output mocks --case underscore
+//go:generate mockery --name IAuditor --output mocks --case underscore
+//go:generate mockery --name ILogger --output mocks --case underscore
+//go:generate mockery --name IUseCase --output mocks --case underscore
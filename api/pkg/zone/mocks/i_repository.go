@@ -0,0 +1,67 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRepository is an autogenerated mock type for the IRepository type
+type IRepository struct {
+	mock.Mock
+}
+
+// CreateList provides a mock function with given fields: ctx, zones
+func (_m *IRepository) CreateList(ctx context.Context, zones *[]domain.Zone) error {
+	ret := _m.Called(ctx, zones)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]domain.Zone) error); ok {
+		r0 = rf(ctx, zones)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetList provides a mock function with given fields: ctx
+func (_m *IRepository) GetList(ctx context.Context) (*[]domain.Zone, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *[]domain.Zone
+	if rf, ok := ret.Get(0).(func(context.Context) *[]domain.Zone); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Zone)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIRepository creates a new instance of IRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIRepository(t mockConstructorTestingTNewIRepository) *IRepository {
+	mock := &IRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "shared-bike/domain"
+
+	decimal "github.com/shopspring/decimal"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IUseCase is an autogenerated mock type for the IUseCase type
+type IUseCase struct {
+	mock.Mock
+}
+
+// CheckReturn provides a mock function with given fields: ctx, lat, long
+func (_m *IUseCase) CheckReturn(ctx context.Context, lat decimal.Decimal, long decimal.Decimal) (decimal.Decimal, error) {
+	ret := _m.Called(ctx, lat, long)
+
+	var r0 decimal.Decimal
+	if rf, ok := ret.Get(0).(func(context.Context, decimal.Decimal, decimal.Decimal) decimal.Decimal); ok {
+		r0 = rf(ctx, lat, long)
+	} else {
+		r0 = ret.Get(0).(decimal.Decimal)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, decimal.Decimal, decimal.Decimal) error); ok {
+		r1 = rf(ctx, lat, long)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetList provides a mock function with given fields: ctx
+func (_m *IUseCase) GetList(ctx context.Context) (domain.ZoneFeatureCollection, error) {
+	ret := _m.Called(ctx)
+
+	var r0 domain.ZoneFeatureCollection
+	if rf, ok := ret.Get(0).(func(context.Context) domain.ZoneFeatureCollection); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.ZoneFeatureCollection)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Import provides a mock function with given fields: ctx, body
+func (_m *IUseCase) Import(ctx context.Context, body domain.ZoneFeatureCollection) (domain.ZoneFeatureCollection, error) {
+	ret := _m.Called(ctx, body)
+
+	var r0 domain.ZoneFeatureCollection
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ZoneFeatureCollection) domain.ZoneFeatureCollection); ok {
+		r0 = rf(ctx, body)
+	} else {
+		r0 = ret.Get(0).(domain.ZoneFeatureCollection)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ZoneFeatureCollection) error); ok {
+		r1 = rf(ctx, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIUseCase creates a new instance of IUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIUseCase(t mockConstructorTestingTNewIUseCase) *IUseCase {
+	mock := &IUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
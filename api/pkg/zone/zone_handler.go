@@ -0,0 +1,78 @@
+package zone
+
+import (
+	"fmt"
+	"net/http"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"github.com/labstack/echo/v4"
+)
+
+type handlerImpl struct {
+	useCase IUseCase
+}
+
+func NewHandler(useCase IUseCase) *handlerImpl {
+	return &handlerImpl{
+		useCase: useCase,
+	}
+}
+
+// GetList godoc
+// @Summary      Get all zones
+// @Description  API for getting the service areas and no-parking zones as a GeoJSON FeatureCollection
+// @Tags         zones
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  domain.ZoneFeatureCollection "Success"
+// @Failure      500  {string}  string 	"internal server error"
+// @Security     BearerAuth
+// @Router       /zones [get]
+func (h *handlerImpl) GetList(c echo.Context) error {
+	span := tracing.StartHandler(c, "ZoneHandler.GetList")
+	defer span.End()
+	c.Logger().Info("[ZoneHandler.GetList] starting")
+	ctx := c.Request().Context()
+	zones, err := h.useCase.GetList(ctx)
+	if err != nil {
+		c.Logger().Error("[ZoneHandler.GetList] cannot get zones", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info("[ZoneHandler.GetList] success")
+	return c.JSON(http.StatusOK, zones)
+}
+
+// Import godoc
+// @Summary      Import zones
+// @Description  API for importing service areas and no-parking zones from a GeoJSON FeatureCollection of Polygon or MultiPolygon features
+// @Tags         zones
+// @Accept       json
+// @Produce      json
+// @Param    		 request  body      domain.ZoneFeatureCollection  true  "Zones"
+// @Success      201  {object}  domain.ZoneFeatureCollection "Success"
+// @Failure      400  {string}  string 	"invalid body | invalid zone, expected a GeoJSON FeatureCollection of polygons"
+// @Failure      403  {string}  string 	"you do not have permission to perform this action"
+// @Failure      500  {string}  string 	"internal server error"
+// @Security     BearerAuth
+// @Router       /admin/zones [post]
+func (h *handlerImpl) Import(c echo.Context) error {
+	span := tracing.StartHandler(c, "ZoneHandler.Import")
+	defer span.End()
+	c.Logger().Info("[ZoneHandler.Import] starting")
+	ctx := c.Request().Context()
+	body := domain.ZoneFeatureCollection{}
+	if err := c.Bind(&body); err != nil {
+		c.Logger().Error("[ZoneHandler.Import] invalid body", err)
+		return c.JSON(apperrors.GetStatusCode(apperrors.ErrInvalidBody), apperrors.ErrInvalidBody.Error())
+	}
+	zones, err := h.useCase.Import(ctx, body)
+	if err != nil {
+		c.Logger().Error("[ZoneHandler.Import] cannot import zones", err)
+		return c.JSON(apperrors.GetStatusCode(err), err.Error())
+	}
+	c.Logger().Info(fmt.Sprintf("[ZoneHandler.Import] imported %d zones", len(zones.Features)))
+	return c.JSON(http.StatusCreated, zones)
+}
@@ -0,0 +1,107 @@
+package zone
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/zone/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type ZoneHandlerTestSuite struct {
+	suite.Suite
+	mockUseCase *mocks.IUseCase
+	echo        *echo.Echo
+	handlerImpl *handlerImpl
+}
+
+func (s *ZoneHandlerTestSuite) SetupTest() {
+	mockUseCase := &mocks.IUseCase{}
+	s.mockUseCase = mockUseCase
+	s.echo = echo.New()
+	s.handlerImpl = NewHandler(mockUseCase)
+}
+
+func TestZoneHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ZoneHandlerTestSuite))
+}
+
+func (s *ZoneHandlerTestSuite) TestGetList_Success() {
+	zones := domain.ZoneFeatureCollection{
+		Type: domain.GeoJSONFeatureCollection,
+		Features: []domain.ZoneFeature{{
+			Type:       domain.GeoJSONFeature,
+			ID:         1,
+			Properties: domain.ZoneProperties{Name: "Zeil", Kind: domain.ZoneKindNoParking, Policy: domain.ZonePolicyReject, Surcharge: "0.00"},
+			Geometry:   json.RawMessage(`{"type":"Polygon","coordinates":[]}`),
+		}},
+	}
+	s.mockUseCase.On("GetList", context.Background()).Return(zones, nil)
+	req := httptest.NewRequest(http.MethodGet, "/zones", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(`{"type":"FeatureCollection","features":[{"type":"Feature","id":1,"properties":{"name":"Zeil","kind":"no_parking","policy":"reject","surcharge":"0.00"},"geometry":{"type":"Polygon","coordinates":[]}}]}`+"\n", rec.Body.String())
+}
+
+func (s *ZoneHandlerTestSuite) TestGetList_Failed() {
+	s.mockUseCase.On("GetList", context.Background()).Return(domain.ZoneFeatureCollection{}, apperrors.ErrInternalServerError)
+	req := httptest.NewRequest(http.MethodGet, "/zones", nil)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	s.NoError(s.handlerImpl.GetList(c))
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Equal(`"e5000 internal server error"`+"\n", rec.Body.String())
+}
+
+func (s *ZoneHandlerTestSuite) newImportContext(body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPost, "/admin/zones", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return s.echo.NewContext(req, rec), rec
+}
+
+func (s *ZoneHandlerTestSuite) TestImport_Success() {
+	geometry := `{"type":"Polygon","coordinates":[[[8,50],[9,50],[9,51],[8,50]]]}`
+	body := domain.ZoneFeatureCollection{
+		Type: domain.GeoJSONFeatureCollection,
+		Features: []domain.ZoneFeature{{
+			Type:       domain.GeoJSONFeature,
+			Properties: domain.ZoneProperties{Name: "Frankfurt", Kind: domain.ZoneKindServiceArea, Policy: domain.ZonePolicyReject},
+			Geometry:   json.RawMessage(geometry),
+		}},
+	}
+	result := body
+	result.Features = []domain.ZoneFeature{body.Features[0]}
+	result.Features[0].ID = 1
+	s.mockUseCase.On("Import", context.Background(), body).Return(result, nil)
+	c, rec := s.newImportContext(`{"type":"FeatureCollection","features":[{"type":"Feature","properties":{"name":"Frankfurt","kind":"service_area","policy":"reject"},"geometry":` + geometry + `}]}`)
+	s.NoError(s.handlerImpl.Import(c))
+	s.Equal(http.StatusCreated, rec.Code)
+	s.Contains(rec.Body.String(), `"id":1`)
+}
+
+func (s *ZoneHandlerTestSuite) TestImport_FailedBody() {
+	c, rec := s.newImportContext(`{"type":`)
+	s.NoError(s.handlerImpl.Import(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(`"`+apperrors.ErrInvalidBody.Error()+`"`+"\n", rec.Body.String())
+}
+
+func (s *ZoneHandlerTestSuite) TestImport_FailedUseCase() {
+	body := domain.ZoneFeatureCollection{Type: domain.GeoJSONFeatureCollection}
+	s.mockUseCase.On("Import", context.Background(), body).Return(domain.ZoneFeatureCollection{}, apperrors.ErrInvalidZone)
+	c, rec := s.newImportContext(`{"type":"FeatureCollection"}`)
+	s.NoError(s.handlerImpl.Import(c))
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(`"e40019 invalid zone, expected a GeoJSON FeatureCollection of polygons"`+"\n", rec.Body.String())
+}
@@ -0,0 +1,42 @@
+package zone
+
+import (
+	"context"
+
+	"shared-bike/domain"
+	"shared-bike/tracing"
+
+	"gorm.io/gorm"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repositoryImpl {
+	return &repositoryImpl{
+		db: db,
+	}
+}
+
+func (r *repositoryImpl) GetList(ctx context.Context) (*[]domain.Zone, error) {
+	ctx, span := tracing.Start(ctx, "ZoneRepository.GetList")
+	defer span.End()
+	zones := []domain.Zone{}
+	err := r.db.WithContext(ctx).Order("id").Find(&zones).Error
+	if err != nil {
+		return nil, err
+	}
+	return &zones, nil
+}
+
+// CreateList inserts every zone in one statement, so an import is stored completely or not at all.
+func (r *repositoryImpl) CreateList(ctx context.Context, zones *[]domain.Zone) error {
+	ctx, span := tracing.Start(ctx, "ZoneRepository.CreateList")
+	defer span.End()
+	err := r.db.WithContext(ctx).Create(zones).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package zone
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"shared-bike/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type ZoneRepositoryTestSuite struct {
+	suite.Suite
+	mockDB         sqlmock.Sqlmock
+	repositoryImpl *repositoryImpl
+}
+
+func (s *ZoneRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.Error(err, "Failed to open mock sql db, got error")
+	}
+
+	if db == nil {
+		s.Error(nil, "mock db is null")
+	}
+
+	if mock == nil {
+		s.Error(nil, "sqlmock is null")
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		s.Error(err, "gormDB init err")
+	}
+	s.mockDB = mock
+	repositoryImpl := NewRepository(gormDB)
+	s.repositoryImpl = repositoryImpl
+}
+
+func TestZoneRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ZoneRepositoryTestSuite))
+}
+
+func (s *ZoneRepositoryTestSuite) TestGetList_Success() {
+	query := regexp.QuoteMeta("SELECT * FROM `zone` WHERE `zone`.`deleted_at` IS NULL ORDER BY id")
+	rows := sqlmock.NewRows([]string{"id", "name", "kind", "policy", "surcharge", "geometry"}).
+		AddRow(1, "Zeil", "no_parking", "surcharge", "5.00", `{"type":"Polygon"}`)
+	s.mockDB.ExpectQuery(query).WillReturnRows(rows)
+	actual, err := s.repositoryImpl.GetList(context.TODO())
+	expected := &[]domain.Zone{{
+		ID:        1,
+		Name:      "Zeil",
+		Kind:      domain.ZoneKindNoParking,
+		Policy:    domain.ZonePolicySurcharge,
+		Surcharge: decimal.RequireFromString("5.00"),
+		Geometry:  `{"type":"Polygon"}`,
+	}}
+	s.Equal(expected, actual)
+	s.Nil(err)
+}
+
+func (s *ZoneRepositoryTestSuite) TestGetList_Failed() {
+	query := regexp.QuoteMeta("SELECT * FROM `zone`")
+	s.mockDB.ExpectQuery(query).WillReturnError(gorm.ErrInvalidDB)
+	actual, err := s.repositoryImpl.GetList(context.TODO())
+	s.Nil(actual)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
+
+func (s *ZoneRepositoryTestSuite) TestCreateList_Success() {
+	zones := &[]domain.Zone{
+		{Name: "Frankfurt", Kind: domain.ZoneKindServiceArea, Policy: domain.ZonePolicyReject, Geometry: `{"type":"Polygon"}`},
+		{Name: "Zeil", Kind: domain.ZoneKindNoParking, Policy: domain.ZonePolicySurcharge, Surcharge: decimal.NewFromInt(5), Geometry: `{"type":"Polygon"}`},
+	}
+	query := regexp.QuoteMeta("INSERT INTO `zone` (`name`,`kind`,`policy`,`surcharge`,`geometry`,`created_at`,`updated_at`,`deleted_at`) VALUES (?,?,?,?,?,?,?,?),(?,?,?,?,?,?,?,?)")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnResult(sqlmock.NewResult(1, 2))
+	s.mockDB.ExpectCommit()
+	err := s.repositoryImpl.CreateList(context.TODO(), zones)
+	s.Nil(err)
+	s.Equal(int64(1), (*zones)[0].ID)
+	s.Equal(int64(2), (*zones)[1].ID)
+}
+
+func (s *ZoneRepositoryTestSuite) TestCreateList_Failed() {
+	zones := &[]domain.Zone{{Name: "Frankfurt"}}
+	query := regexp.QuoteMeta("INSERT INTO `zone`")
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(query).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	err := s.repositoryImpl.CreateList(context.TODO(), zones)
+	s.Equal(gorm.ErrInvalidDB, err)
+}
@@ -0,0 +1,175 @@
+package zone
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/geo"
+	"shared-bike/tracing"
+
+	"github.com/shopspring/decimal"
+)
+
+type useCaseImpl struct {
+	logger     ILogger
+	repository IRepository
+	auditor    IAuditor
+}
+
+func NewUseCase(logger ILogger, repository IRepository, auditor IAuditor) *useCaseImpl {
+	return &useCaseImpl{
+		logger:     logger,
+		repository: repository,
+		auditor:    auditor,
+	}
+}
+
+func (u *useCaseImpl) GetList(ctx context.Context) (domain.ZoneFeatureCollection, error) {
+	ctx, span := tracing.Start(ctx, "ZoneUseCase.GetList")
+	defer span.End()
+	u.logger.Info("[ZoneUseCase.GetList] fetching all zones")
+	zones, err := u.repository.GetList(ctx)
+	if err != nil {
+		u.logger.Error("[ZoneUseCase.GetList] fetch all zones failed", err)
+		return domain.ZoneFeatureCollection{}, apperrors.ErrInternalServerError
+	}
+	u.logger.Info("[ZoneUseCase.GetList] fetch all zones success")
+	return toFeatureCollection(zones), nil
+}
+
+func (u *useCaseImpl) Import(ctx context.Context, body domain.ZoneFeatureCollection) (domain.ZoneFeatureCollection, error) {
+	ctx, span := tracing.Start(ctx, "ZoneUseCase.Import")
+	defer span.End()
+	if body.Type != domain.GeoJSONFeatureCollection || len(body.Features) == 0 {
+		u.logger.Info("[ZoneUseCase.Import] body is not a feature collection")
+		return domain.ZoneFeatureCollection{}, apperrors.ErrInvalidZone
+	}
+	zones := []domain.Zone{}
+	for i, feature := range body.Features {
+		zone, err := toZone(feature)
+		if err != nil {
+			u.logger.Info(fmt.Sprintf("[ZoneUseCase.Import] feature %d is invalid", i), err)
+			return domain.ZoneFeatureCollection{}, apperrors.ErrInvalidZone
+		}
+		zones = append(zones, zone)
+	}
+	if err := u.repository.CreateList(ctx, &zones); err != nil {
+		u.logger.Error("[ZoneUseCase.Import] create zones failed", err)
+		return domain.ZoneFeatureCollection{}, apperrors.ErrInternalServerError
+	}
+	result := toFeatureCollection(&zones)
+	for _, feature := range result.Features {
+		u.audit(ctx, domain.AuditRecord{
+			Action:     domain.AuditActionZoneImport,
+			TargetType: domain.AuditTargetZone,
+			TargetID:   feature.ID,
+			After:      feature.Properties,
+		})
+	}
+	u.logger.Info(fmt.Sprintf("[ZoneUseCase.Import] imported %d zones", len(zones)))
+	return result, nil
+}
+
+// CheckReturn rejects a drop-off or returns the surcharge it costs. Being outside every service area applies the
+// strictest service area policy, being inside a no-parking zone applies that zone's policy, surcharges add up.
+func (u *useCaseImpl) CheckReturn(ctx context.Context, lat, long decimal.Decimal) (decimal.Decimal, error) {
+	ctx, span := tracing.Start(ctx, "ZoneUseCase.CheckReturn")
+	defer span.End()
+	zones, err := u.repository.GetList(ctx)
+	if err != nil {
+		u.logger.Error("[ZoneUseCase.CheckReturn] fetch all zones failed", err)
+		return decimal.Zero, apperrors.ErrInternalServerError
+	}
+	pointLat, _ := lat.Float64()
+	pointLong, _ := long.Float64()
+	var (
+		surcharge         = decimal.Zero
+		serviceAreas      []domain.Zone
+		insideServiceArea bool
+	)
+	for _, zone := range *zones {
+		shape, err := geo.ParseGeometry([]byte(zone.Geometry))
+		if err != nil {
+			u.logger.Error(fmt.Sprintf("[ZoneUseCase.CheckReturn] zone %d has an invalid geometry", zone.ID), err)
+			continue
+		}
+		inside := shape.Contains(pointLat, pointLong)
+		switch zone.Kind {
+		case domain.ZoneKindServiceArea:
+			serviceAreas = append(serviceAreas, zone)
+			insideServiceArea = insideServiceArea || inside
+		case domain.ZoneKindNoParking:
+			if !inside {
+				continue
+			}
+			if zone.Policy == domain.ZonePolicyReject {
+				u.logger.Info(fmt.Sprintf("[ZoneUseCase.CheckReturn] drop-off is inside no-parking zone %d", zone.ID))
+				return decimal.Zero, apperrors.ErrInNoParkingZone
+			}
+			surcharge = surcharge.Add(zone.Surcharge)
+		}
+	}
+	if len(serviceAreas) > 0 && !insideServiceArea {
+		outsideSurcharge := decimal.Zero
+		for _, zone := range serviceAreas {
+			if zone.Policy == domain.ZonePolicyReject {
+				u.logger.Info("[ZoneUseCase.CheckReturn] drop-off is outside the service area")
+				return decimal.Zero, apperrors.ErrOutsideServiceArea
+			}
+			outsideSurcharge = decimal.Max(outsideSurcharge, zone.Surcharge)
+		}
+		surcharge = surcharge.Add(outsideSurcharge)
+	}
+	return surcharge, nil
+}
+
+// audit never fails the caller because the zones have already been written.
+func (u *useCaseImpl) audit(ctx context.Context, record domain.AuditRecord) {
+	if err := u.auditor.Record(ctx, record); err != nil {
+		u.logger.Error(fmt.Sprintf("[ZoneUseCase.audit] record %s of %s %d failed", record.Action, record.TargetType, record.TargetID), err)
+	}
+}
+
+func toZone(feature domain.ZoneFeature) (domain.Zone, error) {
+	properties := feature.Properties
+	if feature.Type != domain.GeoJSONFeature || properties.Name == "" || !properties.Kind.IsValid() || !properties.Policy.IsValid() {
+		return domain.Zone{}, apperrors.ErrInvalidZone
+	}
+	surcharge := decimal.Zero
+	if properties.Policy == domain.ZonePolicySurcharge {
+		amount, err := decimal.NewFromString(properties.Surcharge)
+		if err != nil || !amount.IsPositive() || !amount.Equal(amount.Round(2)) {
+			return domain.Zone{}, apperrors.ErrInvalidZone
+		}
+		surcharge = amount
+	}
+	if _, err := geo.ParseGeometry(feature.Geometry); err != nil {
+		return domain.Zone{}, err
+	}
+	geometry := bytes.Buffer{}
+	if err := json.Compact(&geometry, feature.Geometry); err != nil {
+		return domain.Zone{}, err
+	}
+	return domain.Zone{
+		Name:      properties.Name,
+		Kind:      properties.Kind,
+		Policy:    properties.Policy,
+		Surcharge: surcharge,
+		Geometry:  geometry.String(),
+	}, nil
+}
+
+func toFeatureCollection(zones *[]domain.Zone) domain.ZoneFeatureCollection {
+	collection := domain.ZoneFeatureCollection{
+		Type:     domain.GeoJSONFeatureCollection,
+		Features: []domain.ZoneFeature{},
+	}
+	for _, zone := range *zones {
+		collection.Features = append(collection.Features, zone.ToFeature())
+	}
+	return collection
+}
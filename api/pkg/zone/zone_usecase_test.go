@@ -0,0 +1,245 @@
+package zone
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"shared-bike/apperrors"
+	"shared-bike/domain"
+	"shared-bike/pkg/zone/mocks"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+const (
+	// roughly Frankfurt, 8-9 E and 50-51 N
+	serviceAreaGeometry = `{"type":"Polygon","coordinates":[[[8,50],[9,50],[9,51],[8,51],[8,50]]]}`
+	// a small square in the middle of the service area
+	noParkingGeometry = `{"type":"Polygon","coordinates":[[[8.4,50.4],[8.6,50.4],[8.6,50.6],[8.4,50.6],[8.4,50.4]]]}`
+)
+
+var (
+	insideLat     = decimal.RequireFromString("50.2")
+	insideLong    = decimal.RequireFromString("8.2")
+	noParkingLat  = decimal.RequireFromString("50.5")
+	noParkingLong = decimal.RequireFromString("8.5")
+	outsideLat    = decimal.RequireFromString("52.5")
+	outsideLong   = decimal.RequireFromString("13.4")
+)
+
+type ZoneUseCaseTestSuite struct {
+	suite.Suite
+	mockRepository *mocks.IRepository
+	mockAuditor    *mocks.IAuditor
+	mockLogger     *mocks.ILogger
+	useCaseImpl    *useCaseImpl
+}
+
+func (s *ZoneUseCaseTestSuite) SetupTest() {
+	s.mockRepository = &mocks.IRepository{}
+	s.mockAuditor = &mocks.IAuditor{}
+	s.mockLogger = &mocks.ILogger{}
+	s.mockLogger.On("Info", mock.Anything).Return()
+	s.mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+	s.mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+	s.useCaseImpl = NewUseCase(s.mockLogger, s.mockRepository, s.mockAuditor)
+}
+
+func TestZoneUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ZoneUseCaseTestSuite))
+}
+
+func (s *ZoneUseCaseTestSuite) zones(serviceAreaPolicy, noParkingPolicy domain.ZonePolicy) *[]domain.Zone {
+	return &[]domain.Zone{
+		{ID: 1, Name: "Frankfurt", Kind: domain.ZoneKindServiceArea, Policy: serviceAreaPolicy, Surcharge: decimal.NewFromInt(10), Geometry: serviceAreaGeometry},
+		{ID: 2, Name: "Zeil", Kind: domain.ZoneKindNoParking, Policy: noParkingPolicy, Surcharge: decimal.NewFromInt(5), Geometry: noParkingGeometry},
+	}
+}
+
+func (s *ZoneUseCaseTestSuite) TestGetList_Success() {
+	mockContext := context.TODO()
+	s.mockRepository.On("GetList", mockContext).Return(s.zones(domain.ZonePolicyReject, domain.ZonePolicySurcharge), nil)
+	actual, err := s.useCaseImpl.GetList(mockContext)
+	s.Nil(err)
+	s.Equal(domain.GeoJSONFeatureCollection, actual.Type)
+	s.Len(actual.Features, 2)
+	s.Equal(domain.ZoneFeature{
+		Type: domain.GeoJSONFeature,
+		ID:   2,
+		Properties: domain.ZoneProperties{
+			Name:      "Zeil",
+			Kind:      domain.ZoneKindNoParking,
+			Policy:    domain.ZonePolicySurcharge,
+			Surcharge: "5.00",
+		},
+		Geometry: json.RawMessage(noParkingGeometry),
+	}, actual.Features[1])
+}
+
+func (s *ZoneUseCaseTestSuite) TestGetList_InternalServerError() {
+	mockContext := context.TODO()
+	s.mockRepository.On("GetList", mockContext).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.GetList(mockContext)
+	s.Equal(domain.ZoneFeatureCollection{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
+
+func (s *ZoneUseCaseTestSuite) TestImport_Success() {
+	mockContext := context.TODO()
+	body := domain.ZoneFeatureCollection{
+		Type: domain.GeoJSONFeatureCollection,
+		Features: []domain.ZoneFeature{
+			{
+				Type:       domain.GeoJSONFeature,
+				Properties: domain.ZoneProperties{Name: "Frankfurt", Kind: domain.ZoneKindServiceArea, Policy: domain.ZonePolicyReject},
+				Geometry:   json.RawMessage(serviceAreaGeometry),
+			},
+			{
+				Type:       domain.GeoJSONFeature,
+				Properties: domain.ZoneProperties{Name: "Zeil", Kind: domain.ZoneKindNoParking, Policy: domain.ZonePolicySurcharge, Surcharge: "5"},
+				Geometry:   json.RawMessage(`{ "type": "Polygon", "coordinates": [[[8.4,50.4],[8.6,50.4],[8.6,50.6],[8.4,50.6],[8.4,50.4]]] }`),
+			},
+		},
+	}
+	expectedZones := &[]domain.Zone{
+		{Name: "Frankfurt", Kind: domain.ZoneKindServiceArea, Policy: domain.ZonePolicyReject, Surcharge: decimal.Zero, Geometry: serviceAreaGeometry},
+		{Name: "Zeil", Kind: domain.ZoneKindNoParking, Policy: domain.ZonePolicySurcharge, Surcharge: decimal.RequireFromString("5"), Geometry: noParkingGeometry},
+	}
+	s.mockRepository.On("CreateList", mockContext, expectedZones).Run(func(args mock.Arguments) {
+		zones := args.Get(1).(*[]domain.Zone)
+		(*zones)[0].ID = 1
+		(*zones)[1].ID = 2
+	}).Return(nil)
+	s.mockAuditor.On("Record", mockContext, domain.AuditRecord{
+		Action:     domain.AuditActionZoneImport,
+		TargetType: domain.AuditTargetZone,
+		TargetID:   1,
+		After:      domain.ZoneProperties{Name: "Frankfurt", Kind: domain.ZoneKindServiceArea, Policy: domain.ZonePolicyReject, Surcharge: "0.00"},
+	}).Return(nil)
+	s.mockAuditor.On("Record", mockContext, mock.Anything).Return(apperrors.ErrInternalServerError)
+	actual, err := s.useCaseImpl.Import(mockContext, body)
+	s.Nil(err)
+	s.Len(actual.Features, 2)
+	s.Equal(int64(2), actual.Features[1].ID)
+	s.Equal("5.00", actual.Features[1].Properties.Surcharge)
+	s.mockAuditor.AssertNumberOfCalls(s.T(), "Record", 2)
+}
+
+func (s *ZoneUseCaseTestSuite) TestImport_InvalidZone() {
+	valid := domain.ZoneFeature{
+		Type:       domain.GeoJSONFeature,
+		Properties: domain.ZoneProperties{Name: "Zeil", Kind: domain.ZoneKindNoParking, Policy: domain.ZonePolicySurcharge, Surcharge: "5"},
+		Geometry:   json.RawMessage(noParkingGeometry),
+	}
+	withFeature := func(change func(feature *domain.ZoneFeature)) domain.ZoneFeatureCollection {
+		feature := valid
+		change(&feature)
+		return domain.ZoneFeatureCollection{Type: domain.GeoJSONFeatureCollection, Features: []domain.ZoneFeature{feature}}
+	}
+	for _, body := range []domain.ZoneFeatureCollection{
+		{Type: domain.GeoJSONFeatureCollection},
+		{Type: domain.GeoJSONFeature, Features: []domain.ZoneFeature{valid}},
+		withFeature(func(feature *domain.ZoneFeature) { feature.Type = "Polygon" }),
+		withFeature(func(feature *domain.ZoneFeature) { feature.Properties.Name = "" }),
+		withFeature(func(feature *domain.ZoneFeature) { feature.Properties.Kind = "parking" }),
+		withFeature(func(feature *domain.ZoneFeature) { feature.Properties.Policy = "warn" }),
+		withFeature(func(feature *domain.ZoneFeature) { feature.Properties.Surcharge = "" }),
+		withFeature(func(feature *domain.ZoneFeature) { feature.Properties.Surcharge = "-1" }),
+		withFeature(func(feature *domain.ZoneFeature) { feature.Properties.Surcharge = "1.005" }),
+		withFeature(func(feature *domain.ZoneFeature) {
+			feature.Geometry = json.RawMessage(`{"type":"Point","coordinates":[8.5,50.5]}`)
+		}),
+	} {
+		actual, err := s.useCaseImpl.Import(context.TODO(), body)
+		s.Equal(domain.ZoneFeatureCollection{}, actual)
+		s.Equal(apperrors.ErrInvalidZone, err)
+	}
+	s.mockRepository.AssertNotCalled(s.T(), "CreateList", mock.Anything, mock.Anything)
+}
+
+func (s *ZoneUseCaseTestSuite) TestImport_InternalServerError() {
+	mockContext := context.TODO()
+	body := domain.ZoneFeatureCollection{
+		Type: domain.GeoJSONFeatureCollection,
+		Features: []domain.ZoneFeature{{
+			Type:       domain.GeoJSONFeature,
+			Properties: domain.ZoneProperties{Name: "Frankfurt", Kind: domain.ZoneKindServiceArea, Policy: domain.ZonePolicyReject},
+			Geometry:   json.RawMessage(serviceAreaGeometry),
+		}},
+	}
+	s.mockRepository.On("CreateList", mockContext, mock.Anything).Return(gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.Import(mockContext, body)
+	s.Equal(domain.ZoneFeatureCollection{}, actual)
+	s.Equal(apperrors.ErrInternalServerError, err)
+	s.mockAuditor.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything)
+}
+
+func (s *ZoneUseCaseTestSuite) TestCheckReturn_InsideServiceArea() {
+	mockContext := context.TODO()
+	s.mockRepository.On("GetList", mockContext).Return(s.zones(domain.ZonePolicyReject, domain.ZonePolicyReject), nil)
+	actual, err := s.useCaseImpl.CheckReturn(mockContext, insideLat, insideLong)
+	s.True(actual.IsZero())
+	s.Nil(err)
+}
+
+func (s *ZoneUseCaseTestSuite) TestCheckReturn_WithoutZones() {
+	mockContext := context.TODO()
+	s.mockRepository.On("GetList", mockContext).Return(&[]domain.Zone{}, nil)
+	actual, err := s.useCaseImpl.CheckReturn(mockContext, outsideLat, outsideLong)
+	s.True(actual.IsZero())
+	s.Nil(err)
+}
+
+func (s *ZoneUseCaseTestSuite) TestCheckReturn_OutsideServiceAreaRejected() {
+	mockContext := context.TODO()
+	s.mockRepository.On("GetList", mockContext).Return(s.zones(domain.ZonePolicyReject, domain.ZonePolicySurcharge), nil)
+	actual, err := s.useCaseImpl.CheckReturn(mockContext, outsideLat, outsideLong)
+	s.True(actual.IsZero())
+	s.Equal(apperrors.ErrOutsideServiceArea, err)
+}
+
+func (s *ZoneUseCaseTestSuite) TestCheckReturn_OutsideServiceAreaSurcharged() {
+	mockContext := context.TODO()
+	s.mockRepository.On("GetList", mockContext).Return(s.zones(domain.ZonePolicySurcharge, domain.ZonePolicySurcharge), nil)
+	actual, err := s.useCaseImpl.CheckReturn(mockContext, outsideLat, outsideLong)
+	s.Equal("10", actual.String())
+	s.Nil(err)
+}
+
+func (s *ZoneUseCaseTestSuite) TestCheckReturn_NoParkingRejected() {
+	mockContext := context.TODO()
+	s.mockRepository.On("GetList", mockContext).Return(s.zones(domain.ZonePolicyReject, domain.ZonePolicyReject), nil)
+	actual, err := s.useCaseImpl.CheckReturn(mockContext, noParkingLat, noParkingLong)
+	s.True(actual.IsZero())
+	s.Equal(apperrors.ErrInNoParkingZone, err)
+}
+
+func (s *ZoneUseCaseTestSuite) TestCheckReturn_NoParkingSurcharged() {
+	mockContext := context.TODO()
+	s.mockRepository.On("GetList", mockContext).Return(s.zones(domain.ZonePolicyReject, domain.ZonePolicySurcharge), nil)
+	actual, err := s.useCaseImpl.CheckReturn(mockContext, noParkingLat, noParkingLong)
+	s.Equal("5", actual.String())
+	s.Nil(err)
+}
+
+func (s *ZoneUseCaseTestSuite) TestCheckReturn_SkipsInvalidGeometry() {
+	mockContext := context.TODO()
+	zones := s.zones(domain.ZonePolicyReject, domain.ZonePolicyReject)
+	(*zones)[1].Geometry = `{"type":"Point"}`
+	s.mockRepository.On("GetList", mockContext).Return(zones, nil)
+	actual, err := s.useCaseImpl.CheckReturn(mockContext, noParkingLat, noParkingLong)
+	s.True(actual.IsZero())
+	s.Nil(err)
+}
+
+func (s *ZoneUseCaseTestSuite) TestCheckReturn_InternalServerError() {
+	mockContext := context.TODO()
+	s.mockRepository.On("GetList", mockContext).Return(nil, gorm.ErrInvalidDB)
+	actual, err := s.useCaseImpl.CheckReturn(mockContext, insideLat, insideLong)
+	s.True(actual.IsZero())
+	s.Equal(apperrors.ErrInternalServerError, err)
+}
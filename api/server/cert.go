@@ -0,0 +1,76 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertReloader hands the TLS certificate to new connections. At most once per interval it looks at the modification
+// time of both files and loads them again when one changed, so a renewed certificate is served without a restart.
+// A pair that fails to load, like a certificate written before its key, is logged and the previous one kept.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+	logger   ILogger
+	now      func() time.Time
+
+	mu          sync.Mutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+	checkedAt   time.Time
+}
+
+func NewCertReloader(certFile, keyFile string, interval time.Duration, logger ILogger) (*CertReloader, error) {
+	r := &CertReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		interval: interval,
+		logger:   logger,
+		now:      time.Now,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.now().Sub(r.checkedAt) >= r.interval {
+		if err := r.load(); err != nil {
+			r.logger.Error("[CertReloader.GetCertificate] reload certificate failed, serving the previous one", err)
+		}
+	}
+	return r.cert, nil
+}
+
+// load reads the pair when it changed since the last load, the caller holds mu.
+func (r *CertReloader) load() error {
+	r.checkedAt = r.now()
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return err
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return err
+	}
+	if r.cert != nil && certInfo.ModTime().Equal(r.certModTime) && keyInfo.ModTime().Equal(r.keyModTime) {
+		return nil
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load %s and %s: %w", r.certFile, r.keyFile, err)
+	}
+	r.cert = &cert
+	r.certModTime = certInfo.ModTime()
+	r.keyModTime = keyInfo.ModTime()
+	r.logger.Info(fmt.Sprintf("[CertReloader.load] loaded certificate %s", r.certFile))
+	return nil
+}
@@ -0,0 +1,8 @@
+package server
+
+//go:generate mockery --name ILogger --output mocks --case underscore
+
+type ILogger interface {
+	Info(i ...interface{})
+	Error(i ...interface{})
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
+const certCheckInterval = 10 * time.Second
+
+type Config struct {
+	Port string
+	// ReadHeaderTimeout bounds reading the headers, so slow clients cannot hold connections open.
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout bounds reading the whole request, body included.
+	ReadTimeout time.Duration
+	// WriteTimeout bounds the handler and writing the response, it must cover a rent waiting for the lock.
+	WriteTimeout time.Duration
+	// IdleTimeout closes keep-alive connections without a request for that long.
+	IdleTimeout time.Duration
+	// CertFile and KeyFile turn on TLS, both files are read again when they change on disk.
+	CertFile string
+	KeyFile  string
+}
+
+// New returns the server of handler with the timeouts of config. With a certificate it serves TLS 1.2 or later and
+// picks up a renewed certificate without a restart.
+func New(handler http.Handler, config Config, logger ILogger, errorLog *log.Logger) (*http.Server, error) {
+	srv := &http.Server{
+		Addr:              net.JoinHostPort("", config.Port),
+		Handler:           handler,
+		ReadHeaderTimeout: config.ReadHeaderTimeout,
+		ReadTimeout:       config.ReadTimeout,
+		WriteTimeout:      config.WriteTimeout,
+		IdleTimeout:       config.IdleTimeout,
+		ErrorLog:          errorLog,
+	}
+	if config.CertFile == "" && config.KeyFile == "" {
+		return srv, nil
+	}
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("TLS needs both a certificate and a key file")
+	}
+	reloader, err := NewCertReloader(config.CertFile, config.KeyFile, certCheckInterval, logger)
+	if err != nil {
+		return nil, err
+	}
+	srv.TLSConfig = &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}
+	return srv, nil
+}
+
+// ListenAndServe serves TLS when New configured it, plain HTTP otherwise.
+func ListenAndServe(srv *http.Server) error {
+	if srv.TLSConfig != nil {
+		return srv.ListenAndServeTLS("", "")
+	}
+	return srv.ListenAndServe()
+}
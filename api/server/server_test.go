@@ -0,0 +1,170 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"shared-bike/server/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+	logger   *mocks.ILogger
+	certFile string
+	keyFile  string
+	modTime  time.Time
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.logger = &mocks.ILogger{}
+	s.logger.On("Info", mock.Anything).Return()
+	dir := s.T().TempDir()
+	s.certFile = filepath.Join(dir, "tls.crt")
+	s.keyFile = filepath.Join(dir, "tls.key")
+	s.modTime = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	s.writeCert(1)
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+// writeCert writes a self-signed certificate for localhost with serial, one second newer than the previous one.
+func (s *ServerTestSuite) writeCert(serial int64) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().Nil(err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	s.Require().Nil(err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	s.Require().Nil(err)
+	s.Require().Nil(os.WriteFile(s.certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	s.Require().Nil(os.WriteFile(s.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	s.touch(s.certFile, s.keyFile)
+}
+
+func (s *ServerTestSuite) touch(files ...string) {
+	s.modTime = s.modTime.Add(time.Second)
+	for _, file := range files {
+		s.Require().Nil(os.Chtimes(file, s.modTime, s.modTime))
+	}
+}
+
+func serialOf(cert *tls.Certificate) int64 {
+	parsed, _ := x509.ParseCertificate(cert.Certificate[0])
+	return parsed.SerialNumber.Int64()
+}
+
+func (s *ServerTestSuite) TestNew_Plain() {
+	handler := http.NotFoundHandler()
+	srv, err := New(handler, Config{
+		Port:              "8000",
+		ReadHeaderTimeout: time.Second,
+		ReadTimeout:       2 * time.Second,
+		WriteTimeout:      3 * time.Second,
+		IdleTimeout:       4 * time.Second,
+	}, s.logger, nil)
+	s.Nil(err)
+	s.Equal(":8000", srv.Addr)
+	s.Equal(time.Second, srv.ReadHeaderTimeout)
+	s.Equal(2*time.Second, srv.ReadTimeout)
+	s.Equal(3*time.Second, srv.WriteTimeout)
+	s.Equal(4*time.Second, srv.IdleTimeout)
+	s.Nil(srv.TLSConfig)
+}
+
+func (s *ServerTestSuite) TestNew_Invalid() {
+	_, err := New(http.NotFoundHandler(), Config{Port: "8000", CertFile: s.certFile}, s.logger, nil)
+	s.EqualError(err, "TLS needs both a certificate and a key file")
+	s.Nil(os.WriteFile(s.keyFile, []byte("not a key"), 0600))
+	_, err = New(http.NotFoundHandler(), Config{Port: "8000", CertFile: s.certFile, KeyFile: s.keyFile}, s.logger, nil)
+	s.Error(err)
+}
+
+func (s *ServerTestSuite) TestNew_ServesTLS() {
+	srv, err := New(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	}), Config{CertFile: s.certFile, KeyFile: s.keyFile}, s.logger, nil)
+	s.Require().Nil(err)
+	s.Equal(uint16(tls.VersionTLS12), srv.TLSConfig.MinVersion)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().Nil(err)
+	go srv.ServeTLS(listener, "", "")
+	defer srv.Close()
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	res, err := client.Get("https://" + listener.Addr().String() + "/health")
+	s.Require().Nil(err)
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	s.Equal("OK", string(body))
+	s.Equal(int64(1), res.TLS.PeerCertificates[0].SerialNumber.Int64())
+}
+
+func (s *ServerTestSuite) TestCertReloader_Reload() {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	reloader, err := NewCertReloader(s.certFile, s.keyFile, time.Minute, s.logger)
+	s.Require().Nil(err)
+	reloader.now = func() time.Time { return now }
+	reloader.checkedAt = now
+	s.writeCert(2)
+	cert, err := reloader.GetCertificate(nil)
+	s.Nil(err)
+	s.Equal(int64(1), serialOf(cert))
+	now = now.Add(time.Minute)
+	cert, err = reloader.GetCertificate(nil)
+	s.Nil(err)
+	s.Equal(int64(2), serialOf(cert))
+	s.logger.AssertNumberOfCalls(s.T(), "Info", 2)
+}
+
+func (s *ServerTestSuite) TestCertReloader_Unchanged() {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	reloader, err := NewCertReloader(s.certFile, s.keyFile, time.Minute, s.logger)
+	s.Require().Nil(err)
+	reloader.now = func() time.Time { return now }
+	reloader.checkedAt = now
+	first, _ := reloader.GetCertificate(nil)
+	now = now.Add(time.Hour)
+	second, _ := reloader.GetCertificate(nil)
+	s.Same(first, second)
+	s.logger.AssertNumberOfCalls(s.T(), "Info", 1)
+}
+
+func (s *ServerTestSuite) TestCertReloader_KeepsPreviousOnError() {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	reloader, err := NewCertReloader(s.certFile, s.keyFile, time.Minute, s.logger)
+	s.Require().Nil(err)
+	reloader.now = func() time.Time { return now }
+	reloader.checkedAt = now
+	s.logger.On("Error", "[CertReloader.GetCertificate] reload certificate failed, serving the previous one", mock.Anything).Return()
+	s.Nil(os.WriteFile(s.certFile, []byte("half written"), 0600))
+	s.touch(s.certFile)
+	now = now.Add(time.Minute)
+	cert, err := reloader.GetCertificate(nil)
+	s.Nil(err)
+	s.Equal(int64(1), serialOf(cert))
+	s.logger.AssertNumberOfCalls(s.T(), "Error", 1)
+}
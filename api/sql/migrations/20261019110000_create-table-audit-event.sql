@@ -0,0 +1,32 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS `audit_event` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `actor_id` bigint(20) DEFAULT NULL,
+  `action` varchar(128) NOT NULL DEFAULT '',
+  `target_type` varchar(64) NOT NULL DEFAULT '',
+  `target_id` bigint(20) NOT NULL DEFAULT 0,
+  `before` json DEFAULT NULL,
+  `after` json DEFAULT NULL,
+  `request_id` varchar(64) NOT NULL DEFAULT '',
+  `ip` varchar(45) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_actor_id` (`actor_id`),
+  KEY `idx_action` (`action`),
+  KEY `idx_target` (`target_type`, `target_id`),
+  KEY `idx_request_id` (`request_id`),
+  KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+INSERT INTO `permission` (`id`, `code`, `description`) VALUES
+(10, 'audit:read', 'Query the audit log');
+
+INSERT INTO `role_permission` (`role_id`, `permission_id`) VALUES
+(1, 10);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DELETE FROM `role_permission` WHERE `permission_id` = 10;
+DELETE FROM `permission` WHERE `id` = 10;
+DROP TABLE IF EXISTS `audit_event`;
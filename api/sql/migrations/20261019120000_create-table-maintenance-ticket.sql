@@ -0,0 +1,20 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS `maintenance_ticket` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `bike_id` bigint(20) NOT NULL,
+  `reporter_id` bigint(20) NOT NULL,
+  `category` varchar(64) NOT NULL DEFAULT '',
+  `note` varchar(1024) NOT NULL DEFAULT '',
+  `status` varchar(32) NOT NULL DEFAULT 'open',
+  `resolved_at` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_bike_id_status` (`bike_id`, `status`),
+  KEY `idx_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS `maintenance_ticket`;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS `bike_photo` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `bike_id` bigint(20) NOT NULL,
+  `ticket_id` bigint(20) DEFAULT NULL,
+  `uploader_id` bigint(20) NOT NULL,
+  `kind` varchar(32) NOT NULL DEFAULT '',
+  `content_type` varchar(64) NOT NULL DEFAULT '',
+  `size` bigint(20) NOT NULL DEFAULT 0,
+  `storage_key` varchar(255) NOT NULL DEFAULT '',
+  `thumbnail_key` varchar(255) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_bike_id` (`bike_id`),
+  KEY `idx_ticket_id` (`ticket_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS `bike_photo`;
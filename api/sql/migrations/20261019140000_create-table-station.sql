@@ -0,0 +1,25 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS `station` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `name` varchar(128) NOT NULL DEFAULT '',
+  `lat` decimal(8,6) NOT NULL,
+  `long` decimal(9,6) NOT NULL,
+  `capacity` int(11) NOT NULL DEFAULT 0,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+ALTER TABLE `bike`
+  ADD COLUMN `station_id` bigint(20) DEFAULT NULL AFTER `user_id`,
+  ADD KEY `idx_station_id` (`station_id`);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE `bike`
+  DROP KEY `idx_station_id`,
+  DROP COLUMN `station_id`;
+
+DROP TABLE IF EXISTS `station`;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS `zone` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `name` varchar(128) NOT NULL DEFAULT '',
+  `kind` varchar(32) NOT NULL DEFAULT '',
+  `policy` varchar(32) NOT NULL DEFAULT 'reject',
+  `surcharge` decimal(10,2) NOT NULL DEFAULT 0,
+  `geometry` json NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_kind` (`kind`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+INSERT INTO `permission` (`id`, `code`, `description`) VALUES
+(11, 'zones:manage', 'Import service areas and no-parking zones');
+
+INSERT INTO `role_permission` (`role_id`, `permission_id`) VALUES
+(1, 11);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DELETE FROM `role_permission` WHERE `permission_id` = 11;
+DELETE FROM `permission` WHERE `id` = 11;
+DROP TABLE IF EXISTS `zone`;
@@ -0,0 +1,42 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS `device` (
+  `id` varchar(64) NOT NULL,
+  `bike_id` bigint(20) NOT NULL,
+  `secret` varchar(128) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_bike_id` (`bike_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `telemetry_reading` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `device_id` varchar(64) NOT NULL,
+  `bike_id` bigint(20) NOT NULL,
+  `recorded_at` datetime(3) NOT NULL,
+  `lat` decimal(8,6) DEFAULT NULL,
+  `long` decimal(9,6) DEFAULT NULL,
+  `battery` tinyint(3) DEFAULT NULL,
+  `lock_state` varchar(16) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_device_recorded_at` (`device_id`, `recorded_at`),
+  KEY `idx_bike_recorded_at` (`bike_id`, `recorded_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+ALTER TABLE `bike`
+  ADD COLUMN `battery` tinyint(3) DEFAULT NULL AFTER `station_id`,
+  ADD COLUMN `lock_state` varchar(16) NOT NULL DEFAULT '' AFTER `battery`,
+  ADD COLUMN `last_seen_at` datetime(3) DEFAULT NULL AFTER `lock_state`;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE `bike`
+  DROP COLUMN `last_seen_at`,
+  DROP COLUMN `lock_state`,
+  DROP COLUMN `battery`;
+
+DROP TABLE IF EXISTS `telemetry_reading`;
+DROP TABLE IF EXISTS `device`;
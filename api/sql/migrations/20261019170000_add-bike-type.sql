@@ -0,0 +1,11 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE `bike`
+  ADD COLUMN `type` varchar(32) NOT NULL DEFAULT 'classic' AFTER `name`,
+  ADD KEY `idx_type` (`type`);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE `bike`
+  DROP KEY `idx_type`,
+  DROP COLUMN `type`;
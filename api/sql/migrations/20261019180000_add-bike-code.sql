@@ -0,0 +1,13 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE `bike`
+  ADD COLUMN `code` varchar(16) DEFAULT NULL AFTER `type`,
+  ADD UNIQUE KEY `uk_code` (`code`);
+
+UPDATE `bike` SET `code` = UPPER(LEFT(SHA2(CONCAT('bike-', `id`), 256), 8)) WHERE `code` IS NULL;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE `bike`
+  DROP KEY `uk_code`,
+  DROP COLUMN `code`;
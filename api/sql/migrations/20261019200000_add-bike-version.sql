@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE `bike`
+  ADD COLUMN `version` bigint(20) NOT NULL DEFAULT 1 AFTER `last_seen_at`;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE `bike`
+  DROP COLUMN `version`;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE `idempotent_request`
+  ADD COLUMN `locked_until` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) AFTER `expires_at`;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE `idempotent_request`
+  DROP COLUMN `locked_until`;
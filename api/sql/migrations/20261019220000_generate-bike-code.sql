@@ -0,0 +1,12 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Expression defaults need MySQL 8.0.13 or later.
+UPDATE `bike` SET `code` = UPPER(LEFT(SHA2(CONCAT('bike-', `id`), 256), 8)) WHERE `code` IS NULL;
+
+ALTER TABLE `bike`
+  MODIFY COLUMN `code` varchar(16) NOT NULL DEFAULT (UPPER(LEFT(SHA2(UUID(), 256), 8)));
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE `bike`
+  MODIFY COLUMN `code` varchar(16) DEFAULT NULL;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- New codes get 12 hex digits, 8 made a clash on uk_bike_code likely once the fleet grows and the insert fails then.
+-- Expression defaults need MySQL 8.0.13 or later.
+ALTER TABLE `bike`
+  MODIFY COLUMN `code` varchar(16) NOT NULL DEFAULT (UPPER(LEFT(SHA2(UUID(), 256), 12)));
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE `bike`
+  MODIFY COLUMN `code` varchar(16) NOT NULL DEFAULT (UPPER(LEFT(SHA2(UUID(), 256), 8)));
@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE `idempotent_request`
+  ADD COLUMN `etag` varchar(128) NOT NULL DEFAULT '' AFTER `content_type`;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE `idempotent_request`
+  DROP COLUMN `etag`;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Export and erasure look photos up by uploader, erasure locks them, without the index it locks the whole table.
+ALTER TABLE `bike_photo`
+  ADD KEY `idx_uploader_id` (`uploader_id`);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE `bike_photo`
+  DROP KEY `idx_uploader_id`;
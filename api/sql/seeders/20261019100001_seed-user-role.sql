@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+INSERT INTO `user_role` (`user_id`, `role_id`, `created_at`) VALUES
+(1, 1, '2022-07-06 18:51:44');
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+TRUNCATE TABLE `user_role`;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+INSERT INTO `station` (`id`, `name`, `lat`, `long`, `capacity`, `created_at`, `updated_at`, `deleted_at`) VALUES
+(1, "Westend", 50.119504, 8.638137, 10, '2026-10-19 14:00:00', '2026-10-19 14:00:00', NULL),
+(2, "Bockenheimer Warte", 50.120452, 8.650507, 6, '2026-10-19 14:00:00', '2026-10-19 14:00:00', NULL);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+TRUNCATE TABLE `station`;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- development secrets, the simulator signs with them: make simulate DEVICE=lock-0001 SECRET=dev-secret-1
+INSERT INTO `device` (`id`, `bike_id`, `secret`, `created_at`, `updated_at`, `deleted_at`) VALUES
+("lock-0001", 1, "dev-secret-1", '2026-10-19 16:00:00', '2026-10-19 16:00:00', NULL),
+("lock-0002", 2, "dev-secret-2", '2026-10-19 16:00:00', '2026-10-19 16:00:00', NULL),
+("lock-0003", 3, "dev-secret-3", '2026-10-19 16:00:00', '2026-10-19 16:00:00', NULL),
+("lock-0004", 4, "dev-secret-4", '2026-10-19 16:00:00', '2026-10-19 16:00:00', NULL);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+TRUNCATE TABLE `device`;
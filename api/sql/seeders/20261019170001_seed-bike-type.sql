@@ -0,0 +1,8 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+UPDATE `bike` SET `type` = 'ebike', `battery` = 80 WHERE `id` = 3;
+UPDATE `bike` SET `type` = 'cargo' WHERE `id` = 4;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+UPDATE `bike` SET `type` = 'classic', `battery` = NULL WHERE `id` IN (3, 4);
@@ -0,0 +1,167 @@
+package tracing
+
+import (
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const protobufContentType = "application/x-protobuf"
+
+const maxCollectorBody = 4 << 20
+
+// CollectedSpan is a span received by the Collector, flattened for printing and assertions.
+type CollectedSpan struct {
+	Service      string
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Name         string
+	Duration     time.Duration
+	Error        string
+	Attributes   map[string]string
+}
+
+// Collector is a stand-in for an OpenTelemetry collector. It accepts OTLP/HTTP protobuf on /v1/traces, the encoding
+// the otlptracehttp exporter sends, and keeps the spans in memory, see cmd/tracecollector.
+type Collector struct {
+	mu    sync.Mutex
+	spans []CollectedSpan
+	// OnSpan is called for every received span, cmd/tracecollector prints them.
+	OnSpan func(span CollectedSpan)
+}
+
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != otlpTracesPath {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Header.Get("Content-Type") != protobufContentType {
+		// the JSON encoding is not supported
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCollectorBody))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	request := &coltracepb.ExportTraceServiceRequest{}
+	if err := proto.Unmarshal(body, request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	received := flatten(request)
+	c.mu.Lock()
+	c.spans = append(c.spans, received...)
+	c.mu.Unlock()
+	if c.OnSpan != nil {
+		for _, span := range received {
+			c.OnSpan(span)
+		}
+	}
+	response, err := proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", protobufContentType)
+	w.Write(response)
+}
+
+// Spans returns the spans received so far.
+func (c *Collector) Spans() []CollectedSpan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]CollectedSpan{}, c.spans...)
+}
+
+func flatten(request *coltracepb.ExportTraceServiceRequest) []CollectedSpan {
+	result := []CollectedSpan{}
+	for _, resourceSpans := range request.ResourceSpans {
+		service := ""
+		for _, kv := range resourceSpans.GetResource().GetAttributes() {
+			if kv.Key == "service.name" {
+				service = valueString(kv.Value)
+			}
+		}
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			for _, span := range scopeSpans.Spans {
+				collected := CollectedSpan{
+					Service:      service,
+					TraceID:      hex.EncodeToString(span.TraceId),
+					SpanID:       hex.EncodeToString(span.SpanId),
+					ParentSpanID: hex.EncodeToString(span.ParentSpanId),
+					Name:         span.Name,
+					Duration:     time.Duration(span.EndTimeUnixNano - span.StartTimeUnixNano),
+					Attributes:   map[string]string{},
+				}
+				if span.GetStatus().GetCode() == tracepb.Status_STATUS_CODE_ERROR {
+					collected.Error = span.Status.Message
+				}
+				for _, kv := range span.Attributes {
+					collected.Attributes[kv.Key] = valueString(kv.Value)
+				}
+				result = append(result, collected)
+			}
+		}
+	}
+	return result
+}
+
+// valueString renders an attribute value like it would appear in a log line.
+func valueString(value *commonpb.AnyValue) string {
+	switch v := value.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue)
+	case *commonpb.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10)
+	case *commonpb.AnyValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'f', -1, 64)
+	case *commonpb.AnyValue_ArrayValue:
+		values := make([]string, 0, len(v.ArrayValue.Values))
+		for _, item := range v.ArrayValue.Values {
+			values = append(values, valueString(item))
+		}
+		return "[" + strings.Join(values, ",") + "]"
+	}
+	return ""
+}
+
+// String renders the span as one log line with its attributes sorted by key.
+func (s CollectedSpan) String() string {
+	var b strings.Builder
+	b.WriteString(s.TraceID + " " + s.SpanID + " parent=" + s.ParentSpanID + " " + s.Name + " " + s.Duration.String())
+	if s.Error != "" {
+		b.WriteString(" error=" + strconv.Quote(s.Error))
+	}
+	keys := make([]string, 0, len(s.Attributes))
+	for key := range s.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		b.WriteString(" " + key + "=" + strconv.Quote(s.Attributes[key]))
+	}
+	return b.String()
+}
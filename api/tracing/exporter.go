@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const otlpTracesPath = "/v1/traces"
+
+// newOTLPExporter sends to endpoint, the base URL of an OTLP/HTTP receiver like http://localhost:4318. Spans are
+// posted in the protobuf encoding to /v1/traces below it, options come after the ones derived from endpoint.
+func newOTLPExporter(endpoint string, options ...otlptracehttp.Option) (sdktrace.SpanExporter, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	if endpointURL.Host == "" || (endpointURL.Scheme != "http" && endpointURL.Scheme != "https") {
+		return nil, fmt.Errorf("invalid endpoint %q, expected a URL like http://localhost:4318", endpoint)
+	}
+	defaults := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(endpointURL.Host),
+		otlptracehttp.WithURLPath(strings.TrimSuffix(endpointURL.Path, "/") + otlpTracesPath),
+		otlptracehttp.WithTimeout(otlpTimeout),
+	}
+	if endpointURL.Scheme == "http" {
+		defaults = append(defaults, otlptracehttp.WithInsecure())
+	}
+	return otlptracehttp.New(context.Background(), append(defaults, options...)...)
+}
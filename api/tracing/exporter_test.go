@@ -0,0 +1,145 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+)
+
+type ExporterTestSuite struct {
+	suite.Suite
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (s *ExporterTestSuite) SetupTest() {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(recorder),
+		sdktrace.WithResource(sdkresource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String("shared-bike"))),
+	)
+	ctx, parent := provider.Tracer(instrumentationName).Start(context.Background(), "BikeUseCase.Rent")
+	_, child := provider.Tracer(instrumentationName).Start(ctx, "gorm.update")
+	child.SetAttributes(
+		attribute.String("db.statement", "UPDATE `bike` SET `status`=? WHERE id = ?"),
+		attribute.Int64("db.rows_affected", 1),
+		attribute.Bool("cache.hit", false),
+		attribute.Float64("ratio", 0.5),
+		attribute.StringSlice("roles", []string{"mechanic", "support"}),
+	)
+	child.SetStatus(codes.Error, "deadlock")
+	child.End()
+	parent.End()
+	s.spans = recorder.Ended()
+}
+
+func TestExporterTestSuite(t *testing.T) {
+	suite.Run(t, new(ExporterTestSuite))
+}
+
+func (s *ExporterTestSuite) TestOTLPExporter_Success() {
+	collector := NewCollector()
+	server := httptest.NewServer(collector)
+	defer server.Close()
+	exporter, err := newOTLPExporter(server.URL + "/")
+	s.Nil(err)
+	s.Nil(exporter.ExportSpans(context.Background(), s.spans))
+	s.Nil(exporter.Shutdown(context.Background()))
+	received := collector.Spans()
+	s.Len(received, 2)
+	update := received[0]
+	s.Equal("shared-bike", update.Service)
+	s.Equal("gorm.update", update.Name)
+	s.Equal(received[1].SpanID, update.ParentSpanID)
+	s.Equal(received[1].TraceID, update.TraceID)
+	s.Empty(received[1].ParentSpanID)
+	s.Len(update.TraceID, 32)
+	s.Len(update.SpanID, 16)
+	s.Equal("deadlock", update.Error)
+	s.Empty(received[1].Error)
+	s.Equal(map[string]string{
+		"db.statement":     "UPDATE `bike` SET `status`=? WHERE id = ?",
+		"db.rows_affected": "1",
+		"cache.hit":        "false",
+		"ratio":            "0.5",
+		"roles":            "[mechanic,support]",
+	}, update.Attributes)
+	s.Equal(s.spans[0].EndTime().Sub(s.spans[0].StartTime()), update.Duration)
+}
+
+func (s *ExporterTestSuite) TestOTLPExporter_PathPrefix() {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}))
+	defer server.Close()
+	exporter, err := newOTLPExporter(server.URL + "/otlp")
+	s.Nil(err)
+	s.Nil(exporter.ExportSpans(context.Background(), s.spans))
+	s.Equal("/otlp/v1/traces", path)
+}
+
+func (s *ExporterTestSuite) TestOTLPExporter_Rejected() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	exporter, err := newOTLPExporter(server.URL)
+	s.Nil(err)
+	err = exporter.ExportSpans(context.Background(), s.spans)
+	s.Error(err)
+	s.Contains(err.Error(), "400 Bad Request")
+}
+
+func (s *ExporterTestSuite) TestOTLPExporter_InvalidEndpoint() {
+	for _, endpoint := range []string{"localhost:4318", "grpc://localhost:4317", "http://", "http://%zz"} {
+		exporter, err := newOTLPExporter(endpoint)
+		s.Error(err, endpoint)
+		s.Nil(exporter, endpoint)
+	}
+}
+
+func (s *ExporterTestSuite) TestCollector_Rejects() {
+	collector := NewCollector()
+	for _, tc := range []struct {
+		method      string
+		target      string
+		contentType string
+		body        string
+		status      int
+	}{
+		{http.MethodPost, "/v1/metrics", protobufContentType, "", http.StatusNotFound},
+		{http.MethodGet, "/v1/traces", protobufContentType, "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/v1/traces", "application/json", "{}", http.StatusUnsupportedMediaType},
+		{http.MethodPost, "/v1/traces", protobufContentType, "\xff", http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", tc.contentType)
+		rec := httptest.NewRecorder()
+		collector.ServeHTTP(rec, req)
+		s.Equal(tc.status, rec.Code, tc.target+" "+tc.contentType)
+	}
+	s.Empty(collector.Spans())
+}
+
+func (s *ExporterTestSuite) TestCollectedSpan_String() {
+	span := CollectedSpan{
+		TraceID:      callerTraceID,
+		SpanID:       "b7ad6b7169203331",
+		ParentSpanID: callerSpanID,
+		Name:         "gorm.query",
+		Duration:     1500000,
+		Error:        "deadlock",
+		Attributes:   map[string]string{"db.sql.table": "bike", "db.rows_affected": "1"},
+	}
+	s.Equal(callerTraceID+` b7ad6b7169203331 parent=`+callerSpanID+` gorm.query 1.5ms error="deadlock" db.rows_affected="1" db.sql.table="bike"`, span.String())
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+// GormPlugin opens a client span around every query GORM sends. The spans only join the request trace when the
+// repository passes its context with db.WithContext(ctx).
+type GormPlugin struct{}
+
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{}
+}
+
+func (p *GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	for _, err := range []error{
+		callback.Create().Before("gorm:create").Register("tracing:before_create", startQuery("create")),
+		callback.Create().After("gorm:create").Register("tracing:after_create", endQuery),
+		callback.Query().Before("gorm:query").Register("tracing:before_query", startQuery("query")),
+		callback.Query().After("gorm:query").Register("tracing:after_query", endQuery),
+		callback.Update().Before("gorm:update").Register("tracing:before_update", startQuery("update")),
+		callback.Update().After("gorm:update").Register("tracing:after_update", endQuery),
+		callback.Delete().Before("gorm:delete").Register("tracing:before_delete", startQuery("delete")),
+		callback.Delete().After("gorm:delete").Register("tracing:after_delete", endQuery),
+		callback.Row().Before("gorm:row").Register("tracing:before_row", startQuery("row")),
+		callback.Row().After("gorm:row").Register("tracing:after_row", endQuery),
+		callback.Raw().Before("gorm:raw").Register("tracing:before_raw", startQuery("raw")),
+		callback.Raw().After("gorm:raw").Register("tracing:after_raw", endQuery),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startQuery(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if tracer == nil || db.Statement.Context == nil {
+			return
+		}
+		ctx, span := tracer.Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemMySQL),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func endQuery(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	defer span.End()
+	// the statement keeps its placeholders, the values may be personal data
+	span.SetAttributes(
+		semconv.DBStatementKey.String(db.Statement.SQL.String()),
+		semconv.DBSQLTableKey.String(db.Statement.Table),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,110 @@
+package tracing
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"shared-bike/domain"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type GormPluginTestSuite struct {
+	suite.Suite
+	recorder *tracetest.SpanRecorder
+	mockDB   sqlmock.Sqlmock
+	db       *gorm.DB
+}
+
+func (s *GormPluginTestSuite) SetupTest() {
+	s.recorder = tracetest.NewSpanRecorder()
+	use(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder)))
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.Error(err, "Failed to open mock sql db, got error")
+	}
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		s.Error(err, "gormDB init err")
+	}
+	s.Nil(gormDB.Use(NewGormPlugin()))
+	s.mockDB = mock
+	s.db = gormDB
+}
+
+func (s *GormPluginTestSuite) TearDownTest() {
+	tracer = nil
+}
+
+func TestGormPluginTestSuite(t *testing.T) {
+	suite.Run(t, new(GormPluginTestSuite))
+}
+
+func (s *GormPluginTestSuite) querySpans() []sdktrace.ReadOnlySpan {
+	spans := []sdktrace.ReadOnlySpan{}
+	for _, span := range s.recorder.Ended() {
+		if attributeOf(span, "db.system").AsString() == "mysql" {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+func (s *GormPluginTestSuite) TestQuery_ChildOfRepositorySpan() {
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `bike` WHERE id = ?")).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	ctx, parent := Start(context.Background(), "BikeRepository.GetByID")
+	bike := domain.Bike{}
+	s.Nil(s.db.WithContext(ctx).Where("id = ?", int64(1)).First(&bike).Error)
+	parent.End()
+	spans := s.querySpans()
+	s.Len(spans, 1)
+	s.Equal("gorm.query", spans[0].Name())
+	s.Equal(parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	s.Equal("SELECT * FROM `bike` WHERE id = ? AND `bike`.`deleted_at` IS NULL ORDER BY `bike`.`id` LIMIT 1", attributeOf(spans[0], "db.statement").AsString())
+	s.Equal("bike", attributeOf(spans[0], "db.sql.table").AsString())
+	s.Equal(int64(1), attributeOf(spans[0], "db.rows_affected").AsInt64())
+	s.Equal(codes.Unset, spans[0].Status().Code)
+}
+
+func (s *GormPluginTestSuite) TestUpdate_Failed() {
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `bike` SET")).WillReturnError(gorm.ErrInvalidDB)
+	s.mockDB.ExpectRollback()
+	err := s.db.WithContext(context.Background()).Model(&domain.Bike{}).Where("id = ?", 1).Update("status", domain.BikeStatusRented).Error
+	s.Equal(gorm.ErrInvalidDB, err)
+	spans := s.querySpans()
+	s.Len(spans, 1)
+	s.Equal("gorm.update", spans[0].Name())
+	s.Equal(codes.Error, spans[0].Status().Code)
+	s.Equal(gorm.ErrInvalidDB.Error(), spans[0].Status().Description)
+}
+
+func (s *GormPluginTestSuite) TestQuery_NotFoundIsNoError() {
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `bike`")).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	bike := domain.Bike{}
+	s.Equal(gorm.ErrRecordNotFound, s.db.WithContext(context.Background()).First(&bike).Error)
+	spans := s.querySpans()
+	s.Len(spans, 1)
+	s.Equal(codes.Unset, spans[0].Status().Code)
+}
+
+func (s *GormPluginTestSuite) TestDisabled() {
+	tracer = nil
+	s.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `bike`")).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	var total int64
+	s.Nil(s.db.WithContext(context.Background()).Model(&domain.Bike{}).Count(&total).Error)
+	s.Equal(int64(2), total)
+	s.Empty(s.recorder.Ended())
+}
@@ -0,0 +1,175 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+
+	// W3C trace context headers, browsers only send them cross-origin when CORS allows them
+	HeaderTraceparent = "traceparent"
+	HeaderTracestate  = "tracestate"
+
+	DefaultServiceName  = "shared-bike"
+	instrumentationName = "shared-bike"
+	otlpTimeout         = 10 * time.Second
+)
+
+var (
+	// tracer is nil while tracing is off, Start then hands the context back untouched.
+	tracer trace.Tracer
+	// noopSpan is what Start returns while tracing is off.
+	noopSpan = trace.SpanFromContext(context.Background())
+)
+
+type Config struct {
+	// Exporter is ExporterNone, ExporterStdout or ExporterOTLP.
+	Exporter string
+	// Endpoint is the base URL of the OTLP/HTTP receiver, like http://localhost:4318.
+	Endpoint    string
+	ServiceName string
+	// SampleRatio is the share of new traces recorded, traces started by a caller follow the caller's decision.
+	SampleRatio float64
+}
+
+// Setup installs the tracer provider and the W3C trace context propagator. The returned function flushes the spans
+// still buffered and must be called before the process exits.
+func Setup(config Config, stdout io.Writer) (func(ctx context.Context) error, error) {
+	if config.Exporter == "" || config.Exporter == ExporterNone {
+		return func(ctx context.Context) error { return nil }, nil
+	}
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("sample ratio %v is not between 0 and 1", config.SampleRatio)
+	}
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch config.Exporter {
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(stdout))
+	case ExporterOTLP:
+		if config.Endpoint == "" {
+			return nil, fmt.Errorf("the %s exporter needs an endpoint", ExporterOTLP)
+		}
+		exporter, err = newOTLPExporter(config.Endpoint)
+	default:
+		return nil, fmt.Errorf("unknown exporter %q", config.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(sdkresource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	use(provider)
+	return provider.Shutdown, nil
+}
+
+func use(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	tracer = provider.Tracer(instrumentationName)
+}
+
+// Start opens a span named like the log prefix of the method, e.g. BikeUseCase.Rent, as child of the span in ctx.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if tracer == nil {
+		return ctx, noopSpan
+	}
+	return tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// StartHandler opens the span of a handler and hands its context to the rest of the request through c.Request().
+func StartHandler(c echo.Context, name string) trace.Span {
+	req := c.Request()
+	ctx, span := Start(req.Context(), name)
+	if ctx != req.Context() {
+		c.SetRequest(req.WithContext(ctx))
+	}
+	return span
+}
+
+// TraceID is the hex trace ID of the span in ctx, empty when there is none.
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// Middleware opens the server span of every request, continuing the trace of the caller when the request carries
+// a traceparent header. It has to run before the handlers and the logger middleware so both see the span.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if tracer == nil {
+				return next(c)
+			}
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			route := c.Path()
+			ctx, span := tracer.Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPMethodKey.String(req.Method),
+					semconv.HTTPRouteKey.String(route),
+					semconv.HTTPTargetKey.String(req.URL.Path),
+					semconv.HTTPClientIPKey.String(c.RealIP()),
+				),
+			)
+			defer span.End()
+			if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+				span.SetAttributes(attribute.String("http.request_id", id))
+			}
+			c.SetRequest(req.WithContext(ctx))
+			err := next(c)
+			if errors.Is(err, echo.ErrNotFound) {
+				// echo reports the raw path when no route matches, it must not end up in the span name
+				span.SetName(req.Method + " unmatched")
+			}
+			status := c.Response().Status
+			if err != nil {
+				span.RecordError(err)
+				var httpError *echo.HTTPError
+				if errors.As(err, &httpError) {
+					status = httpError.Code
+				} else {
+					status = http.StatusInternalServerError
+				}
+			}
+			span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, strconv.Itoa(status))
+			}
+			return err
+		}
+	}
+}
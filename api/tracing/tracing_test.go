@@ -0,0 +1,193 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	callerTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	callerSpanID  = "00f067aa0ba902b7"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+	recorder *tracetest.SpanRecorder
+	echo     *echo.Echo
+	traceIDs []string
+}
+
+func (s *TracingTestSuite) SetupTest() {
+	s.recorder = tracetest.NewSpanRecorder()
+	use(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder)))
+	s.traceIDs = nil
+	s.echo = echo.New()
+	s.echo.Use(Middleware())
+	s.echo.GET("/api/v1/bikes/:id", func(c echo.Context) error {
+		span := StartHandler(c, "BikeHandler.GetByID")
+		defer span.End()
+		s.traceIDs = append(s.traceIDs, TraceID(c.Request().Context()))
+		return c.JSON(http.StatusOK, "OK")
+	})
+	s.echo.GET("/boom", func(c echo.Context) error {
+		return errors.New("boom")
+	})
+}
+
+func (s *TracingTestSuite) TearDownTest() {
+	tracer = nil
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
+
+func (s *TracingTestSuite) serve(target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *TracingTestSuite) spanNamed(name string) sdktrace.ReadOnlySpan {
+	for _, span := range s.recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	s.FailNow("span not found", name)
+	return nil
+}
+
+func attributeOf(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func (s *TracingTestSuite) TestStart_Disabled() {
+	tracer = nil
+	ctx := context.WithValue(context.Background(), struct{}{}, "value")
+	actual, span := Start(ctx, "BikeUseCase.Rent")
+	span.End()
+	s.Equal(ctx, actual)
+	s.False(span.SpanContext().IsValid())
+	s.Empty(TraceID(actual))
+	s.Empty(s.recorder.Ended())
+}
+
+func (s *TracingTestSuite) TestStart_ChildOfSpanInContext() {
+	ctx, parent := Start(context.Background(), "BikeUseCase.Rent")
+	_, child := Start(ctx, "BikeRepository.CountByUserID", attribute.Int64("user.id", 1))
+	child.End()
+	parent.End()
+	s.Equal(parent.SpanContext().TraceID().String(), TraceID(ctx))
+	repository := s.spanNamed("BikeRepository.CountByUserID")
+	s.Equal(parent.SpanContext().SpanID(), repository.Parent().SpanID())
+	s.Equal(int64(1), attributeOf(repository, "user.id").AsInt64())
+}
+
+func (s *TracingTestSuite) TestMiddleware_ContinuesCallerTrace() {
+	rec := s.serve("/api/v1/bikes/1", http.Header{"Traceparent": {"00-" + callerTraceID + "-" + callerSpanID + "-01"}})
+	s.Equal(http.StatusOK, rec.Code)
+	server := s.spanNamed("GET /api/v1/bikes/:id")
+	handler := s.spanNamed("BikeHandler.GetByID")
+	s.Equal(callerTraceID, server.SpanContext().TraceID().String())
+	s.Equal(callerSpanID, server.Parent().SpanID().String())
+	s.True(server.Parent().IsRemote())
+	s.Equal(server.SpanContext().SpanID(), handler.Parent().SpanID())
+	s.Equal([]string{callerTraceID}, s.traceIDs)
+	s.Equal(int64(http.StatusOK), attributeOf(server, "http.status_code").AsInt64())
+	s.Equal("/api/v1/bikes/:id", attributeOf(server, "http.route").AsString())
+	s.Equal("/api/v1/bikes/1", attributeOf(server, "http.target").AsString())
+	s.Equal(codes.Unset, server.Status().Code)
+}
+
+func (s *TracingTestSuite) TestMiddleware_NewTrace() {
+	s.serve("/api/v1/bikes/1", nil)
+	server := s.spanNamed("GET /api/v1/bikes/:id")
+	s.False(server.Parent().IsValid())
+	s.Equal([]string{server.SpanContext().TraceID().String()}, s.traceIDs)
+}
+
+func (s *TracingTestSuite) TestMiddleware_ServerError() {
+	s.serve("/boom", nil)
+	server := s.spanNamed("GET /boom")
+	s.Equal(codes.Error, server.Status().Code)
+	s.Equal(int64(http.StatusInternalServerError), attributeOf(server, "http.status_code").AsInt64())
+	s.Len(server.Events(), 1)
+}
+
+func (s *TracingTestSuite) TestMiddleware_Unmatched() {
+	s.serve("/wp-login.php", nil)
+	server := s.spanNamed("GET unmatched")
+	s.Equal(int64(http.StatusNotFound), attributeOf(server, "http.status_code").AsInt64())
+	s.Equal(codes.Unset, server.Status().Code)
+}
+
+func (s *TracingTestSuite) TestMiddleware_Disabled() {
+	tracer = nil
+	rec := s.serve("/api/v1/bikes/1", http.Header{"Traceparent": {"00-" + callerTraceID + "-" + callerSpanID + "-01"}})
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal([]string{""}, s.traceIDs)
+	s.Empty(s.recorder.Ended())
+}
+
+func (s *TracingTestSuite) TestSetup_None() {
+	tracer = nil
+	shutdown, err := Setup(Config{Exporter: ExporterNone}, nil)
+	s.Nil(err)
+	s.Nil(tracer)
+	s.Nil(shutdown(context.Background()))
+}
+
+func (s *TracingTestSuite) TestSetup_Stdout() {
+	output := &bytes.Buffer{}
+	shutdown, err := Setup(Config{Exporter: ExporterStdout, ServiceName: "shared-bike-test", SampleRatio: 1}, output)
+	s.Nil(err)
+	_, span := Start(context.Background(), "BikeUseCase.Rent")
+	span.End()
+	s.Nil(shutdown(context.Background()))
+	s.Contains(output.String(), `"Name":"BikeUseCase.Rent"`)
+	s.Contains(output.String(), `{"Key":"service.name","Value":{"Type":"STRING","Value":"shared-bike-test"}}`)
+}
+
+func (s *TracingTestSuite) TestSetup_NotSampled() {
+	output := &bytes.Buffer{}
+	shutdown, err := Setup(Config{Exporter: ExporterStdout, SampleRatio: 0}, output)
+	s.Nil(err)
+	_, span := Start(context.Background(), "BikeUseCase.Rent")
+	span.End()
+	s.Nil(shutdown(context.Background()))
+	s.Empty(output.String())
+}
+
+func (s *TracingTestSuite) TestSetup_Invalid() {
+	for _, config := range []Config{
+		{Exporter: "jaeger"},
+		{Exporter: ExporterOTLP},
+		{Exporter: ExporterOTLP, Endpoint: "localhost:4318"},
+		{Exporter: ExporterStdout, SampleRatio: 1.5},
+	} {
+		shutdown, err := Setup(config, nil)
+		s.Error(err, config.Exporter)
+		s.Nil(shutdown)
+	}
+}
@@ -85,6 +85,15 @@ describe('rentBike', () => {
 })
 
 describe('returnBike', () => {
+  const mockGeolocation = (getCurrentPosition: jest.Mock) => {
+    Object.defineProperty(global.navigator, 'geolocation', {
+      value: { getCurrentPosition },
+      configurable: true,
+    })
+  }
+  beforeEach(() => {
+    mockGeolocation(jest.fn((success) => success({ coords: { latitude: 50.1234564, longitude: 8.1234561 } })))
+  })
   it('should return a bikes', async () => {
     const mockBike = {
       id: 1,
@@ -95,12 +104,13 @@ describe('returnBike', () => {
       nameOfRenter: '',
       userId: 0,
     }
-    jest.spyOn(axiosApiInstance, 'patch').mockResolvedValue({
+    const patch = jest.spyOn(axiosApiInstance, 'patch').mockResolvedValue({
       data: mockBike,
       status: HTTP_STATUS.OK
     })
     const result = await returnBike({ bikeId: 1 })
     expect(result).toEqual(mockBike)
+    expect(patch).toHaveBeenCalledWith(expect.stringContaining('/bikes/1/return'), { lat: '50.123456', long: '8.123456' })
   })
   it('should throw error normal', async () => {
     jest.spyOn(axiosApiInstance, 'patch').mockRejectedValue(new Error('mockError'))
@@ -112,4 +122,17 @@ describe('returnBike', () => {
     }
     expect(err).toEqual('mockError')
   })
+  it('should throw error when location is unavailable', async () => {
+    mockGeolocation(jest.fn((_, failure) => failure({ message: 'User denied Geolocation' })))
+    const patch = jest.spyOn(axiosApiInstance, 'patch')
+    patch.mockClear()
+    let err
+    try {
+      await returnBike({ bikeId: 1 })
+    } catch (error) {
+      err = error
+    }
+    expect(err).toEqual('User denied Geolocation')
+    expect(patch).not.toHaveBeenCalled()
+  })
 })
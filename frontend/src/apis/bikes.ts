@@ -1,5 +1,5 @@
 import { AxiosResponse } from 'axios'
-import { Bike, RentBikeVariables, ReturnBikeVariables } from '../typings/types'
+import { Bike, RentBikeVariables, ReturnBikeBody, ReturnBikeVariables } from '../typings/types'
 import { axiosApiInstance } from './axiosInstance'
 
 export const fetchBikes = (): Promise<Bike[]> => {
@@ -22,12 +22,23 @@ export const rentBike = ({ bikeId }: RentBikeVariables): Promise<Bike> => {
     })
 }
 
-export const returnBike = ({ bikeId }: ReturnBikeVariables): Promise<Bike> => {
+const getCurrentLocation = (): Promise<ReturnBikeBody> => new Promise((resolve, reject) => {
+  navigator.geolocation.getCurrentPosition(
+    (position) => resolve({
+      lat: position.coords.latitude.toFixed(6),
+      long: position.coords.longitude.toFixed(6),
+    }),
+    (error) => reject(new Error(error.message)),
+  )
+})
+
+export const returnBike = async ({ bikeId }: ReturnBikeVariables): Promise<Bike> => {
   const returnBikeUrl = `${window.sharedBike.config.baseUrl}/bikes/${bikeId}/return`
-  return axiosApiInstance
-    .patch<undefined, AxiosResponse<Bike>>(returnBikeUrl)
-    .then((resp) => resp.data)
-    .catch((error) => {
-      throw (error as Error).message
-    })
+  try {
+    const location = await getCurrentLocation()
+    const resp = await axiosApiInstance.patch<ReturnBikeBody, AxiosResponse<Bike>>(returnBikeUrl, location)
+    return resp.data
+  } catch (error) {
+    throw (error as Error).message
+  }
 }
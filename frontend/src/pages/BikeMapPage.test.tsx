@@ -217,6 +217,20 @@ describe('contentMap', () => {
     expect(view).toContain('RENT BIKE')
   })
 
+  it('should render content map for bike in maintenance', () => {
+    const map = contentMap[BikeStatus.MAINTENANCE]
+    const view = map.renderButton({
+      id: 1,
+      name: 'mockName',
+      lat: '50.123456',
+      long: '8.123456',
+      status: BikeStatus.MAINTENANCE,
+    } as Bike)
+    expect(view).toContain('bike-action-1')
+    expect(view).toContain('OUT OF SERVICE')
+    expect(view).toContain('disabled')
+  })
+
   it('should render content map for retur bike', () => {
     const map = contentMap.returnBike
     const view = map.renderButton({
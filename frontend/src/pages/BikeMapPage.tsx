@@ -21,6 +21,27 @@ export const customIcon = (color: string) => {
 
 export const availableColor = '#2ecc71'
 export const rentedColor = '#34495e'
+export const outOfServiceColor = '#e74c3c'
+
+const outOfServiceContent = {
+  icon: customIcon(outOfServiceColor),
+  renderButton: (bike: Bike) => {
+    return `
+    <div class="flex justify-end">
+      <button
+        id="bike-action-${bike.id}"
+        type="button"
+        disabled
+        class="w-1/2 py-2.5 rounded-3xl mt-4 btn-disabled"
+      >
+        <div class="flex flex-row items-center justify-center">
+          <div class="font-light">OUT OF SERVICE</div>
+        </div>
+      </button>
+    </div>
+    `
+  }
+}
 
 export const contentMap = {
   [BikeStatus.AVAILABLE]: {
@@ -60,6 +81,8 @@ export const contentMap = {
       `
     }
   },
+  [BikeStatus.MAINTENANCE]: outOfServiceContent,
+  [BikeStatus.RETIRED]: outOfServiceContent,
   returnBike: {
     icon: customIcon(availableColor),
     renderButton: (bike: Bike) => {
@@ -21,19 +21,50 @@ export type ReturnBikeVariables = {
   bikeId: number
 }
 
+export type ReturnBikeBody = {
+  lat: string
+  long: string
+}
+
 export enum BikeStatus {
   RENTED = 'rented',
-  AVAILABLE = 'available'
+  AVAILABLE = 'available',
+  MAINTENANCE = 'maintenance',
+  RETIRED = 'retired'
+}
+
+export enum LockState {
+  LOCKED = 'locked',
+  UNLOCKED = 'unlocked'
+}
+
+export type BikeTelemetry = {
+  battery?: number
+  lockState?: LockState
+  recordedAt: string
+}
+
+export enum BikeType {
+  CLASSIC = 'classic',
+  EBIKE = 'ebike',
+  CARGO = 'cargo'
 }
 
 export type Bike = {
   id: number
   name: string
+  type?: BikeType
+  code?: string
+  battery?: number
   lat: string
   long: string
   status: BikeStatus
   userId?: number
   nameOfRenter?: string
+  stationId?: number
+  surcharge?: string
+  telemetry?: BikeTelemetry
+  version?: number
 }
 
 export type RegisterVariables = {